@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 
@@ -52,17 +53,46 @@ func main() {
 
 	app := application.NewApplication(cfg, db, ch, s)
 
+	if err := app.DiscoverySrv.Start(context.Background()); err != nil {
+		log.Printf("Discovery could not be started. Error: %s", err)
+	}
+
+	app.MonitorSrv.Start(context.Background())
+	app.TrashSrv.Start(context.Background())
+	app.MessageSrv.Start(context.Background())
+	app.AlertSrv.Start(context.Background())
+	app.PresenceSrv.Start(context.Background())
+	app.ValueSrv.Start(context.Background())
+
 	mRule := middleware.NewRule(app.AuthSrv, app.UserSrv)
 	mInfo := middleware.NewInfo(cfg)
 
 	userHnd := handler.NewUserHandler(cfg, app.UserSrv, app.UserMap)
-	brokerHnd := handler.NewBrokerHandler(app.BrokerSrv, app.BrokerMap)
+	brokerHnd := handler.NewBrokerHandler(app.BrokerSrv, app.MonitorSrv, app.BrokerMap)
 	deviceHnd := handler.NewDeviceHandler(app.DeviceSrv, app.ControlSrv, app.DeviceMap, app.ControlMap)
 	eventHnd := handler.NewEventHandler(cfg, app.EventSrv)
+	transferHnd := handler.NewTransferHandler(app.TransferSrv, app.TransferMap)
+	discoveryHnd := handler.NewDiscoveryHandler(app.DiscoverySrv, app.DiscoveryMap)
+	certificateHnd := handler.NewCertificateHandler(app.CertSrv, app.CertMap)
+	controlTypeHnd := handler.NewControlTypeHandler(app.ControlTypes, app.TypeMap)
+	searchHnd := handler.NewSearchHandler(app.SearchSrv, app.SearchMap)
+	dashboardHnd := handler.NewDashboardHandler(app.DashboardSrv, app.DashboardMap)
+	roomHnd := handler.NewRoomHandler(app.RoomSrv, app.RoomMap)
+	tagHnd := handler.NewTagHandler(app.TagSrv, app.TagMap)
+	groupHnd := handler.NewGroupHandler(app.GroupSrv, app.GroupMap)
+	batchHnd := handler.NewBatchHandler(app.BatchSrv, app.BatchMap)
+	trashHnd := handler.NewTrashHandler(app.TrashSrv, app.TrashMap)
+	revisionHnd := handler.NewRevisionHandler(app.RevisionSrv, app.RevisionMap)
+	cloneHnd := handler.NewCloneHandler(app.CloneSrv, app.BrokerMap, app.DeviceMap)
+	topicHnd := handler.NewTopicHandler(app.TopicSrv, app.TopicMap)
+	exploreHnd := handler.NewExploreHandler(app.ExploreSrv, app.ExploreMap)
+	messageHnd := handler.NewMessageHandler(app.MessageSrv, app.MessageMap)
+	alertHnd := handler.NewAlertHandler(app.AlertSrv, app.AlertMap)
+	presenceHnd := handler.NewPresenceHandler(app.PresenceSrv, app.PresenceMap)
 
 	gin.Use(middleware.CORS(cfg.CORS))
 
-	rest.NewRouter(gin, mRule, mInfo, userHnd, brokerHnd, deviceHnd, eventHnd)
+	rest.NewRouter(gin, mRule, mInfo, userHnd, brokerHnd, deviceHnd, eventHnd, transferHnd, discoveryHnd, certificateHnd, controlTypeHnd, searchHnd, dashboardHnd, roomHnd, tagHnd, groupHnd, batchHnd, trashHnd, revisionHnd, cloneHnd, topicHnd, exploreHnd, messageHnd, alertHnd, presenceHnd)
 
 	setupSwagger(gin, cfg.Server)
 
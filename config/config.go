@@ -20,6 +20,14 @@ type Config struct {
 	Frontend    *FrontendConfig
 	MailAddress *MailAddressConfig
 	CORS        *CORSConfig
+	Monitor     *MonitorConfig
+	Trash       *TrashConfig
+	Topic       *TopicConfig
+	Explore     *ExploreConfig
+	MessageLog  *MessageLogConfig
+	Alert       *AlertConfig
+	Presence    *PresenceConfig
+	Value       *ValueConfig
 }
 
 type ServerConfig struct {
@@ -106,6 +114,61 @@ type CORSConfig struct {
 	Headers     string `mapstructure:"CORS_HEADERS"`
 }
 
+type MonitorConfig struct {
+	IntervalSeconds uint16 `mapstructure:"MONITOR_INTERVAL_SECONDS"`
+	Concurrency     uint8  `mapstructure:"MONITOR_CONCURRENCY"`
+}
+
+type TrashConfig struct {
+	RetentionDays        uint16 `mapstructure:"TRASH_RETENTION_DAYS"`
+	PurgeIntervalMinutes uint16 `mapstructure:"TRASH_PURGE_INTERVAL_MINUTES"`
+}
+
+// TopicConfig sets whether the controls whose topics conflict with the other controls of the broker are rejected.
+type TopicConfig struct {
+	BlockConflicts bool `mapstructure:"TOPIC_BLOCK_CONFLICTS"`
+}
+
+// ExploreConfig bounds the topic explorer, the window is used when the request does not set one
+// and the payload previews are cut to the given number of bytes.
+type ExploreConfig struct {
+	WindowSeconds    uint16 `mapstructure:"EXPLORE_WINDOW_SECONDS"`
+	MaxWindowSeconds uint16 `mapstructure:"EXPLORE_MAX_WINDOW_SECONDS"`
+	MaxTopics        uint16 `mapstructure:"EXPLORE_MAX_TOPICS"`
+	PreviewBytes     uint16 `mapstructure:"EXPLORE_PREVIEW_BYTES"`
+}
+
+// MessageLogConfig limits the message log of the devices, the payloads over the size are cut
+// and the log is trimmed every interval.
+type MessageLogConfig struct {
+	Enabled         bool   `mapstructure:"MESSAGE_LOG_ENABLED"`
+	RetentionHours  uint16 `mapstructure:"MESSAGE_LOG_RETENTION_HOURS"`
+	MaxPerDevice    uint16 `mapstructure:"MESSAGE_LOG_MAX_PER_DEVICE"`
+	PayloadBytes    uint16 `mapstructure:"MESSAGE_LOG_PAYLOAD_BYTES"`
+	IntervalMinutes uint16 `mapstructure:"MESSAGE_LOG_INTERVAL_MINUTES"`
+}
+
+// AlertConfig sets how often the alerts of the devices and the brokers are checked
+// and how long the webhooks of the incidents are waited for.
+type AlertConfig struct {
+	IntervalSeconds       uint16 `mapstructure:"ALERT_INTERVAL_SECONDS"`
+	WebhookTimeoutSeconds uint16 `mapstructure:"ALERT_WEBHOOK_TIMEOUT_SECONDS"`
+}
+
+// PresenceConfig enables the tracking of the devices with the availability and sets how often
+// the heartbeats are checked and the brokers which could not be connected to are retried.
+type PresenceConfig struct {
+	Enabled         bool   `mapstructure:"PRESENCE_ENABLED"`
+	IntervalSeconds uint16 `mapstructure:"PRESENCE_INTERVAL_SECONDS"`
+}
+
+// ValueConfig enables the decoding of the payloads received by the controls into their last values
+// and sets how often the brokers which could not be connected to are retried.
+type ValueConfig struct {
+	Enabled         bool   `mapstructure:"VALUE_ENABLED"`
+	IntervalSeconds uint16 `mapstructure:"VALUE_INTERVAL_SECONDS"`
+}
+
 func loadConfig[T interface{}](v *viper.Viper, c T) *T {
 	err := v.Unmarshal(&c)
 	if err != nil {
@@ -135,6 +198,14 @@ func NewConfig(path string) *Config {
 		Frontend:    loadConfig(v, FrontendConfig{}),
 		MailAddress: loadConfig(v, MailAddressConfig{}),
 		CORS:        loadConfig(v, CORSConfig{}),
+		Monitor:     loadConfig(v, MonitorConfig{}),
+		Trash:       loadConfig(v, TrashConfig{}),
+		Topic:       loadConfig(v, TopicConfig{}),
+		Explore:     loadConfig(v, ExploreConfig{}),
+		MessageLog:  loadConfig(v, MessageLogConfig{}),
+		Alert:       loadConfig(v, AlertConfig{}),
+		Presence:    loadConfig(v, PresenceConfig{}),
+		Value:       loadConfig(v, ValueConfig{}),
 	}
 
 	return &config
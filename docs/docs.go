@@ -17,7 +17,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/brokers": {
+        "/alerts": {
             "get": {
                 "security": [
                     {
@@ -31,16 +31,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Brokers"
+                    "Alerts"
                 ],
-                "summary": "List brokers",
+                "summary": "List alerts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.GetBrokerResponse"
+                                "$ref": "#/definitions/dto.GetAlertResponse"
                             }
                         }
                     },
@@ -50,12 +50,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -70,6 +64,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "The threshold alerts are raised when the value of the control gets above or below the threshold\nand resolved once it gets back past the threshold by the hysteresis. The state change alerts are\nraised while the value of the control is the state, the device offline alerts when the device with\nthe availability has been offline for the minutes and the broker disconnected alerts when the broker\nfails its health check. The threshold and state change alerts need the values of the controls to be\ndecoded and the device offline alerts need the presence to be tracked, they are rejected otherwise.\nA new incident is not opened until the cooldown has passed since the last one was resolved. The incident\nis resolved when the control, the device or the broker of the alert is moved to the trash.\nThe incidents are published as events and can be sent by email and to a webhook. The webhooks are\nsent to public addresses only and their redirects are not followed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,17 +72,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Brokers"
+                    "Alerts"
                 ],
-                "summary": "Create a broker",
+                "summary": "Create an alert",
                 "parameters": [
                     {
-                        "description": "Create data",
+                        "description": "Alert data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateBrokerRequest"
+                            "$ref": "#/definitions/dto.SetAlertRequest"
                         }
                     }
                 ],
@@ -95,7 +90,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateBrokerResponse"
+                            "$ref": "#/definitions/dto.CreateAlertResponse"
                         }
                     },
                     "400": {
@@ -110,14 +105,116 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/errors.HTTPError"
                         }
+                    }
+                }
+            }
+        },
+        "/alerts/incidents": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the incidents of all the alerts of the user, the unresolved ones can be listed with the status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "List alert incidents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert UUID",
+                        "name": "alertId",
+                        "in": "query"
                     },
-                    "409": {
-                        "description": "Conflict",
+                    {
+                        "enum": [
+                            "open",
+                            "acknowledged",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "Incident status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, sent in the Link header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "openedAt",
+                            "status"
+                        ],
+                        "type": "string",
+                        "default": "openedAt",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetAlertIncidentResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Count of all the matching incidents"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/errors.HTTPError"
                         }
@@ -131,13 +228,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/brokers/{brokerId}": {
-            "get": {
+        "/alerts/incidents/{incidentId}/acknowledge": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Silences the open incident, it is still resolved once the condition of the alert clears.\nAcknowledging an acknowledged incident does nothing.",
                 "consumes": [
                     "application/json"
                 ],
@@ -145,14 +243,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Brokers"
+                    "Alerts"
                 ],
-                "summary": "Get a broker",
+                "summary": "Acknowledge an alert incident",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Broker UUID",
-                        "name": "brokerId",
+                        "description": "Incident UUID",
+                        "name": "incidentId",
                         "in": "path",
                         "required": true
                     }
@@ -161,7 +259,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetBrokerResponse"
+                            "$ref": "#/definitions/dto.GetAlertIncidentResponse"
                         }
                     },
                     "400": {
@@ -182,6 +280,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -189,8 +293,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/alerts/{alertId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -203,21 +309,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Brokers"
+                    "Alerts"
                 ],
-                "summary": "Delete a broker",
+                "summary": "Get a single alert",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Broker UUID",
-                        "name": "brokerId",
+                        "description": "Alert UUID",
+                        "name": "alertId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAlertResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -245,12 +354,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Replaces the whole definition of the alert. The unresolved incident is resolved when the alert\nis disabled or its condition changes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -258,24 +368,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Brokers"
+                    "Alerts"
                 ],
-                "summary": "Update a broker",
+                "summary": "Replace an alert",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Broker UUID",
-                        "name": "brokerId",
+                        "description": "Alert UUID",
+                        "name": "alertId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update data",
+                        "description": "Alert data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateBrokerRequest"
+                            "$ref": "#/definitions/dto.SetAlertRequest"
                         }
                     }
                 ],
@@ -301,12 +411,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -314,15 +418,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/brokers/{brokerId}/credentials": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "The incidents of the alert are deleted along with it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -330,24 +433,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Brokers"
+                    "Alerts"
                 ],
-                "summary": "Get broker's credentials",
+                "summary": "Delete an alert",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Broker UUID",
-                        "name": "brokerId",
+                        "description": "Alert UUID",
+                        "name": "alertId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.GetBrokerCredentialsResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -374,13 +474,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/batch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "The operations are applied in their order in a single transaction, so either all or none of them are.\nThe data of an operation is the body of the matching create or update endpoint. Entities created\nearlier in the batch are referenced by their ref prefixed with $ in id, deviceId and the brokerId\nof the device data. The version is compared like the If-Match header of the single endpoints.\nThe errors of the failed operation point at it, e.g. operations[2] or operations[2].data.name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -388,30 +491,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Brokers"
+                    "Batch"
                 ],
-                "summary": "Set broker's credentials",
+                "summary": "Apply a batch of changes to brokers, devices and controls",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Broker UUID",
-                        "name": "brokerId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Overwrite data",
+                        "description": "Operations",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SetBrokerCredentialsRequest"
+                            "$ref": "#/definitions/dto.BatchRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -431,6 +530,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -440,7 +551,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/devices": {
+        "/brokers": {
             "get": {
                 "security": [
                     {
@@ -454,15 +565,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "List devices",
+                "summary": "List brokers",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "UUID",
-                        "description": "Broker UUID",
-                        "name": "brokerId",
+                        "description": "Cursor of the next page, sent in the Link header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "server",
+                            "createdAt",
+                            "updatedAt"
+                        ],
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Sort key",
+                        "name": "sort",
                         "in": "query"
                     }
                 ],
@@ -472,16 +620,38 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.GetDeviceResponse"
+                                "$ref": "#/definitions/dto.GetBrokerResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Count of all the matching brokers"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -503,9 +673,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "Create a device",
+                "summary": "Create a broker",
                 "parameters": [
                     {
                         "description": "Create data",
@@ -513,7 +683,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateDeviceRequest"
+                            "$ref": "#/definitions/dto.CreateBrokerRequest"
                         }
                     }
                 ],
@@ -521,7 +691,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateDeviceResponse"
+                            "$ref": "#/definitions/dto.CreateBrokerResponse"
                         }
                     },
                     "400": {
@@ -542,6 +712,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -551,13 +727,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/devices/{deviceId}": {
-            "get": {
+        "/brokers/test": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Connects to a broker which has not been saved yet and reports the DNS, TCP, TLS and CONNACK steps.",
                 "consumes": [
                     "application/json"
                 ],
@@ -565,23 +742,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "Get a single device",
+                "summary": "Test broker settings",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device UUID",
-                        "name": "deviceId",
-                        "in": "path",
-                        "required": true
+                        "description": "Broker settings",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TestBrokerRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetDeviceResponse"
+                            "$ref": "#/definitions/dto.TestBrokerResponse"
                         }
                     },
                     "400": {
@@ -596,12 +775,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -609,13 +782,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/brokers/{brokerId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "The ETag follows the version of the broker settings along with its status.",
                 "consumes": [
                     "application/json"
                 ],
@@ -623,16 +799,95 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "Delete a device",
+                "summary": "Get a broker",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device UUID",
-                        "name": "deviceId",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached broker",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetBrokerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version and status of the broker"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brokers"
+                ],
+                "summary": "Delete a broker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the broker to delete",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -657,6 +912,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -678,24 +939,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "Update a device",
+                "summary": "Update a broker",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device UUID",
-                        "name": "deviceId",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the broker the update is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "Update data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateDeviceRequest"
+                            "$ref": "#/definitions/dto.UpdateBrokerRequest"
                         }
                     }
                 ],
@@ -721,6 +988,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -730,13 +1009,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/devices/{deviceId}/controls": {
+        "/brokers/{brokerId}/certificates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Describes the stored CA bundle and client certificate, the private key is never returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -744,14 +1024,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "List a device controls",
+                "summary": "Get broker's certificates",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device UUID",
-                        "name": "deviceId",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
                         "in": "path",
                         "required": true
                     }
@@ -760,10 +1040,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.GetDeviceControlResponse"
-                            }
+                            "$ref": "#/definitions/dto.GetBrokerCertificatesResponse"
                         }
                     },
                     "400": {
@@ -791,13 +1068,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/brokers/{brokerId}/certificates/ca": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "The PEM bundle replaces the system roots when connecting to the broker over TLS.",
                 "consumes": [
                     "application/json"
                 ],
@@ -805,32 +1085,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "Create a device control",
+                "summary": "Upload or rotate broker's CA bundle",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device UUID",
-                        "name": "deviceId",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Create data",
+                        "description": "CA bundle",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateDeviceControlRequest"
+                            "$ref": "#/definitions/dto.SetCACertificateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateDeviceControlResponse"
+                            "$ref": "#/definitions/dto.GetBrokerCertificatesResponse"
                         }
                     },
                     "400": {
@@ -851,12 +1131,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -864,9 +1138,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/devices/{deviceId}/controls/{controlId}": {
+            },
             "delete": {
                 "security": [
                     {
@@ -880,28 +1152,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "Delete a device control",
+                "summary": "Remove broker's CA bundle",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device UUID",
-                        "name": "deviceId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Control UUID",
-                        "name": "controlId",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetBrokerCertificatesResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -928,13 +1196,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/brokers/{brokerId}/certificates/client": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "The certificate and its private key are used for mutual TLS, the key is stored encrypted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -942,37 +1213,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "Brokers"
                 ],
-                "summary": "Update a device control",
+                "summary": "Upload or rotate broker's client certificate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device UUID",
-                        "name": "deviceId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Control UUID",
-                        "name": "controlId",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update data",
+                        "description": "Client certificate and key",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateDeviceControlRequest"
+                            "$ref": "#/definitions/dto.SetClientCertificateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetBrokerCertificatesResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -992,41 +1259,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/events": {
-            "get": {
-                "description": "The event bus allows you to receive events along a specific user,",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "text/event-stream"
-                ],
-                "tags": [
-                    "Events"
-                ],
-                "summary": "Subscribe a client (user) to the events bus",
-                "responses": {
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1034,16 +1266,13 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/confirm-account": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Requires a valid confirm token sent in the Authorization header",
                 "consumes": [
                     "application/json"
                 ],
@@ -1051,12 +1280,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
+                ],
+                "summary": "Remove broker's client certificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Confirm a newly registered account",
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetBrokerCertificatesResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1070,8 +1311,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/errors.HTTPError"
                         }
@@ -1085,57 +1326,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/confirm-account/resend": {
+        "/brokers/{brokerId}/clone": {
             "post": {
-                "description": "Sends a token to provided mailbox, if the account awaits to be confirmed",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Send a new token to confirm an account",
-                "parameters": [
+                "security": [
                     {
-                        "description": "Resend confirm input",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserEmailRequest"
-                        }
+                        "BearerAuth": []
                     }
                 ],
-                "responses": {
-                    "202": {
-                        "description": "Accepted"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/users/login": {
-            "post": {
+                "description": "The broker is copied to the new server along with its credentials, certificates and all its\ndevices with their controls. The client ID is not copied.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1143,25 +1341,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
                 ],
-                "summary": "Login a user",
+                "summary": "Clone a broker",
                 "parameters": [
                     {
-                        "description": "Login data",
-                        "name": "data",
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone options",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginUserRequest"
+                            "$ref": "#/definitions/dto.CloneBrokerRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.Tokens"
+                            "$ref": "#/definitions/dto.CreateBrokerResponse"
                         }
                     },
                     "400": {
@@ -1170,6 +1375,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1191,7 +1402,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/brokers/{brokerId}/credentials": {
             "get": {
                 "security": [
                     {
@@ -1205,14 +1416,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
+                ],
+                "summary": "Get broker's credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached credentials",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get a logged in user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetUserResponse"
+                            "$ref": "#/definitions/dto.GetBrokerCredentialsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the broker"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
                     "401": {
@@ -1235,7 +1476,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1248,17 +1489,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
                 ],
-                "summary": "Delete a logged in user",
+                "summary": "Set broker's credentials",
                 "parameters": [
                     {
-                        "description": "Delete data",
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the broker the credentials are based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Overwrite data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DeleteUserRequest"
+                            "$ref": "#/definitions/dto.SetBrokerCredentialsRequest"
                         }
                     }
                 ],
@@ -1284,6 +1538,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1291,13 +1551,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/brokers/{brokerId}/discovery": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Proposals are created from the Home Assistant discovery messages when the discovery mode of the broker is set to propose.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1305,23 +1568,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Discovery"
                 ],
-                "summary": "Update a logged in user",
+                "summary": "List discovery proposals of a broker",
                 "parameters": [
                     {
-                        "description": "Update data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetDiscoveryProposalResponse"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1341,12 +1608,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/errors.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1356,8 +1617,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/logout": {
-            "post": {
+        "/brokers/{brokerId}/discovery/{proposalId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A dismissed proposal is not proposed again, even if the entity is announced once more.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1365,13 +1632,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Discovery"
+                ],
+                "summary": "Dismiss a discovery proposal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proposal UUID",
+                        "name": "proposalId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Logout a user",
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1393,13 +1682,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/password": {
-            "patch": {
+        "/brokers/{brokerId}/discovery/{proposalId}/accept": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Creates the control of the proposal, the device is reused when the broker already has a device with the same name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1407,23 +1697,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Discovery"
                 ],
-                "summary": "Change a password of logged in user",
+                "summary": "Accept a discovery proposal",
                 "parameters": [
                     {
-                        "description": "Change passoword data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ChangeUserPasswordRequest"
-                        }
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proposal UUID",
+                        "name": "proposalId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AcceptDiscoveryProposalResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1443,6 +1741,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1452,29 +1756,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/tokens": {
-            "post": {
+        "/brokers/{brokerId}/explore": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Requires a valid refresh token sent in the Authorization header",
+                "description": "The server subscribes to the filter for the window and streams the topic tree as the tree events\nwhenever new messages arrive, with their counts, the previews of the last payloads and the retained flags.\nThe end event is sent after the last tree, when the window is over.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
+                ],
+                "summary": "Explore the topics of a broker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Topic filter, # by default",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Window in seconds",
+                        "name": "window",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Generate a new pair of user tokens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.Tokens"
+                            "$ref": "#/definitions/dto.TopicTreeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
                     "401": {
@@ -1498,14 +1829,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/refresh": {
+        "/brokers/{brokerId}/explore/controls": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Requires a valid refresh token sent in the Authorization header",
+                "description": "The control is added to the device of the broker with the topic relative to the base path of the device,\nwhich has to contain the topic. The text-out type is used when the type is not sent.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1513,15 +1844,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
+                ],
+                "summary": "Create a control from an explored topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Control",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExploreControlRequest"
+                        }
+                    }
                 ],
-                "summary": "Generate a new pair of user tokens",
-                "deprecated": true,
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.Tokens"
+                            "$ref": "#/definitions/dto.CreateDeviceControlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
                     "401": {
@@ -1536,6 +1890,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1545,9 +1905,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/register": {
+        "/brokers/{brokerId}/restore": {
             "post": {
-                "description": "A link to confirm account will be sent to the provided email",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The devices detached from the broker by its deletion are attached back to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1555,23 +1920,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
                 ],
-                "summary": "Register a new user",
+                "summary": "Restore a deleted broker",
                 "parameters": [
                     {
-                        "description": "Register input",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1579,6 +1942,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -1590,9 +1965,67 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/errors.HTTPError"
                         }
+                    }
+                }
+            }
+        },
+        "/brokers/{brokerId}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The revisions are ordered from the latest one, the credentials are not kept in them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "List the revisions of a broker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RevisionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/errors.HTTPError"
                         }
@@ -1600,9 +2033,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/reset-password": {
+        "/brokers/{brokerId}/revisions/{revision}/restore": {
             "post": {
-                "description": "User receives a token at a given email",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The settings of the broker are set back to the revision, which is stored as a new revision.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1610,23 +2048,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Revisions"
                 ],
-                "summary": "Call an action to reset user's password",
+                "summary": "Restore a broker to a revision",
                 "parameters": [
                     {
-                        "description": "Reset passoword data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserEmailRequest"
-                        }
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the broker the restore is based on",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1634,6 +2083,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1641,14 +2114,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/brokers/{brokerId}/test": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Requires a token sent in the Authorization header to verify password's change action",
+                "description": "Connects to the broker with the stored credentials and reports the DNS, TCP, TLS and CONNACK steps.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1656,23 +2131,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Brokers"
                 ],
-                "summary": "Set a new password for a user",
+                "summary": "Test a broker connection",
                 "parameters": [
                     {
-                        "description": "Reset passoword data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ResetUserPasswordRequest"
-                        }
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TestBrokerResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1686,6 +2162,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1694,262 +2176,7452 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.ChangeUserPasswordRequest": {
-            "type": "object",
-            "required": [
-                "newPassword",
-                "password"
-            ],
-            "properties": {
-                "newPassword": {
-                    "type": "string",
-                    "minLength": 6
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                }
-            }
         },
-        "dto.ControlAttributes": {
-            "type": "object",
-            "properties": {
-                "colorFormat": {
-                    "type": "string"
-                },
-                "maxValue": {
-                    "type": "number"
-                },
-                "minValue": {
-                    "type": "number"
-                },
-                "offPayload": {
-                    "type": "string"
-                },
-                "onPayload": {
-                    "type": "string"
-                },
-                "payload": {
-                    "type": "string"
-                },
-                "payloadTemplate": {
-                    "type": "string"
-                },
-                "payloads": {
-                    "type": "object",
-                    "additionalProperties": {
+        "/brokers/{brokerId}/topics/lint": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports the invalid topics, the topics published to by more than one control and the wildcard\nsubscriptions receiving the messages of other controls. The topics are the effective ones,\nthe base path of the device joined with the topic of the control.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brokers"
+                ],
+                "summary": "Lint the topics of a broker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TopicIssueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/control-types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Each control type comes with the JSON Schema of its attributes, the forms of the controls can be rendered from it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Control Types"
+                ],
+                "summary": "List control types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetControlTypeResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/control-types/{type}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Control Types"
+                ],
+                "summary": "Get a single control type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Control type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetControlTypeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/controls/transform/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The transform of the displayed values reads the value with the JSONPath, captures it with the regex,\nscales, offsets and rounds it and replaces it with its label. The result is decoded as a control\nof the type when it is set, with the attributes which the type requires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Control Types"
+                ],
+                "summary": "Preview a transform of the received payloads",
+                "parameters": [
+                    {
+                        "description": "Payload and transform",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransformPreviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransformPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "List dashboards in their order",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetDashboardResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Create a dashboard",
+                "parameters": [
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDashboardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDashboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The ids have to list every dashboard of the user exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Reorder dashboards",
+                "parameters": [
+                    {
+                        "description": "Dashboard ids in the new order",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/{dashboardId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Get a single dashboard with its tabs and widgets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetDashboardDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Delete a dashboard with its tabs and widgets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Update a dashboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDashboardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/{dashboardId}/tabs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Add a tab at the end of a dashboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDashboardTabRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDashboardTabResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/{dashboardId}/tabs/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The ids have to list every tab of the dashboard exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Reorder dashboard tabs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tab ids in the new order",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/{dashboardId}/tabs/{tabId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Delete a dashboard tab with its widgets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tab UUID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Update a dashboard tab",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tab UUID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDashboardTabRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/{dashboardId}/tabs/{tabId}/layout": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The widgets which are not listed keep their places.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Move and resize widgets of a dashboard tab at once",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tab UUID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Widget places",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WidgetLayoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/{dashboardId}/tabs/{tabId}/widgets": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The widget shows a device, or one of its controls when the control is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Place a widget on a dashboard tab",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tab UUID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDashboardWidgetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDashboardWidgetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboards/{dashboardId}/tabs/{tabId}/widgets/{widgetId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Remove a widget from a dashboard tab",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tab UUID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Widget UUID",
+                        "name": "widgetId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboards"
+                ],
+                "summary": "Move, resize or change the overrides of a widget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dashboard UUID",
+                        "name": "dashboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tab UUID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Widget UUID",
+                        "name": "widgetId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDashboardWidgetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "List devices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "UUID",
+                        "description": "Broker UUID",
+                        "name": "brokerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "UUID",
+                        "description": "Room UUID",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "UUID",
+                        "description": "Tag UUID",
+                        "name": "tagId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Placing, deprecated in favour of the rooms",
+                        "name": "placing",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Type of a control of the device",
+                        "name": "controlType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, sent in the Link header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "placing",
+                            "createdAt",
+                            "updatedAt"
+                        ],
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetDeviceResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Count of all the matching devices"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Create a device",
+                "parameters": [
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The ETag follows the version of the device along with its status and the time it was last seen.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Get a single device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached device",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetDeviceResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version and status of the device"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Delete a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the device to delete",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Update a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the device the update is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/availability": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Get the availability of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The status of the device is tracked by the server. The lwt devices go online and offline with\nthe payloads received on the topic, usually their last will. The heartbeat devices are online while\nthey publish on the topic, or on the topics of their controls without it, within the timeout and the\nretained messages are not counted. The topic is relative to the base path of the device. Every change\nof the status is recorded in the history and published as a DEVICE_ONLINE or DEVICE_OFFLINE event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Set the availability of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Availability data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetAvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops the tracking of the device, its status is unknown again while the history is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Delete the availability of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/availability/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the changes of the status of the device, along with what has caused them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "List the availability history of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changed at or after, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changed before, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, sent in the Link header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "createdAt"
+                        ],
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AvailabilityTransitionResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Count of all the matching changes"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The device is copied along with its controls and tags, the fields which are not sent keep\nthe values of the device. The null broker leaves the copy without one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Clone a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloneDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/controls": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "List a device controls",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Availability",
+                        "name": "isAvailable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "UUID",
+                        "description": "Tag UUID",
+                        "name": "tagId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, sent in the Link header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "type",
+                            "topic"
+                        ],
+                        "type": "string",
+                        "default": "name",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetDeviceControlResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Count of all the matching controls"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Create a device control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDeviceControlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDeviceControlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/controls/{controlId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Delete a device control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control UUID",
+                        "name": "controlId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the control to delete, in quotes",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Update a device control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control UUID",
+                        "name": "controlId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the control the update is based on, in quotes",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDeviceControlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/controls/{controlId}/render": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the payload which the control would publish for the value, rendered with its payload template,\nwithout publishing it. The payloads which are not valid UTF-8 are encoded with base64.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Render the payload of a device control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control UUID",
+                        "name": "controlId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rendered value",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RenderControlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RenderControlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/controls/{controlId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The device has to be restored first when it has been deleted too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Restore a deleted device control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control UUID",
+                        "name": "controlId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/controls/{controlId}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The revisions are ordered from the latest one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "List the revisions of a device control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control UUID",
+                        "name": "controlId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RevisionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/controls/{controlId}/revisions/{revision}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The definition of the control is set back to the revision, which is stored as a new revision.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Restore a device control to a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control UUID",
+                        "name": "controlId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the control the restore is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/controls/{controlId}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Replace the tags of a device control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Control UUID",
+                        "name": "controlId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag ids",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/messages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The log holds the messages received on the topics of the controls of the device and the ones published\nby the server, the outbound ones are linked to their source. The topic is a filter which can contain\nwildcards and the contains is searched for in the payloads. The payloads which are not valid UTF-8\nare encoded with base64.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "List the messages of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Topic filter",
+                        "name": "topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text contained in the payload",
+                        "name": "contains",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Received at or after, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Received before, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, sent in the Link header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "createdAt",
+                            "topic"
+                        ],
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DeviceMessageResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Count of all the matching messages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/messages/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exports the messages matching the filters from the oldest one, as newline delimited JSON\nwith a message per line or as CSV with a header row.",
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Export the messages of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ndjson",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Topic filter",
+                        "name": "topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text contained in the payload",
+                        "name": "contains",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Received at or after, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Received before, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DeviceMessageResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The controls deleted along with the device are restored too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Restore a deleted device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The revisions are ordered from the latest one, a device revision holds the definitions of its controls,\nso changing a control makes a new revision of the device too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "List the revisions of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RevisionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/revisions/{revision}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The device and its control set are set back to the revision. The controls added since are deleted,\nthe deleted ones are restored and the ones purged from the trash are created with a new ID.\nThe broker and the room which no longer exist are left unset.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Restore a device to a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the device the restore is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{deviceId}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Replace the tags of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device UUID",
+                        "name": "deviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag ids",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "The event bus allows you to receive events along a specific user,",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Subscribe a client (user) to the events bus",
+                "responses": {
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exports the whole configuration of a user as a single document, which can be imported later on.",
+                "produces": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "tags": [
+                    "Transfer"
+                ],
+                "summary": "Export brokers, devices and controls",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "yaml"
+                        ],
+                        "type": "string",
+                        "description": "Document format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include broker credentials",
+                        "name": "credentials",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferDocument"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "List device groups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetGroupResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The type of the group is the type of all its controls.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Create a device group",
+                "parameters": [
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get a single device group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group UUID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Delete a device group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group UUID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Update a device group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group UUID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/controls": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The controls have to be of the type of the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Replace the controls of a device group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group UUID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Control ids",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetGroupControlsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The value is published to the brokers of the members concurrently, the result of each member is returned.\nOnce a broker fails, its remaining members get the same error without waiting for it again.\nThe members which could not be published do not fail the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Publish a value to every control of a device group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group UUID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Published value",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PublishGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PublishGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/state": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The state is derived from the last values published to the controls. The group is all-on\nwhen every control with a known value is on, e.g. a switch set to true or a slider above its minimum.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get the state of a device group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group UUID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetGroupStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Imports a document created by the export. Entities already present are matched - brokers by their server,",
+                "consumes": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfer"
+                ],
+                "summary": "Import brokers, devices and controls",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only report the changes",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "overwrite",
+                            "rename"
+                        ],
+                        "type": "string",
+                        "description": "Conflict strategy",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Configuration document",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "List rooms in their order",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetRoomResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Room names are unique per user regardless of the case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Create a room",
+                "parameters": [
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The ids have to list every room of the user exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Reorder rooms",
+                "parameters": [
+                    {
+                        "description": "Room ids in the new order",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{roomId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Get a single room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room UUID",
+                        "name": "roomId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The devices of the room are left without a room.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Delete a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room UUID",
+                        "name": "roomId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Update a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room UUID",
+                        "name": "roomId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{roomId}/state": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Counts the devices and controls of the room. The room is online when the brokers of all its devices\nare online, partial when only some of them are and offline when none of them is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Get the aggregate state of a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room UUID",
+                        "name": "roomId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The text mode matches the words of the names, servers, placings, paths and topics, the last word as a prefix.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search brokers, devices and controls",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Searched text or topic filter",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "text",
+                            "topic"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "Search mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "broker",
+                                "device",
+                                "control"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Types of the results",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Count of the results",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SearchResultResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetTagResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tag names are unique per user regardless of the case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Create a tag",
+                "parameters": [
+                    {
+                        "description": "Create data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tagId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The tag is removed from all devices and controls.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag UUID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Update a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag UUID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The entries are ordered from the most recently deleted, they are purged for good at purgeAt.\nThe dependents of a broker are the devices detached from it and the dependents of a device are\nthe controls deleted along with it, restoring the entry restores its dependents too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List the deleted brokers, devices and controls",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TrashEntryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/confirm-account": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requires a valid confirm token sent in the Authorization header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm a newly registered account",
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/confirm-account/resend": {
+            "post": {
+                "description": "Sends a token to provided mailbox, if the account awaits to be confirmed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Send a new token to confirm an account",
+                "parameters": [
+                    {
+                        "description": "Resend confirm input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Login a user",
+                "parameters": [
+                    {
+                        "description": "Login data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Tokens"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a logged in user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetUserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete a logged in user",
+                "parameters": [
+                    {
+                        "description": "Delete data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update a logged in user",
+                "parameters": [
+                    {
+                        "description": "Update data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/logout": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Logout a user",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change a password of logged in user",
+                "parameters": [
+                    {
+                        "description": "Change passoword data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangeUserPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/tokens": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requires a valid refresh token sent in the Authorization header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Generate a new pair of user tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Tokens"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requires a valid refresh token sent in the Authorization header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Generate a new pair of user tokens",
+                "deprecated": true,
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Tokens"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "A link to confirm account will be sent to the provided email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Register input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/reset-password": {
+            "post": {
+                "description": "User receives a token at a given email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Call an action to reset user's password",
+                "parameters": [
+                    {
+                        "description": "Reset passoword data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requires a token sent in the Authorization header to verify password's change action",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set a new password for a user",
+                "parameters": [
+                    {
+                        "description": "Reset passoword data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetUserPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AcceptDiscoveryProposalResponse": {
+            "type": "object",
+            "properties": {
+                "controlId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.AvailabilityTransitionResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "reason": {
+                    "enum": [
+                        "payload",
+                        "message",
+                        "timeout"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AvailabilityReason"
+                        }
+                    ]
+                },
+                "status": {
+                    "enum": [
+                        "online",
+                        "offline"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.DeviceStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.BatchOperationRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "entity"
+            ],
+            "properties": {
+                "action": {
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.BatchAction"
+                        }
+                    ]
+                },
+                "data": {
+                    "type": "object"
+                },
+                "deviceId": {
+                    "type": "string"
+                },
+                "entity": {
+                    "enum": [
+                        "broker",
+                        "device",
+                        "control"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.BatchEntity"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BatchRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.BatchOperationRequest"
+                    }
+                }
+            }
+        },
+        "dto.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BatchResultResponse"
+                    }
+                }
+            }
+        },
+        "dto.BatchResultResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/enum.BatchAction"
+                },
+                "entity": {
+                    "$ref": "#/definitions/enum.BatchEntity"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "ref": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificateResponse": {
+            "type": "object",
+            "properties": {
+                "fingerprint": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "notAfter": {
+                    "type": "string"
+                },
+                "notBefore": {
+                    "type": "string"
+                },
+                "serialNumber": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificateWarningResponse": {
+            "type": "object",
+            "properties": {
+                "expiresInDays": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "ca"
+                },
+                "notAfter": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChangeUserPasswordRequest": {
+            "type": "object",
+            "required": [
+                "newPassword",
+                "password"
+            ],
+            "properties": {
+                "newPassword": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.CloneBrokerRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "server"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "server": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CloneDeviceRequest": {
+            "type": "object",
+            "properties": {
+                "basePath": {
+                    "type": "string",
+                    "nullable": true
+                },
+                "brokerId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "nullable": true
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ControlAttributes": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "dto.CreateAlertResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateBrokerRequest": {
+            "type": "object",
+            "required": [
+                "icon",
+                "isSsl",
+                "keepAlive",
+                "name",
+                "port",
+                "server"
+            ],
+            "properties": {
+                "cleanStart": {
+                    "type": "boolean"
+                },
+                "clientId": {
+                    "type": "string",
+                    "nullable": true
+                },
+                "discoveryMode": {
+                    "enum": [
+                        "disabled",
+                        "propose",
+                        "auto"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.DiscoveryMode"
+                        }
+                    ]
+                },
+                "discoveryPrefix": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "isSsl": {
+                    "type": "boolean"
+                },
+                "keepAlive": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "nullable": true,
+                    "example": "/mqtt"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "protocolVersion": {
+                    "enum": [
+                        "3.1.1",
+                        "5"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTVersion"
+                        }
+                    ]
+                },
+                "server": {
+                    "type": "string"
+                },
+                "sessionExpiry": {
+                    "type": "integer"
+                },
+                "transport": {
+                    "enum": [
+                        "tcp",
+                        "ws",
+                        "wss"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTTransport"
+                        }
+                    ]
+                },
+                "userProperties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateBrokerResponse": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateDashboardRequest": {
+            "type": "object",
+            "required": [
+                "icon",
+                "name"
+            ],
+            "properties": {
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.CreateDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateDashboardTabRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.CreateDashboardTabResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateDashboardWidgetRequest": {
+            "type": "object",
+            "required": [
+                "deviceId",
+                "height",
+                "width",
+                "x",
+                "y"
+            ],
+            "properties": {
+                "controlId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "height": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
+                },
+                "overrides": {
+                    "$ref": "#/definitions/dto.WidgetOverrides"
+                },
+                "width": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
+                },
+                "x": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "y": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.CreateDashboardWidgetResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateDeviceControlRequest": {
+            "type": "object",
+            "required": [
+                "canDisplayName",
+                "canNotifyOnPublish",
+                "icon",
+                "isAvailable",
+                "isConfirmationRequired",
+                "name",
+                "topic",
+                "type"
+            ],
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/dto.ControlAttributes"
+                },
+                "canDisplayName": {
+                    "type": "boolean"
+                },
+                "canNotifyOnPublish": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "isConfirmationRequired": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "qualityOfService": {
+                    "$ref": "#/definitions/enum.QoSLevel"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/enum.ControlType"
+                }
+            }
+        },
+        "dto.CreateDeviceControlResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateDeviceRequest": {
+            "type": "object",
+            "required": [
+                "icon",
+                "name"
+            ],
+            "properties": {
+                "basePath": {
+                    "type": "string"
+                },
+                "brokerId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "placing": {
+                    "type": "string"
+                },
+                "roomId": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateGroupRequest": {
+            "type": "object",
+            "required": [
+                "icon",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "type": {
+                    "enum": [
+                        "switch",
+                        "button",
+                        "slider"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.ControlType"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.CreateGroupResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateRoomRequest": {
+            "type": "object",
+            "required": [
+                "icon",
+                "name"
+            ],
+            "properties": {
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.CreateRoomResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateTagRequest": {
+            "type": "object",
+            "required": [
+                "color",
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "dto.CreateTagResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.DeleteUserRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.DeviceMessageResponse": {
+            "type": "object",
+            "properties": {
+                "controlId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "direction": {
+                    "enum": [
+                        "inbound",
+                        "outbound"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MessageDirection"
+                        }
+                    ]
+                },
+                "encoding": {
+                    "enum": [
+                        "utf8",
+                        "base64"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.PayloadEncoding"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "qualityOfService": {
+                    "$ref": "#/definitions/enum.QoSLevel"
+                },
+                "retained": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "$ref": "#/definitions/dto.MessageSourceResponse"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.DiscoveryControl": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/dto.ControlAttributes"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "qualityOfService": {
+                    "$ref": "#/definitions/enum.QoSLevel"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/enum.ControlType"
+                }
+            }
+        },
+        "dto.DiscoveryDevice": {
+            "type": "object",
+            "properties": {
+                "identifier": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExploreControlRequest": {
+            "type": "object",
+            "required": [
+                "deviceId",
+                "name",
+                "topic"
+            ],
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/dto.ControlAttributes"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "qualityOfService": {
+                    "$ref": "#/definitions/enum.QoSLevel"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/enum.ControlType"
+                }
+            }
+        },
+        "dto.GetAlertIncidentResponse": {
+            "type": "object",
+            "properties": {
+                "acknowledgedAt": {
+                    "type": "string"
+                },
+                "alertId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "alertName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "kind": {
+                    "enum": [
+                        "threshold",
+                        "stateChange",
+                        "deviceOffline",
+                        "brokerDisconnected"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AlertKind"
+                        }
+                    ]
+                },
+                "message": {
+                    "type": "string"
+                },
+                "openedAt": {
+                    "type": "string"
+                },
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "open",
+                        "acknowledged",
+                        "resolved"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.IncidentStatus"
+                        }
+                    ]
+                },
+                "value": {}
+            }
+        },
+        "dto.GetAlertResponse": {
+            "type": "object",
+            "properties": {
+                "brokerId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "controlId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "cooldownMinutes": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "hysteresis": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "isEnabled": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "enum": [
+                        "threshold",
+                        "stateChange",
+                        "deviceOffline",
+                        "brokerDisconnected"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AlertKind"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notifyEmail": {
+                    "type": "boolean"
+                },
+                "offlineMinutes": {
+                    "type": "integer"
+                },
+                "operator": {
+                    "enum": [
+                        "above",
+                        "below"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AlertOperator"
+                        }
+                    ]
+                },
+                "state": {},
+                "threshold": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "webhookUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "mode": {
+                    "enum": [
+                        "lwt",
+                        "heartbeat"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AvailabilityMode"
+                        }
+                    ]
+                },
+                "offlinePayload": {
+                    "type": "string"
+                },
+                "onlinePayload": {
+                    "type": "string"
+                },
+                "timeoutSeconds": {
+                    "type": "integer"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetBrokerCertificatesResponse": {
+            "type": "object",
+            "properties": {
+                "ca": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CertificateResponse"
+                    }
+                },
+                "client": {
+                    "$ref": "#/definitions/dto.CertificateResponse"
+                },
+                "hasClientKey": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CertificateWarningResponse"
+                    }
+                }
+            }
+        },
+        "dto.GetBrokerCredentialsResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetBrokerResponse": {
+            "type": "object",
+            "properties": {
+                "cleanStart": {
+                    "type": "boolean"
+                },
+                "clientId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "discoveryMode": {
+                    "$ref": "#/definitions/enum.DiscoveryMode"
+                },
+                "discoveryPrefix": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "isSsl": {
+                    "type": "boolean"
+                },
+                "keepAlive": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "protocolVersion": {
+                    "$ref": "#/definitions/enum.MQTTVersion"
+                },
+                "server": {
+                    "type": "string"
+                },
+                "sessionExpiry": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/enum.BrokerStatus"
+                },
+                "statusChangedAt": {
+                    "type": "string"
+                },
+                "transport": {
+                    "$ref": "#/definitions/enum.MQTTTransport"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userProperties": {
+                    "type": "object",
+                    "additionalProperties": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "dto.GetControlTypeResponse": {
+            "type": "object",
+            "properties": {
+                "canDisplay": {
+                    "type": "boolean"
+                },
+                "canPublish": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "schema": {
+                    "type": "object"
+                },
+                "type": {
+                    "$ref": "#/definitions/enum.ControlType"
+                }
+            }
+        },
+        "dto.GetDashboardDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "tabs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDashboardTabResponse"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetDashboardTabResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "widgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetDashboardWidgetResponse"
+                    }
+                }
+            }
+        },
+        "dto.GetDashboardWidgetResponse": {
+            "type": "object",
+            "properties": {
+                "controlId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "overrides": {
+                    "$ref": "#/definitions/dto.WidgetOverrides"
+                },
+                "width": {
+                    "type": "integer"
+                },
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetDeviceControlResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/dto.ControlAttributes"
+                },
+                "canDisplayName": {
+                    "type": "boolean"
+                },
+                "canNotifyOnPublish": {
+                    "type": "boolean"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "isConfirmationRequired": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "qualityOfService": {
+                    "$ref": "#/definitions/enum.QoSLevel"
+                },
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/enum.ControlType"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "basePath": {
+                    "type": "string"
+                },
+                "brokerId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "placing": {
+                    "type": "string"
+                },
+                "roomId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "status": {
+                    "description": "Status is tracked for the devices with the availability, the others are unknown.",
+                    "enum": [
+                        "unknown",
+                        "online",
+                        "offline"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.DeviceStatus"
+                        }
+                    ]
+                },
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetDiscoveryProposalResponse": {
+            "type": "object",
+            "properties": {
+                "brokerId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "component": {
+                    "type": "string"
+                },
+                "configTopic": {
+                    "type": "string"
+                },
+                "control": {
+                    "$ref": "#/definitions/dto.DiscoveryControl"
+                },
+                "device": {
+                    "$ref": "#/definitions/dto.DiscoveryDevice"
+                },
+                "discoveredAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "dto.GetGroupResponse": {
+            "type": "object",
+            "properties": {
+                "controlIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "switch",
+                        "button",
+                        "slider"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.ControlType"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetGroupStateResponse": {
+            "type": "object",
+            "properties": {
+                "groupId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "offCount": {
+                    "type": "integer"
+                },
+                "onCount": {
+                    "type": "integer"
+                },
+                "state": {
+                    "enum": [
+                        "all-on",
+                        "some-on",
+                        "all-off",
+                        "unknown"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.GroupState"
+                        }
+                    ]
+                },
+                "unknownCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetRoomResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.Icon"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetRoomStateResponse": {
+            "type": "object",
+            "properties": {
+                "availableControlCount": {
+                    "type": "integer"
+                },
+                "brokers": {
+                    "$ref": "#/definitions/dto.RoomBrokerStatuses"
+                },
+                "controlCount": {
+                    "type": "integer"
+                },
+                "deviceCount": {
+                    "type": "integer"
+                },
+                "roomId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "status": {
+                    "enum": [
+                        "online",
+                        "partial",
+                        "offline",
+                        "unknown"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.RoomStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.GetTagResponse": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetUserResponse": {
+            "type": "object",
+            "required": [
+                "email",
+                "id",
+                "language",
+                "name",
+                "theme"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GroupPublishError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "CONTROL_VALUE_INVALID"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "value is not valid for the control"
+                }
+            }
+        },
+        "dto.GroupPublishResult": {
+            "type": "object",
+            "properties": {
+                "controlId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "error": {
+                    "$ref": "#/definitions/dto.GroupPublishError"
+                },
+                "published": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.Icon": {
+            "type": "object",
+            "required": [
+                "backgroundColor",
+                "name"
+            ],
+            "properties": {
+                "backgroundColor": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.IconOptional": {
+            "type": "object",
+            "properties": {
+                "backgroundColor": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.MessageSourceResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "type": {
+                    "enum": [
+                        "user",
+                        "apiKey",
+                        "automation"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MessageSource"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.OrderRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                }
+            }
+        },
+        "dto.PublishGroupRequest": {
+            "type": "object",
+            "properties": {
+                "value": {}
+            }
+        },
+        "dto.PublishGroupResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GroupPublishResult"
+                    }
+                }
+            }
+        },
+        "dto.RenderControlRequest": {
+            "type": "object",
+            "properties": {
+                "value": {}
+            }
+        },
+        "dto.RenderControlResponse": {
+            "type": "object",
+            "properties": {
+                "encoding": {
+                    "$ref": "#/definitions/enum.PayloadEncoding"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "qualityOfService": {
+                    "$ref": "#/definitions/enum.QoSLevel"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResetUserPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.RevisionChangeResponse": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "dto.RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RevisionChangeResponse"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "type": "object"
+                }
+            }
+        },
+        "dto.RoomBrokerStatuses": {
+            "type": "object",
+            "properties": {
+                "offline": {
+                    "type": "integer"
                 },
-                "sendAsTicks": {
-                    "type": "boolean"
+                "online": {
+                    "type": "integer"
+                },
+                "unknown": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SearchResultResponse": {
+            "type": "object",
+            "properties": {
+                "brokerId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "highlight": {
+                    "type": "string",
+                    "example": "\u003cmark\u003eKitchen\u003c/mark\u003e humidity"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
                 },
-                "value": {
+                "name": {
                     "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "type": {
+                    "enum": [
+                        "broker",
+                        "device",
+                        "control"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.SearchResultType"
+                        }
+                    ]
                 }
             }
         },
-        "dto.CreateBrokerRequest": {
+        "dto.SetAlertRequest": {
             "type": "object",
             "required": [
-                "icon",
-                "isSsl",
-                "keepAlive",
-                "name",
-                "port",
-                "server"
+                "kind",
+                "name"
             ],
             "properties": {
-                "clientId": {
+                "brokerId": {
                     "type": "string",
-                    "nullable": true
+                    "format": "uuid"
                 },
-                "icon": {
-                    "$ref": "#/definitions/dto.Icon"
+                "controlId": {
+                    "type": "string",
+                    "format": "uuid"
                 },
-                "isSsl": {
-                    "type": "boolean"
+                "cooldownMinutes": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 0
                 },
-                "keepAlive": {
-                    "type": "integer"
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "hysteresis": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "isEnabled": {
+                    "type": "boolean",
+                    "default": true
+                },
+                "kind": {
+                    "enum": [
+                        "threshold",
+                        "stateChange",
+                        "deviceOffline",
+                        "brokerDisconnected"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AlertKind"
+                        }
+                    ]
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "port": {
-                    "type": "integer"
+                "notifyEmail": {
+                    "type": "boolean"
                 },
-                "server": {
+                "offlineMinutes": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 1
+                },
+                "operator": {
+                    "enum": [
+                        "above",
+                        "below"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AlertOperator"
+                        }
+                    ]
+                },
+                "state": {},
+                "threshold": {
+                    "type": "number"
+                },
+                "webhookUrl": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "dto.SetAvailabilityRequest": {
+            "type": "object",
+            "required": [
+                "mode"
+            ],
+            "properties": {
+                "mode": {
+                    "enum": [
+                        "lwt",
+                        "heartbeat"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.AvailabilityMode"
+                        }
+                    ]
+                },
+                "offlinePayload": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "onlinePayload": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "timeoutSeconds": {
+                    "type": "integer",
+                    "maximum": 604800,
+                    "minimum": 5
+                },
+                "topic": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "dto.SetBrokerCredentialsRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "nullable": true
+                },
+                "username": {
+                    "type": "string",
+                    "nullable": true
+                }
+            }
+        },
+        "dto.SetCACertificateRequest": {
+            "type": "object",
+            "required": [
+                "certificate"
+            ],
+            "properties": {
+                "certificate": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateBrokerResponse": {
+        "dto.SetClientCertificateRequest": {
             "type": "object",
             "required": [
-                "id"
+                "certificate",
+                "key"
             ],
             "properties": {
-                "id": {
+                "certificate": {
+                    "type": "string"
+                },
+                "key": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateDeviceControlRequest": {
+        "dto.SetGroupControlsRequest": {
             "type": "object",
             "required": [
-                "canDisplayName",
-                "canNotifyOnPublish",
-                "icon",
-                "isAvailable",
-                "isConfirmationRequired",
-                "name",
-                "topic",
-                "type"
+                "ids"
             ],
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/dto.ControlAttributes"
+                "ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                }
+            }
+        },
+        "dto.SetTagsRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                }
+            }
+        },
+        "dto.TestBrokerRequest": {
+            "type": "object",
+            "required": [
+                "isSsl",
+                "port",
+                "server"
+            ],
+            "properties": {
+                "caCertificate": {
+                    "type": "string",
+                    "nullable": true
                 },
-                "canDisplayName": {
+                "cleanStart": {
                     "type": "boolean"
                 },
-                "canNotifyOnPublish": {
-                    "type": "boolean"
+                "clientCertificate": {
+                    "type": "string",
+                    "nullable": true
                 },
-                "icon": {
-                    "$ref": "#/definitions/dto.Icon"
+                "clientKey": {
+                    "type": "string",
+                    "nullable": true
                 },
-                "isAvailable": {
+                "isSsl": {
                     "type": "boolean"
                 },
-                "isConfirmationRequired": {
-                    "type": "boolean"
+                "keepAlive": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "password": {
+                    "type": "string",
+                    "nullable": true
                 },
-                "qualityOfService": {
-                    "$ref": "#/definitions/enum.QoSLevel"
+                "path": {
+                    "type": "string",
+                    "example": "/mqtt"
                 },
-                "topic": {
+                "port": {
+                    "type": "integer"
+                },
+                "protocolVersion": {
+                    "enum": [
+                        "3.1.1",
+                        "5"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTVersion"
+                        }
+                    ]
+                },
+                "server": {
                     "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/enum.ControlType"
+                "sessionExpiry": {
+                    "type": "integer"
+                },
+                "transport": {
+                    "enum": [
+                        "tcp",
+                        "ws",
+                        "wss"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTTransport"
+                        }
+                    ]
+                },
+                "userProperties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "username": {
+                    "type": "string",
+                    "nullable": true
                 }
             }
         },
-        "dto.CreateDeviceControlResponse": {
+        "dto.TestBrokerResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "format": "uuid"
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TestBrokerStep"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreateDeviceRequest": {
+        "dto.TestBrokerStep": {
             "type": "object",
-            "required": [
-                "icon",
-                "name"
-            ],
             "properties": {
-                "basePath": {
+                "error": {
                     "type": "string"
                 },
-                "brokerId": {
-                    "type": "string",
-                    "format": "uuid"
-                },
-                "icon": {
-                    "$ref": "#/definitions/dto.Icon"
+                "latencyMs": {
+                    "type": "number"
                 },
                 "name": {
+                    "type": "string",
+                    "example": "connack"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.Tokens": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
                     "type": "string"
                 },
-                "placing": {
+                "refreshToken": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateDeviceResponse": {
+        "dto.TopicIssueControlResponse": {
             "type": "object",
             "properties": {
+                "deviceId": {
+                    "type": "string",
+                    "format": "uuid"
+                },
                 "id": {
                     "type": "string",
                     "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CreateUserRequest": {
+        "dto.TopicIssueResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "controls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TopicIssueControlResponse"
+                    }
                 },
-                "name": {
-                    "type": "string",
-                    "minLength": 3
+                "kind": {
+                    "enum": [
+                        "invalid",
+                        "duplicate",
+                        "overlap"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.TopicIssueKind"
+                        }
+                    ]
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "topic": {
+                    "type": "string"
                 }
             }
         },
-        "dto.DeleteUserRequest": {
+        "dto.TopicNodeResponse": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TopicNodeResponse"
+                    }
+                },
+                "messages": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "receivedAt": {
+                    "type": "string"
+                },
+                "retained": {
+                    "type": "boolean"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.GetBrokerCredentialsResponse": {
+        "dto.TopicTreeResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "format": "uuid"
+                "ignored": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string"
+                "messages": {
+                    "type": "integer"
                 },
-                "username": {
-                    "type": "string"
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TopicNodeResponse"
+                    }
+                },
+                "topics": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.GetBrokerResponse": {
+        "dto.TransferBroker": {
             "type": "object",
+            "required": [
+                "icon",
+                "isSsl",
+                "keepAlive",
+                "name",
+                "port",
+                "ref",
+                "server"
+            ],
             "properties": {
+                "cleanStart": {
+                    "type": "boolean"
+                },
                 "clientId": {
                     "type": "string"
                 },
-                "createdAt": {
+                "credentials": {
+                    "$ref": "#/definitions/dto.TransferCredentials"
+                },
+                "discoveryMode": {
+                    "enum": [
+                        "disabled",
+                        "propose",
+                        "auto"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.DiscoveryMode"
+                        }
+                    ]
+                },
+                "discoveryPrefix": {
                     "type": "string"
                 },
                 "icon": {
                     "$ref": "#/definitions/dto.Icon"
                 },
-                "id": {
-                    "type": "string",
-                    "format": "uuid"
-                },
                 "isSsl": {
                     "type": "boolean"
                 },
@@ -1959,19 +9631,76 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "path": {
+                    "type": "string"
+                },
                 "port": {
                     "type": "integer"
                 },
-                "server": {
+                "protocolVersion": {
+                    "enum": [
+                        "3.1.1",
+                        "5"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTVersion"
+                        }
+                    ]
+                },
+                "ref": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "server": {
                     "type": "string"
+                },
+                "sessionExpiry": {
+                    "type": "integer"
+                },
+                "transport": {
+                    "enum": [
+                        "tcp",
+                        "ws",
+                        "wss"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTTransport"
+                        }
+                    ]
+                },
+                "userProperties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.GetDeviceControlResponse": {
+        "dto.TransferChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "dto.TransferControl": {
             "type": "object",
+            "required": [
+                "canDisplayName",
+                "canNotifyOnPublish",
+                "icon",
+                "isAvailable",
+                "isConfirmationRequired",
+                "name",
+                "qualityOfService",
+                "ref",
+                "topic",
+                "type"
+            ],
             "properties": {
                 "attributes": {
                     "$ref": "#/definitions/dto.ControlAttributes"
@@ -1982,17 +9711,9 @@ const docTemplate = `{
                 "canNotifyOnPublish": {
                     "type": "boolean"
                 },
-                "deviceId": {
-                    "type": "string",
-                    "format": "uuid"
-                },
                 "icon": {
                     "$ref": "#/definitions/dto.Icon"
                 },
-                "id": {
-                    "type": "string",
-                    "format": "uuid"
-                },
                 "isAvailable": {
                     "type": "boolean"
                 },
@@ -2005,6 +9726,9 @@ const docTemplate = `{
                 "qualityOfService": {
                     "$ref": "#/definitions/enum.QoSLevel"
                 },
+                "ref": {
+                    "type": "string"
+                },
                 "topic": {
                     "type": "string"
                 },
@@ -2013,166 +9737,329 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.GetDeviceResponse": {
+        "dto.TransferCredentials": {
             "type": "object",
             "properties": {
-                "basePath": {
+                "password": {
                     "type": "string"
                 },
-                "brokerId": {
-                    "type": "string",
-                    "format": "uuid"
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TransferDevice": {
+            "type": "object",
+            "required": [
+                "icon",
+                "name",
+                "ref"
+            ],
+            "properties": {
+                "basePath": {
+                    "type": "string"
                 },
-                "createdAt": {
+                "brokerRef": {
                     "type": "string"
                 },
+                "controls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransferControl"
+                    }
+                },
                 "icon": {
                     "$ref": "#/definitions/dto.Icon"
                 },
-                "id": {
-                    "type": "string",
-                    "format": "uuid"
-                },
                 "name": {
                     "type": "string"
                 },
                 "placing": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "ref": {
                     "type": "string"
                 }
             }
         },
-        "dto.GetUserResponse": {
+        "dto.TransferDocument": {
             "type": "object",
             "required": [
-                "email",
-                "id",
-                "language",
-                "name",
-                "theme"
+                "version"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "brokers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransferBroker"
+                    }
                 },
-                "language": {
-                    "type": "string"
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransferDevice"
+                    }
                 },
-                "name": {
+                "exportedAt": {
                     "type": "string"
                 },
-                "theme": {
-                    "type": "string"
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.Icon": {
+        "dto.TransferReportItem": {
             "type": "object",
-            "required": [
-                "backgroundColor",
-                "name"
-            ],
             "properties": {
-                "backgroundColor": {
-                    "type": "string"
+                "action": {
+                    "$ref": "#/definitions/enum.TransferAction"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransferChange"
+                    }
+                },
+                "entity": {
+                    "$ref": "#/definitions/enum.EventEntity"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
                 },
                 "name": {
                     "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
                 }
             }
         },
-        "dto.IconOptional": {
+        "dto.TransferReportResponse": {
             "type": "object",
             "properties": {
-                "backgroundColor": {
-                    "type": "string"
+                "dryRun": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransferReportItem"
+                    }
+                },
+                "strategy": {
+                    "$ref": "#/definitions/enum.TransferStrategy"
                 }
             }
         },
-        "dto.LoginUserRequest": {
+        "dto.TransformPreviewRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "transform"
             ],
             "properties": {
-                "email": {
+                "attributes": {
+                    "$ref": "#/definitions/dto.ControlAttributes"
+                },
+                "payload": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "transform": {
+                    "$ref": "#/definitions/dto.ControlAttributes"
+                },
+                "type": {
+                    "$ref": "#/definitions/enum.ControlType"
                 }
             }
         },
-        "dto.ResetUserPasswordRequest": {
+        "dto.TransformPreviewResponse": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                }
+                "decoded": {},
+                "label": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "value": {}
             }
         },
-        "dto.SetBrokerCredentialsRequest": {
+        "dto.TrashEntryResponse": {
             "type": "object",
             "properties": {
-                "password": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "dependents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrashEntryResponse"
+                    }
+                },
+                "deviceId": {
                     "type": "string",
-                    "nullable": true
+                    "format": "uuid"
                 },
-                "username": {
+                "id": {
                     "type": "string",
-                    "nullable": true
-                }
-            }
-        },
-        "dto.Tokens": {
-            "type": "object",
-            "properties": {
-                "accessToken": {
+                    "format": "uuid"
+                },
+                "name": {
                     "type": "string"
                 },
-                "refreshToken": {
+                "purgeAt": {
                     "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "broker",
+                        "device",
+                        "control"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.TrashEntryType"
+                        }
+                    ]
                 }
             }
         },
         "dto.UpdateBrokerRequest": {
             "type": "object",
             "properties": {
+                "cleanStart": {
+                    "type": "boolean"
+                },
                 "clientId": {
                     "type": "string",
                     "nullable": true
                 },
+                "discoveryMode": {
+                    "enum": [
+                        "disabled",
+                        "propose",
+                        "auto"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.DiscoveryMode"
+                        }
+                    ]
+                },
+                "discoveryPrefix": {
+                    "type": "string"
+                },
                 "icon": {
                     "$ref": "#/definitions/dto.IconOptional"
                 },
                 "isSsl": {
                     "type": "boolean"
                 },
-                "keepAlive": {
-                    "type": "integer"
+                "keepAlive": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "nullable": true,
+                    "example": "/mqtt"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "protocolVersion": {
+                    "enum": [
+                        "3.1.1",
+                        "5"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTVersion"
+                        }
+                    ]
+                },
+                "server": {
+                    "type": "string"
+                },
+                "sessionExpiry": {
+                    "type": "integer"
+                },
+                "transport": {
+                    "enum": [
+                        "tcp",
+                        "ws",
+                        "wss"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enum.MQTTTransport"
+                        }
+                    ]
+                },
+                "userProperties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.UpdateDashboardRequest": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "$ref": "#/definitions/dto.IconOptional"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "dto.UpdateDashboardTabRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "dto.UpdateDashboardWidgetRequest": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
+                },
+                "overrides": {
+                    "$ref": "#/definitions/dto.WidgetOverrides"
                 },
-                "name": {
-                    "type": "string"
+                "width": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
                 },
-                "port": {
-                    "type": "integer"
+                "x": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
                 },
-                "server": {
-                    "type": "string"
+                "y": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
@@ -2232,6 +10119,50 @@ const docTemplate = `{
                 "placing": {
                     "type": "string",
                     "nullable": true
+                },
+                "roomId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "nullable": true
+                }
+            }
+        },
+        "dto.UpdateGroupRequest": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "$ref": "#/definitions/dto.IconOptional"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "dto.UpdateRoomRequest": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "$ref": "#/definitions/dto.IconOptional"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "dto.UpdateTagRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
                 }
             }
         },
@@ -2263,6 +10194,172 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.WidgetIconOverride": {
+            "type": "object",
+            "properties": {
+                "backgroundColor": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WidgetLayoutRequest": {
+            "type": "object",
+            "required": [
+                "widgets"
+            ],
+            "properties": {
+                "widgets": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/dto.WidgetPlacement"
+                    }
+                }
+            }
+        },
+        "dto.WidgetOverrides": {
+            "type": "object",
+            "properties": {
+                "canDisplayName": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "$ref": "#/definitions/dto.WidgetIconOverride"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.WidgetPlacement": {
+            "type": "object",
+            "required": [
+                "height",
+                "id",
+                "width",
+                "x",
+                "y"
+            ],
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "width": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
+                },
+                "x": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "y": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "enum.AlertKind": {
+            "type": "string",
+            "enum": [
+                "threshold",
+                "stateChange",
+                "deviceOffline",
+                "brokerDisconnected"
+            ],
+            "x-enum-varnames": [
+                "AlertThreshold",
+                "AlertStateChange",
+                "AlertDeviceOffline",
+                "AlertBrokerDisconnected"
+            ]
+        },
+        "enum.AlertOperator": {
+            "type": "string",
+            "enum": [
+                "above",
+                "below"
+            ],
+            "x-enum-varnames": [
+                "AlertAbove",
+                "AlertBelow"
+            ]
+        },
+        "enum.AvailabilityMode": {
+            "type": "string",
+            "enum": [
+                "lwt",
+                "heartbeat"
+            ],
+            "x-enum-varnames": [
+                "AvailabilityLWT",
+                "AvailabilityHeartbeat"
+            ]
+        },
+        "enum.AvailabilityReason": {
+            "type": "string",
+            "enum": [
+                "payload",
+                "message",
+                "timeout"
+            ],
+            "x-enum-varnames": [
+                "AvailabilityPayload",
+                "AvailabilityMessage",
+                "AvailabilityTimeout"
+            ]
+        },
+        "enum.BatchAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "BatchCreateAction",
+                "BatchUpdateAction",
+                "BatchDeleteAction"
+            ]
+        },
+        "enum.BatchEntity": {
+            "type": "string",
+            "enum": [
+                "broker",
+                "device",
+                "control"
+            ],
+            "x-enum-varnames": [
+                "BatchBrokerEntity",
+                "BatchDeviceEntity",
+                "BatchControlEntity"
+            ]
+        },
+        "enum.BrokerStatus": {
+            "type": "string",
+            "enum": [
+                "unknown",
+                "online",
+                "offline"
+            ],
+            "x-enum-varnames": [
+                "BrokerUnknown",
+                "BrokerOnline",
+                "BrokerOffline"
+            ]
+        },
         "enum.ControlType": {
             "type": "string",
             "enum": [
@@ -2273,7 +10370,12 @@ const docTemplate = `{
                 "slider",
                 "state",
                 "radio",
-                "text-out"
+                "text-out",
+                "gauge",
+                "chart",
+                "json-state",
+                "number-in",
+                "multi-switch"
             ],
             "x-enum-varnames": [
                 "ControlButton",
@@ -2283,7 +10385,158 @@ const docTemplate = `{
                 "ControlSlider",
                 "ControlState",
                 "ControlRadio",
-                "ControlTextOut"
+                "ControlTextOut",
+                "ControlGauge",
+                "ControlChart",
+                "ControlJSONState",
+                "ControlNumberIn",
+                "ControlMultiSwitch"
+            ]
+        },
+        "enum.DeviceStatus": {
+            "type": "string",
+            "enum": [
+                "unknown",
+                "online",
+                "offline"
+            ],
+            "x-enum-varnames": [
+                "DeviceUnknown",
+                "DeviceOnline",
+                "DeviceOffline"
+            ]
+        },
+        "enum.DiscoveryMode": {
+            "type": "string",
+            "enum": [
+                "disabled",
+                "propose",
+                "auto"
+            ],
+            "x-enum-varnames": [
+                "DiscoveryDisabled",
+                "DiscoveryPropose",
+                "DiscoveryAuto"
+            ]
+        },
+        "enum.EventEntity": {
+            "type": "string",
+            "enum": [
+                "USER",
+                "BROKERS",
+                "DEVICES",
+                "DEVICE_CONTROLS",
+                "DISCOVERY",
+                "DASHBOARDS",
+                "DASHBOARD_TABS",
+                "DASHBOARD_WIDGETS",
+                "ROOMS",
+                "TAGS",
+                "GROUPS",
+                "ALERTS",
+                "ALERT_INCIDENTS"
+            ],
+            "x-enum-varnames": [
+                "UserEntity",
+                "BrokersEntity",
+                "DevicesEntity",
+                "DeviceControlsEntity",
+                "DiscoveryEntity",
+                "DashboardsEntity",
+                "DashboardTabsEntity",
+                "DashboardWidgetsEntity",
+                "RoomsEntity",
+                "TagsEntity",
+                "GroupsEntity",
+                "AlertsEntity",
+                "AlertIncidentsEntity"
+            ]
+        },
+        "enum.GroupState": {
+            "type": "string",
+            "enum": [
+                "unknown",
+                "all-on",
+                "some-on",
+                "all-off"
+            ],
+            "x-enum-varnames": [
+                "GroupUnknown",
+                "GroupAllOn",
+                "GroupSomeOn",
+                "GroupAllOff"
+            ]
+        },
+        "enum.IncidentStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "acknowledged",
+                "resolved"
+            ],
+            "x-enum-varnames": [
+                "IncidentOpen",
+                "IncidentAcknowledged",
+                "IncidentResolved"
+            ]
+        },
+        "enum.MQTTTransport": {
+            "type": "string",
+            "enum": [
+                "tcp",
+                "ws",
+                "wss"
+            ],
+            "x-enum-varnames": [
+                "TCPTransport",
+                "WSTransport",
+                "WSSTransport"
+            ]
+        },
+        "enum.MQTTVersion": {
+            "type": "string",
+            "enum": [
+                "3.1.1",
+                "5"
+            ],
+            "x-enum-varnames": [
+                "MQTTVersion311",
+                "MQTTVersion5"
+            ]
+        },
+        "enum.MessageDirection": {
+            "type": "string",
+            "enum": [
+                "inbound",
+                "outbound"
+            ],
+            "x-enum-varnames": [
+                "MessageInbound",
+                "MessageOutbound"
+            ]
+        },
+        "enum.MessageSource": {
+            "type": "string",
+            "enum": [
+                "user",
+                "apiKey",
+                "automation"
+            ],
+            "x-enum-varnames": [
+                "MessageSourceUser",
+                "MessageSourceAPIKey",
+                "MessageSourceAutomation"
+            ]
+        },
+        "enum.PayloadEncoding": {
+            "type": "string",
+            "enum": [
+                "utf8",
+                "base64"
+            ],
+            "x-enum-varnames": [
+                "PayloadUTF8",
+                "PayloadBase64"
             ]
         },
         "enum.QoSLevel": {
@@ -2299,11 +10552,141 @@ const docTemplate = `{
                 "QoSTwo"
             ]
         },
-        "errors.HTTPError": {
+        "enum.RoomStatus": {
+            "type": "string",
+            "enum": [
+                "unknown",
+                "online",
+                "partial",
+                "offline"
+            ],
+            "x-enum-varnames": [
+                "RoomUnknown",
+                "RoomOnline",
+                "RoomPartial",
+                "RoomOffline"
+            ]
+        },
+        "enum.SearchResultType": {
+            "type": "string",
+            "enum": [
+                "broker",
+                "device",
+                "control"
+            ],
+            "x-enum-varnames": [
+                "SearchBroker",
+                "SearchDevice",
+                "SearchControl"
+            ]
+        },
+        "enum.TopicIssueKind": {
+            "type": "string",
+            "enum": [
+                "invalid",
+                "duplicate",
+                "overlap"
+            ],
+            "x-enum-varnames": [
+                "TopicInvalid",
+                "TopicDuplicate",
+                "TopicOverlap"
+            ]
+        },
+        "enum.TransferAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "skip",
+                "rename"
+            ],
+            "x-enum-varnames": [
+                "TransferCreateAction",
+                "TransferUpdateAction",
+                "TransferSkipAction",
+                "TransferRenameAction"
+            ]
+        },
+        "enum.TransferStrategy": {
+            "type": "string",
+            "enum": [
+                "skip",
+                "overwrite",
+                "rename"
+            ],
+            "x-enum-varnames": [
+                "TransferSkip",
+                "TransferOverwrite",
+                "TransferRename"
+            ]
+        },
+        "enum.TrashEntryType": {
+            "type": "string",
+            "enum": [
+                "broker",
+                "device",
+                "control"
+            ],
+            "x-enum-varnames": [
+                "TrashBroker",
+                "TrashDevice",
+                "TrashControl"
+            ]
+        },
+        "errors.FieldError": {
             "type": "object",
             "properties": {
+                "field": {
+                    "type": "string"
+                },
                 "message": {
                     "type": "string"
+                },
+                "param": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "errors.HTTPError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "VALIDATION_FAILED"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "request validation failed"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/brokers"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "request validation failed"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
                 }
             }
         }
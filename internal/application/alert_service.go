@@ -0,0 +1,605 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+)
+
+const defaultAlertInterval = time.Minute
+
+// AlertService raises the incidents of the alerts and resolves them once their conditions clear. The values
+// of the controls are checked as they are received, the devices and the brokers are checked every interval
+// and also when their status changes. The incidents are published as events, and when the alert
+// asks for it, sent by email and to the webhook. The kinds which could not fire with the values
+// or the presence disabled are rejected.
+type AlertService interface {
+	Start(ctx context.Context)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Alert, error)
+	Get(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (*domain.Alert, error)
+	Create(ctx context.Context, alert *domain.CreateAlert) (uuid.UUID, error)
+	Update(ctx context.Context, alert *domain.UpdateAlert) error
+	Delete(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) error
+	ListIncidents(ctx context.Context, filters *domain.ListAlertIncidentFilters) (*domain.List[*domain.AlertIncident], error)
+	Acknowledge(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID) (*domain.AlertIncident, error)
+	OnValue(ctx context.Context, controlID uuid.UUID, value interface{})
+}
+
+type alertService struct {
+	c   *config.Config
+	ar  repository.AlertRepository
+	dar repository.DeviceAvailabilityRepository
+	bhr repository.BrokerHealthRepository
+	dcr repository.DeviceControlRepository
+	ur  repository.UserRepository
+	ds  DeviceService
+	bs  BrokerService
+	ms  MailService
+	wa  adapter.WebhookAdapter
+	es  EventService
+}
+
+func NewAlertService(
+	c *config.Config,
+	ar repository.AlertRepository,
+	dar repository.DeviceAvailabilityRepository,
+	bhr repository.BrokerHealthRepository,
+	dcr repository.DeviceControlRepository,
+	ur repository.UserRepository,
+	ds DeviceService,
+	bs BrokerService,
+	ms MailService,
+	wa adapter.WebhookAdapter,
+	es EventService) AlertService {
+	s := &alertService{c, ar, dar, bhr, dcr, ur, ds, bs, ms, wa, es}
+
+	es.Listen(s.onEvent)
+
+	return s
+}
+
+// Start checks the devices and the brokers right away and then every interval until the context is done.
+func (s *alertService) Start(ctx context.Context) {
+	interval := defaultAlertInterval
+	if s.c.Alert != nil && s.c.Alert.IntervalSeconds > 0 {
+		interval = time.Duration(s.c.Alert.IntervalSeconds) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.checkDevices(ctx)
+			s.checkBrokers(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *alertService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Alert, error) {
+	return s.ar.List(ctx, userID)
+}
+
+func (s *alertService) Get(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (*domain.Alert, error) {
+	return s.ar.Get(ctx, alertID, userID)
+}
+
+func (s *alertService) Create(ctx context.Context, alert *domain.CreateAlert) (uuid.UUID, error) {
+	if err := s.validate(ctx, alert); err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.ar.Create(ctx, alert)
+}
+
+// Update replaces the definition, when the alert is disabled or its condition changes the incident
+// of the previous condition is resolved and the new one is checked with the next value or interval.
+func (s *alertService) Update(ctx context.Context, alert *domain.UpdateAlert) error {
+	previous, err := s.ar.Get(ctx, alert.ID, alert.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validate(ctx, &alert.CreateAlert); err != nil {
+		return err
+	}
+
+	if err := s.ar.Update(ctx, alert); err != nil {
+		return err
+	}
+
+	updated, err := s.ar.Get(ctx, alert.ID, alert.UserID)
+	if err != nil {
+		return err
+	}
+
+	if updated.IsEnabled && sameCondition(previous, updated) {
+		return nil
+	}
+
+	incident, err := s.unresolved(ctx, updated.ID)
+	if err != nil {
+		return err
+	}
+	s.apply(ctx, updated, incident, false, nil, "")
+
+	return nil
+}
+
+func (s *alertService) Delete(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) error {
+	return s.ar.Delete(ctx, alertID, userID)
+}
+
+func (s *alertService) ListIncidents(ctx context.Context, filters *domain.ListAlertIncidentFilters) (*domain.List[*domain.AlertIncident], error) {
+	return s.ar.ListIncidents(ctx, filters)
+}
+
+// Acknowledge silences the open incident, it stays unresolved until the condition of the alert clears.
+func (s *alertService) Acknowledge(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID) (*domain.AlertIncident, error) {
+	incident, err := s.ar.GetIncident(ctx, incidentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if incident.Status == enum.IncidentResolved {
+		return nil, ae.ErrAlertIncidentResolved
+	}
+
+	if incident.Status == enum.IncidentAcknowledged {
+		return incident, nil
+	}
+
+	if err := s.ar.AcknowledgeIncident(ctx, incidentID, userID); err != nil {
+		return nil, err
+	}
+
+	incident, err = s.ar.GetIncident(ctx, incidentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.es.PublishAlertIncidents(ctx, enum.IncidentAcknowledgedAction, userID, incident.AlertID, incident.ID)
+
+	return incident, nil
+}
+
+// OnValue checks the alerts of the control with the value decoded from the payload it has received.
+func (s *alertService) OnValue(ctx context.Context, controlID uuid.UUID, value interface{}) {
+	alerts, err := s.ar.ListByControl(ctx, controlID)
+	if err != nil {
+		log.Printf("alertService.OnValue: control %s, %s", controlID, err)
+		return
+	}
+
+	for _, alert := range alerts {
+		incident, err := s.unresolved(ctx, alert.ID)
+		if err != nil {
+			log.Printf("alertService.OnValue: alert %s, %s", alert.ID, err)
+			continue
+		}
+
+		active, err := AlertActive(alert, value, incident != nil)
+		if err != nil {
+			log.Printf("alertService.OnValue: alert %s, %s", alert.ID, err)
+			continue
+		}
+
+		s.apply(ctx, alert, incident, active, value, valueMessage(alert, value))
+	}
+}
+
+// AlertActive tells whether the condition of the threshold or the state change alert holds for the value.
+// The incident of a threshold alert is kept open until the value gets back past the threshold by the hysteresis,
+// so the values hovering around the threshold do not open a new incident with every message.
+func AlertActive(alert *domain.Alert, value interface{}, open bool) (bool, error) {
+	switch alert.Kind {
+	case enum.AlertThreshold:
+		if alert.Operator == nil || alert.Threshold == nil {
+			return false, errors.New("threshold alert has no operator or threshold")
+		}
+
+		n, err := templateNumber(value)
+		if err != nil {
+			return false, err
+		}
+
+		threshold := *alert.Threshold
+		if *alert.Operator == enum.AlertBelow {
+			if open {
+				threshold += alert.Hysteresis
+			}
+			return n < threshold, nil
+		}
+
+		if open {
+			threshold -= alert.Hysteresis
+		}
+		return n > threshold, nil
+	case enum.AlertStateChange:
+		if alert.State == nil {
+			return false, errors.New("state change alert has no state")
+		}
+
+		v, err := json.Marshal(value)
+		if err != nil {
+			return false, err
+		}
+
+		state, err := json.Marshal(alert.State.Data)
+		if err != nil {
+			return false, err
+		}
+
+		return string(v) == string(state), nil
+	}
+
+	return false, fmt.Errorf("%s alerts are not checked with the values", alert.Kind)
+}
+
+func sameCondition(a *domain.Alert, b *domain.Alert) bool {
+	ja, _ := json.Marshal([]interface{}{a.Kind, a.BrokerID, a.DeviceID, a.ControlID, a.Operator, a.Threshold, a.Hysteresis, a.State, a.OfflineMinutes})
+	jb, _ := json.Marshal([]interface{}{b.Kind, b.BrokerID, b.DeviceID, b.ControlID, b.Operator, b.Threshold, b.Hysteresis, b.State, b.OfflineMinutes})
+
+	return string(ja) == string(jb)
+}
+
+func valueMessage(alert *domain.Alert, value interface{}) string {
+	if alert.Kind == enum.AlertThreshold {
+		return fmt.Sprintf("value %s is %s the threshold %s", transformText(value), *alert.Operator, formatNumber(*alert.Threshold))
+	}
+
+	return fmt.Sprintf("value is %s", transformText(value))
+}
+
+// checkDevices opens the incidents of the devices which have been offline for the minutes of the alerts, the time
+// is counted from the last change of the status, or from the last update of the alert when it is more recent.
+func (s *alertService) checkDevices(ctx context.Context) {
+	if !s.presenceEnabled() {
+		return
+	}
+
+	alerts, err := s.ar.ListByKind(ctx, enum.AlertDeviceOffline)
+	if err != nil {
+		log.Printf("alertService.checkDevices: %s", err)
+		return
+	}
+
+	deviceIDs := make([]uuid.UUID, 0, len(alerts))
+	for _, alert := range alerts {
+		deviceIDs = append(deviceIDs, alert.DeviceID.UUID)
+	}
+
+	presence, err := s.dar.ListPresence(ctx, deviceIDs)
+	if err != nil {
+		log.Printf("alertService.checkDevices: %s", err)
+		return
+	}
+
+	for _, alert := range alerts {
+		p, ok := presence[alert.DeviceID.UUID]
+		if !ok {
+			continue
+		}
+
+		since := alert.UpdatedAt
+		if p.ChangedAt != nil && p.ChangedAt.After(since) {
+			since = *p.ChangedAt
+		}
+
+		minutes := 0
+		if alert.OfflineMinutes != nil {
+			minutes = *alert.OfflineMinutes
+		}
+
+		incident, err := s.unresolved(ctx, alert.ID)
+		if err != nil {
+			log.Printf("alertService.checkDevices: alert %s, %s", alert.ID, err)
+			continue
+		}
+
+		active := p.Status == enum.DeviceOffline && time.Since(since) >= time.Duration(minutes)*time.Minute
+		s.apply(ctx, alert, incident, active, nil, fmt.Sprintf("device has been offline for %d minutes", minutes))
+	}
+}
+
+// checkBrokers opens the incidents of the brokers whose last health check has failed.
+func (s *alertService) checkBrokers(ctx context.Context) {
+	alerts, err := s.ar.ListByKind(ctx, enum.AlertBrokerDisconnected)
+	if err != nil {
+		log.Printf("alertService.checkBrokers: %s", err)
+		return
+	}
+
+	brokerIDs := make([]uuid.UUID, 0, len(alerts))
+	for _, alert := range alerts {
+		brokerIDs = append(brokerIDs, alert.BrokerID.UUID)
+	}
+
+	health, err := s.bhr.List(ctx, brokerIDs)
+	if err != nil {
+		log.Printf("alertService.checkBrokers: %s", err)
+		return
+	}
+
+	for _, alert := range alerts {
+		h, ok := health[alert.BrokerID.UUID]
+		if !ok {
+			continue
+		}
+
+		incident, err := s.unresolved(ctx, alert.ID)
+		if err != nil {
+			log.Printf("alertService.checkBrokers: alert %s, %s", alert.ID, err)
+			continue
+		}
+
+		message := "broker is disconnected"
+		if h.Error != "" {
+			message = fmt.Sprintf("broker is disconnected: %s", h.Error)
+		}
+
+		s.apply(ctx, alert, incident, h.Status == enum.BrokerOffline, nil, message)
+	}
+}
+
+// resolveTrashed resolves the incidents of the alerts whose control, device or broker has been moved to the trash,
+// those alerts are not checked anymore so their incidents would stay open until the target is restored.
+func (s *alertService) resolveTrashed(ctx context.Context, userID uuid.UUID) {
+	alerts, err := s.ar.ListTrashedUnresolved(ctx, userID)
+	if err != nil {
+		log.Printf("alertService.resolveTrashed: user %s, %s", userID, err)
+		return
+	}
+
+	for _, alert := range alerts {
+		incident, err := s.unresolved(ctx, alert.ID)
+		if err != nil {
+			log.Printf("alertService.resolveTrashed: alert %s, %s", alert.ID, err)
+			continue
+		}
+
+		s.apply(ctx, alert, incident, false, nil, "")
+	}
+}
+
+func (s *alertService) onEvent(ctx context.Context, userID uuid.UUID, event domain.Event) {
+	switch event.Data.Action {
+	case enum.BrokerOnlineAction, enum.BrokerOfflineAction:
+		s.checkBrokers(ctx)
+	case enum.DeviceOnlineAction, enum.DeviceOfflineAction:
+		s.checkDevices(ctx)
+	default:
+		// The deletes of the devices and the controls are not published as such, every change is checked instead
+		if e := event.Data.Entity; e != nil &&
+			(e.Name == enum.BrokersEntity || e.Name == enum.DevicesEntity || e.Name == enum.DeviceControlsEntity) {
+			s.resolveTrashed(ctx, userID)
+		}
+	}
+}
+
+func (s *alertService) valuesEnabled() bool {
+	return s.c.Value != nil && s.c.Value.Enabled
+}
+
+func (s *alertService) presenceEnabled() bool {
+	return s.c.Presence != nil && s.c.Presence.Enabled
+}
+
+// unresolved returns the open or acknowledged incident of the alert, nil when there is none.
+func (s *alertService) unresolved(ctx context.Context, alertID uuid.UUID) (*domain.AlertIncident, error) {
+	incident, err := s.ar.GetUnresolvedIncident(ctx, alertID)
+	if errors.Is(err, ae.ErrAlertIncidentNotFound) {
+		return nil, nil
+	}
+
+	return incident, err
+}
+
+// apply opens the incident when the condition holds and there is none, unless the alert is cooling down
+// after the previous one, and resolves the incident when the condition does not hold anymore.
+func (s *alertService) apply(ctx context.Context, alert *domain.Alert, incident *domain.AlertIncident, active bool, value interface{}, message string) {
+	if active && incident == nil {
+		if alert.CooldownMinutes > 0 {
+			resolvedAt, err := s.ar.LastResolvedAt(ctx, alert.ID)
+			if err != nil {
+				log.Printf("alertService.apply: alert %s, %s", alert.ID, err)
+				return
+			}
+
+			if resolvedAt != nil && time.Since(*resolvedAt) < time.Duration(alert.CooldownMinutes)*time.Minute {
+				return
+			}
+		}
+
+		create := &domain.CreateAlertIncident{AlertID: alert.ID, Message: message}
+		if value != nil {
+			create.Value = &domain.ControlValue{Data: value}
+		}
+
+		incidentID, err := s.ar.CreateIncident(ctx, create)
+		if err != nil || incidentID == uuid.Nil {
+			if err != nil {
+				log.Printf("alertService.apply: alert %s, %s", alert.ID, err)
+			}
+			return
+		}
+
+		opened, err := s.ar.GetIncident(ctx, incidentID, alert.UserID)
+		if err != nil {
+			log.Printf("alertService.apply: alert %s, %s", alert.ID, err)
+			return
+		}
+
+		log.Printf("alertService.apply: alert %s, opened incident %s", alert.ID, incidentID)
+		s.notify(ctx, alert, opened, enum.IncidentOpenedAction)
+		return
+	}
+
+	if !active && incident != nil {
+		if err := s.ar.ResolveIncident(ctx, incident.ID); err != nil {
+			if !errors.Is(err, ae.ErrAlertIncidentNotFound) {
+				log.Printf("alertService.apply: alert %s, %s", alert.ID, err)
+			}
+			return
+		}
+
+		resolvedAt := time.Now().UTC()
+		incident.Status = enum.IncidentResolved
+		incident.ResolvedAt = &resolvedAt
+
+		log.Printf("alertService.apply: alert %s, resolved incident %s", alert.ID, incident.ID)
+		s.notify(ctx, alert, incident, enum.IncidentResolvedAction)
+	}
+}
+
+// notify publishes the change of the incident, the email and the webhook are sent in the background.
+func (s *alertService) notify(ctx context.Context, alert *domain.Alert, incident *domain.AlertIncident, action enum.EventAction) {
+	s.es.PublishAlertIncidents(ctx, action, alert.UserID, alert.ID, incident.ID)
+
+	n := &domain.AlertNotification{
+		Action:     action,
+		AlertID:    alert.ID,
+		AlertName:  alert.Name,
+		Kind:       alert.Kind,
+		IncidentID: incident.ID,
+		Status:     incident.Status,
+		Message:    incident.Message,
+		OpenedAt:   incident.OpenedAt,
+		ResolvedAt: incident.ResolvedAt,
+	}
+	if incident.Value != nil {
+		n.Value = incident.Value.Data
+	}
+
+	if alert.NotifyEmail {
+		go func() {
+			user, err := s.ur.Get(context.Background(), alert.UserID)
+			if err != nil {
+				log.Printf("alertService.notify: alert %s, %s", alert.ID, err)
+				return
+			}
+
+			s.ms.SendAlertIncident(user.Email, n)
+		}()
+	}
+
+	if alert.WebhookURL != nil {
+		go func(url string) {
+			if err := s.wa.Post(context.Background(), url, n); err != nil {
+				log.Printf("alertService.notify: alert %s, webhook %s", alert.ID, err)
+			}
+		}(*alert.WebhookURL)
+	}
+}
+
+// validate checks the fields required by the kind of the alert and clears the ones of the other kinds,
+// the device, the control and the broker have to belong to the user. The kinds checked with the values
+// need them to be decoded and the device offline alerts need the presence of the device to be tracked.
+func (s *alertService) validate(ctx context.Context, alert *domain.CreateAlert) error {
+	fields := []*ae.FieldError{}
+	require := func(field string, set bool) {
+		if !set {
+			fields = append(fields, &ae.FieldError{Field: field, Rule: "required_if", Param: "kind " + string(alert.Kind), Message: "is required"})
+		}
+	}
+	enabled := func(feature string, set bool) {
+		if !set {
+			fields = append(fields, &ae.FieldError{Field: "kind", Rule: "enabled", Param: feature, Message: "can not fire while the " + feature + " is disabled"})
+		}
+	}
+
+	switch alert.Kind {
+	case enum.AlertThreshold:
+		require("deviceId", alert.DeviceID.Valid)
+		require("controlId", alert.ControlID.Valid)
+		require("operator", alert.Operator != nil)
+		require("threshold", alert.Threshold != nil)
+		enabled("decoding of the values", s.valuesEnabled())
+		alert.BrokerID, alert.State, alert.OfflineMinutes = uuid.NullUUID{}, nil, nil
+	case enum.AlertStateChange:
+		require("deviceId", alert.DeviceID.Valid)
+		require("controlId", alert.ControlID.Valid)
+		require("state", alert.State != nil && alert.State.Data != nil)
+		enabled("decoding of the values", s.valuesEnabled())
+		alert.BrokerID, alert.Operator, alert.Threshold, alert.Hysteresis, alert.OfflineMinutes = uuid.NullUUID{}, nil, nil, 0, nil
+	case enum.AlertDeviceOffline:
+		require("deviceId", alert.DeviceID.Valid)
+		require("offlineMinutes", alert.OfflineMinutes != nil)
+		enabled("presence", s.presenceEnabled())
+		alert.BrokerID, alert.ControlID, alert.Operator, alert.Threshold, alert.Hysteresis, alert.State = uuid.NullUUID{}, uuid.NullUUID{}, nil, nil, 0, nil
+	case enum.AlertBrokerDisconnected:
+		require("brokerId", alert.BrokerID.Valid)
+		alert.DeviceID, alert.ControlID, alert.Operator, alert.Threshold, alert.Hysteresis, alert.State, alert.OfflineMinutes = uuid.NullUUID{}, uuid.NullUUID{}, nil, nil, 0, nil, nil
+	}
+
+	if alert.WebhookURL != nil {
+		if u, err := url.Parse(*alert.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fields = append(fields, &ae.FieldError{Field: "webhookUrl", Rule: "url", Message: "should be a valid http or https URL"})
+		}
+	}
+
+	if len(fields) > 0 {
+		return &ae.ValidationError{Err: ae.ErrValidation, Fields: fields}
+	}
+
+	if alert.BrokerID.Valid {
+		if _, err := s.bs.Get(ctx, alert.BrokerID.UUID, alert.UserID); err != nil {
+			return err
+		}
+	}
+
+	if alert.DeviceID.Valid {
+		if _, err := s.ds.Get(ctx, alert.DeviceID.UUID, alert.UserID); err != nil {
+			return err
+		}
+	}
+
+	if alert.Kind == enum.AlertDeviceOffline {
+		if _, err := s.dar.Get(ctx, alert.DeviceID.UUID); err != nil {
+			if !errors.Is(err, ae.ErrAvailabilityNotFound) {
+				return err
+			}
+
+			return &ae.ValidationError{
+				Err:    ae.ErrValidation,
+				Fields: []*ae.FieldError{{Field: "deviceId", Rule: "availability", Message: "should have the availability set"}},
+			}
+		}
+	}
+
+	if alert.ControlID.Valid {
+		controls, err := s.dcr.ListByDevice(ctx, alert.DeviceID.UUID)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, c := range controls {
+			found = found || c.ID == alert.ControlID.UUID
+		}
+
+		if !found {
+			return ae.ErrDeviceControlNotFound
+		}
+	}
+
+	return nil
+}
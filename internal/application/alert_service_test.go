@@ -0,0 +1,83 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/go-playground/assert"
+)
+
+func TestAlertActive(t *testing.T) {
+	threshold := func(operator enum.AlertOperator, value float64, hysteresis float64) *domain.Alert {
+		return &domain.Alert{Kind: enum.AlertThreshold, Operator: &operator, Threshold: &value, Hysteresis: hysteresis}
+	}
+
+	t.Run("should be raised above the threshold", func(t *testing.T) {
+		alert := threshold(enum.AlertAbove, 30, 2)
+
+		for _, c := range []struct {
+			value  interface{}
+			open   bool
+			active bool
+		}{
+			{29.5, false, false},
+			{30.0, false, false},
+			{30.5, false, true},
+			{"31", false, true},
+			{29.0, true, true},
+			{28.0, true, false},
+			{27.5, true, false},
+		} {
+			active, err := application.AlertActive(alert, c.value, c.open)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, c.active, active)
+		}
+	})
+
+	t.Run("should be raised below the threshold", func(t *testing.T) {
+		alert := threshold(enum.AlertBelow, 10, 1)
+
+		for _, c := range []struct {
+			value  float64
+			open   bool
+			active bool
+		}{
+			{10.5, false, false},
+			{9.5, false, true},
+			{10.5, true, true},
+			{11, true, false},
+		} {
+			active, err := application.AlertActive(alert, c.value, c.open)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, c.active, active)
+		}
+	})
+
+	t.Run("should fail for values which are not numbers", func(t *testing.T) {
+		_, err := application.AlertActive(threshold(enum.AlertAbove, 30, 0), true, false)
+		assert.NotEqual(t, nil, err)
+	})
+
+	t.Run("should be raised in the state", func(t *testing.T) {
+		alert := &domain.Alert{Kind: enum.AlertStateChange, State: &domain.ControlValue{Data: true}}
+
+		active, err := application.AlertActive(alert, true, false)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, active)
+
+		active, err = application.AlertActive(alert, false, true)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, active)
+
+		active, err = application.AlertActive(&domain.Alert{Kind: enum.AlertStateChange, State: &domain.ControlValue{Data: 1}}, 1.0, false)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, active)
+	})
+
+	t.Run("should fail for the kinds which do not watch the values", func(t *testing.T) {
+		_, err := application.AlertActive(&domain.Alert{Kind: enum.AlertDeviceOffline}, 1.0, false)
+		assert.NotEqual(t, nil, err)
+	})
+}
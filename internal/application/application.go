@@ -4,8 +4,10 @@ import (
 	"github.com/Deve-Lite/DashboardX-API/config"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
 	"github.com/Deve-Lite/DashboardX-API/internal/infrastructure/cache"
+	imqtt "github.com/Deve-Lite/DashboardX-API/internal/infrastructure/mqtt"
 	"github.com/Deve-Lite/DashboardX-API/internal/infrastructure/persistance"
 	ismtp "github.com/Deve-Lite/DashboardX-API/internal/infrastructure/smtp"
+	"github.com/Deve-Lite/DashboardX-API/internal/infrastructure/webhook"
 	"github.com/Deve-Lite/DashboardX-API/pkg/smtp"
 	"github.com/Deve-Lite/DashboardX-API/pkg/validate"
 	"github.com/gin-gonic/gin/binding"
@@ -15,40 +17,94 @@ import (
 )
 
 type Application struct {
-	AuthSrv    RESTAuthService
-	UserSrv    UserService
-	BrokerSrv  BrokerService
-	DeviceSrv  DeviceService
-	ControlSrv DeviceControlService
-	EventSrv   EventService
+	AuthSrv      RESTAuthService
+	UserSrv      UserService
+	BrokerSrv    BrokerService
+	DeviceSrv    DeviceService
+	ControlSrv   DeviceControlService
+	ControlTypes ControlTypeService
+	EventSrv     EventService
+	TransferSrv  TransferService
+	BridgeSrv    BridgeService
+	DiscoverySrv DiscoveryService
+	MonitorSrv   BrokerMonitorService
+	CertSrv      BrokerCertificateService
+	SearchSrv    SearchService
+	DashboardSrv DashboardService
+	RoomSrv      RoomService
+	TagSrv       TagService
+	GroupSrv     GroupService
+	BatchSrv     BatchService
+	TrashSrv     TrashService
+	RevisionSrv  RevisionService
+	CloneSrv     CloneService
+	TopicSrv     TopicService
+	ExploreSrv   ExploreService
+	MessageSrv   MessageLogService
+	AlertSrv     AlertService
+	PresenceSrv  PresenceService
+	ValueSrv     ValueService
 
-	UserMap    mapper.UserMapper
-	BrokerMap  mapper.BrokerMapper
-	DeviceMap  mapper.DeviceMapper
-	ControlMap mapper.DeviceControlMapper
+	UserMap      mapper.UserMapper
+	BrokerMap    mapper.BrokerMapper
+	DeviceMap    mapper.DeviceMapper
+	ControlMap   mapper.DeviceControlMapper
+	TypeMap      mapper.ControlTypeMapper
+	TransferMap  mapper.TransferMapper
+	DiscoveryMap mapper.DiscoveryMapper
+	CertMap      mapper.BrokerCertificateMapper
+	SearchMap    mapper.SearchMapper
+	DashboardMap mapper.DashboardMapper
+	RoomMap      mapper.RoomMapper
+	TagMap       mapper.TagMapper
+	GroupMap     mapper.GroupMapper
+	BatchMap     mapper.BatchMapper
+	TrashMap     mapper.TrashMapper
+	RevisionMap  mapper.RevisionMapper
+	TopicMap     mapper.TopicMapper
+	ExploreMap   mapper.ExploreMapper
+	MessageMap   mapper.MessageMapper
+	AlertMap     mapper.AlertMapper
+	PresenceMap  mapper.AvailabilityMapper
 }
 
 func NewApplication(c *config.Config, d *sqlx.DB, ch *redis.Client, s smtp.Client) *Application {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(validate.FieldName)
 		v.RegisterValidation("emptymin", validate.EmptyMin)
 		v.RegisterValidation("emptyemail", validate.EmptyEmail)
 		v.RegisterValidation("emptyuuid", validate.EmptyUUID)
 		v.RegisterValidation("emptyhexcolor", validate.EmptyHexColor)
-		v.RegisterValidation("control_attributes", validate.ControlAttributes)
-		v.RegisterValidation("control_type", validate.ControlType)
 		v.RegisterValidation("qos_level", validate.QoSLevel)
 		v.RegisterValidation("requirednullstring", validate.RequiredNullString)
+		v.RegisterValidation("batchref", validate.BatchRef)
 	}
 
 	userRepo := persistance.NewUserRepository(d)
 	brokerRepo := persistance.NewBrokerRepository(d)
+	brokerCertRepo := persistance.NewBrokerCertificateRepository(d)
 	deviceRepo := persistance.NewDeviceRepository(d)
 	controlRepo := persistance.NewDeviceControlRepository(d)
+	searchRepo := persistance.NewSearchRepository(d)
+	dashboardRepo := persistance.NewDashboardRepository(d)
+	roomRepo := persistance.NewRoomRepository(d)
+	tagRepo := persistance.NewTagRepository(d)
+	groupRepo := persistance.NewGroupRepository(d)
+	trashRepo := persistance.NewTrashRepository(d)
+	revisionRepo := persistance.NewRevisionRepository(d)
+	messageRepo := persistance.NewDeviceMessageRepository(d)
+	alertRepo := persistance.NewAlertRepository(d)
+	availabilityRepo := persistance.NewDeviceAvailabilityRepository(d)
+	transactor := persistance.NewTransactor(d)
 	tokenRepo := cache.NewTokenRepository(ch)
 	preUserRepo := cache.NewPreUserRepository(ch)
 	userActionRepo := cache.NewUserActionRepository(ch)
+	discoveryRepo := cache.NewDiscoveryRepository(ch)
+	brokerHealthRepo := cache.NewBrokerHealthRepository(ch)
 
 	mailAdp := ismtp.NewMailAdapter(c, s)
+	mqttAdp := imqtt.NewMQTTAdapter()
+	webhookAdp := webhook.NewWebhookAdapter(c)
 
 	eventSrv := NewEventService()
 	mailSrv := NewMailService(mailAdp)
@@ -56,14 +112,53 @@ func NewApplication(c *config.Config, d *sqlx.DB, ch *redis.Client, s smtp.Clien
 	authSrv := NewRESTAuthService(c, tokenRepo, cryptoSrv)
 	userSrv := NewUserService(c, preUserRepo, userRepo, userActionRepo,
 		authSrv, mailSrv, cryptoSrv, eventSrv)
-	brokerSrv := NewBrokerService(c, brokerRepo, cryptoSrv, eventSrv)
-	deviceSrv := NewDeviceService(deviceRepo, brokerSrv, eventSrv)
-	controlSrv := NewDeviceControlService(controlRepo, deviceSrv, eventSrv)
+	revisionRec := NewRevisionRecorder(revisionRepo, brokerRepo, deviceRepo, controlRepo)
+	brokerSrv := NewBrokerService(c, brokerRepo, brokerHealthRepo, brokerCertRepo, cryptoSrv, revisionRec, eventSrv)
+	deviceSrv := NewDeviceService(deviceRepo, roomRepo, tagRepo, brokerSrv, revisionRec, eventSrv)
+	controlTypeSrv := NewControlTypeService()
+	topicSrv := NewTopicService(c, controlRepo, brokerSrv, controlTypeSrv)
+	controlSrv := NewDeviceControlService(controlRepo, tagRepo, deviceSrv, controlTypeSrv, topicSrv, revisionRec, eventSrv)
+	transferSrv := NewTransferService(transactor, brokerSrv, deviceSrv, controlSrv, controlTypeSrv, revisionRec, eventSrv)
+	bridgeSrv := NewBridgeService(brokerSrv, mqttAdp, eventSrv)
+	discoverySrv := NewDiscoveryService(brokerRepo, discoveryRepo, brokerSrv, deviceSrv, controlSrv, bridgeSrv, eventSrv)
+	certSrv := NewBrokerCertificateService(brokerCertRepo, brokerSrv, cryptoSrv, eventSrv)
+	monitorSrv := NewBrokerMonitorService(c, brokerRepo, brokerHealthRepo, brokerSrv, mqttAdp, eventSrv)
+	searchSrv := NewSearchService(searchRepo)
+	dashboardSrv := NewDashboardService(dashboardRepo, controlRepo, deviceSrv, eventSrv)
+	roomSrv := NewRoomService(roomRepo, brokerHealthRepo, eventSrv)
+	tagSrv := NewTagService(tagRepo, controlRepo, deviceSrv, eventSrv)
+	alertSrv := NewAlertService(c, alertRepo, availabilityRepo, brokerHealthRepo, controlRepo, userRepo, deviceSrv, brokerSrv, mailSrv, webhookAdp, eventSrv)
+	messageSrv := NewMessageLogService(c, messageRepo, brokerRepo, controlRepo, deviceSrv, bridgeSrv, eventSrv)
+	presenceSrv := NewPresenceService(c, availabilityRepo, brokerRepo, controlRepo, deviceSrv, bridgeSrv, eventSrv)
+	valueSrv := NewValueService(c, brokerRepo, controlRepo, controlTypeSrv, bridgeSrv, alertSrv, eventSrv)
+	groupSrv := NewGroupService(groupRepo, controlRepo, controlTypeSrv, bridgeSrv, messageSrv, eventSrv)
+	batchSrv := NewBatchService(transactor, brokerSrv, deviceSrv, controlSrv, revisionRec, eventSrv)
+	trashSrv := NewTrashService(c, trashRepo)
+	cloneSrv := NewCloneService(transactor, brokerCertRepo, brokerSrv, deviceSrv, controlSrv, tagSrv, cryptoSrv, revisionRec, eventSrv)
+	revisionSrv := NewRevisionService(transactor, revisionRepo, controlRepo, revisionRec, brokerSrv, deviceSrv, controlSrv, roomSrv, eventSrv)
+	exploreSrv := NewExploreService(c, brokerSrv, deviceSrv, controlSrv, bridgeSrv)
 
 	userMap := mapper.NewUserMapper()
 	brokerMap := mapper.NewBrokerMapper()
 	deviceMap := mapper.NewDeviceMapper()
 	controlMap := mapper.NewDeviceControlMapper()
+	typeMap := mapper.NewControlTypeMapper()
+	transferMap := mapper.NewTransferMapper()
+	discoveryMap := mapper.NewDiscoveryMapper()
+	certMap := mapper.NewBrokerCertificateMapper()
+	searchMap := mapper.NewSearchMapper()
+	dashboardMap := mapper.NewDashboardMapper()
+	roomMap := mapper.NewRoomMapper()
+	tagMap := mapper.NewTagMapper()
+	groupMap := mapper.NewGroupMapper()
+	batchMap := mapper.NewBatchMapper()
+	trashMap := mapper.NewTrashMapper()
+	revisionMap := mapper.NewRevisionMapper()
+	topicMap := mapper.NewTopicMapper()
+	exploreMap := mapper.NewExploreMapper()
+	messageMap := mapper.NewMessageMapper()
+	alertMap := mapper.NewAlertMapper()
+	presenceMap := mapper.NewAvailabilityMapper()
 
 	return &Application{
 		authSrv,
@@ -71,10 +166,48 @@ func NewApplication(c *config.Config, d *sqlx.DB, ch *redis.Client, s smtp.Clien
 		brokerSrv,
 		deviceSrv,
 		controlSrv,
+		controlTypeSrv,
 		eventSrv,
+		transferSrv,
+		bridgeSrv,
+		discoverySrv,
+		monitorSrv,
+		certSrv,
+		searchSrv,
+		dashboardSrv,
+		roomSrv,
+		tagSrv,
+		groupSrv,
+		batchSrv,
+		trashSrv,
+		revisionSrv,
+		cloneSrv,
+		topicSrv,
+		exploreSrv,
+		messageSrv,
+		alertSrv,
+		presenceSrv,
+		valueSrv,
 		userMap,
 		brokerMap,
 		deviceMap,
 		controlMap,
+		typeMap,
+		transferMap,
+		discoveryMap,
+		certMap,
+		searchMap,
+		dashboardMap,
+		roomMap,
+		tagMap,
+		groupMap,
+		batchMap,
+		trashMap,
+		revisionMap,
+		topicMap,
+		exploreMap,
+		messageMap,
+		alertMap,
+		presenceMap,
 	}
 }
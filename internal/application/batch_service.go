@@ -0,0 +1,205 @@
+package application
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+type BatchService interface {
+	Execute(ctx context.Context, userID uuid.UUID, operations []*domain.BatchOperation) ([]*domain.BatchResult, error)
+}
+
+type batchService struct {
+	tr  repository.Transactor
+	bs  BrokerService
+	ds  DeviceService
+	dcs DeviceControlService
+	rec RevisionRecorder
+	es  EventService
+}
+
+func NewBatchService(
+	tr repository.Transactor,
+	bs BrokerService,
+	ds DeviceService,
+	dcs DeviceControlService,
+	rec RevisionRecorder,
+	es EventService) BatchService {
+	return &batchService{tr, bs, ds, dcs, rec, es}
+}
+
+// batchCreated is the entity created by an operation with a reference.
+type batchCreated struct {
+	entity enum.BatchEntity
+	id     uuid.UUID
+}
+
+// Execute applies the operations in their order in a single transaction, the failing operation rolls back
+// all of them. Every changed entity gets a single revision and the events of the changes are published
+// once the transaction has been committed.
+func (s *batchService) Execute(ctx context.Context, userID uuid.UUID, operations []*domain.BatchOperation) ([]*domain.BatchResult, error) {
+	results := make([]*domain.BatchResult, len(operations))
+
+	ctx, flush := s.es.Defer(ctx)
+	ctx, record := s.rec.Defer(ctx)
+
+	err := s.tr.Transaction(ctx, func(ctx context.Context) error {
+		created := map[string]batchCreated{}
+
+		for i, op := range operations {
+			if _, ok := created[op.Ref]; ok && op.Ref != "" {
+				return &domain.BatchError{Index: i, Err: ae.ErrBatchRefDuplicated}
+			}
+
+			id, err := s.execute(ctx, userID, op, created)
+			if err != nil {
+				return &domain.BatchError{Index: i, Err: err}
+			}
+
+			if op.Action == enum.BatchCreateAction && op.Ref != "" {
+				created[op.Ref] = batchCreated{op.Entity, id}
+			}
+
+			results[i] = &domain.BatchResult{
+				Action: op.Action,
+				Entity: op.Entity,
+				Ref:    op.Ref,
+				ID:     id,
+			}
+		}
+
+		return record(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	return results, nil
+}
+
+func (s *batchService) execute(ctx context.Context, userID uuid.UUID, op *domain.BatchOperation, created map[string]batchCreated) (uuid.UUID, error) {
+	switch op.Entity {
+	case enum.BatchBrokerEntity:
+		return s.executeBroker(ctx, userID, op, created)
+	case enum.BatchDeviceEntity:
+		return s.executeDevice(ctx, userID, op, created)
+	case enum.BatchControlEntity:
+		return s.executeControl(ctx, userID, op, created)
+	}
+
+	return uuid.Nil, ae.ErrMissingParams
+}
+
+func (s *batchService) executeBroker(ctx context.Context, userID uuid.UUID, op *domain.BatchOperation, created map[string]batchCreated) (uuid.UUID, error) {
+	if op.Action == enum.BatchCreateAction && op.CreateBroker != nil {
+		op.CreateBroker.UserID = userID
+		return s.bs.Create(ctx, op.CreateBroker)
+	}
+
+	brokerID, err := resolveBatchRef(created, op.ID, enum.BatchBrokerEntity)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch {
+	case op.Action == enum.BatchUpdateAction && op.UpdateBroker != nil:
+		op.UpdateBroker.ID = brokerID
+		op.UpdateBroker.UserID = userID
+		op.UpdateBroker.Version = op.Version
+		return brokerID, s.bs.Update(ctx, op.UpdateBroker)
+	case op.Action == enum.BatchDeleteAction:
+		return brokerID, s.bs.Delete(ctx, brokerID, userID, op.Version)
+	}
+
+	return uuid.Nil, ae.ErrMissingParams
+}
+
+func (s *batchService) executeDevice(ctx context.Context, userID uuid.UUID, op *domain.BatchOperation, created map[string]batchCreated) (uuid.UUID, error) {
+	var brokerID uuid.UUID
+	if op.BrokerRef != "" {
+		var err error
+		brokerID, err = resolveBatchRef(created, domain.BatchRef{Ref: op.BrokerRef}, enum.BatchBrokerEntity)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if op.Action == enum.BatchCreateAction && op.CreateDevice != nil {
+		op.CreateDevice.UserID = userID
+		if op.BrokerRef != "" {
+			op.CreateDevice.BrokerID = uuid.NullUUID{UUID: brokerID, Valid: true}
+		}
+		return s.ds.Create(ctx, op.CreateDevice)
+	}
+
+	deviceID, err := resolveBatchRef(created, op.ID, enum.BatchDeviceEntity)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch {
+	case op.Action == enum.BatchUpdateAction && op.UpdateDevice != nil:
+		op.UpdateDevice.ID = deviceID
+		op.UpdateDevice.UserID = userID
+		op.UpdateDevice.Version = op.Version
+		if op.BrokerRef != "" {
+			op.UpdateDevice.BrokerID = t.NewNullable(brokerID, false, true)
+		}
+		return deviceID, s.ds.Update(ctx, op.UpdateDevice)
+	case op.Action == enum.BatchDeleteAction:
+		return deviceID, s.ds.Delete(ctx, deviceID, userID, op.Version)
+	}
+
+	return uuid.Nil, ae.ErrMissingParams
+}
+
+func (s *batchService) executeControl(ctx context.Context, userID uuid.UUID, op *domain.BatchOperation, created map[string]batchCreated) (uuid.UUID, error) {
+	deviceID, err := resolveBatchRef(created, op.DeviceID, enum.BatchDeviceEntity)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if op.Action == enum.BatchCreateAction && op.CreateControl != nil {
+		op.CreateControl.DeviceID = deviceID
+		return s.dcs.Create(ctx, userID, op.CreateControl)
+	}
+
+	controlID, err := resolveBatchRef(created, op.ID, enum.BatchControlEntity)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch {
+	case op.Action == enum.BatchUpdateAction && op.UpdateControl != nil:
+		op.UpdateControl.ID = controlID
+		op.UpdateControl.DeviceID = deviceID
+		op.UpdateControl.Version = op.Version
+		return controlID, s.dcs.Update(ctx, userID, op.UpdateControl)
+	case op.Action == enum.BatchDeleteAction:
+		return controlID, s.dcs.Delete(ctx, userID, deviceID, controlID, op.Version)
+	}
+
+	return uuid.Nil, ae.ErrMissingParams
+}
+
+// resolveBatchRef returns the id of the entity, the reference has to point at an entity of the same kind.
+func resolveBatchRef(created map[string]batchCreated, ref domain.BatchRef, entity enum.BatchEntity) (uuid.UUID, error) {
+	if ref.Ref == "" {
+		return ref.ID, nil
+	}
+
+	c, ok := created[ref.Ref]
+	if !ok || c.entity != entity {
+		return uuid.Nil, ae.ErrBatchRefNotFound
+	}
+
+	return c.id, nil
+}
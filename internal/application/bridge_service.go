@@ -0,0 +1,326 @@
+package application
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+// BridgeService shares a single server-side MQTT connection per broker between its subscribers.
+// The connection is opened with the first subscription and closed with the last one,
+// it follows the changes of the broker and is dropped when the broker is deleted.
+type BridgeService interface {
+	Subscribe(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, topic string, qos enum.QoSLevel, handler domain.BridgeHandler) (uuid.UUID, error)
+	Unsubscribe(ctx context.Context, brokerID uuid.UUID, subscriptionID uuid.UUID) error
+	Publish(ctx context.Context, userID uuid.UUID, message *domain.BridgeMessage) error
+}
+
+// bridgeDialTimeout bounds the dial shared by the callers of a broker, along with the lookup of its credentials.
+const bridgeDialTimeout = 30 * time.Second
+
+type bridgeSubscription struct {
+	topic   string
+	qos     enum.QoSLevel
+	handler domain.BridgeHandler
+}
+
+type bridgeBroker struct {
+	userID    uuid.UUID
+	connected bool
+	// dial is set while the connection is being opened, the other callers wait for it instead of dialing again.
+	dial *bridgeDial
+	// generation is bumped when the broker changes, a connection opened with its previous settings is reopened.
+	generation int
+	// users counts the calls using the connection outside of the mutex, it is not closed while there are some.
+	users         int
+	subscriptions map[uuid.UUID]*bridgeSubscription
+}
+
+type bridgeDial struct {
+	done chan struct{}
+	err  error
+}
+
+type bridgeService struct {
+	bs      BrokerService
+	ma      adapter.MQTTAdapter
+	brokers map[uuid.UUID]*bridgeBroker
+	mutex   sync.Mutex
+}
+
+func NewBridgeService(bs BrokerService, ma adapter.MQTTAdapter, es EventService) BridgeService {
+	s := &bridgeService{
+		bs:      bs,
+		ma:      ma,
+		brokers: make(map[uuid.UUID]*bridgeBroker),
+	}
+
+	es.Listen(s.onEvent)
+
+	return s
+}
+
+func (s *bridgeService) Subscribe(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, topic string, qos enum.QoSLevel, handler domain.BridgeHandler) (uuid.UUID, error) {
+	b, err := s.acquire(ctx, userID, brokerID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer s.done(brokerID, b)
+
+	// The subscription is added first, so the retained messages sent right after subscribing are dispatched to it
+	subscriptionID := uuid.New()
+	s.mutex.Lock()
+	b.subscriptions[subscriptionID] = &bridgeSubscription{topic, qos, handler}
+	s.mutex.Unlock()
+
+	if err := s.ma.Subscribe(brokerID, topic, byte(qos)); err != nil {
+		s.mutex.Lock()
+		delete(b.subscriptions, subscriptionID)
+		s.mutex.Unlock()
+
+		return uuid.Nil, err
+	}
+
+	return subscriptionID, nil
+}
+
+func (s *bridgeService) Unsubscribe(ctx context.Context, brokerID uuid.UUID, subscriptionID uuid.UUID) error {
+	s.mutex.Lock()
+
+	b, ok := s.brokers[brokerID]
+	if !ok {
+		s.mutex.Unlock()
+		return nil
+	}
+
+	sub, ok := b.subscriptions[subscriptionID]
+	if !ok {
+		s.mutex.Unlock()
+		return nil
+	}
+	delete(b.subscriptions, subscriptionID)
+
+	if s.release(brokerID, b) {
+		s.mutex.Unlock()
+		return nil
+	}
+
+	for _, other := range b.subscriptions {
+		if other.topic == sub.topic {
+			s.mutex.Unlock()
+			return nil
+		}
+	}
+
+	b.users++
+	s.mutex.Unlock()
+	defer s.done(brokerID, b)
+
+	return s.ma.Unsubscribe(brokerID, sub.topic)
+}
+
+func (s *bridgeService) Publish(ctx context.Context, userID uuid.UUID, message *domain.BridgeMessage) error {
+	b, err := s.acquire(ctx, userID, message.BrokerID)
+	if err != nil {
+		return err
+	}
+	defer s.done(message.BrokerID, b)
+
+	return s.ma.Publish(message.BrokerID, message.Topic, message.QoS, message.Retained, message.Payload)
+}
+
+// acquire returns the connected broker, which has to be given back with done. The connection is dialed
+// without the mutex held, so a broker which can not be reached does not stall the others, and the callers
+// asking for a broker which is being dialed wait for that dial or give up with their own context.
+func (s *bridgeService) acquire(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) (*bridgeBroker, error) {
+	s.mutex.Lock()
+
+	b, ok := s.brokers[brokerID]
+	if !ok {
+		b = &bridgeBroker{
+			userID:        userID,
+			subscriptions: make(map[uuid.UUID]*bridgeSubscription),
+		}
+		s.brokers[brokerID] = b
+	}
+	b.users++
+
+	for !b.connected {
+		d := b.dial
+		if d == nil {
+			d = &bridgeDial{done: make(chan struct{})}
+			b.dial = d
+			b.users++
+
+			go s.dial(context.WithoutCancel(ctx), brokerID, b, d)
+		}
+		s.mutex.Unlock()
+
+		var err error
+		select {
+		case <-d.done:
+			err = d.err
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		s.mutex.Lock()
+		if err != nil {
+			b.users--
+			s.release(brokerID, b)
+			s.mutex.Unlock()
+			return nil, err
+		}
+	}
+
+	s.mutex.Unlock()
+
+	return b, nil
+}
+
+// dial connects the broker for the callers of acquire. Its context is not the one of the caller which has
+// started it, so the dial the others wait for is not cancelled with that caller. The subscriptions kept
+// for the broker are restored when it has been disconnected.
+func (s *bridgeService) dial(ctx context.Context, brokerID uuid.UUID, b *bridgeBroker, d *bridgeDial) {
+	ctx, cancel := context.WithTimeout(ctx, bridgeDialTimeout)
+	defer cancel()
+
+	s.mutex.Lock()
+	for {
+		generation := b.generation
+		s.mutex.Unlock()
+
+		d.err = s.connect(ctx, b.userID, brokerID)
+
+		s.mutex.Lock()
+		if d.err != nil || b.generation == generation {
+			break
+		}
+
+		s.ma.Disconnect(brokerID)
+	}
+
+	b.dial = nil
+	b.connected = d.err == nil
+
+	subscriptions := []*bridgeSubscription{}
+	if b.connected {
+		for _, sub := range b.subscriptions {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, sub := range subscriptions {
+		if err := s.ma.Subscribe(brokerID, sub.topic, byte(sub.qos)); err != nil {
+			log.Printf("bridgeService.dial: broker %s, could not subscribe %s: %s", brokerID, sub.topic, err)
+		}
+	}
+
+	close(d.done)
+	s.done(brokerID, b)
+}
+
+// done gives back the broker taken with acquire.
+func (s *bridgeService) done(brokerID uuid.UUID, b *bridgeBroker) {
+	s.mutex.Lock()
+	b.users--
+	s.release(brokerID, b)
+	s.mutex.Unlock()
+}
+
+func (s *bridgeService) connect(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) error {
+	broker, err := s.bs.GetCredentials(ctx, brokerID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ma.Connect(broker, s.dispatch); err != nil {
+		return err
+	}
+
+	log.Printf("bridgeService.connect: broker %s, connected", brokerID)
+
+	return nil
+}
+
+// release closes the connection of a broker without subscriptions and users, it has to be called with the mutex locked.
+// The broker which has been deleted meanwhile is left as it is.
+func (s *bridgeService) release(brokerID uuid.UUID, b *bridgeBroker) bool {
+	if len(b.subscriptions) > 0 || b.users > 0 || s.brokers[brokerID] != b {
+		return false
+	}
+
+	s.ma.Disconnect(brokerID)
+	delete(s.brokers, brokerID)
+
+	return true
+}
+
+func (s *bridgeService) dispatch(message *domain.BridgeMessage) {
+	s.mutex.Lock()
+	handlers := []domain.BridgeHandler{}
+	if b, ok := s.brokers[message.BrokerID]; ok {
+		for _, sub := range b.subscriptions {
+			if mqtt.Match(sub.topic, message.Topic) {
+				handlers = append(handlers, sub.handler)
+			}
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, h := range handlers {
+		h(message)
+	}
+}
+
+func (s *bridgeService) onEvent(ctx context.Context, _ uuid.UUID, event domain.Event) {
+	if event.Data.Entity == nil || event.Data.Entity.Name != enum.BrokersEntity {
+		return
+	}
+
+	brokerID := event.Data.Entity.ID
+
+	s.mutex.Lock()
+
+	b, ok := s.brokers[brokerID]
+	if !ok {
+		s.mutex.Unlock()
+		return
+	}
+
+	switch event.Data.Action {
+	case enum.EntityUpdatedAction:
+		// The broker being dialed is reopened by the dial itself
+		b.generation++
+		if !b.connected {
+			s.mutex.Unlock()
+			return
+		}
+
+		s.ma.Disconnect(brokerID)
+		b.connected = false
+		s.mutex.Unlock()
+
+		reconnected, err := s.acquire(ctx, b.userID, brokerID)
+		if err != nil {
+			log.Printf("bridgeService.onEvent: broker %s, could not reconnect: %s", brokerID, err)
+			return
+		}
+		s.done(brokerID, reconnected)
+	case enum.EntityDeletedAction:
+		b.generation++
+		s.ma.Disconnect(brokerID)
+		delete(s.brokers, brokerID)
+		s.mutex.Unlock()
+	default:
+		s.mutex.Unlock()
+	}
+}
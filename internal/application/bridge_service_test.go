@@ -0,0 +1,144 @@
+package application_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+// blockingBrokerService holds the credentials of the blocked brokers until their channels are closed.
+type blockingBrokerService struct {
+	application.BrokerService
+	blocked map[uuid.UUID]chan struct{}
+}
+
+func (s *blockingBrokerService) GetCredentials(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) (*domain.Broker, error) {
+	if ch, ok := s.blocked[brokerID]; ok {
+		<-ch
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &domain.Broker{ID: brokerID, UserID: userID}, nil
+}
+
+type countingMQTTAdapter struct {
+	adapter.MQTTAdapter
+	dials map[uuid.UUID]int
+	mutex sync.Mutex
+}
+
+func (a *countingMQTTAdapter) Connect(broker *domain.Broker, _ domain.BridgeHandler) error {
+	a.mutex.Lock()
+	a.dials[broker.ID]++
+	a.mutex.Unlock()
+
+	return nil
+}
+
+func (a *countingMQTTAdapter) Disconnect(uuid.UUID) {}
+
+func (a *countingMQTTAdapter) Subscribe(uuid.UUID, string, byte) error { return nil }
+
+type silentEventService struct {
+	application.EventService
+}
+
+func (silentEventService) Listen(application.EventListener) {}
+
+func TestBridgeSubscribe(t *testing.T) {
+	userID, slowID, fastID := uuid.New(), uuid.New(), uuid.New()
+
+	newBridge := func() (application.BridgeService, *countingMQTTAdapter, chan struct{}) {
+		unblock := make(chan struct{})
+		bs := &blockingBrokerService{blocked: map[uuid.UUID]chan struct{}{slowID: unblock}}
+		ma := &countingMQTTAdapter{dials: map[uuid.UUID]int{}}
+
+		return application.NewBridgeService(bs, ma, silentEventService{}), ma, unblock
+	}
+
+	subscribeWith := func(ctx context.Context, bgs application.BridgeService, brokerID uuid.UUID) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := bgs.Subscribe(ctx, userID, brokerID, "a/b", enum.QoSZero, func(*domain.BridgeMessage) {})
+			done <- err
+		}()
+		return done
+	}
+
+	subscribe := func(bgs application.BridgeService, brokerID uuid.UUID) <-chan error {
+		return subscribeWith(context.Background(), bgs, brokerID)
+	}
+
+	t.Run("should not stall the other brokers while one is dialed", func(t *testing.T) {
+		bgs, _, unblock := newBridge()
+
+		slow := subscribe(bgs, slowID)
+		time.Sleep(50 * time.Millisecond)
+
+		select {
+		case err := <-subscribe(bgs, fastID):
+			assert.Equal(t, nil, err)
+		case <-time.After(time.Second):
+			t.Fatal("the subscription has waited for the dial of the other broker")
+		}
+
+		close(unblock)
+		assert.Equal(t, nil, <-slow)
+	})
+
+	t.Run("should dial once for the callers waiting for the broker", func(t *testing.T) {
+		bgs, ma, unblock := newBridge()
+
+		waiting := []<-chan error{}
+		for i := 0; i < 5; i++ {
+			waiting = append(waiting, subscribe(bgs, slowID))
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(unblock)
+
+		for _, done := range waiting {
+			assert.Equal(t, nil, <-done)
+		}
+
+		ma.mutex.Lock()
+		assert.Equal(t, 1, ma.dials[slowID])
+		ma.mutex.Unlock()
+	})
+
+	t.Run("should keep dialing for the callers waiting when the first one gives up", func(t *testing.T) {
+		bgs, ma, unblock := newBridge()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		first := subscribeWith(ctx, bgs, slowID)
+		time.Sleep(50 * time.Millisecond)
+
+		waiting := subscribe(bgs, slowID)
+		time.Sleep(50 * time.Millisecond)
+
+		cancel()
+		select {
+		case err := <-first:
+			assert.Equal(t, context.Canceled, err)
+		case <-time.After(time.Second):
+			t.Fatal("the caller has not given up with its context")
+		}
+
+		close(unblock)
+		assert.Equal(t, nil, <-waiting)
+
+		ma.mutex.Lock()
+		assert.Equal(t, 1, ma.dials[slowID])
+		ma.mutex.Unlock()
+	})
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/Deve-Lite/DashboardX-API/pkg/certs"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+const (
+	CertificateCA     = "ca"
+	CertificateClient = "client"
+
+	// certificateExpiryWarning is how long before the expiry a certificate gets a warning.
+	certificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+// BrokerCertificateService stores the CA bundle and the client certificate used for the TLS connections of a broker,
+// the private key of the client certificate is encrypted and never returned.
+type BrokerCertificateService interface {
+	Get(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) (*domain.BrokerCertificatesInfo, error)
+	Set(ctx context.Context, certificates *domain.UpdateBrokerCertificates) (*domain.BrokerCertificatesInfo, error)
+}
+
+type brokerCertificateService struct {
+	bcr repository.BrokerCertificateRepository
+	bs  BrokerService
+	cs  CryptoService
+	es  EventService
+}
+
+func NewBrokerCertificateService(bcr repository.BrokerCertificateRepository, bs BrokerService, cs CryptoService, es EventService) BrokerCertificateService {
+	return &brokerCertificateService{bcr, bs, cs, es}
+}
+
+func (s *brokerCertificateService) Get(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) (*domain.BrokerCertificatesInfo, error) {
+	if _, err := s.bs.Get(ctx, brokerID, userID); err != nil {
+		return nil, err
+	}
+
+	certificates, err := s.bcr.Get(ctx, brokerID)
+	if err != nil {
+		if errors.Is(err, ae.ErrBrokerCertificatesNotFound) {
+			return NewCertificatesInfo(&domain.BrokerCertificates{BrokerID: brokerID}, time.Now())
+		}
+
+		return nil, err
+	}
+
+	info, err := NewCertificatesInfo(certificates, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	info.UpdatedAt = &certificates.UpdatedAt
+
+	return info, nil
+}
+
+// Set validates and stores the set certificates, the client certificate and key can only be changed together.
+func (s *brokerCertificateService) Set(ctx context.Context, certificates *domain.UpdateBrokerCertificates) (*domain.BrokerCertificatesInfo, error) {
+	if _, err := s.bs.Get(ctx, certificates.BrokerID, certificates.UserID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if certificates.CACertificate.Set && !certificates.CACertificate.Null {
+		if _, err := parseCertificates(certificates.CACertificate.String, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if certificates.ClientCertificate.Set != certificates.ClientKey.Set ||
+		certificates.ClientCertificate.Null != certificates.ClientKey.Null {
+		return nil, ae.ErrCertificateKeyInvalid
+	}
+
+	if certificates.ClientCertificate.Set && !certificates.ClientCertificate.Null {
+		if _, err := parseCertificates(certificates.ClientCertificate.String, now); err != nil {
+			return nil, err
+		}
+
+		if err := certs.ValidateKeyPair([]byte(certificates.ClientCertificate.String), []byte(certificates.ClientKey.String)); err != nil {
+			return nil, fmt.Errorf("%w: %s", ae.ErrCertificateKeyInvalid, err.Error())
+		}
+
+		key, err := s.cs.Encrypt(certificates.ClientKey.String, enum.CryptoBrokerKey)
+		if err != nil {
+			return nil, err
+		}
+
+		certificates.ClientKey = t.NewString(key, false, true)
+	}
+
+	if err := s.bcr.Set(ctx, certificates); err != nil {
+		return nil, err
+	}
+
+	s.es.PublishBrokers(ctx, enum.EntityUpdatedAction, certificates.UserID, certificates.BrokerID)
+
+	return s.Get(ctx, certificates.UserID, certificates.BrokerID)
+}
+
+// NewCertificatesInfo describes the stored certificates, those which expire within 30 days get a warning.
+func NewCertificatesInfo(certificates *domain.BrokerCertificates, at time.Time) (*domain.BrokerCertificatesInfo, error) {
+	info := &domain.BrokerCertificatesInfo{
+		BrokerID:     certificates.BrokerID,
+		CA:           []*domain.CertificateInfo{},
+		HasClientKey: certificates.ClientKey.Set && !certificates.ClientKey.Null,
+		Warnings:     []*domain.CertificateWarning{},
+	}
+
+	warn := func(kind string, c *x509.Certificate) {
+		if expiresIn := c.NotAfter.Sub(at); expiresIn < certificateExpiryWarning {
+			info.Warnings = append(info.Warnings, &domain.CertificateWarning{
+				Kind:      kind,
+				Subject:   c.Subject.String(),
+				NotAfter:  c.NotAfter,
+				ExpiresIn: expiresIn,
+			})
+		}
+	}
+
+	if certificates.CACertificate.Set && !certificates.CACertificate.Null {
+		ca, err := certs.Parse([]byte(certificates.CACertificate.String))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range ca {
+			info.CA = append(info.CA, certificateInfo(c))
+			warn(CertificateCA, c)
+		}
+	}
+
+	if certificates.ClientCertificate.Set && !certificates.ClientCertificate.Null {
+		client, err := certs.Parse([]byte(certificates.ClientCertificate.String))
+		if err != nil {
+			return nil, err
+		}
+
+		info.Client = certificateInfo(client[0])
+		warn(CertificateClient, client[0])
+	}
+
+	return info, nil
+}
+
+func parseCertificates(v string, at time.Time) ([]*x509.Certificate, error) {
+	certificates, err := certs.Parse([]byte(v))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ae.ErrCertificateInvalid, err.Error())
+	}
+
+	if err := certs.Validate(certificates, at); err != nil {
+		return nil, fmt.Errorf("%w: %s", ae.ErrCertificateExpired, err.Error())
+	}
+
+	return certificates, nil
+}
+
+func certificateInfo(c *x509.Certificate) *domain.CertificateInfo {
+	return &domain.CertificateInfo{
+		Subject:      c.Subject.String(),
+		Issuer:       c.Issuer.String(),
+		SerialNumber: c.SerialNumber.String(),
+		Fingerprint:  certs.Fingerprint(c),
+		NotBefore:    c.NotBefore,
+		NotAfter:     c.NotAfter,
+	}
+}
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMonitorInterval    = time.Minute
+	defaultMonitorConcurrency = 8
+)
+
+// BrokerMonitorService probes the brokers periodically and records whether they are up,
+// a change of the status is published as a BROKER_ONLINE or BROKER_OFFLINE event.
+type BrokerMonitorService interface {
+	Start(ctx context.Context)
+	Test(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) (*domain.BrokerProbe, error)
+	TestSettings(ctx context.Context, broker *domain.Broker) *domain.BrokerProbe
+}
+
+type brokerMonitorService struct {
+	c   *config.Config
+	br  repository.BrokerRepository
+	bhr repository.BrokerHealthRepository
+	bs  BrokerService
+	ma  adapter.MQTTAdapter
+	es  EventService
+}
+
+func NewBrokerMonitorService(
+	c *config.Config,
+	br repository.BrokerRepository,
+	bhr repository.BrokerHealthRepository,
+	bs BrokerService,
+	ma adapter.MQTTAdapter,
+	es EventService) BrokerMonitorService {
+	s := &brokerMonitorService{c, br, bhr, bs, ma, es}
+
+	es.Listen(s.onEvent)
+
+	return s
+}
+
+// Start checks the brokers right away and then every interval until the context is done.
+func (s *brokerMonitorService) Start(ctx context.Context) {
+	interval := defaultMonitorInterval
+	if s.c.Monitor != nil && s.c.Monitor.IntervalSeconds > 0 {
+		interval = time.Duration(s.c.Monitor.IntervalSeconds) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.checkAll(ctx); err != nil {
+				log.Printf("brokerMonitorService.Start: %s", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *brokerMonitorService) Test(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) (*domain.BrokerProbe, error) {
+	broker, err := s.bs.GetCredentials(ctx, brokerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ma.Probe(ctx, broker), nil
+}
+
+// TestSettings probes the broker settings which have not been saved yet, the credentials are expected in plain text.
+func (s *brokerMonitorService) TestSettings(ctx context.Context, broker *domain.Broker) *domain.BrokerProbe {
+	return s.ma.Probe(ctx, broker)
+}
+
+func (s *brokerMonitorService) checkAll(ctx context.Context) error {
+	brokers, err := s.br.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	concurrency := defaultMonitorConcurrency
+	if s.c.Monitor != nil && s.c.Monitor.Concurrency > 0 {
+		concurrency = int(s.c.Monitor.Concurrency)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for _, broker := range brokers {
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func(broker *domain.Broker) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			s.check(ctx, broker.UserID, broker.ID)
+		}(broker)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (s *brokerMonitorService) check(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) {
+	broker, err := s.bs.GetCredentials(ctx, brokerID, userID)
+	if err != nil {
+		log.Printf("brokerMonitorService.check: broker %s, %s", brokerID, err)
+		return
+	}
+
+	probe := s.ma.Probe(ctx, broker)
+
+	health := &domain.BrokerHealth{
+		BrokerID:  brokerID,
+		Status:    enum.BrokerOffline,
+		CheckedAt: time.Now().UTC(),
+	}
+
+	if probe.Success {
+		health.Status = enum.BrokerOnline
+	} else if len(probe.Steps) > 0 {
+		health.Error = probe.Steps[len(probe.Steps)-1].Error
+	}
+
+	if broker.Certificates != nil {
+		info, err := NewCertificatesInfo(broker.Certificates, health.CheckedAt)
+		if err != nil {
+			log.Printf("brokerMonitorService.check: broker %s, %s", brokerID, err)
+		} else {
+			for _, w := range info.Warnings {
+				if health.CertificateExpiresAt == nil || w.NotAfter.Before(*health.CertificateExpiresAt) {
+					notAfter := w.NotAfter
+					health.CertificateExpiresAt = &notAfter
+				}
+			}
+		}
+	}
+
+	previous, err := s.bhr.List(ctx, []uuid.UUID{brokerID})
+	if err != nil {
+		log.Printf("brokerMonitorService.check: broker %s, %s", brokerID, err)
+		return
+	}
+
+	p, ok := previous[brokerID]
+
+	changed := true
+	health.ChangedAt = health.CheckedAt
+	if ok && p.Status == health.Status {
+		changed = false
+		health.ChangedAt = p.ChangedAt
+	}
+
+	// The expiry is published once, when a certificate enters the warning period.
+	expiring := health.CertificateExpiresAt != nil &&
+		(!ok || p.CertificateExpiresAt == nil || !p.CertificateExpiresAt.Equal(*health.CertificateExpiresAt))
+
+	if err := s.bhr.Set(ctx, health); err != nil {
+		log.Printf("brokerMonitorService.check: broker %s, %s", brokerID, err)
+		return
+	}
+
+	if expiring {
+		log.Printf("brokerMonitorService.check: broker %s, certificate expires at %s", brokerID, health.CertificateExpiresAt)
+		s.es.PublishBrokers(ctx, enum.CertificateExpiringAction, userID, brokerID)
+	}
+
+	if !changed {
+		return
+	}
+
+	action := enum.BrokerOfflineAction
+	if health.Status == enum.BrokerOnline {
+		action = enum.BrokerOnlineAction
+	}
+
+	log.Printf("brokerMonitorService.check: broker %s, %s", brokerID, health.Status)
+	s.es.PublishBrokers(ctx, action, userID, brokerID)
+}
+
+func (s *brokerMonitorService) onEvent(ctx context.Context, userID uuid.UUID, event domain.Event) {
+	if event.Data.Entity == nil || event.Data.Entity.Name != enum.BrokersEntity {
+		return
+	}
+
+	brokerID := event.Data.Entity.ID
+
+	switch event.Data.Action {
+	case enum.EntityCreatedAction, enum.EntityUpdatedAction:
+		s.check(ctx, userID, brokerID)
+	case enum.EntityDeletedAction:
+		if err := s.bhr.Delete(ctx, brokerID); err != nil {
+			log.Printf("brokerMonitorService.onEvent: broker %s, %s", brokerID, err)
+		}
+	}
+}
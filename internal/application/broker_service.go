@@ -2,44 +2,76 @@ package application
 
 import (
 	"context"
+	"errors"
 	"log"
+	"strings"
 
 	"github.com/Deve-Lite/DashboardX-API/config"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
 	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/google/uuid"
 )
 
 type BrokerService interface {
 	Get(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) (*domain.Broker, error)
-	List(ctx context.Context, userID uuid.UUID) ([]*domain.Broker, error)
+	List(ctx context.Context, filters *domain.ListBrokerFilters) (*domain.List[*domain.Broker], error)
 	Create(ctx context.Context, broker *domain.CreateBroker) (uuid.UUID, error)
 	Update(ctx context.Context, broker *domain.UpdateBroker) error
-	Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error
+	Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID, version *int64) error
+	Restore(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error
 	GetCredentials(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) (*domain.Broker, error)
 	SetCredentials(ctx context.Context, broker *domain.UpdateBroker) error
 }
 
 type brokerService struct {
-	c  *config.Config
-	br repository.BrokerRepository
-	cs CryptoService
-	es EventService
+	c   *config.Config
+	br  repository.BrokerRepository
+	bhr repository.BrokerHealthRepository
+	bcr repository.BrokerCertificateRepository
+	cs  CryptoService
+	rec RevisionRecorder
+	es  EventService
 }
 
-func NewBrokerService(c *config.Config, br repository.BrokerRepository, cs CryptoService, es EventService) BrokerService {
-	return &brokerService{c, br, cs, es}
+func NewBrokerService(
+	c *config.Config,
+	br repository.BrokerRepository,
+	bhr repository.BrokerHealthRepository,
+	bcr repository.BrokerCertificateRepository,
+	cs CryptoService,
+	rec RevisionRecorder,
+	es EventService) BrokerService {
+	return &brokerService{c, br, bhr, bcr, cs, rec, es}
 }
 
 func (b *brokerService) Get(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) (*domain.Broker, error) {
-	return b.br.Get(ctx, brokerID, userID)
+	broker, err := b.br.Get(ctx, brokerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.setHealth(ctx, []*domain.Broker{broker}); err != nil {
+		return nil, err
+	}
+
+	return broker, nil
 }
 
-func (b *brokerService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Broker, error) {
-	return b.br.List(ctx, userID)
+func (b *brokerService) List(ctx context.Context, filters *domain.ListBrokerFilters) (*domain.List[*domain.Broker], error) {
+	brokers, err := b.br.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.setHealth(ctx, brokers.Items); err != nil {
+		return nil, err
+	}
+
+	return brokers, nil
 }
 
 func (b *brokerService) Create(ctx context.Context, broker *domain.CreateBroker) (uuid.UUID, error) {
@@ -49,11 +81,36 @@ func (b *brokerService) Create(ctx context.Context, broker *domain.CreateBroker)
 		broker.Username = t.NewString("", false, false)
 	}
 
+	version, transport := broker.ProtocolVersion, broker.Transport
+	if version == "" {
+		version = enum.MQTTVersion311
+	}
+	if transport == "" {
+		transport = enum.TCPTransport
+	}
+
+	var sessionExpiry uint32
+	if broker.SessionExpiry != nil {
+		sessionExpiry = *broker.SessionExpiry
+	}
+
+	if err := validateTransport(version, transport, broker.Path, sessionExpiry, broker.UserProperties); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := validateDiscoveryPrefix(broker.DiscoveryPrefix); err != nil {
+		return uuid.Nil, err
+	}
+
 	brokerID, err := b.br.Create(ctx, broker)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
+	if err := b.rec.RecordBroker(ctx, broker.UserID, brokerID); err != nil {
+		return uuid.Nil, err
+	}
+
 	// enum.EntityCreatedAction, broker.UserID, brokerID
 
 	b.es.Publish(ctx, domain.Event{
@@ -77,17 +134,56 @@ func (b *brokerService) Update(ctx context.Context, broker *domain.UpdateBroker)
 		broker.Username = t.NewString("", false, false)
 	}
 
+	if broker.ProtocolVersion != nil || broker.Transport != nil || broker.Path.Set ||
+		broker.SessionExpiry != nil || broker.UserProperties != nil {
+		current, err := b.br.Get(ctx, broker.ID, broker.UserID)
+		if err != nil {
+			return err
+		}
+
+		version, transport, path := current.ProtocolVersion, current.Transport, current.Path
+		sessionExpiry, props := current.SessionExpiry, current.UserProperties
+
+		if broker.ProtocolVersion != nil {
+			version = *broker.ProtocolVersion
+		}
+		if broker.Transport != nil {
+			transport = *broker.Transport
+		}
+		if broker.Path.Set {
+			path = broker.Path
+		}
+		if broker.SessionExpiry != nil {
+			sessionExpiry = *broker.SessionExpiry
+		}
+		if broker.UserProperties != nil {
+			props = broker.UserProperties
+		}
+
+		if err := validateTransport(version, transport, path, sessionExpiry, props); err != nil {
+			return err
+		}
+	}
+
+	if err := validateDiscoveryPrefix(broker.DiscoveryPrefix); err != nil {
+		return err
+	}
+
 	if err := b.br.Update(ctx, broker); err != nil {
 		return err
 	}
 
+	if err := b.rec.RecordBroker(ctx, broker.UserID, broker.ID); err != nil {
+		return err
+	}
+
 	b.es.PublishBrokers(ctx, enum.EntityUpdatedAction, broker.UserID, broker.ID)
 
 	return nil
 }
 
-func (b *brokerService) Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error {
-	if err := b.br.Delete(ctx, brokerID, userID); err != nil {
+func (b *brokerService) Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID, version *int64) error {
+	if err := b.br.Delete(ctx, brokerID, userID, version); err != nil {
 		return err
 	}
 
@@ -96,6 +192,16 @@ func (b *brokerService) Delete(ctx context.Context, brokerID uuid.UUID, userID u
 	return nil
 }
 
+func (b *brokerService) Restore(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error {
+	if err := b.br.Restore(ctx, brokerID, userID); err != nil {
+		return err
+	}
+
+	b.es.PublishBrokers(ctx, enum.EntityCreatedAction, userID, brokerID)
+
+	return nil
+}
+
 func (b *brokerService) GetCredentials(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) (*domain.Broker, error) {
 	broker, err := b.br.Get(ctx, brokerID, userID)
 	if err != nil {
@@ -120,6 +226,24 @@ func (b *brokerService) GetCredentials(ctx context.Context, brokerID uuid.UUID,
 		broker.Password = t.NewString(password, false, true)
 	}
 
+	certificates, err := b.bcr.Get(ctx, brokerID)
+	if err != nil && !errors.Is(err, ae.ErrBrokerCertificatesNotFound) {
+		return nil, err
+	}
+
+	if certificates != nil {
+		if certificates.ClientKey.Set && !certificates.ClientKey.Null {
+			key, err := b.cs.Decrypt(certificates.ClientKey.String, enum.CryptoBrokerKey)
+			if err != nil {
+				return nil, err
+			}
+
+			certificates.ClientKey = t.NewString(key, false, true)
+		}
+
+		broker.Certificates = certificates
+	}
+
 	return broker, nil
 }
 
@@ -154,3 +278,57 @@ func (b *brokerService) SetCredentials(ctx context.Context, broker *domain.Updat
 
 	return nil
 }
+
+// setHealth sets the status recorded by the broker monitor, brokers which have not been checked yet are unknown.
+func (b *brokerService) setHealth(ctx context.Context, brokers []*domain.Broker) error {
+	brokerIDs := make([]uuid.UUID, len(brokers))
+	for i, broker := range brokers {
+		brokerIDs[i] = broker.ID
+	}
+
+	health, err := b.bhr.List(ctx, brokerIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, broker := range brokers {
+		broker.Status = enum.BrokerUnknown
+
+		if h, ok := health[broker.ID]; ok {
+			broker.Status = h.Status
+			broker.StatusChangedAt = &h.ChangedAt
+		}
+	}
+
+	return nil
+}
+
+// validateTransport checks the options which depend on each other, the path is used by the websocket transports
+// and the session expiry with the user properties are sent only over MQTT 5.
+func validateTransport(
+	version enum.MQTTVersion,
+	transport enum.MQTTTransport,
+	path t.String,
+	sessionExpiry uint32,
+	props domain.BrokerUserProperties) error {
+	if path.Set && !path.Null {
+		if transport == enum.TCPTransport || !strings.HasPrefix(path.String, "/") {
+			return ae.ErrBrokerPathInvalid
+		}
+	}
+
+	if version != enum.MQTTVersion5 && (sessionExpiry > 0 || len(props) > 0) {
+		return ae.ErrBrokerMQTT5Required
+	}
+
+	return nil
+}
+
+// validateDiscoveryPrefix checks the prefix is a topic, the discovery subscribes to the filter of the topics under it.
+func validateDiscoveryPrefix(prefix t.String) error {
+	if prefix.Set && !prefix.Null && !mqtt.ValidTopic(prefix.String) {
+		return ae.ErrBrokerPrefixInvalid
+	}
+
+	return nil
+}
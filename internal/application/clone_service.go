@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+type CloneService interface {
+	CloneDevice(ctx context.Context, clone *domain.CloneDevice) (uuid.UUID, error)
+	CloneBroker(ctx context.Context, clone *domain.CloneBroker) (uuid.UUID, error)
+}
+
+type cloneService struct {
+	tr  repository.Transactor
+	bcr repository.BrokerCertificateRepository
+	bs  BrokerService
+	ds  DeviceService
+	dcs DeviceControlService
+	tgs TagService
+	cs  CryptoService
+	rec RevisionRecorder
+	es  EventService
+}
+
+func NewCloneService(
+	tr repository.Transactor,
+	bcr repository.BrokerCertificateRepository,
+	bs BrokerService,
+	ds DeviceService,
+	dcs DeviceControlService,
+	tgs TagService,
+	cs CryptoService,
+	rec RevisionRecorder,
+	es EventService) CloneService {
+	return &cloneService{tr, bcr, bs, ds, dcs, tgs, cs, rec, es}
+}
+
+// CloneDevice copies the device along with its controls and tags in a single transaction.
+func (s *cloneService) CloneDevice(ctx context.Context, clone *domain.CloneDevice) (uuid.UUID, error) {
+	device, err := s.ds.Get(ctx, clone.DeviceID, clone.UserID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	brokerID := device.BrokerID
+	if clone.BrokerID.Set {
+		brokerID = uuid.NullUUID{UUID: clone.BrokerID.Value, Valid: !clone.BrokerID.Null}
+	}
+
+	if clone.Name.Set && !clone.Name.Null {
+		device.Name = clone.Name.String
+	}
+
+	if clone.BasePath.Set {
+		device.BasePath = stringPtr(clone.BasePath.String, clone.BasePath.Null)
+	}
+
+	var deviceID uuid.UUID
+	err = transaction(ctx, s.tr, s.rec, s.es, func(ctx context.Context) error {
+		deviceID, err = s.cloneDevice(ctx, clone.UserID, device, brokerID)
+		return err
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return deviceID, nil
+}
+
+// CloneBroker copies the broker to the new server along with its credentials and certificates, which are
+// encrypted anew, and all its devices with their controls. The client ID is not copied, as two connections
+// with the same one would take over each other.
+func (s *cloneService) CloneBroker(ctx context.Context, clone *domain.CloneBroker) (uuid.UUID, error) {
+	broker, err := s.bs.GetCredentials(ctx, clone.BrokerID, clone.UserID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	devices, err := s.ds.List(ctx, &domain.ListDeviceFilters{
+		UserID:   clone.UserID,
+		BrokerID: uuid.NullUUID{UUID: clone.BrokerID, Valid: true},
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var brokerID uuid.UUID
+	err = transaction(ctx, s.tr, s.rec, s.es, func(ctx context.Context) error {
+		brokerID, err = s.bs.Create(ctx, &domain.CreateBroker{
+			UserID:              clone.UserID,
+			Name:                clone.Name,
+			Server:              clone.Server,
+			Port:                broker.Port,
+			KeepAlive:           broker.KeepAlive,
+			IconName:            broker.IconName,
+			IconBackgroundColor: broker.IconBackgroundColor,
+			IsSSL:               broker.IsSSL,
+			DiscoveryMode:       broker.DiscoveryMode,
+			DiscoveryPrefix:     t.NewString(broker.DiscoveryPrefix, false, true),
+			ProtocolVersion:     broker.ProtocolVersion,
+			Transport:           broker.Transport,
+			Path:                broker.Path,
+			CleanStart:          &broker.CleanStart,
+			SessionExpiry:       &broker.SessionExpiry,
+			UserProperties:      broker.UserProperties,
+		})
+		if err != nil {
+			return err
+		}
+
+		username, password := broker.Username.Set && !broker.Username.Null, broker.Password.Set && !broker.Password.Null
+		if username || password {
+			err := s.bs.SetCredentials(ctx, &domain.UpdateBroker{
+				ID:       brokerID,
+				UserID:   clone.UserID,
+				Username: t.NewString(broker.Username.String, !username, true),
+				Password: t.NewString(broker.Password.String, !password, true),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.cloneCertificates(ctx, brokerID, broker.Certificates); err != nil {
+			return err
+		}
+
+		for _, device := range devices.Items {
+			if _, err := s.cloneDevice(ctx, clone.UserID, device, uuid.NullUUID{UUID: brokerID, Valid: true}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return brokerID, nil
+}
+
+func (s *cloneService) cloneDevice(ctx context.Context, userID uuid.UUID, device *domain.Device, brokerID uuid.NullUUID) (uuid.UUID, error) {
+	controls, err := s.dcs.List(ctx, userID, &domain.ListDeviceControlFilters{DeviceID: device.ID})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	deviceID, err := s.ds.Create(ctx, &domain.CreateDevice{
+		UserID:              userID,
+		BrokerID:            brokerID,
+		RoomID:              device.RoomID,
+		Name:                device.Name,
+		IconName:            device.IconName,
+		IconBackgroundColor: device.IconBackgroundColor,
+		Placing:             stringPtrToNullable(device.Placing),
+		BasePath:            stringPtrToNullable(device.BasePath),
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if len(device.TagIDs) > 0 {
+		if err := s.tgs.SetDeviceTags(ctx, userID, deviceID, device.TagIDs); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	for _, c := range controls.Items {
+		controlID, err := s.dcs.Create(ctx, userID, &domain.CreateDeviceControl{
+			DeviceID:               deviceID,
+			Name:                   c.Name,
+			Type:                   c.Type,
+			QoS:                    c.QoS,
+			IconName:               c.IconName,
+			IconBackgroundColor:    c.IconBackgroundColor,
+			IsAvailable:            c.IsAvailable,
+			IsConfirmationRequired: c.IsConfirmationRequired,
+			CanNotifyOnPublish:     c.CanNotifyOnPublish,
+			CanDisplayName:         c.CanDisplayName,
+			Topic:                  c.Topic,
+			Attributes:             c.Attributes,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		if len(c.TagIDs) > 0 {
+			if err := s.tgs.SetControlTags(ctx, userID, deviceID, controlID, c.TagIDs); err != nil {
+				return uuid.Nil, err
+			}
+		}
+	}
+
+	return deviceID, nil
+}
+
+// cloneCertificates stores the certificates of the broker as they are, so the expired ones are copied too.
+func (s *cloneService) cloneCertificates(ctx context.Context, brokerID uuid.UUID, certificates *domain.BrokerCertificates) error {
+	if certificates == nil {
+		return nil
+	}
+
+	update := &domain.UpdateBrokerCertificates{
+		BrokerID:          brokerID,
+		CACertificate:     certificates.CACertificate,
+		ClientCertificate: certificates.ClientCertificate,
+		ClientKey:         certificates.ClientKey,
+	}
+
+	if update.ClientKey.Set && !update.ClientKey.Null {
+		key, err := s.cs.Encrypt(update.ClientKey.String, enum.CryptoBrokerKey)
+		if err != nil {
+			return err
+		}
+
+		update.ClientKey = t.NewString(key, false, true)
+	}
+
+	return s.bcr.Set(ctx, update)
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonpath"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+)
+
+const transformAttribute = "transform"
+
+func init() {
+	jsonschema.RegisterFormat("regex", func(v string) bool {
+		_, err := regexp.Compile(v)
+		return err == nil
+	})
+}
+
+// transformSchema is the schema of the transform attribute, shared by all the types displaying values.
+func transformSchema() *jsonschema.Schema {
+	s := objectSchema(nil, map[string]*jsonschema.Schema{
+		"path":   pathSchema(),
+		"regex":  {Type: "string", Format: "regex", Description: "Regular expression matched against the value, the first group is kept when there is one"},
+		"scale":  {Type: "number", Description: "Multiplies the value"},
+		"offset": {Type: "number", Description: "Added to the value after the scale"},
+		"round":  {Type: "integer", Minimum: jsonschema.Number(0), Maximum: jsonschema.Number(10), Description: "Decimal places the value is rounded to"},
+		"unit":   unitSchema(),
+		"labels": {Type: "object", AdditionalProperties: &jsonschema.Schema{Type: "string"}, Description: "Labels displayed instead of the values"},
+	})
+	s.Description = "Applied to the received payloads, in the order of the properties, before they are decoded"
+
+	return s
+}
+
+// controlTransform is the transform attribute of a control, read from the attributes matching its schema.
+type controlTransform struct {
+	path   string
+	regex  *regexp.Regexp
+	scale  *float64
+	offset *float64
+	round  *int
+	unit   *string
+	labels map[string]string
+}
+
+func newControlTransform(v map[string]interface{}) *controlTransform {
+	t := &controlTransform{labels: map[string]string{}}
+
+	t.path, _ = v["path"].(string)
+
+	if r, ok := v["regex"].(string); ok {
+		t.regex = regexp.MustCompile(r)
+	}
+	if n, ok := v["scale"].(float64); ok {
+		t.scale = &n
+	}
+	if n, ok := v["offset"].(float64); ok {
+		t.offset = &n
+	}
+	if n, ok := v["round"].(float64); ok {
+		r := int(n)
+		t.round = &r
+	}
+	if u, ok := v["unit"].(string); ok {
+		t.unit = &u
+	}
+
+	labels, _ := v["labels"].(map[string]interface{})
+	for k, l := range labels {
+		t.labels[k] = l.(string)
+	}
+
+	return t
+}
+
+// apply extracts the value with the path and the regex, scales and rounds it and looks up its label.
+func (t *controlTransform) apply(payload []byte) (*domain.TransformResult, error) {
+	var value interface{} = string(payload)
+
+	if t.path != "" {
+		v, err := jsonpath.Lookup(t.path, payload)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ae.ErrControlPayloadInvalid, err.Error())
+		}
+		value = v
+	}
+
+	text := transformText(value)
+
+	if t.regex != nil {
+		m := t.regex.FindStringSubmatch(text)
+		if m == nil {
+			return nil, fmt.Errorf("%w: %q does not match the regex", ae.ErrControlPayloadInvalid, text)
+		}
+
+		text = m[0]
+		if len(m) > 1 {
+			text = m[1]
+		}
+		value = text
+	}
+
+	if t.scale != nil || t.offset != nil || t.round != nil {
+		n, ok := value.(float64)
+		if !ok {
+			var err error
+			if n, err = strconv.ParseFloat(strings.TrimSpace(text), 64); err != nil {
+				return nil, fmt.Errorf("%w: %q is not a number", ae.ErrControlPayloadInvalid, text)
+			}
+		}
+
+		if t.scale != nil {
+			n *= *t.scale
+		}
+		if t.offset != nil {
+			n += *t.offset
+		}
+		if t.round != nil {
+			p := math.Pow10(*t.round)
+			n = math.Round(n*p) / p
+		}
+
+		value = n
+		text = formatNumber(n)
+	}
+
+	r := &domain.TransformResult{Text: text, Value: value, Unit: t.unit}
+
+	if l, ok := t.labels[text]; ok {
+		r.Label = &l
+		r.Text = l
+	}
+
+	return r, nil
+}
+
+// transformText is the text of a value read from a JSON payload, the strings are not quoted.
+func transformText(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, _ := json.Marshal(v)
+	return string(b)
+}
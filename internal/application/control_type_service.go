@@ -0,0 +1,246 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+)
+
+// ControlTypeService is the registry of the control types, a new type is added by registering
+// its definition, the attributes of the controls are validated against the schema of their type.
+type ControlTypeService interface {
+	Register(controlType *domain.ControlType)
+	Get(name enum.ControlType) (*domain.ControlType, error)
+	List() []*domain.ControlType
+	Validate(name enum.ControlType, attributes domain.ControlAttributes) error
+	Encode(name enum.ControlType, attributes domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error)
+	Decode(name enum.ControlType, attributes domain.ControlAttributes, payload []byte) (interface{}, error)
+	Preview(preview *domain.TransformPreview) (*domain.TransformResult, error)
+}
+
+type controlTypeService struct {
+	mu    sync.RWMutex
+	names []enum.ControlType
+	types map[enum.ControlType]*domain.ControlType
+}
+
+// NewControlTypeService returns the registry with the built-in control types registered.
+func NewControlTypeService() ControlTypeService {
+	s := &controlTypeService{names: []enum.ControlType{}, types: map[enum.ControlType]*domain.ControlType{}}
+
+	for _, t := range builtinControlTypes() {
+		s.Register(t)
+	}
+
+	return s
+}
+
+// Register adds the control type or replaces the one registered under the same name,
+// the types displaying values accept the transform of the received payloads.
+func (s *controlTypeService) Register(controlType *domain.ControlType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if controlType.Decode != nil && controlType.Schema != nil && controlType.Schema.Properties != nil {
+		t, schema := *controlType, *controlType.Schema
+
+		schema.Properties = make(map[string]*jsonschema.Schema, len(controlType.Schema.Properties)+1)
+		for k, v := range controlType.Schema.Properties {
+			schema.Properties[k] = v
+		}
+		schema.Properties[transformAttribute] = transformSchema()
+
+		t.Schema = &schema
+		controlType = &t
+	}
+
+	if _, ok := s.types[controlType.Name]; !ok {
+		s.names = append(s.names, controlType.Name)
+	}
+
+	s.types[controlType.Name] = controlType
+}
+
+func (s *controlTypeService) Get(name enum.ControlType) (*domain.ControlType, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.types[name]
+	if !ok {
+		return nil, ae.ErrControlTypeUnknown
+	}
+
+	return t, nil
+}
+
+func (s *controlTypeService) List() []*domain.ControlType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := make([]*domain.ControlType, 0, len(s.names))
+	for _, n := range s.names {
+		r = append(r, s.types[n])
+	}
+
+	return r
+}
+
+// Validate returns a validation error with the paths of the invalid attributes, relative to the control.
+func (s *controlTypeService) Validate(name enum.ControlType, attributes domain.ControlAttributes) error {
+	t, err := s.Get(name)
+	if err != nil {
+		names := []string{}
+		for _, t := range s.List() {
+			names = append(names, string(t.Name))
+		}
+
+		return &ae.ValidationError{
+			Err:    err,
+			Fields: []*ae.FieldError{{Field: "type", Rule: "oneof", Param: strings.Join(names, " "), Message: fmt.Sprintf("%q is not a registered control type", name)}},
+		}
+	}
+
+	a, err := normalizeControlAttributes(attributes)
+	if err != nil {
+		return err
+	}
+
+	errs := t.Schema.Validate(a)
+	if len(errs) == 0 && t.Check != nil {
+		errs = t.Check(a)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	fields := make([]*ae.FieldError, len(errs))
+	for i, e := range errs {
+		field := "attributes"
+		if e.Field != "" {
+			field += "." + e.Field
+		}
+
+		fields[i] = &ae.FieldError{Field: field, Rule: e.Keyword, Param: e.Param, Message: e.Message}
+	}
+
+	return &ae.ValidationError{Err: ae.ErrControlAttributesInvalid, Fields: fields}
+}
+
+func (s *controlTypeService) Encode(name enum.ControlType, attributes domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+	t, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Encode == nil {
+		return nil, ae.ErrControlNotWritable
+	}
+
+	a, err := normalizeControlAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Encode(a, value, pc)
+}
+
+func (s *controlTypeService) Decode(name enum.ControlType, attributes domain.ControlAttributes, payload []byte) (interface{}, error) {
+	t, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Decode == nil {
+		return nil, ae.ErrControlNotReadable
+	}
+
+	a, err := normalizeControlAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := a[transformAttribute].(map[string]interface{}); ok {
+		r, err := newControlTransform(v).apply(payload)
+		if err != nil {
+			return nil, err
+		}
+		payload = []byte(r.Text)
+	}
+
+	return t.Decode(a, payload)
+}
+
+// Preview validates the transform and applies it to the payload, the result is decoded
+// when the type is set and the attributes, with the transform added, are valid for it.
+func (s *controlTypeService) Preview(preview *domain.TransformPreview) (*domain.TransformResult, error) {
+	v, err := normalizeControlAttributes(preview.Transform)
+	if err != nil {
+		return nil, err
+	}
+
+	if errs := transformSchema().Validate(map[string]interface{}(v)); len(errs) > 0 {
+		fields := make([]*ae.FieldError, len(errs))
+		for i, e := range errs {
+			field := transformAttribute
+			if e.Field != "" {
+				field += "." + e.Field
+			}
+
+			fields[i] = &ae.FieldError{Field: field, Rule: e.Keyword, Param: e.Param, Message: e.Message}
+		}
+
+		return nil, &ae.ValidationError{Err: ae.ErrValidation, Fields: fields}
+	}
+
+	r, err := newControlTransform(v).apply(preview.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if preview.Type == "" {
+		return r, nil
+	}
+
+	attributes := domain.ControlAttributes{}
+	for k, a := range preview.Attributes {
+		attributes[k] = a
+	}
+	attributes[transformAttribute] = map[string]interface{}(v)
+
+	if err := s.Validate(preview.Type, attributes); err != nil {
+		return nil, err
+	}
+
+	if r.Decoded, err = s.Decode(preview.Type, attributes, preview.Payload); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// normalizeControlAttributes round trips the attributes through JSON, so they have the same
+// types whether they were read from the database, bound from a request or built in the code.
+func normalizeControlAttributes(v domain.ControlAttributes) (domain.ControlAttributes, error) {
+	r := domain.ControlAttributes{}
+	if v == nil {
+		return r, nil
+	}
+
+	b, err := json.Marshal(map[string]interface{}(v))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
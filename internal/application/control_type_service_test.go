@@ -0,0 +1,303 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+	"github.com/go-playground/assert"
+)
+
+func fields(err error) []string {
+	r := []string{}
+
+	var ve *ae.ValidationError
+	if errors.As(err, &ve) {
+		for _, f := range ve.Fields {
+			r = append(r, f.Field)
+		}
+	}
+
+	return r
+}
+
+func TestControlTypeServiceValidate(t *testing.T) {
+	cts := application.NewControlTypeService()
+
+	t.Run("should accept valid attributes", func(t *testing.T) {
+		assert.Equal(t, nil, cts.Validate(enum.ControlButton, domain.ControlAttributes{"payload": "ON"}))
+		assert.Equal(t, nil, cts.Validate(enum.ControlTextOut, nil))
+		assert.Equal(t, nil, cts.Validate(enum.ControlSlider, domain.ControlAttributes{
+			"payloadTemplate": "{{ value }}",
+			"minValue":        float32(1),
+			"maxValue":        100,
+		}))
+	})
+
+	t.Run("should reject unknown types", func(t *testing.T) {
+		err := cts.Validate("unknown", domain.ControlAttributes{})
+		assert.Equal(t, true, errors.Is(err, ae.ErrControlTypeUnknown))
+		assert.Equal(t, []string{"type"}, fields(err))
+	})
+
+	t.Run("should return the fields of invalid attributes", func(t *testing.T) {
+		err := cts.Validate(enum.ControlGauge, domain.ControlAttributes{
+			"minValue":   0,
+			"maxValue":   "100",
+			"thresholds": []interface{}{map[string]interface{}{"value": 50, "color": "red"}},
+			"payload":    "ON",
+		})
+		assert.Equal(t, true, errors.Is(err, ae.ErrControlAttributesInvalid))
+		assert.Equal(t, []string{"attributes.maxValue", "attributes.payload", "attributes.thresholds[0].color"}, fields(err))
+	})
+
+	t.Run("should check the rules between attributes", func(t *testing.T) {
+		err := cts.Validate(enum.ControlMultiSwitch, domain.ControlAttributes{
+			"switches": []interface{}{
+				map[string]interface{}{"name": "a", "onPayload": "ON", "offPayload": "OFF"},
+				map[string]interface{}{"name": "a", "onPayload": "ON", "offPayload": "OFF"},
+			},
+		})
+		assert.Equal(t, []string{"attributes.switches[1].name"}, fields(err))
+
+		err = cts.Validate(enum.ControlNumberIn, domain.ControlAttributes{
+			"payloadTemplate": "{{ value }}",
+			"minValue":        10,
+			"maxValue":        0,
+			"step":            1,
+		})
+		assert.Equal(t, []string{"attributes.maxValue"}, fields(err))
+	})
+
+	t.Run("should check the JSONPath expressions", func(t *testing.T) {
+		err := cts.Validate(enum.ControlChart, domain.ControlAttributes{
+			"series": []interface{}{map[string]interface{}{"name": "a", "path": "a.b"}},
+		})
+		assert.Equal(t, []string{"attributes.series[0].path"}, fields(err))
+	})
+
+	t.Run("should check the payload templates", func(t *testing.T) {
+		attributes := func(template string) domain.ControlAttributes {
+			return domain.ControlAttributes{"payloadTemplate": template, "minValue": 0, "maxValue": 10}
+		}
+
+		assert.Equal(t, nil, cts.Validate(enum.ControlSlider, attributes(`{"v": {{ .Value | round 1 }}, "at": {{ ticks .Timestamp }}}`)))
+
+		for _, template := range []string{
+			"{{ value",
+			"{{ unknown }}",
+			"{{ range .Value }}x{{ end }}",
+			`{{ define "a" }}x{{ end }}`,
+			`{{ template "payload" }}`,
+			"{{ call .Value }}",
+			"{{ if true }}{{ (call .Value) }}{{ end }}",
+		} {
+			assert.Equal(t, []string{"attributes.payloadTemplate"}, fields(cts.Validate(enum.ControlSlider, attributes(template))))
+		}
+	})
+
+	t.Run("should check the transform of the displayed values", func(t *testing.T) {
+		assert.Equal(t, nil, cts.Validate(enum.ControlTextOut, domain.ControlAttributes{
+			"transform": map[string]interface{}{"path": "$.temp", "scale": 0.1, "round": 1, "unit": "°C"},
+		}))
+
+		err := cts.Validate(enum.ControlTextOut, domain.ControlAttributes{
+			"transform": map[string]interface{}{"regex": "(", "labels": map[string]interface{}{"1": 1}},
+		})
+		assert.Equal(t, []string{"attributes.transform.labels.1", "attributes.transform.regex"}, fields(err))
+
+		err = cts.Validate(enum.ControlButton, domain.ControlAttributes{"payload": "ON", "transform": map[string]interface{}{}})
+		assert.Equal(t, []string{"attributes.transform"}, fields(err))
+	})
+}
+
+func TestControlTypeServiceRegister(t *testing.T) {
+	cts := application.NewControlTypeService()
+
+	cts.Register(&domain.ControlType{
+		Name:   "custom",
+		Schema: &jsonschema.Schema{Type: "object", Required: []string{"topicSuffix"}},
+	})
+
+	t.Run("should list the registered type after the built-in ones", func(t *testing.T) {
+		types := cts.List()
+		assert.Equal(t, enum.ControlButton, types[0].Name)
+		assert.Equal(t, enum.ControlType("custom"), types[len(types)-1].Name)
+	})
+
+	t.Run("should validate against the registered schema", func(t *testing.T) {
+		assert.Equal(t, nil, cts.Validate("custom", domain.ControlAttributes{"topicSuffix": "/set"}))
+		assert.Equal(t, []string{"attributes.topicSuffix"}, fields(cts.Validate("custom", nil)))
+	})
+}
+
+func TestControlTypeServiceEncode(t *testing.T) {
+	cts := application.NewControlTypeService()
+
+	t.Run("should render the payload template", func(t *testing.T) {
+		p, err := cts.Encode(enum.ControlNumberIn, domain.ControlAttributes{
+			"payloadTemplate": `{"brightness": {{value}}}`,
+			"minValue":        0,
+			"maxValue":        10,
+			"step":            0.5,
+		}, 2.5, nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"brightness": 2.5}`, string(p))
+	})
+
+	t.Run("should reject values out of the step", func(t *testing.T) {
+		_, err := cts.Encode(enum.ControlNumberIn, domain.ControlAttributes{
+			"payloadTemplate": "{{ value }}",
+			"minValue":        0,
+			"maxValue":        10,
+			"step":            0.5,
+		}, 2.2, nil)
+		assert.Equal(t, true, errors.Is(err, ae.ErrControlValueInvalid))
+	})
+
+	t.Run("should send dates as ticks", func(t *testing.T) {
+		p, err := cts.Encode(enum.ControlDateTime, domain.ControlAttributes{
+			"payloadTemplate": "{{ value }}",
+			"sendAsTicks":     true,
+		}, "1970-01-01T00:00:01Z", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "621355968010000000", string(p))
+	})
+
+	t.Run("should publish the payload of a switch", func(t *testing.T) {
+		p, err := cts.Encode(enum.ControlMultiSwitch, domain.ControlAttributes{
+			"switches": []interface{}{
+				map[string]interface{}{"name": "a", "onPayload": "A:ON", "offPayload": "A:OFF"},
+				map[string]interface{}{"name": "b", "onPayload": "B:ON", "offPayload": "B:OFF"},
+			},
+		}, map[string]interface{}{"name": "b", "on": false}, nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "B:OFF", string(p))
+	})
+
+	t.Run("should render the template with the helpers and the context", func(t *testing.T) {
+		p, err := cts.Encode(enum.ControlColor, domain.ControlAttributes{
+			"payloadTemplate": `{"device": {{ json .Device.Name }}, "rgb": "{{ rgb .Value }}", "hsv": "{{ hsv .Value }}"}`,
+			"colorFormat":     "hex",
+		}, "#ff8000", &domain.PayloadContext{DeviceName: "Lamp"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"device": "Lamp", "rgb": "255,128,0", "hsv": "30,100,100"}`, string(p))
+
+		p, err = cts.Encode(enum.ControlDateTime, domain.ControlAttributes{
+			"payloadTemplate": `{{ unix .Value }}/{{ .Value | formatTime "2006-01-02" }}`,
+			"sendAsTicks":     false,
+		}, "1970-01-02T00:00:00Z", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "86400/1970-01-02", string(p))
+	})
+
+	t.Run("should reject the values the template can not render", func(t *testing.T) {
+		_, err := cts.Encode(enum.ControlColor, domain.ControlAttributes{
+			"payloadTemplate": "{{ rgb .Value }}",
+			"colorFormat":     "hex",
+		}, "red", nil)
+		assert.Equal(t, true, errors.Is(err, ae.ErrControlValueInvalid))
+	})
+
+	t.Run("should not publish read only controls", func(t *testing.T) {
+		_, err := cts.Encode(enum.ControlState, domain.ControlAttributes{"onPayload": "ON", "offPayload": "OFF"}, true, nil)
+		assert.Equal(t, ae.ErrControlNotWritable, err)
+	})
+}
+
+func TestControlTypeServiceDecode(t *testing.T) {
+	cts := application.NewControlTypeService()
+
+	t.Run("should decode the on and off payloads", func(t *testing.T) {
+		v, err := cts.Decode(enum.ControlSwitch, domain.ControlAttributes{"onPayload": "ON", "offPayload": "OFF"}, []byte("OFF"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, v)
+
+		_, err = cts.Decode(enum.ControlSwitch, domain.ControlAttributes{"onPayload": "ON", "offPayload": "OFF"}, []byte("?"))
+		assert.Equal(t, ae.ErrControlPayloadInvalid, err)
+	})
+
+	t.Run("should read the chart series", func(t *testing.T) {
+		v, err := cts.Decode(enum.ControlChart, domain.ControlAttributes{
+			"series": []interface{}{
+				map[string]interface{}{"name": "inside", "path": "$.t[0]"},
+				map[string]interface{}{"name": "outside", "path": "$.t[1]"},
+			},
+		}, []byte(`{"t": [21.5, -3]}`))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, map[string]float64{"inside": 21.5, "outside": -3}, v)
+	})
+
+	t.Run("should read the labelled values", func(t *testing.T) {
+		v, err := cts.Decode(enum.ControlJSONState, domain.ControlAttributes{
+			"fields": []interface{}{
+				map[string]interface{}{"label": "Battery", "path": "$.battery", "unit": "%"},
+				map[string]interface{}{"label": "Missing", "path": "$.missing"},
+			},
+		}, []byte(`{"battery": 87}`))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []*application.LabelledValue{
+			{Label: "Battery", Value: float64(87), Unit: "%"},
+			{Label: "Missing"},
+		}, v)
+	})
+}
+
+func TestControlTypeServiceTransform(t *testing.T) {
+	cts := application.NewControlTypeService()
+
+	t.Run("should decode the transformed payload", func(t *testing.T) {
+		v, err := cts.Decode(enum.ControlTextOut, domain.ControlAttributes{
+			"transform": map[string]interface{}{"path": "$.temp"},
+		}, []byte(`{"temp": 21.4, "hum": 40}`))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "21.4", v)
+
+		v, err = cts.Decode(enum.ControlState, domain.ControlAttributes{
+			"onPayload":  "ON",
+			"offPayload": "OFF",
+			"transform":  map[string]interface{}{"path": "$.relay", "labels": map[string]interface{}{"1": "ON", "0": "OFF"}},
+		}, []byte(`{"relay": 1}`))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, v)
+	})
+
+	t.Run("should capture, scale and round the value", func(t *testing.T) {
+		r, err := cts.Preview(&domain.TransformPreview{
+			Payload:   []byte("T=2143 mV"),
+			Transform: map[string]interface{}{"regex": `T=(\d+)`, "scale": 0.01, "offset": -1, "round": 1, "unit": "°C"},
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "20.4", r.Text)
+		assert.Equal(t, 20.4, r.Value)
+		assert.Equal(t, "°C", *r.Unit)
+	})
+
+	t.Run("should decode the preview as the type", func(t *testing.T) {
+		r, err := cts.Preview(&domain.TransformPreview{
+			Payload:    []byte(`{"level": "0.5"}`),
+			Transform:  map[string]interface{}{"path": "$.level", "scale": 100},
+			Type:       enum.ControlGauge,
+			Attributes: domain.ControlAttributes{"minValue": 0, "maxValue": 100},
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, float64(50), r.Decoded)
+	})
+
+	t.Run("should reject the payloads the transform can not read", func(t *testing.T) {
+		_, err := cts.Preview(&domain.TransformPreview{Payload: []byte(`{}`), Transform: map[string]interface{}{"path": "$.temp"}})
+		assert.Equal(t, true, errors.Is(err, ae.ErrControlPayloadInvalid))
+
+		_, err = cts.Preview(&domain.TransformPreview{Payload: []byte("abc"), Transform: map[string]interface{}{"scale": 2}})
+		assert.Equal(t, true, errors.Is(err, ae.ErrControlPayloadInvalid))
+	})
+
+	t.Run("should validate the transform", func(t *testing.T) {
+		_, err := cts.Preview(&domain.TransformPreview{Payload: []byte("1"), Transform: map[string]interface{}{"round": 11}})
+		assert.Equal(t, []string{"transform.round"}, fields(err))
+	})
+}
@@ -0,0 +1,502 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonpath"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+)
+
+// ticksAtUnixEpoch is the number of the .NET ticks, 100 ns intervals since 0001-01-01, at the Unix epoch.
+const ticksAtUnixEpoch = 621355968000000000
+
+func init() {
+	jsonschema.RegisterFormat("jsonpath", func(v string) bool {
+		return jsonpath.Validate(v) == nil
+	})
+}
+
+// LabelledValue is a value of the json-state control.
+type LabelledValue struct {
+	Label string      `json:"label"`
+	Value interface{} `json:"value"`
+	Unit  string      `json:"unit,omitempty"`
+}
+
+// SwitchValue is a value of the multi-switch control.
+type SwitchValue struct {
+	Name string `json:"name"`
+	On   bool   `json:"on"`
+}
+
+func builtinControlTypes() []*domain.ControlType {
+	return []*domain.ControlType{
+		{
+			Name:        enum.ControlButton,
+			Description: "Publishes a fixed payload when pressed.",
+			Schema: attributesSchema([]string{"payload"}, map[string]*jsonschema.Schema{
+				"payload": payloadSchema("Published payload"),
+			}),
+			Encode: func(a domain.ControlAttributes, _ interface{}, _ *domain.PayloadContext) ([]byte, error) {
+				return []byte(a["payload"].(string)), nil
+			},
+		},
+		{
+			Name:        enum.ControlColor,
+			Description: "Publishes the picked color rendered with the payload template.",
+			Schema: attributesSchema([]string{"payloadTemplate", "colorFormat"}, map[string]*jsonschema.Schema{
+				"payloadTemplate": templateSchema(),
+				"colorFormat":     {Type: "string", MinLength: jsonschema.Int(1), Description: "Format of the picked color"},
+			}),
+			Encode: func(a domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+				v, ok := value.(string)
+				if !ok || v == "" {
+					return nil, ae.ErrControlValueInvalid
+				}
+
+				return renderPayloadTemplate(a, v, v, pc)
+			},
+		},
+		{
+			Name:        enum.ControlDateTime,
+			Description: "Publishes the picked date, as RFC 3339 or as .NET ticks, rendered with the payload template.",
+			Schema: attributesSchema([]string{"payloadTemplate", "sendAsTicks"}, map[string]*jsonschema.Schema{
+				"payloadTemplate": templateSchema(),
+				"sendAsTicks":     {Type: "boolean", Description: "Sends the date as 100 ns intervals since 0001-01-01"},
+			}),
+			Encode: func(a domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+				v, ok := value.(string)
+				if !ok {
+					return nil, ae.ErrControlValueInvalid
+				}
+
+				at, err := time.Parse(time.RFC3339Nano, v)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s", ae.ErrControlValueInvalid, err.Error())
+				}
+
+				if a["sendAsTicks"].(bool) {
+					return renderPayloadTemplate(a, strconv.FormatInt(at.UnixNano()/100+ticksAtUnixEpoch, 10), at, pc)
+				}
+
+				return renderPayloadTemplate(a, at.Format(time.RFC3339Nano), at, pc)
+			},
+		},
+		{
+			Name:        enum.ControlRadio,
+			Description: "Publishes the payload of the picked option.",
+			Schema: attributesSchema([]string{"payloads"}, map[string]*jsonschema.Schema{
+				"payloads": {
+					Type:                 "object",
+					Description:          "Payloads by the option names",
+					AdditionalProperties: payloadSchema(""),
+				},
+			}),
+			Encode: func(a domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+				v, ok := value.(string)
+				if !ok {
+					return nil, ae.ErrControlValueInvalid
+				}
+
+				p, ok := a["payloads"].(map[string]interface{})[v]
+				if !ok {
+					return nil, fmt.Errorf("%w: unknown option %q", ae.ErrControlValueInvalid, v)
+				}
+
+				return []byte(p.(string)), nil
+			},
+			Decode: func(a domain.ControlAttributes, payload []byte) (interface{}, error) {
+				for k, p := range a["payloads"].(map[string]interface{}) {
+					if p.(string) == string(payload) {
+						return k, nil
+					}
+				}
+
+				return nil, ae.ErrControlPayloadInvalid
+			},
+		},
+		{
+			Name:        enum.ControlSlider,
+			Description: "Publishes the picked number rendered with the payload template.",
+			Schema: attributesSchema([]string{"payloadTemplate", "minValue", "maxValue"}, map[string]*jsonschema.Schema{
+				"payloadTemplate": templateSchema(),
+				"minValue":        {Type: "number"},
+				"maxValue":        {Type: "number"},
+			}),
+			Check: checkRange,
+			Encode: func(a domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+				v, err := numberInRange(a, value)
+				if err != nil {
+					return nil, err
+				}
+
+				return renderPayloadTemplate(a, formatNumber(v), v, pc)
+			},
+			Decode: decodeNumber,
+		},
+		{
+			Name:        enum.ControlState,
+			Description: "Displays whether the device is on or off, one per device.",
+			Schema:      onOffSchema(),
+			Decode:      decodeOnOff,
+		},
+		{
+			Name:        enum.ControlSwitch,
+			Description: "Publishes the on or off payload and displays the current one.",
+			Schema:      onOffSchema(),
+			Encode: func(a domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+				v, ok := value.(bool)
+				if !ok {
+					return nil, ae.ErrControlValueInvalid
+				}
+
+				if v {
+					return []byte(a["onPayload"].(string)), nil
+				}
+
+				return []byte(a["offPayload"].(string)), nil
+			},
+			Decode: decodeOnOff,
+		},
+		{
+			Name:        enum.ControlTextOut,
+			Description: "Displays the received payload as text.",
+			Schema:      attributesSchema(nil, map[string]*jsonschema.Schema{}),
+			Decode: func(_ domain.ControlAttributes, payload []byte) (interface{}, error) {
+				return string(payload), nil
+			},
+		},
+		{
+			Name:        enum.ControlGauge,
+			Description: "Displays the received number on a scale, colored by the thresholds.",
+			Schema: attributesSchema([]string{"minValue", "maxValue"}, map[string]*jsonschema.Schema{
+				"minValue": {Type: "number"},
+				"maxValue": {Type: "number"},
+				"unit":     unitSchema(),
+				"thresholds": {
+					Type:        "array",
+					Description: "Colors the gauge from the value on, up to the next threshold",
+					Items: objectSchema([]string{"value", "color"}, map[string]*jsonschema.Schema{
+						"value": {Type: "number"},
+						"color": colorSchema(),
+					}),
+				},
+			}),
+			Check: func(a domain.ControlAttributes) []*jsonschema.Error {
+				if errs := checkRange(a); len(errs) > 0 {
+					return errs
+				}
+
+				errs := []*jsonschema.Error{}
+				thresholds, _ := a["thresholds"].([]interface{})
+				for i, h := range thresholds {
+					v := h.(map[string]interface{})["value"].(float64)
+					if v < a["minValue"].(float64) || v > a["maxValue"].(float64) {
+						errs = append(errs, &jsonschema.Error{
+							Path:    fmt.Sprintf("/thresholds/%d/value", i),
+							Field:   fmt.Sprintf("thresholds[%d].value", i),
+							Keyword: "within",
+							Message: "should be between the minimum and the maximum value",
+						})
+					}
+				}
+
+				return errs
+			},
+			Decode: decodeNumber,
+		},
+		{
+			Name:        enum.ControlChart,
+			Description: "Plots the numbers read from the received JSON payloads.",
+			Schema: attributesSchema([]string{"series"}, map[string]*jsonschema.Schema{
+				"unit": unitSchema(),
+				"series": {
+					Type:     "array",
+					MinItems: jsonschema.Int(1),
+					Items: objectSchema([]string{"name", "path"}, map[string]*jsonschema.Schema{
+						"name":  {Type: "string", MinLength: jsonschema.Int(1)},
+						"path":  pathSchema(),
+						"color": colorSchema(),
+					}),
+				},
+			}),
+			Check: func(a domain.ControlAttributes) []*jsonschema.Error {
+				return checkUnique(a, "series", "name")
+			},
+			Decode: func(a domain.ControlAttributes, payload []byte) (interface{}, error) {
+				values := map[string]float64{}
+				for _, e := range a["series"].([]interface{}) {
+					s := e.(map[string]interface{})
+
+					v, err := jsonpath.Lookup(s["path"].(string), payload)
+					if err != nil {
+						return nil, fmt.Errorf("%w: %s", ae.ErrControlPayloadInvalid, err.Error())
+					}
+
+					n, ok := v.(float64)
+					if !ok {
+						return nil, fmt.Errorf("%w: %s is not a number", ae.ErrControlPayloadInvalid, s["path"])
+					}
+
+					values[s["name"].(string)] = n
+				}
+
+				return values, nil
+			},
+		},
+		{
+			Name:        enum.ControlJSONState,
+			Description: "Displays the labelled values read from the received JSON payloads.",
+			Schema: attributesSchema([]string{"fields"}, map[string]*jsonschema.Schema{
+				"fields": {
+					Type:     "array",
+					MinItems: jsonschema.Int(1),
+					Items: objectSchema([]string{"label", "path"}, map[string]*jsonschema.Schema{
+						"label": {Type: "string", MinLength: jsonschema.Int(1)},
+						"path":  pathSchema(),
+						"unit":  unitSchema(),
+					}),
+				},
+			}),
+			Decode: func(a domain.ControlAttributes, payload []byte) (interface{}, error) {
+				var doc interface{}
+				if err := json.Unmarshal(payload, &doc); err != nil {
+					return nil, fmt.Errorf("%w: %s", ae.ErrControlPayloadInvalid, err.Error())
+				}
+
+				values := []*LabelledValue{}
+				for _, e := range a["fields"].([]interface{}) {
+					f := e.(map[string]interface{})
+
+					p, err := jsonpath.Parse(f["path"].(string))
+					if err != nil {
+						return nil, err
+					}
+
+					// A missing field is displayed as empty, the others may still be present.
+					v, _ := p.Get(doc)
+
+					unit, _ := f["unit"].(string)
+					values = append(values, &LabelledValue{Label: f["label"].(string), Value: v, Unit: unit})
+				}
+
+				return values, nil
+			},
+		},
+		{
+			Name:        enum.ControlNumberIn,
+			Description: "Publishes the typed number, a multiple of the step, rendered with the payload template.",
+			Schema: attributesSchema([]string{"payloadTemplate", "minValue", "maxValue", "step"}, map[string]*jsonschema.Schema{
+				"payloadTemplate": templateSchema(),
+				"minValue":        {Type: "number"},
+				"maxValue":        {Type: "number"},
+				"step":            {Type: "number", ExclusiveMinimum: jsonschema.Number(0)},
+				"unit":            unitSchema(),
+			}),
+			Check: checkRange,
+			Encode: func(a domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+				v, err := numberInRange(a, value)
+				if err != nil {
+					return nil, err
+				}
+
+				steps := (v - a["minValue"].(float64)) / a["step"].(float64)
+				if math.Abs(steps-math.Round(steps)) > 1e-9 {
+					return nil, fmt.Errorf("%w: %s is not a multiple of the step", ae.ErrControlValueInvalid, formatNumber(v))
+				}
+
+				return renderPayloadTemplate(a, formatNumber(v), v, pc)
+			},
+		},
+		{
+			Name:        enum.ControlMultiSwitch,
+			Description: "Publishes the on or off payload of one of the switches.",
+			Schema: attributesSchema([]string{"switches"}, map[string]*jsonschema.Schema{
+				"switches": {
+					Type:     "array",
+					MinItems: jsonschema.Int(1),
+					Items: objectSchema([]string{"name", "onPayload", "offPayload"}, map[string]*jsonschema.Schema{
+						"name":       {Type: "string", MinLength: jsonschema.Int(1)},
+						"onPayload":  payloadSchema(""),
+						"offPayload": payloadSchema(""),
+					}),
+				},
+			}),
+			Check: func(a domain.ControlAttributes) []*jsonschema.Error {
+				return checkUnique(a, "switches", "name")
+			},
+			Encode: func(a domain.ControlAttributes, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+				v := &SwitchValue{}
+				b, err := json.Marshal(value)
+				if err != nil || json.Unmarshal(b, v) != nil || v.Name == "" {
+					return nil, ae.ErrControlValueInvalid
+				}
+
+				for _, e := range a["switches"].([]interface{}) {
+					s := e.(map[string]interface{})
+					if s["name"] != v.Name {
+						continue
+					}
+
+					if v.On {
+						return []byte(s["onPayload"].(string)), nil
+					}
+
+					return []byte(s["offPayload"].(string)), nil
+				}
+
+				return nil, fmt.Errorf("%w: unknown switch %q", ae.ErrControlValueInvalid, v.Name)
+			},
+			Decode: func(a domain.ControlAttributes, payload []byte) (interface{}, error) {
+				for _, e := range a["switches"].([]interface{}) {
+					s := e.(map[string]interface{})
+
+					switch string(payload) {
+					case s["onPayload"]:
+						return &SwitchValue{Name: s["name"].(string), On: true}, nil
+					case s["offPayload"]:
+						return &SwitchValue{Name: s["name"].(string), On: false}, nil
+					}
+				}
+
+				return nil, ae.ErrControlPayloadInvalid
+			},
+		},
+	}
+}
+
+func attributesSchema(required []string, properties map[string]*jsonschema.Schema) *jsonschema.Schema {
+	s := objectSchema(required, properties)
+	s.Schema = jsonschema.Draft
+	return s
+}
+
+func objectSchema(required []string, properties map[string]*jsonschema.Schema) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:                 "object",
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: jsonschema.Bool(false),
+	}
+}
+
+func payloadSchema(description string) *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "string", MinLength: jsonschema.Int(1), Description: description}
+}
+
+func templateSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:      "string",
+		MinLength: jsonschema.Int(1),
+		Format:    "template",
+		Description: "Published payload, a Go template in which {{ value }} is the published value, .Value the picked one, " +
+			".Timestamp the time of publishing and .Device and .Control their ID, Name, Placing, BasePath and Topic. " +
+			"The helpers are hex, rgb, hsl and hsv for the colors, ticks, unix, unixMilli and formatTime for the dates, " +
+			"round, json, upper, lower and trim, the templates can not use range, call or other templates",
+		Default: "{{ value }}",
+	}
+}
+
+func unitSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "string"}
+}
+
+func colorSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "string", Format: "hexcolor"}
+}
+
+func pathSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "string", Format: "jsonpath", Description: "JSONPath of the value in the payload"}
+}
+
+func onOffSchema() *jsonschema.Schema {
+	return attributesSchema([]string{"onPayload", "offPayload"}, map[string]*jsonschema.Schema{
+		"onPayload":  payloadSchema("Payload of the on state"),
+		"offPayload": payloadSchema("Payload of the off state"),
+	})
+}
+
+func checkRange(a domain.ControlAttributes) []*jsonschema.Error {
+	if a["minValue"].(float64) >= a["maxValue"].(float64) {
+		return []*jsonschema.Error{{
+			Path:    "/maxValue",
+			Field:   "maxValue",
+			Keyword: "range",
+			Message: "should be greater than the minimum value",
+		}}
+	}
+
+	return nil
+}
+
+func checkUnique(a domain.ControlAttributes, list string, key string) []*jsonschema.Error {
+	errs := []*jsonschema.Error{}
+	seen := map[interface{}]bool{}
+
+	for i, e := range a[list].([]interface{}) {
+		v := e.(map[string]interface{})[key]
+		if seen[v] {
+			errs = append(errs, &jsonschema.Error{
+				Path:    fmt.Sprintf("/%s/%d/%s", list, i, key),
+				Field:   fmt.Sprintf("%s[%d].%s", list, i, key),
+				Keyword: "unique",
+				Message: "should be unique",
+			})
+		}
+		seen[v] = true
+	}
+
+	return errs
+}
+
+func numberInRange(a domain.ControlAttributes, value interface{}) (float64, error) {
+	var v float64
+	switch n := value.(type) {
+	case float64:
+		v = n
+	case float32:
+		v = float64(n)
+	case int:
+		v = float64(n)
+	default:
+		return 0, ae.ErrControlValueInvalid
+	}
+
+	if v < a["minValue"].(float64) || v > a["maxValue"].(float64) {
+		return 0, fmt.Errorf("%w: %s is out of range", ae.ErrControlValueInvalid, formatNumber(v))
+	}
+
+	return v, nil
+}
+
+func decodeNumber(_ domain.ControlAttributes, payload []byte) (interface{}, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a number", ae.ErrControlPayloadInvalid)
+	}
+
+	return v, nil
+}
+
+func decodeOnOff(a domain.ControlAttributes, payload []byte) (interface{}, error) {
+	switch string(payload) {
+	case a["onPayload"]:
+		return true, nil
+	case a["offPayload"]:
+		return false, nil
+	}
+
+	return nil, ae.ErrControlPayloadInvalid
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
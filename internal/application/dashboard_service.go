@@ -0,0 +1,352 @@
+package application
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type DashboardService interface {
+	Get(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) (*domain.Dashboard, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Dashboard, error)
+	Create(ctx context.Context, dashboard *domain.CreateDashboard) (uuid.UUID, error)
+	Update(ctx context.Context, dashboard *domain.UpdateDashboard) error
+	Delete(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) error
+	Reorder(ctx context.Context, userID uuid.UUID, dashboardIDs []uuid.UUID) error
+	CreateTab(ctx context.Context, userID uuid.UUID, tab *domain.CreateDashboardTab) (uuid.UUID, error)
+	UpdateTab(ctx context.Context, userID uuid.UUID, tab *domain.UpdateDashboardTab) error
+	DeleteTab(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabID uuid.UUID) error
+	ReorderTabs(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabIDs []uuid.UUID) error
+	CreateWidget(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, widget *domain.CreateDashboardWidget) (uuid.UUID, error)
+	UpdateWidget(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, widget *domain.UpdateDashboardWidget) error
+	DeleteWidget(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabID uuid.UUID, widgetID uuid.UUID) error
+	SetLayout(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabID uuid.UUID, layout []*domain.WidgetLayout) error
+}
+
+type dashboardService struct {
+	dr  repository.DashboardRepository
+	dcr repository.DeviceControlRepository
+	ds  DeviceService
+	es  EventService
+}
+
+func NewDashboardService(dr repository.DashboardRepository, dcr repository.DeviceControlRepository, ds DeviceService, es EventService) DashboardService {
+	return &dashboardService{dr, dcr, ds, es}
+}
+
+// Get returns the dashboard with its tabs and their widgets.
+func (d *dashboardService) Get(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) (*domain.Dashboard, error) {
+	dashboard, err := d.dr.Get(ctx, dashboardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tabs, err := d.dr.ListTabs(ctx, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	widgets, err := d.dr.ListWidgets(ctx, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	byTab := make(map[uuid.UUID][]*domain.DashboardWidget, len(tabs))
+	for _, w := range widgets {
+		byTab[w.TabID] = append(byTab[w.TabID], w)
+	}
+
+	for _, t := range tabs {
+		t.Widgets = byTab[t.ID]
+		if t.Widgets == nil {
+			t.Widgets = []*domain.DashboardWidget{}
+		}
+	}
+
+	dashboard.Tabs = tabs
+	return dashboard, nil
+}
+
+func (d *dashboardService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Dashboard, error) {
+	return d.dr.List(ctx, userID)
+}
+
+func (d *dashboardService) Create(ctx context.Context, dashboard *domain.CreateDashboard) (uuid.UUID, error) {
+	dashboardID, err := d.dr.Create(ctx, dashboard)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	d.es.PublishDashboards(ctx, enum.EntityCreatedAction, dashboard.UserID, dashboardID)
+
+	return dashboardID, nil
+}
+
+func (d *dashboardService) Update(ctx context.Context, dashboard *domain.UpdateDashboard) error {
+	if err := d.dr.Update(ctx, dashboard); err != nil {
+		return err
+	}
+
+	d.es.PublishDashboards(ctx, enum.EntityUpdatedAction, dashboard.UserID, dashboard.ID)
+
+	return nil
+}
+
+func (d *dashboardService) Delete(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) error {
+	if err := d.dr.Delete(ctx, dashboardID, userID); err != nil {
+		return err
+	}
+
+	d.es.PublishDashboards(ctx, enum.EntityDeletedAction, userID, dashboardID)
+
+	return nil
+}
+
+func (d *dashboardService) Reorder(ctx context.Context, userID uuid.UUID, dashboardIDs []uuid.UUID) error {
+	dashboards, err := d.dr.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	current := make([]uuid.UUID, len(dashboards))
+	for i, db := range dashboards {
+		current[i] = db.ID
+	}
+
+	if !sameIDs(current, dashboardIDs) {
+		return ae.ErrDashboardOrderInvalid
+	}
+
+	if err := d.dr.Reorder(ctx, userID, dashboardIDs); err != nil {
+		return err
+	}
+
+	for _, id := range dashboardIDs {
+		d.es.PublishDashboards(ctx, enum.EntityUpdatedAction, userID, id)
+	}
+
+	return nil
+}
+
+func (d *dashboardService) CreateTab(ctx context.Context, userID uuid.UUID, tab *domain.CreateDashboardTab) (uuid.UUID, error) {
+	if _, err := d.dr.Get(ctx, tab.DashboardID, userID); err != nil {
+		return uuid.Nil, err
+	}
+
+	tabID, err := d.dr.CreateTab(ctx, tab)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	d.es.PublishDashboardTabs(ctx, enum.EntityCreatedAction, userID, tab.DashboardID, tabID)
+
+	return tabID, nil
+}
+
+func (d *dashboardService) UpdateTab(ctx context.Context, userID uuid.UUID, tab *domain.UpdateDashboardTab) error {
+	if _, err := d.dr.Get(ctx, tab.DashboardID, userID); err != nil {
+		return err
+	}
+
+	if err := d.dr.UpdateTab(ctx, tab); err != nil {
+		return err
+	}
+
+	d.es.PublishDashboardTabs(ctx, enum.EntityUpdatedAction, userID, tab.DashboardID, tab.ID)
+
+	return nil
+}
+
+func (d *dashboardService) DeleteTab(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabID uuid.UUID) error {
+	if _, err := d.dr.Get(ctx, dashboardID, userID); err != nil {
+		return err
+	}
+
+	if err := d.dr.DeleteTab(ctx, tabID, dashboardID); err != nil {
+		return err
+	}
+
+	d.es.PublishDashboardTabs(ctx, enum.EntityDeletedAction, userID, dashboardID, tabID)
+
+	return nil
+}
+
+func (d *dashboardService) ReorderTabs(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabIDs []uuid.UUID) error {
+	if _, err := d.dr.Get(ctx, dashboardID, userID); err != nil {
+		return err
+	}
+
+	tabs, err := d.dr.ListTabs(ctx, dashboardID)
+	if err != nil {
+		return err
+	}
+
+	current := make([]uuid.UUID, len(tabs))
+	for i, t := range tabs {
+		current[i] = t.ID
+	}
+
+	if !sameIDs(current, tabIDs) {
+		return ae.ErrDashboardOrderInvalid
+	}
+
+	if err := d.dr.ReorderTabs(ctx, dashboardID, tabIDs); err != nil {
+		return err
+	}
+
+	for _, id := range tabIDs {
+		d.es.PublishDashboardTabs(ctx, enum.EntityUpdatedAction, userID, dashboardID, id)
+	}
+
+	return nil
+}
+
+func (d *dashboardService) CreateWidget(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, widget *domain.CreateDashboardWidget) (uuid.UUID, error) {
+	if err := d.checkTab(ctx, userID, dashboardID, widget.TabID); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := d.checkTarget(ctx, userID, widget.DeviceID, widget.ControlID); err != nil {
+		return uuid.Nil, err
+	}
+
+	widgetID, err := d.dr.CreateWidget(ctx, widget)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	d.es.PublishDashboardWidgets(ctx, enum.EntityCreatedAction, userID, dashboardID, widget.TabID, widgetID)
+
+	return widgetID, nil
+}
+
+func (d *dashboardService) UpdateWidget(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, widget *domain.UpdateDashboardWidget) error {
+	if err := d.checkTab(ctx, userID, dashboardID, widget.TabID); err != nil {
+		return err
+	}
+
+	if err := d.dr.UpdateWidget(ctx, widget); err != nil {
+		return err
+	}
+
+	d.es.PublishDashboardWidgets(ctx, enum.EntityUpdatedAction, userID, dashboardID, widget.TabID, widget.ID)
+
+	return nil
+}
+
+func (d *dashboardService) DeleteWidget(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabID uuid.UUID, widgetID uuid.UUID) error {
+	if err := d.checkTab(ctx, userID, dashboardID, tabID); err != nil {
+		return err
+	}
+
+	if err := d.dr.DeleteWidget(ctx, widgetID, tabID); err != nil {
+		return err
+	}
+
+	d.es.PublishDashboardWidgets(ctx, enum.EntityDeletedAction, userID, dashboardID, tabID, widgetID)
+
+	return nil
+}
+
+// SetLayout moves and resizes the given widgets of the tab, the widgets which are not listed keep their places.
+func (d *dashboardService) SetLayout(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabID uuid.UUID, layout []*domain.WidgetLayout) error {
+	if err := d.checkTab(ctx, userID, dashboardID, tabID); err != nil {
+		return err
+	}
+
+	widgets, err := d.dr.ListWidgets(ctx, dashboardID)
+	if err != nil {
+		return err
+	}
+
+	inTab := make(map[uuid.UUID]bool, len(widgets))
+	for _, w := range widgets {
+		inTab[w.ID] = w.TabID == tabID
+	}
+
+	for _, l := range layout {
+		if !inTab[l.ID] {
+			return ae.ErrDashboardWidgetNotFound
+		}
+	}
+
+	if err := d.dr.SetLayout(ctx, tabID, layout); err != nil {
+		return err
+	}
+
+	for _, l := range layout {
+		d.es.PublishDashboardWidgets(ctx, enum.EntityUpdatedAction, userID, dashboardID, tabID, l.ID)
+	}
+
+	return nil
+}
+
+// checkTab checks that the tab belongs to the dashboard of the user.
+func (d *dashboardService) checkTab(ctx context.Context, userID uuid.UUID, dashboardID uuid.UUID, tabID uuid.UUID) error {
+	if _, err := d.dr.Get(ctx, dashboardID, userID); err != nil {
+		return err
+	}
+
+	tabs, err := d.dr.ListTabs(ctx, dashboardID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tabs {
+		if t.ID == tabID {
+			return nil
+		}
+	}
+
+	return ae.ErrDashboardTabNotFound
+}
+
+// checkTarget checks that the device belongs to the user and the control, when given, to the device.
+func (d *dashboardService) checkTarget(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.NullUUID) error {
+	if _, err := d.ds.Get(ctx, deviceID, userID); err != nil {
+		return err
+	}
+
+	if !controlID.Valid {
+		return nil
+	}
+
+	controls, err := d.dcr.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range controls {
+		if c.ID == controlID.UUID {
+			return nil
+		}
+	}
+
+	return ae.ErrDeviceControlNotFound
+}
+
+// sameIDs reports whether the order lists every current id exactly once.
+func sameIDs(current []uuid.UUID, order []uuid.UUID) bool {
+	if len(current) != len(order) {
+		return false
+	}
+
+	seen := make(map[uuid.UUID]bool, len(current))
+	for _, id := range current {
+		seen[id] = false
+	}
+
+	for _, id := range order {
+		listed, ok := seen[id]
+		if !ok || listed {
+			return false
+		}
+		seen[id] = true
+	}
+
+	return true
+}
@@ -11,28 +11,63 @@ import (
 )
 
 type DeviceControlService interface {
-	List(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID) ([]*domain.DeviceControl, error)
+	List(ctx context.Context, userID uuid.UUID, filters *domain.ListDeviceControlFilters) (*domain.List[*domain.DeviceControl], error)
 	Create(ctx context.Context, userID uuid.UUID, control *domain.CreateDeviceControl) (uuid.UUID, error)
 	Update(ctx context.Context, userID uuid.UUID, control *domain.UpdateDeviceControl) error
-	Delete(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) error
+	Delete(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, version *int64) error
+	Restore(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) error
+	Render(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, value interface{}) (*domain.RenderedPayload, error)
 }
 
 type deviceControlService struct {
 	dcr repository.DeviceControlRepository
+	tr  repository.TagRepository
 	ds  DeviceService
+	cts ControlTypeService
+	ts  TopicService
+	rec RevisionRecorder
 	es  EventService
 }
 
-func NewDeviceControlService(dcr repository.DeviceControlRepository, ds DeviceService, es EventService) DeviceControlService {
-	return &deviceControlService{dcr, ds, es}
+func NewDeviceControlService(
+	dcr repository.DeviceControlRepository,
+	tr repository.TagRepository,
+	ds DeviceService,
+	cts ControlTypeService,
+	ts TopicService,
+	rec RevisionRecorder,
+	es EventService) DeviceControlService {
+	return &deviceControlService{dcr, tr, ds, cts, ts, rec, es}
 }
 
-func (dc *deviceControlService) List(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID) ([]*domain.DeviceControl, error) {
-	if _, err := dc.ds.Get(ctx, deviceID, userID); err != nil {
+func (dc *deviceControlService) List(ctx context.Context, userID uuid.UUID, filters *domain.ListDeviceControlFilters) (*domain.List[*domain.DeviceControl], error) {
+	if _, err := dc.ds.Get(ctx, filters.DeviceID, userID); err != nil {
 		return nil, err
 	}
 
-	return dc.dcr.ListByDevice(ctx, deviceID)
+	controls, err := dc.dcr.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	controlIDs := make([]uuid.UUID, len(controls.Items))
+	for i, c := range controls.Items {
+		controlIDs[i] = c.ID
+	}
+
+	tags, err := dc.tr.ListByControls(ctx, controlIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range controls.Items {
+		c.TagIDs = tags[c.ID]
+		if c.TagIDs == nil {
+			c.TagIDs = []uuid.UUID{}
+		}
+	}
+
+	return controls, nil
 }
 
 func (dc *deviceControlService) Create(ctx context.Context, userID uuid.UUID, control *domain.CreateDeviceControl) (uuid.UUID, error) {
@@ -41,6 +76,10 @@ func (dc *deviceControlService) Create(ctx context.Context, userID uuid.UUID, co
 		return uuid.Nil, err
 	}
 
+	if err := dc.cts.Validate(control.Type, control.Attributes); err != nil {
+		return uuid.Nil, err
+	}
+
 	if control.Type == enum.ControlState {
 		r, err := dc.dcr.Exist(ctx, &domain.DeviceControlFilters{DeviceID: control.DeviceID, Type: control.Type})
 		if err != nil {
@@ -51,11 +90,19 @@ func (dc *deviceControlService) Create(ctx context.Context, userID uuid.UUID, co
 		}
 	}
 
+	if err := dc.ts.Check(ctx, device, control); err != nil {
+		return uuid.Nil, err
+	}
+
 	controlID, err := dc.dcr.Create(ctx, control)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
+	if err := dc.record(ctx, userID, control.DeviceID, controlID); err != nil {
+		return uuid.Nil, err
+	}
+
 	dc.es.PublishDeviceControls(ctx, enum.EntityCreatedAction, userID, device.BrokerID.UUID, device.ID, controlID)
 
 	return controlID, nil
@@ -67,6 +114,12 @@ func (dc *deviceControlService) Update(ctx context.Context, userID uuid.UUID, co
 		return err
 	}
 
+	if control.Type != nil || control.Attributes != nil {
+		if err := dc.validateUpdate(ctx, control); err != nil {
+			return err
+		}
+	}
+
 	if control.Type != nil && *control.Type == enum.ControlState {
 		ci, err := dc.dcr.ListByType(ctx, &domain.DeviceControlFilters{
 			DeviceID: control.DeviceID,
@@ -86,23 +139,134 @@ func (dc *deviceControlService) Update(ctx context.Context, userID uuid.UUID, co
 		return err
 	}
 
+	if err := dc.record(ctx, userID, control.DeviceID, control.ID); err != nil {
+		return err
+	}
+
 	dc.es.PublishDeviceControls(ctx, enum.EntityCreatedAction, userID, device.BrokerID.UUID, device.ID, control.ID)
 
 	return nil
 }
 
-func (dc *deviceControlService) Delete(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) error {
+func (dc *deviceControlService) Delete(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, version *int64) error {
 	device, err := dc.ds.Get(ctx, deviceID, userID)
 	if err != nil {
 		return err
 	}
 
-	err = dc.dcr.Delete(ctx, deviceID, controlID)
+	err = dc.dcr.Delete(ctx, deviceID, controlID, version)
 	if err != nil {
 		return err
 	}
 
+	if err := dc.rec.RecordDevice(ctx, userID, deviceID); err != nil {
+		return err
+	}
+
 	dc.es.PublishDeviceControls(ctx, enum.EntityCreatedAction, userID, device.BrokerID.UUID, deviceID, controlID)
 
 	return nil
 }
+
+// Restore takes the control out of the trash, its device has to be restored first when it has been deleted too.
+func (dc *deviceControlService) Restore(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) error {
+	device, err := dc.ds.Get(ctx, deviceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := dc.dcr.Restore(ctx, deviceID, controlID); err != nil {
+		return err
+	}
+
+	if err := dc.record(ctx, userID, deviceID, controlID); err != nil {
+		return err
+	}
+
+	dc.es.PublishDeviceControls(ctx, enum.EntityCreatedAction, userID, device.BrokerID.UUID, deviceID, controlID)
+
+	return nil
+}
+
+// Render encodes the value as the control would publish it, without publishing it.
+func (dc *deviceControlService) Render(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, value interface{}) (*domain.RenderedPayload, error) {
+	device, err := dc.ds.Get(ctx, deviceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	controls, err := dc.dcr.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var control *domain.DeviceControl
+	for _, c := range controls {
+		if c.ID == controlID {
+			control = c
+			break
+		}
+	}
+
+	if control == nil {
+		return nil, ae.ErrDeviceControlNotFound
+	}
+
+	topic := controlTopic(device.BasePath, control.Topic)
+
+	payload, err := dc.cts.Encode(control.Type, control.Attributes, value, &domain.PayloadContext{
+		DeviceID:    device.ID,
+		DeviceName:  device.Name,
+		Placing:     device.Placing,
+		BasePath:    device.BasePath,
+		ControlID:   control.ID,
+		ControlName: control.Name,
+		Topic:       topic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RenderedPayload{Topic: topic, QoS: control.QoS, Payload: payload}, nil
+}
+
+// record stores the revisions of the control and of its device, whose control set has changed.
+func (dc *deviceControlService) record(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) error {
+	if err := dc.rec.RecordControl(ctx, userID, deviceID, controlID); err != nil {
+		return err
+	}
+
+	return dc.rec.RecordDevice(ctx, userID, deviceID)
+}
+
+// validateUpdate validates the attributes against the type, the one which is not changed is taken from the stored control.
+func (dc *deviceControlService) validateUpdate(ctx context.Context, control *domain.UpdateDeviceControl) error {
+	controls, err := dc.dcr.ListByDevice(ctx, control.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	var current *domain.DeviceControl
+	for _, c := range controls {
+		if c.ID == control.ID {
+			current = c
+			break
+		}
+	}
+
+	if current == nil {
+		return ae.ErrDeviceControlNotFound
+	}
+
+	controlType := current.Type
+	if control.Type != nil {
+		controlType = *control.Type
+	}
+
+	attributes := current.Attributes
+	if control.Attributes != nil {
+		attributes = control.Attributes
+	}
+
+	return dc.cts.Validate(controlType, attributes)
+}
@@ -11,28 +11,56 @@ import (
 
 type DeviceService interface {
 	Get(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) (*domain.Device, error)
-	List(ctx context.Context, filters *domain.ListDeviceFilters) ([]*domain.Device, error)
+	List(ctx context.Context, filters *domain.ListDeviceFilters) (*domain.List[*domain.Device], error)
 	Create(ctx context.Context, device *domain.CreateDevice) (uuid.UUID, error)
 	Update(ctx context.Context, device *domain.UpdateDevice) error
-	Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error
+	Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID, version *int64) error
+	Restore(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error
 }
 
 type deviceService struct {
-	dr repository.DeviceRepository
-	bs BrokerService
-	es EventService
+	dr  repository.DeviceRepository
+	rr  repository.RoomRepository
+	tr  repository.TagRepository
+	bs  BrokerService
+	rec RevisionRecorder
+	es  EventService
 }
 
-func NewDeviceService(dr repository.DeviceRepository, bs BrokerService, es EventService) DeviceService {
-	return &deviceService{dr, bs, es}
+func NewDeviceService(
+	dr repository.DeviceRepository,
+	rr repository.RoomRepository,
+	tr repository.TagRepository,
+	bs BrokerService,
+	rec RevisionRecorder,
+	es EventService) DeviceService {
+	return &deviceService{dr, rr, tr, bs, rec, es}
 }
 
 func (d *deviceService) Get(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) (*domain.Device, error) {
-	return d.dr.Get(ctx, deviceID, userID)
+	device, err := d.dr.Get(ctx, deviceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.setTags(ctx, []*domain.Device{device}); err != nil {
+		return nil, err
+	}
+
+	return device, nil
 }
 
-func (d *deviceService) List(ctx context.Context, filters *domain.ListDeviceFilters) ([]*domain.Device, error) {
-	return d.dr.List(ctx, filters)
+func (d *deviceService) List(ctx context.Context, filters *domain.ListDeviceFilters) (*domain.List[*domain.Device], error) {
+	devices, err := d.dr.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.setTags(ctx, devices.Items); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
 }
 
 func (d *deviceService) Create(ctx context.Context, device *domain.CreateDevice) (uuid.UUID, error) {
@@ -42,11 +70,21 @@ func (d *deviceService) Create(ctx context.Context, device *domain.CreateDevice)
 		}
 	}
 
+	if device.RoomID.Valid {
+		if _, err := d.rr.Get(ctx, device.RoomID.UUID, device.UserID); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
 	deviceID, err := d.dr.Create(ctx, device)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
+	if err := d.rec.RecordDevice(ctx, device.UserID, deviceID); err != nil {
+		return uuid.Nil, err
+	}
+
 	d.es.PublishDevices(ctx, enum.EntityCreatedAction, device.UserID, device.BrokerID.UUID, deviceID)
 
 	return deviceID, nil
@@ -59,10 +97,20 @@ func (d *deviceService) Update(ctx context.Context, device *domain.UpdateDevice)
 		}
 	}
 
+	if device.RoomID.Set && !device.RoomID.Null {
+		if _, err := d.rr.Get(ctx, device.RoomID.Value, device.UserID); err != nil {
+			return err
+		}
+	}
+
 	if err := d.dr.Update(ctx, device); err != nil {
 		return err
 	}
 
+	if err := d.rec.RecordDevice(ctx, device.UserID, device.ID); err != nil {
+		return err
+	}
+
 	{
 		device, _ := d.Get(ctx, device.ID, device.UserID)
 		d.es.PublishDevices(ctx, enum.EntityUpdatedAction, device.UserID, device.BrokerID.UUID, device.ID)
@@ -71,10 +119,10 @@ func (d *deviceService) Update(ctx context.Context, device *domain.UpdateDevice)
 	return nil
 }
 
-func (d *deviceService) Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error {
+func (d *deviceService) Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID, version *int64) error {
 	device, _ := d.Get(ctx, deviceID, userID)
 
-	if err := d.dr.Delete(ctx, deviceID, userID); err != nil {
+	if err := d.dr.Delete(ctx, deviceID, userID, version); err != nil {
 		return err
 	}
 
@@ -82,3 +130,44 @@ func (d *deviceService) Delete(ctx context.Context, deviceID uuid.UUID, userID u
 
 	return nil
 }
+
+func (d *deviceService) Restore(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error {
+	if err := d.dr.Restore(ctx, deviceID, userID); err != nil {
+		return err
+	}
+
+	if err := d.rec.RecordDevice(ctx, userID, deviceID); err != nil {
+		return err
+	}
+
+	device, err := d.dr.Get(ctx, deviceID, userID)
+	if err != nil {
+		return err
+	}
+
+	d.es.PublishDevices(ctx, enum.EntityCreatedAction, userID, device.BrokerID.UUID, deviceID)
+
+	return nil
+}
+
+// setTags sets the ids of the tags of the devices.
+func (d *deviceService) setTags(ctx context.Context, devices []*domain.Device) error {
+	deviceIDs := make([]uuid.UUID, len(devices))
+	for i, device := range devices {
+		deviceIDs[i] = device.ID
+	}
+
+	tags, err := d.tr.ListByDevices(ctx, deviceIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		device.TagIDs = tags[device.ID]
+		if device.TagIDs == nil {
+			device.TagIDs = []uuid.UUID{}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,542 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+const (
+	discoveryIconBackgroundColor = "#41bdf5"
+)
+
+// DiscoveryService listens to the Home Assistant discovery topics of the brokers with the discovery enabled.
+// Depending on the discovery mode of a broker, the discovered entities are either stored as proposals
+// waiting to be accepted or provisioned right away as devices and their controls.
+type DiscoveryService interface {
+	Start(ctx context.Context) error
+	List(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) ([]*domain.DiscoveryProposal, error)
+	Accept(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, proposalID uuid.UUID) (*domain.DiscoveryResult, error)
+	Dismiss(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, proposalID uuid.UUID) error
+}
+
+type discoveryWatch struct {
+	userID         uuid.UUID
+	subscriptionID uuid.UUID
+	mode           enum.DiscoveryMode
+	prefix         string
+}
+
+type discoveryService struct {
+	br      repository.BrokerRepository
+	dr      repository.DiscoveryRepository
+	bs      BrokerService
+	ds      DeviceService
+	dcs     DeviceControlService
+	bgs     BridgeService
+	es      EventService
+	watches map[uuid.UUID]*discoveryWatch
+	mutex   sync.Mutex
+}
+
+func NewDiscoveryService(
+	br repository.BrokerRepository,
+	dr repository.DiscoveryRepository,
+	bs BrokerService,
+	ds DeviceService,
+	dcs DeviceControlService,
+	bgs BridgeService,
+	es EventService) DiscoveryService {
+	s := &discoveryService{
+		br:      br,
+		dr:      dr,
+		bs:      bs,
+		ds:      ds,
+		dcs:     dcs,
+		bgs:     bgs,
+		es:      es,
+		watches: make(map[uuid.UUID]*discoveryWatch),
+	}
+
+	es.Listen(s.onEvent)
+
+	return s
+}
+
+func (s *discoveryService) Start(ctx context.Context) error {
+	brokers, err := s.br.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, broker := range brokers {
+		if broker.DiscoveryMode == enum.DiscoveryDisabled {
+			continue
+		}
+
+		if err := s.watch(ctx, broker); err != nil {
+			log.Printf("discoveryService.Start: broker %s, %s", broker.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *discoveryService) List(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) ([]*domain.DiscoveryProposal, error) {
+	if _, err := s.bs.Get(ctx, brokerID, userID); err != nil {
+		return nil, err
+	}
+
+	proposals, err := s.dr.List(ctx, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].DiscoveredAt.Before(proposals[j].DiscoveredAt)
+	})
+
+	return proposals, nil
+}
+
+func (s *discoveryService) Accept(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, proposalID uuid.UUID) (*domain.DiscoveryResult, error) {
+	if _, err := s.bs.Get(ctx, brokerID, userID); err != nil {
+		return nil, err
+	}
+
+	proposal, err := s.dr.Get(ctx, brokerID, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.provision(ctx, userID, proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dr.Delete(ctx, brokerID, proposalID); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, enum.EntityDeletedAction, userID, brokerID, proposalID)
+
+	return result, nil
+}
+
+func (s *discoveryService) Dismiss(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, proposalID uuid.UUID) error {
+	if _, err := s.bs.Get(ctx, brokerID, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.dr.Get(ctx, brokerID, proposalID); err != nil {
+		return err
+	}
+
+	if err := s.dr.Dismiss(ctx, brokerID, proposalID); err != nil {
+		return err
+	}
+
+	s.publish(ctx, enum.EntityDeletedAction, userID, brokerID, proposalID)
+
+	return nil
+}
+
+// provision finds or creates the device of the proposal and adds the control to it,
+// a control with the same name and topic is not created twice.
+func (s *discoveryService) provision(ctx context.Context, userID uuid.UUID, proposal *domain.DiscoveryProposal) (*domain.DiscoveryResult, error) {
+	brokerID := uuid.NullUUID{UUID: proposal.BrokerID, Valid: true}
+
+	devices, err := s.ds.List(ctx, &domain.ListDeviceFilters{UserID: userID, BrokerID: brokerID})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.DiscoveryResult{}
+
+	for _, d := range devices.Items {
+		if d.Name == proposal.DeviceName {
+			result.DeviceID = d.ID
+			break
+		}
+	}
+
+	if result.DeviceID == uuid.Nil {
+		result.DeviceID, err = s.ds.Create(ctx, &domain.CreateDevice{
+			UserID:              userID,
+			BrokerID:            brokerID,
+			Name:                proposal.DeviceName,
+			IconName:            proposal.Control.IconName,
+			IconBackgroundColor: discoveryIconBackgroundColor,
+			Placing:             t.NewString("", true, true),
+			BasePath:            t.NewString("", true, true),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	controls, err := s.dcs.List(ctx, userID, &domain.ListDeviceControlFilters{DeviceID: result.DeviceID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range controls.Items {
+		if c.Name == proposal.Control.Name && c.Topic == proposal.Control.Topic {
+			result.ControlID = c.ID
+			return result, nil
+		}
+	}
+
+	control := proposal.Control
+	control.DeviceID = result.DeviceID
+
+	result.ControlID, err = s.dcs.Create(ctx, userID, &control)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *discoveryService) watch(ctx context.Context, broker *domain.Broker) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	w, ok := s.watches[broker.ID]
+	if ok && (broker.DiscoveryMode == enum.DiscoveryDisabled || broker.DiscoveryPrefix != w.prefix) {
+		s.unwatch(ctx, broker.ID)
+		ok = false
+	}
+
+	if broker.DiscoveryMode == enum.DiscoveryDisabled {
+		return s.dr.Clear(ctx, broker.ID)
+	}
+
+	if ok {
+		w.mode = broker.DiscoveryMode
+		return nil
+	}
+
+	subscriptionID, err := s.bgs.Subscribe(ctx, broker.UserID, broker.ID, broker.DiscoveryPrefix+"/#", enum.QoSZero, s.handle)
+	if err != nil {
+		return err
+	}
+
+	s.watches[broker.ID] = &discoveryWatch{
+		userID:         broker.UserID,
+		subscriptionID: subscriptionID,
+		mode:           broker.DiscoveryMode,
+		prefix:         broker.DiscoveryPrefix,
+	}
+
+	log.Printf("discoveryService.watch: broker %s, listening on %s/#", broker.ID, broker.DiscoveryPrefix)
+
+	return nil
+}
+
+// unwatch has to be called with the mutex locked.
+func (s *discoveryService) unwatch(ctx context.Context, brokerID uuid.UUID) {
+	w, ok := s.watches[brokerID]
+	if !ok {
+		return
+	}
+
+	if err := s.bgs.Unsubscribe(ctx, brokerID, w.subscriptionID); err != nil {
+		log.Printf("discoveryService.unwatch: broker %s, %s", brokerID, err)
+	}
+
+	delete(s.watches, brokerID)
+}
+
+func (s *discoveryService) handle(message *domain.BridgeMessage) {
+	s.mutex.Lock()
+	w, ok := s.watches[message.BrokerID]
+	var watch discoveryWatch
+	if ok {
+		watch = *w
+	}
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+
+	proposalID, proposal, err := parseDiscovery(watch.prefix, message)
+	if err != nil {
+		if !errors.Is(err, ae.ErrDiscoveryUnsupported) {
+			log.Printf("discoveryService.handle: broker %s, topic %s, %s", message.BrokerID, message.Topic, err)
+		}
+		return
+	}
+
+	if proposal == nil {
+		if err := s.dr.Delete(ctx, message.BrokerID, proposalID); err != nil {
+			log.Printf("discoveryService.handle: broker %s, %s", message.BrokerID, err)
+		}
+		return
+	}
+
+	if watch.mode == enum.DiscoveryAuto {
+		if _, err := s.provision(ctx, watch.userID, proposal); err != nil {
+			log.Printf("discoveryService.handle: broker %s, could not provision %s, %s", message.BrokerID, message.Topic, err)
+		}
+		return
+	}
+
+	if dismissed, err := s.dr.IsDismissed(ctx, message.BrokerID, proposalID); err != nil || dismissed {
+		return
+	}
+
+	_, err = s.dr.Get(ctx, message.BrokerID, proposalID)
+	isNew := errors.Is(err, ae.ErrDiscoveryProposalNotFound)
+
+	if err := s.dr.Set(ctx, proposal); err != nil {
+		log.Printf("discoveryService.handle: broker %s, %s", message.BrokerID, err)
+		return
+	}
+
+	if isNew {
+		s.publish(ctx, enum.EntityCreatedAction, watch.userID, message.BrokerID, proposalID)
+	}
+}
+
+func (s *discoveryService) onEvent(ctx context.Context, userID uuid.UUID, event domain.Event) {
+	if event.Data.Entity == nil || event.Data.Entity.Name != enum.BrokersEntity {
+		return
+	}
+
+	brokerID := event.Data.Entity.ID
+
+	switch event.Data.Action {
+	case enum.EntityCreatedAction, enum.EntityUpdatedAction:
+		broker, err := s.bs.Get(ctx, brokerID, userID)
+		if err != nil {
+			log.Printf("discoveryService.onEvent: broker %s, %s", brokerID, err)
+			return
+		}
+
+		if err := s.watch(ctx, broker); err != nil {
+			log.Printf("discoveryService.onEvent: broker %s, %s", brokerID, err)
+		}
+	case enum.EntityDeletedAction:
+		s.mutex.Lock()
+		s.unwatch(ctx, brokerID)
+		s.mutex.Unlock()
+
+		if err := s.dr.Clear(ctx, brokerID); err != nil {
+			log.Printf("discoveryService.onEvent: broker %s, %s", brokerID, err)
+		}
+	}
+}
+
+func (s *discoveryService) publish(ctx context.Context, action enum.EventAction, userID, brokerID, proposalID uuid.UUID) {
+	s.es.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   proposalID,
+				Name: enum.DiscoveryEntity,
+			},
+			Related: &[]domain.EventEntity{
+				{
+					ID:   brokerID,
+					Name: enum.BrokersEntity,
+				},
+			},
+		},
+	}, userID, uuid.Nil)
+}
+
+// discoveryAbbreviations are the abbreviations of the config keys allowed by Home Assistant,
+// limited to the keys used by the mapping.
+var discoveryAbbreviations = map[string]string{
+	"cmd_t":   "command_topic",
+	"stat_t":  "state_topic",
+	"dev":     "device",
+	"ids":     "identifiers",
+	"uniq_id": "unique_id",
+	"obj_id":  "object_id",
+	"pl_on":   "payload_on",
+	"pl_off":  "payload_off",
+	"pl_prs":  "payload_press",
+	"ops":     "options",
+	"ic":      "icon",
+}
+
+// parseDiscovery maps a Home Assistant discovery message published on
+// <prefix>/<component>/[<node_id>/]<object_id>/config onto a control proposal.
+// An empty payload removes the entity, so no proposal is returned then.
+func parseDiscovery(prefix string, message *domain.BridgeMessage) (uuid.UUID, *domain.DiscoveryProposal, error) {
+	levels := strings.Split(strings.TrimPrefix(message.Topic, prefix+"/"), "/")
+	if len(levels) < 3 || len(levels) > 4 || levels[len(levels)-1] != "config" {
+		return uuid.Nil, nil, ae.ErrDiscoveryUnsupported
+	}
+
+	component := levels[0]
+	objectID := levels[len(levels)-2]
+	nodeID := objectID
+	if len(levels) == 4 {
+		nodeID = levels[1]
+	}
+
+	proposalID := uuid.NewSHA1(message.BrokerID, []byte(message.Topic))
+
+	if len(message.Payload) == 0 {
+		return proposalID, nil, nil
+	}
+
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(message.Payload, &raw); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("%w: %s", ae.ErrDiscoveryUnsupported, err.Error())
+	}
+	config := expandDiscoveryConfig(raw)
+
+	rawDevice, _ := config["device"].(map[string]interface{})
+	device := expandDiscoveryConfig(rawDevice)
+
+	proposal := &domain.DiscoveryProposal{
+		ID:               proposalID,
+		BrokerID:         message.BrokerID,
+		Component:        component,
+		ConfigTopic:      message.Topic,
+		DeviceIdentifier: nodeID,
+		DeviceName:       nodeID,
+		DiscoveredAt:     message.ReceivedAt,
+	}
+
+	if ids, ok := device["identifiers"].([]interface{}); ok && len(ids) > 0 {
+		proposal.DeviceIdentifier = fmt.Sprint(ids[0])
+	} else if id, ok := device["identifiers"].(string); ok {
+		proposal.DeviceIdentifier = id
+	}
+
+	proposal.DeviceName = device.string("name", proposal.DeviceName)
+
+	control := domain.CreateDeviceControl{
+		Name:                config.string("name", device.string("name", objectID)),
+		QoS:                 enum.QoSLevel(config.number("qos", 0)),
+		IconName:            config.string("icon", component),
+		IconBackgroundColor: discoveryIconBackgroundColor,
+		IsAvailable:         true,
+		CanDisplayName:      true,
+		Attributes:          domain.ControlAttributes{},
+	}
+
+	if control.QoS > enum.QoSTwo {
+		control.QoS = enum.QoSZero
+	}
+
+	switch component {
+	case "switch", "light":
+		control.Type = enum.ControlSwitch
+		control.Topic = config.string("command_topic", "")
+		control.Attributes["onPayload"] = config.string("payload_on", "ON")
+		control.Attributes["offPayload"] = config.string("payload_off", "OFF")
+	case "binary_sensor":
+		control.Type = enum.ControlState
+		control.Topic = config.string("state_topic", "")
+		control.Attributes["onPayload"] = config.string("payload_on", "ON")
+		control.Attributes["offPayload"] = config.string("payload_off", "OFF")
+	case "sensor":
+		control.Type = enum.ControlTextOut
+		control.Topic = config.string("state_topic", "")
+	case "button":
+		control.Type = enum.ControlButton
+		control.Topic = config.string("command_topic", "")
+		control.Attributes["payload"] = config.string("payload_press", "PRESS")
+	case "select":
+		options, _ := config["options"].([]interface{})
+		if len(options) == 0 {
+			return uuid.Nil, nil, ae.ErrDiscoveryUnsupported
+		}
+
+		payloads := map[string]string{}
+		for _, o := range options {
+			payloads[fmt.Sprint(o)] = fmt.Sprint(o)
+		}
+
+		control.Type = enum.ControlRadio
+		control.Topic = config.string("command_topic", "")
+		control.Attributes["payloads"] = payloads
+	case "number":
+		control.Type = enum.ControlSlider
+		control.Topic = config.string("command_topic", "")
+		control.Attributes["minValue"] = config.number("min", 1)
+		control.Attributes["maxValue"] = config.number("max", 100)
+		control.Attributes["payloadTemplate"] = "{{ value }}"
+	default:
+		return uuid.Nil, nil, ae.ErrDiscoveryUnsupported
+	}
+
+	if control.Topic == "" {
+		return uuid.Nil, nil, ae.ErrDiscoveryUnsupported
+	}
+
+	proposal.Control = control
+
+	return proposalID, proposal, nil
+}
+
+type discoveryConfig map[string]interface{}
+
+// expandDiscoveryConfig replaces the abbreviated keys and the ~ in the topics with the base topic.
+func expandDiscoveryConfig(raw map[string]interface{}) discoveryConfig {
+	config := discoveryConfig{}
+	for k, v := range raw {
+		if full, ok := discoveryAbbreviations[k]; ok {
+			k = full
+		}
+		config[k] = v
+	}
+
+	base, ok := config["~"].(string)
+	if !ok {
+		return config
+	}
+
+	for k, v := range config {
+		topic, ok := v.(string)
+		if !ok || !strings.HasSuffix(k, "_topic") {
+			continue
+		}
+
+		if strings.HasPrefix(topic, "~") {
+			config[k] = base + strings.TrimPrefix(topic, "~")
+		} else if strings.HasSuffix(topic, "~") {
+			config[k] = strings.TrimSuffix(topic, "~") + base
+		}
+	}
+
+	return config
+}
+
+func (c discoveryConfig) string(key string, fallback string) string {
+	if v, ok := c[key].(string); ok && v != "" {
+		return v
+	}
+
+	return fallback
+}
+
+func (c discoveryConfig) number(key string, fallback float64) float64 {
+	if v, ok := c[key].(float64); ok {
+		return v
+	}
+
+	return fallback
+}
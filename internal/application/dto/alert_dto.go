@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type AlertParams struct {
+	AlertID string `uri:"alertId" binding:"required,uuid"`
+}
+
+type AlertIncidentParams struct {
+	IncidentID string `uri:"incidentId" binding:"required,uuid"`
+}
+
+// SetAlertRequest defines the alert, the fields which are not used by its kind are cleared. The threshold
+// and the state change alerts watch the control of the device, the device offline alerts the device
+// and the broker disconnected alerts the broker.
+type SetAlertRequest struct {
+	Name            string              `json:"name" binding:"required,max=100"`
+	Kind            enum.AlertKind      `json:"kind" binding:"required,oneof=threshold stateChange deviceOffline brokerDisconnected" enums:"threshold,stateChange,deviceOffline,brokerDisconnected"`
+	BrokerID        uuid.NullUUID       `json:"brokerId" binding:"emptyuuid" swaggertype:"string" format:"uuid"`
+	DeviceID        uuid.NullUUID       `json:"deviceId" binding:"emptyuuid" swaggertype:"string" format:"uuid"`
+	ControlID       uuid.NullUUID       `json:"controlId" binding:"emptyuuid" swaggertype:"string" format:"uuid"`
+	Operator        *enum.AlertOperator `json:"operator" binding:"omitempty,oneof=above below" enums:"above,below"`
+	Threshold       *float64            `json:"threshold"`
+	Hysteresis      float64             `json:"hysteresis" binding:"min=0"`
+	State           interface{}         `json:"state"`
+	OfflineMinutes  *int                `json:"offlineMinutes" binding:"omitempty,min=1,max=10080"`
+	CooldownMinutes int                 `json:"cooldownMinutes" binding:"min=0,max=10080"`
+	NotifyEmail     bool                `json:"notifyEmail"`
+	WebhookURL      *string             `json:"webhookUrl" binding:"omitempty,max=2048"`
+	IsEnabled       *bool               `json:"isEnabled" default:"true"`
+}
+
+type CreateAlertResponse struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+}
+
+type GetAlertResponse struct {
+	ID              uuid.UUID           `json:"id" format:"uuid"`
+	Name            string              `json:"name"`
+	Kind            enum.AlertKind      `json:"kind" enums:"threshold,stateChange,deviceOffline,brokerDisconnected"`
+	BrokerID        uuid.NullUUID       `json:"brokerId" swaggertype:"string" format:"uuid"`
+	DeviceID        uuid.NullUUID       `json:"deviceId" swaggertype:"string" format:"uuid"`
+	ControlID       uuid.NullUUID       `json:"controlId" swaggertype:"string" format:"uuid"`
+	Operator        *enum.AlertOperator `json:"operator" enums:"above,below"`
+	Threshold       *float64            `json:"threshold"`
+	Hysteresis      float64             `json:"hysteresis"`
+	State           interface{}         `json:"state"`
+	OfflineMinutes  *int                `json:"offlineMinutes"`
+	CooldownMinutes int                 `json:"cooldownMinutes"`
+	NotifyEmail     bool                `json:"notifyEmail"`
+	WebhookURL      *string             `json:"webhookUrl"`
+	IsEnabled       bool                `json:"isEnabled"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+}
+
+type AlertIncidentQuery struct {
+	AlertID string              `form:"alertId" binding:"omitempty,uuid"`
+	Status  enum.IncidentStatus `form:"status" binding:"omitempty,oneof=open acknowledged resolved"`
+}
+
+type GetAlertIncidentResponse struct {
+	ID             uuid.UUID           `json:"id" format:"uuid"`
+	AlertID        uuid.UUID           `json:"alertId" format:"uuid"`
+	AlertName      string              `json:"alertName"`
+	Kind           enum.AlertKind      `json:"kind" enums:"threshold,stateChange,deviceOffline,brokerDisconnected"`
+	Status         enum.IncidentStatus `json:"status" enums:"open,acknowledged,resolved"`
+	Value          interface{}         `json:"value"`
+	Message        string              `json:"message"`
+	OpenedAt       time.Time           `json:"openedAt"`
+	AcknowledgedAt *time.Time          `json:"acknowledgedAt"`
+	ResolvedAt     *time.Time          `json:"resolvedAt"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// SetAvailabilityRequest configures the tracking of the device, the topic is relative to the base path
+// of the device. The lwt mode needs the topic and both payloads and the heartbeat mode the timeout,
+// the fields which are not used by the mode are cleared.
+type SetAvailabilityRequest struct {
+	Mode           enum.AvailabilityMode `json:"mode" binding:"required,oneof=lwt heartbeat" enums:"lwt,heartbeat"`
+	Topic          *string               `json:"topic" binding:"omitempty,max=200"`
+	OnlinePayload  *string               `json:"onlinePayload" binding:"omitempty,max=200"`
+	OfflinePayload *string               `json:"offlinePayload" binding:"omitempty,max=200"`
+	TimeoutSeconds *int                  `json:"timeoutSeconds" binding:"omitempty,min=5,max=604800"`
+}
+
+type GetAvailabilityResponse struct {
+	Mode           enum.AvailabilityMode `json:"mode" enums:"lwt,heartbeat"`
+	Topic          *string               `json:"topic"`
+	OnlinePayload  *string               `json:"onlinePayload"`
+	OfflinePayload *string               `json:"offlinePayload"`
+	TimeoutSeconds *int                  `json:"timeoutSeconds"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	UpdatedAt      time.Time             `json:"updatedAt"`
+}
+
+type AvailabilityTransitionQuery struct {
+	From *time.Time `form:"from"`
+	To   *time.Time `form:"to"`
+}
+
+type AvailabilityTransitionResponse struct {
+	ID        uuid.UUID               `json:"id" format:"uuid"`
+	Status    enum.DeviceStatus       `json:"status" enums:"online,offline"`
+	Reason    enum.AvailabilityReason `json:"reason" enums:"payload,message,timeout"`
+	CreatedAt time.Time               `json:"createdAt"`
+}
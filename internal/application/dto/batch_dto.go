@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type BatchRequest struct {
+	Operations []BatchOperationRequest `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// BatchOperationRequest changes a single entity, the ids of the entities created earlier in the batch are referenced
+// with their ref prefixed with $, e.g. "deviceId": "$hall" or "brokerId": "$home" in the data of a device.
+type BatchOperationRequest struct {
+	Action   enum.BatchAction `json:"action" binding:"required,oneof=create update delete" enums:"create,update,delete"`
+	Entity   enum.BatchEntity `json:"entity" binding:"required,oneof=broker device control" enums:"broker,device,control"`
+	Ref      string           `json:"ref" binding:"omitempty,max=100"`
+	ID       string           `json:"id" binding:"required_unless=Action create,omitempty,batchref"`
+	DeviceID string           `json:"deviceId" binding:"required_if=Entity control,omitempty,batchref"`
+	Version  *int64           `json:"version"`
+	Data     json.RawMessage  `json:"data" binding:"required_unless=Action delete" swaggertype:"object"`
+}
+
+type BatchResponse struct {
+	Results []BatchResultResponse `json:"results"`
+}
+
+type BatchResultResponse struct {
+	Action enum.BatchAction `json:"action"`
+	Entity enum.BatchEntity `json:"entity"`
+	Ref    string           `json:"ref,omitempty"`
+	ID     uuid.UUID        `json:"id" format:"uuid"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type GetBrokerCertificatesResponse struct {
+	CA           []CertificateResponse        `json:"ca"`
+	Client       *CertificateResponse         `json:"client"`
+	HasClientKey bool                         `json:"hasClientKey"`
+	Warnings     []CertificateWarningResponse `json:"warnings"`
+	UpdatedAt    *time.Time                   `json:"updatedAt"`
+}
+
+type CertificateResponse struct {
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serialNumber"`
+	Fingerprint  string    `json:"fingerprint"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+}
+
+type CertificateWarningResponse struct {
+	Kind          string    `json:"kind" example:"ca"`
+	Subject       string    `json:"subject"`
+	NotAfter      time.Time `json:"notAfter"`
+	ExpiresInDays int       `json:"expiresInDays"`
+}
+
+type SetCACertificateRequest struct {
+	Certificate string `json:"certificate" binding:"required"`
+}
+
+type SetClientCertificateRequest struct {
+	Certificate string `json:"certificate" binding:"required"`
+	Key         string `json:"key" binding:"required"`
+}
@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/google/uuid"
 )
@@ -12,40 +13,71 @@ type BrokerParams struct {
 }
 
 type CreateBrokerRequest struct {
-	Name      string   `json:"name" binding:"required"`
-	Server    string   `json:"server" binding:"required"`
-	Port      *uint16  `json:"port" binding:"required"`
-	KeepAlive *uint16  `json:"keepAlive" binding:"required"`
-	Icon      Icon     `json:"icon" binding:"required"`
-	IsSSL     *bool    `json:"isSsl" binding:"required"`
-	ClientID  t.String `json:"clientId" swaggertype:"string" extensions:"x-nullable"`
+	Name            string              `json:"name" binding:"required"`
+	Server          string              `json:"server" binding:"required"`
+	Port            *uint16             `json:"port" binding:"required"`
+	KeepAlive       *uint16             `json:"keepAlive" binding:"required"`
+	Icon            Icon                `json:"icon" binding:"required"`
+	IsSSL           *bool               `json:"isSsl" binding:"required"`
+	ClientID        t.String            `json:"clientId" swaggertype:"string" extensions:"x-nullable"`
+	DiscoveryMode   *enum.DiscoveryMode `json:"discoveryMode" binding:"omitempty,oneof=disabled propose auto"`
+	DiscoveryPrefix t.String            `json:"discoveryPrefix" swaggertype:"string"`
+	ProtocolVersion *enum.MQTTVersion   `json:"protocolVersion" binding:"omitempty,oneof=3.1.1 5"`
+	Transport       *enum.MQTTTransport `json:"transport" binding:"omitempty,oneof=tcp ws wss"`
+	Path            t.String            `json:"path" swaggertype:"string" extensions:"x-nullable" example:"/mqtt"`
+	CleanStart      *bool               `json:"cleanStart"`
+	SessionExpiry   *uint32             `json:"sessionExpiry"`
+	UserProperties  map[string]string   `json:"userProperties"`
 }
 
 type CreateBrokerResponse struct {
 	ID uuid.UUID `json:"id" binding:"required,uuid"`
 }
 
+type CloneBrokerRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Server string `json:"server" binding:"required"`
+}
+
 type UpdateBrokerRequest struct {
-	Name      t.String     `json:"name" swaggertype:"string"`
-	Server    t.String     `json:"server" swaggertype:"string"`
-	Port      t.Uint16     `json:"port" swaggertype:"integer"`
-	KeepAlive t.Uint16     `json:"keepAlive" swaggertype:"integer"`
-	Icon      IconOptional `json:"icon"`
-	IsSSL     t.Bool       `json:"isSsl" swaggertype:"boolean"`
-	ClientID  t.String     `json:"clientId" swaggertype:"string" extensions:"x-nullable"`
+	Name            t.String            `json:"name" swaggertype:"string"`
+	Server          t.String            `json:"server" swaggertype:"string"`
+	Port            t.Uint16            `json:"port" swaggertype:"integer"`
+	KeepAlive       t.Uint16            `json:"keepAlive" swaggertype:"integer"`
+	Icon            IconOptional        `json:"icon"`
+	IsSSL           t.Bool              `json:"isSsl" swaggertype:"boolean"`
+	ClientID        t.String            `json:"clientId" swaggertype:"string" extensions:"x-nullable"`
+	DiscoveryMode   *enum.DiscoveryMode `json:"discoveryMode" binding:"omitempty,oneof=disabled propose auto"`
+	DiscoveryPrefix t.String            `json:"discoveryPrefix" swaggertype:"string"`
+	ProtocolVersion *enum.MQTTVersion   `json:"protocolVersion" binding:"omitempty,oneof=3.1.1 5"`
+	Transport       *enum.MQTTTransport `json:"transport" binding:"omitempty,oneof=tcp ws wss"`
+	Path            t.String            `json:"path" swaggertype:"string" extensions:"x-nullable" example:"/mqtt"`
+	CleanStart      t.Bool              `json:"cleanStart" swaggertype:"boolean"`
+	SessionExpiry   *uint32             `json:"sessionExpiry"`
+	UserProperties  map[string]string   `json:"userProperties"`
 }
 
 type GetBrokerResponse struct {
-	ID        uuid.UUID `json:"id" format:"uuid"`
-	Name      string    `json:"name"`
-	Server    string    `json:"server"`
-	Port      uint16    `json:"port"`
-	KeepAlive uint16    `json:"keepAlive"`
-	Icon      Icon      `json:"icon"`
-	IsSSL     bool      `json:"isSsl"`
-	ClientID  *string   `json:"clientId"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID              uuid.UUID          `json:"id" format:"uuid"`
+	Name            string             `json:"name"`
+	Server          string             `json:"server"`
+	Port            uint16             `json:"port"`
+	KeepAlive       uint16             `json:"keepAlive"`
+	Icon            Icon               `json:"icon"`
+	IsSSL           bool               `json:"isSsl"`
+	ClientID        *string            `json:"clientId"`
+	DiscoveryMode   enum.DiscoveryMode `json:"discoveryMode"`
+	DiscoveryPrefix string             `json:"discoveryPrefix"`
+	ProtocolVersion enum.MQTTVersion   `json:"protocolVersion"`
+	Transport       enum.MQTTTransport `json:"transport"`
+	Path            *string            `json:"path"`
+	CleanStart      bool               `json:"cleanStart"`
+	SessionExpiry   uint32             `json:"sessionExpiry"`
+	UserProperties  map[string]string  `json:"userProperties"`
+	Status          enum.BrokerStatus  `json:"status"`
+	StatusChangedAt *time.Time         `json:"statusChangedAt"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	UpdatedAt       time.Time          `json:"updatedAt"`
 }
 
 type GetBrokerCredentialsResponse struct {
@@ -58,3 +90,34 @@ type SetBrokerCredentialsRequest struct {
 	Username t.String `json:"username" binding:"requirednullstring" swaggertype:"string" extensions:"x-nullable"`
 	Password t.String `json:"password" binding:"requirednullstring" swaggertype:"string" extensions:"x-nullable"`
 }
+
+type TestBrokerRequest struct {
+	Server          string              `json:"server" binding:"required"`
+	Port            *uint16             `json:"port" binding:"required"`
+	KeepAlive       *uint16             `json:"keepAlive"`
+	IsSSL           *bool               `json:"isSsl" binding:"required"`
+	ProtocolVersion *enum.MQTTVersion   `json:"protocolVersion" binding:"omitempty,oneof=3.1.1 5"`
+	Transport       *enum.MQTTTransport `json:"transport" binding:"omitempty,oneof=tcp ws wss"`
+	Path            *string             `json:"path" example:"/mqtt"`
+	CleanStart      *bool               `json:"cleanStart"`
+	SessionExpiry   *uint32             `json:"sessionExpiry"`
+	UserProperties  map[string]string   `json:"userProperties"`
+	Username        t.String            `json:"username" swaggertype:"string" extensions:"x-nullable"`
+	Password        t.String            `json:"password" swaggertype:"string" extensions:"x-nullable"`
+
+	CACertificate     t.String `json:"caCertificate" swaggertype:"string" extensions:"x-nullable"`
+	ClientCertificate t.String `json:"clientCertificate" swaggertype:"string" extensions:"x-nullable"`
+	ClientKey         t.String `json:"clientKey" swaggertype:"string" extensions:"x-nullable"`
+}
+
+type TestBrokerResponse struct {
+	Success bool             `json:"success"`
+	Steps   []TestBrokerStep `json:"steps"`
+}
+
+type TestBrokerStep struct {
+	Name      string  `json:"name" example:"connack"`
+	Success   bool    `json:"success"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     *string `json:"error"`
+}
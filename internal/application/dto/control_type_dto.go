@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+)
+
+type GetControlTypeResponse struct {
+	Type        enum.ControlType   `json:"type"`
+	Description string             `json:"description"`
+	Schema      *jsonschema.Schema `json:"schema" swaggertype:"object"`
+	CanPublish  bool               `json:"canPublish"`
+	CanDisplay  bool               `json:"canDisplay"`
+}
+
+// TransformPreviewRequest tests the transform on the payload, the attributes are the other
+// attributes of the type and are only needed when the type is set.
+type TransformPreviewRequest struct {
+	Payload    string             `json:"payload"`
+	Transform  ControlAttributes  `json:"transform" binding:"required"`
+	Type       *enum.ControlType  `json:"type"`
+	Attributes *ControlAttributes `json:"attributes"`
+}
+
+// TransformPreviewResponse holds the text passed on to the control type and the value decoded
+// by it, which is only set when the type of the preview is.
+type TransformPreviewResponse struct {
+	Text    string      `json:"text"`
+	Value   interface{} `json:"value"`
+	Label   *string     `json:"label"`
+	Unit    *string     `json:"unit"`
+	Decoded interface{} `json:"decoded,omitempty"`
+}
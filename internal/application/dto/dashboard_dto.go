@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DashboardParams struct {
+	DashboardID string `uri:"dashboardId" binding:"required,uuid"`
+}
+
+type DashboardTabParams struct {
+	DashboardID string `uri:"dashboardId" binding:"required,uuid"`
+	TabID       string `uri:"tabId" binding:"required,uuid"`
+}
+
+type DashboardWidgetParams struct {
+	DashboardID string `uri:"dashboardId" binding:"required,uuid"`
+	TabID       string `uri:"tabId" binding:"required,uuid"`
+	WidgetID    string `uri:"widgetId" binding:"required,uuid"`
+}
+
+type CreateDashboardRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Icon Icon   `json:"icon" binding:"required"`
+}
+
+type UpdateDashboardRequest struct {
+	Name *string      `json:"name" binding:"omitempty,min=1,max=100"`
+	Icon IconOptional `json:"icon"`
+}
+
+type CreateDashboardResponse struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+}
+
+type GetDashboardResponse struct {
+	ID        uuid.UUID `json:"id" format:"uuid"`
+	Name      string    `json:"name"`
+	Icon      Icon      `json:"icon"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GetDashboardDetailsResponse is the dashboard with its tabs and widgets in their order.
+type GetDashboardDetailsResponse struct {
+	GetDashboardResponse
+	Tabs []*GetDashboardTabResponse `json:"tabs"`
+}
+
+// OrderRequest lists the ids in the new order, every item has to be listed exactly once.
+type OrderRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,unique" swaggertype:"array,string" format:"uuid"`
+}
+
+type CreateDashboardTabRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type UpdateDashboardTabRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=100"`
+}
+
+type CreateDashboardTabResponse struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+}
+
+type GetDashboardTabResponse struct {
+	ID       uuid.UUID                     `json:"id" format:"uuid"`
+	Name     string                        `json:"name"`
+	Position int                           `json:"position"`
+	Widgets  []*GetDashboardWidgetResponse `json:"widgets"`
+}
+
+// WidgetOverrides change how the device or control is displayed on the widget, the omitted ones are not changed.
+type WidgetOverrides struct {
+	Name           *string             `json:"name,omitempty" binding:"omitempty,max=100"`
+	Icon           *WidgetIconOverride `json:"icon,omitempty"`
+	CanDisplayName *bool               `json:"canDisplayName,omitempty"`
+}
+
+type WidgetIconOverride struct {
+	Name            *string `json:"name,omitempty"`
+	BackgroundColor *string `json:"backgroundColor,omitempty" binding:"omitempty,hexcolor"`
+}
+
+type CreateDashboardWidgetRequest struct {
+	DeviceID  uuid.UUID       `json:"deviceId" binding:"required" format:"uuid"`
+	ControlID uuid.NullUUID   `json:"controlId" binding:"emptyuuid" swaggertype:"string" format:"uuid"`
+	X         *int            `json:"x" binding:"required,min=0,max=23"`
+	Y         *int            `json:"y" binding:"required,min=0"`
+	Width     int             `json:"width" binding:"required,min=1,max=24"`
+	Height    int             `json:"height" binding:"required,min=1,max=24"`
+	Overrides WidgetOverrides `json:"overrides"`
+}
+
+type UpdateDashboardWidgetRequest struct {
+	X         *int             `json:"x" binding:"omitempty,min=0,max=23"`
+	Y         *int             `json:"y" binding:"omitempty,min=0"`
+	Width     *int             `json:"width" binding:"omitempty,min=1,max=24"`
+	Height    *int             `json:"height" binding:"omitempty,min=1,max=24"`
+	Overrides *WidgetOverrides `json:"overrides"`
+}
+
+type CreateDashboardWidgetResponse struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+}
+
+type GetDashboardWidgetResponse struct {
+	ID        uuid.UUID       `json:"id" format:"uuid"`
+	DeviceID  uuid.UUID       `json:"deviceId" format:"uuid"`
+	ControlID uuid.NullUUID   `json:"controlId" swaggertype:"string" format:"uuid"`
+	X         int             `json:"x"`
+	Y         int             `json:"y"`
+	Width     int             `json:"width"`
+	Height    int             `json:"height"`
+	Overrides WidgetOverrides `json:"overrides"`
+}
+
+// WidgetLayoutRequest places the widgets of the tab at once, the widgets which are not listed keep their places.
+type WidgetLayoutRequest struct {
+	Widgets []WidgetPlacement `json:"widgets" binding:"required,min=1,unique=ID,dive"`
+}
+
+type WidgetPlacement struct {
+	ID     uuid.UUID `json:"id" binding:"required" format:"uuid"`
+	X      *int      `json:"x" binding:"required,min=0,max=23"`
+	Y      *int      `json:"y" binding:"required,min=0"`
+	Width  int       `json:"width" binding:"required,min=1,max=24"`
+	Height int       `json:"height" binding:"required,min=1,max=24"`
+}
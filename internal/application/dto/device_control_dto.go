@@ -5,23 +5,13 @@ import (
 	"github.com/google/uuid"
 )
 
-type ControlAttributes struct {
-	MaxValue        *float32           `json:"maxValue,omitempty"`
-	MinValue        *float32           `json:"minValue,omitempty"`
-	Value           *string            `json:"value,omitempty"`
-	ColorFormat     *string            `json:"colorFormat,omitempty"`
-	PayloadTemplate *string            `json:"payloadTemplate,omitempty"`
-	Payload         *string            `json:"payload,omitempty"`
-	Payloads        *map[string]string `json:"payloads,omitempty"`
-	OnPayload       *string            `json:"onPayload,omitempty"`
-	OffPayload      *string            `json:"offPayload,omitempty"`
-	SendAsTicks     *bool              `json:"sendAsTicks,omitempty"`
-}
+// ControlAttributes depend on the control type, their JSON Schema is listed by the control types endpoint.
+type ControlAttributes map[string]interface{}
 
 type CreateDeviceControlRequest struct {
 	Name                   string             `json:"name" binding:"required"`
-	Type                   *enum.ControlType  `json:"type" binding:"required,control_type"`
-	Attributes             *ControlAttributes `json:"attributes" binding:"control_attributes"`
+	Type                   *enum.ControlType  `json:"type" binding:"required"`
+	Attributes             *ControlAttributes `json:"attributes"`
 	Topic                  string             `json:"topic" binding:"required"`
 	Icon                   Icon               `json:"icon" binding:"required"`
 	QoS                    *enum.QoSLevel     `json:"qualityOfService" binding:"qos_level"`
@@ -48,12 +38,14 @@ type GetDeviceControlResponse struct {
 	IsAvailable            bool              `json:"isAvailable"`
 	CanNotifyOnPublish     bool              `json:"canNotifyOnPublish"`
 	CanDisplayName         bool              `json:"canDisplayName"`
+	TagIDs                 []uuid.UUID       `json:"tagIds" swaggertype:"array,string" format:"uuid"`
+	Version                int64             `json:"version"`
 }
 
 type UpdateDeviceControlRequest struct {
 	Name                   *string            `json:"name"`
-	Type                   *enum.ControlType  `json:"type" binding:"omitempty,control_type"`
-	Attributes             *ControlAttributes `json:"attributes" binding:"omitempty,control_attributes"`
+	Type                   *enum.ControlType  `json:"type"`
+	Attributes             *ControlAttributes `json:"attributes"`
 	Topic                  *string            `json:"topic"`
 	Icon                   IconOptional       `json:"icon"`
 	QoS                    *enum.QoSLevel     `json:"qualityOfService" binding:"omitempty,qos_level"`
@@ -62,3 +54,16 @@ type UpdateDeviceControlRequest struct {
 	CanNotifyOnPublish     *bool              `json:"canNotifyOnPublish"`
 	CanDisplayName         *bool              `json:"canDisplayName"`
 }
+
+// RenderControlRequest holds the value rendered as the control would publish it, e.g. 50 for sliders.
+type RenderControlRequest struct {
+	Value interface{} `json:"value"`
+}
+
+// RenderControlResponse is the payload the control would publish, base64 encoded when it is not valid UTF-8.
+type RenderControlResponse struct {
+	Topic    string               `json:"topic"`
+	QoS      enum.QoSLevel        `json:"qualityOfService"`
+	Payload  string               `json:"payload"`
+	Encoding enum.PayloadEncoding `json:"encoding"`
+}
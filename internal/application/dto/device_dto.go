@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/google/uuid"
 )
@@ -12,7 +13,17 @@ type DeviceParams struct {
 }
 
 type DeviceQuery struct {
-	BrokerID *string `form:"brokerId" format:"uuid"`
+	BrokerID    *string `form:"brokerId" format:"uuid"`
+	RoomID      *string `form:"roomId" binding:"omitempty,uuid" format:"uuid"`
+	TagID       *string `form:"tagId" binding:"omitempty,uuid" format:"uuid"`
+	Placing     *string `form:"placing"`
+	ControlType *string `form:"controlType"`
+}
+
+type DeviceControlQuery struct {
+	Type        *string `form:"type"`
+	IsAvailable *bool   `form:"isAvailable"`
+	TagID       *string `form:"tagId" binding:"omitempty,uuid" format:"uuid"`
 }
 
 type DeviceControlParams struct {
@@ -22,6 +33,7 @@ type DeviceControlParams struct {
 
 type CreateDeviceRequest struct {
 	BrokerID uuid.NullUUID `json:"brokerId" binding:"emptyuuid" swaggertype:"string" format:"uuid"`
+	RoomID   uuid.NullUUID `json:"roomId" binding:"emptyuuid" swaggertype:"string" format:"uuid"`
 	Name     string        `json:"name" binding:"required"`
 	Icon     Icon          `json:"icon" binding:"required"`
 	Placing  t.String      `json:"placing" swaggertype:"string"`
@@ -34,19 +46,33 @@ type CreateDeviceResponse struct {
 
 type UpdateDeviceRequest struct {
 	BrokerID t.Nullable[uuid.UUID] `json:"brokerId" swaggertype:"string" format:"uuid" extensions:"x-nullable"`
+	RoomID   t.Nullable[uuid.UUID] `json:"roomId" swaggertype:"string" format:"uuid" extensions:"x-nullable"`
 	Name     t.String              `json:"name" swaggertype:"string"`
 	Icon     IconOptional          `json:"icon"`
 	Placing  t.String              `json:"placing" swaggertype:"string" extensions:"x-nullable"`
 	BasePath t.String              `json:"basePath" swaggertype:"string" extensions:"x-nullable"`
 }
 
+// CloneDeviceRequest sets the fields of the copy which differ from the device, the null broker leaves it without one.
+type CloneDeviceRequest struct {
+	BrokerID t.Nullable[uuid.UUID] `json:"brokerId" swaggertype:"string" format:"uuid" extensions:"x-nullable"`
+	Name     t.String              `json:"name" swaggertype:"string"`
+	BasePath t.String              `json:"basePath" swaggertype:"string" extensions:"x-nullable"`
+}
+
 type GetDeviceResponse struct {
 	ID        uuid.UUID     `json:"id" format:"uuid"`
 	BrokerID  uuid.NullUUID `json:"brokerId" swaggertype:"string" format:"uuid"`
+	RoomID    uuid.NullUUID `json:"roomId" swaggertype:"string" format:"uuid"`
 	Name      string        `json:"name"`
 	Icon      Icon          `json:"icon"`
 	Placing   *string       `json:"placing"`
 	BasePath  *string       `json:"basePath"`
+	TagIDs    []uuid.UUID   `json:"tagIds" swaggertype:"array,string" format:"uuid"`
 	CreatedAt time.Time     `json:"createdAt"`
 	UpdatedAt time.Time     `json:"updatedAt"`
+
+	// Status is tracked for the devices with the availability, the others are unknown.
+	Status     enum.DeviceStatus `json:"status" enums:"unknown,online,offline"`
+	LastSeenAt *time.Time        `json:"lastSeenAt"`
 }
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type DiscoveryParams struct {
+	BrokerID   string `uri:"brokerId" binding:"required,uuid"`
+	ProposalID string `uri:"proposalId" binding:"required,uuid"`
+}
+
+type GetDiscoveryProposalResponse struct {
+	ID           uuid.UUID        `json:"id" format:"uuid"`
+	BrokerID     uuid.UUID        `json:"brokerId" format:"uuid"`
+	Component    string           `json:"component"`
+	ConfigTopic  string           `json:"configTopic"`
+	Device       DiscoveryDevice  `json:"device"`
+	Control      DiscoveryControl `json:"control"`
+	DiscoveredAt time.Time        `json:"discoveredAt"`
+}
+
+type DiscoveryDevice struct {
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+}
+
+type DiscoveryControl struct {
+	Name       string            `json:"name"`
+	Type       enum.ControlType  `json:"type"`
+	Attributes ControlAttributes `json:"attributes"`
+	Topic      string            `json:"topic"`
+	Icon       Icon              `json:"icon"`
+	QoS        enum.QoSLevel     `json:"qualityOfService"`
+}
+
+type AcceptDiscoveryProposalResponse struct {
+	DeviceID  uuid.UUID `json:"deviceId" format:"uuid"`
+	ControlID uuid.UUID `json:"controlId" format:"uuid"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type ExploreQuery struct {
+	Filter string `form:"filter" binding:"omitempty,max=200"`
+	Window uint16 `form:"window" binding:"omitempty,min=1"`
+}
+
+// TopicTreeResponse is sent as the tree event of the explorer, the topics are the levels of the tree
+// and the messages on the topics over the limit are counted as ignored.
+type TopicTreeResponse struct {
+	Topics   int                  `json:"topics"`
+	Messages int                  `json:"messages"`
+	Ignored  int                  `json:"ignored"`
+	Nodes    []*TopicNodeResponse `json:"nodes"`
+}
+
+type TopicNodeResponse struct {
+	Name       string               `json:"name"`
+	Topic      string               `json:"topic"`
+	Messages   int                  `json:"messages"`
+	Payload    *string              `json:"payload"`
+	Truncated  bool                 `json:"truncated"`
+	Retained   bool                 `json:"retained"`
+	ReceivedAt *time.Time           `json:"receivedAt"`
+	Children   []*TopicNodeResponse `json:"children"`
+}
+
+type ExploreControlRequest struct {
+	DeviceID   uuid.UUID          `json:"deviceId" binding:"required" format:"uuid"`
+	Name       string             `json:"name" binding:"required"`
+	Type       *enum.ControlType  `json:"type"`
+	Attributes *ControlAttributes `json:"attributes"`
+	Topic      string             `json:"topic" binding:"required"`
+	QoS        *enum.QoSLevel     `json:"qualityOfService" binding:"qos_level"`
+}
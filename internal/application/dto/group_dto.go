@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type GroupParams struct {
+	GroupID string `uri:"groupId" binding:"required,uuid"`
+}
+
+type CreateGroupRequest struct {
+	Name string           `json:"name" binding:"required,max=100"`
+	Type enum.ControlType `json:"type" binding:"required,oneof=switch button slider" enums:"switch,button,slider"`
+	Icon Icon             `json:"icon" binding:"required"`
+}
+
+type UpdateGroupRequest struct {
+	Name *string      `json:"name" binding:"omitempty,min=1,max=100"`
+	Icon IconOptional `json:"icon"`
+}
+
+type CreateGroupResponse struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+}
+
+type GetGroupResponse struct {
+	ID         uuid.UUID        `json:"id" format:"uuid"`
+	Name       string           `json:"name"`
+	Type       enum.ControlType `json:"type" enums:"switch,button,slider"`
+	Icon       Icon             `json:"icon"`
+	ControlIDs []uuid.UUID      `json:"controlIds" swaggertype:"array,string" format:"uuid"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	UpdatedAt  time.Time        `json:"updatedAt"`
+}
+
+// SetGroupControlsRequest replaces the members of the group, an empty list removes all of them.
+type SetGroupControlsRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,unique" swaggertype:"array,string" format:"uuid"`
+}
+
+// PublishGroupRequest holds the value published to every member, e.g. true for switches or 50 for sliders,
+// it is ignored by buttons.
+type PublishGroupRequest struct {
+	Value interface{} `json:"value"`
+}
+
+type PublishGroupResponse struct {
+	Results []GroupPublishResult `json:"results"`
+}
+
+// GroupPublishResult is the outcome of a single member, the error describes why it has not been published.
+type GroupPublishResult struct {
+	ControlID uuid.UUID          `json:"controlId" format:"uuid"`
+	DeviceID  uuid.UUID          `json:"deviceId" format:"uuid"`
+	Published bool               `json:"published"`
+	Error     *GroupPublishError `json:"error,omitempty"`
+}
+
+type GroupPublishError struct {
+	Code    string `json:"code" example:"CONTROL_VALUE_INVALID"`
+	Message string `json:"message" example:"value is not valid for the control"`
+}
+
+type GetGroupStateResponse struct {
+	GroupID      uuid.UUID       `json:"groupId" format:"uuid"`
+	State        enum.GroupState `json:"state" enums:"all-on,some-on,all-off,unknown"`
+	OnCount      int             `json:"onCount"`
+	OffCount     int             `json:"offCount"`
+	UnknownCount int             `json:"unknownCount"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type DeviceMessageQuery struct {
+	Topic    string     `form:"topic" binding:"omitempty,max=200"`
+	Contains string     `form:"contains" binding:"omitempty,max=200"`
+	From     *time.Time `form:"from"`
+	To       *time.Time `form:"to"`
+}
+
+type DeviceMessageExportQuery struct {
+	DeviceMessageQuery
+	Format enum.MessageExportFormat `form:"format" binding:"omitempty,oneof=ndjson csv"`
+}
+
+type DeviceMessageResponse struct {
+	ID        uuid.UUID              `json:"id" format:"uuid"`
+	DeviceID  uuid.UUID              `json:"deviceId" format:"uuid"`
+	ControlID uuid.NullUUID          `json:"controlId" swaggertype:"string" format:"uuid"`
+	Direction enum.MessageDirection  `json:"direction" enums:"inbound,outbound"`
+	Topic     string                 `json:"topic"`
+	Payload   string                 `json:"payload"`
+	Encoding  enum.PayloadEncoding   `json:"encoding" enums:"utf8,base64"`
+	Truncated bool                   `json:"truncated"`
+	QoS       enum.QoSLevel          `json:"qualityOfService"`
+	Retained  bool                   `json:"retained"`
+	Source    *MessageSourceResponse `json:"source"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// MessageSourceResponse is the user, the API key or the automation which triggered the outbound message.
+type MessageSourceResponse struct {
+	Type enum.MessageSource `json:"type" enums:"user,apiKey,automation"`
+	ID   uuid.UUID          `json:"id" format:"uuid"`
+}
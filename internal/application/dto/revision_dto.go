@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type BrokerRevisionParams struct {
+	BrokerID string `uri:"brokerId" binding:"required,uuid"`
+	Revision int    `uri:"revision" binding:"required,min=1"`
+}
+
+type DeviceRevisionParams struct {
+	DeviceID string `uri:"deviceId" binding:"required,uuid"`
+	Revision int    `uri:"revision" binding:"required,min=1"`
+}
+
+type DeviceControlRevisionParams struct {
+	DeviceID  string `uri:"deviceId" binding:"required,uuid"`
+	ControlID string `uri:"controlId" binding:"required,uuid"`
+	Revision  int    `uri:"revision" binding:"required,min=1"`
+}
+
+// RevisionResponse is the snapshot of the entity after a change, the changes are the fields which differ
+// from the revision before, the controls of a device are compared by their ids like controls.<id>.name.
+type RevisionResponse struct {
+	Revision  int                       `json:"revision"`
+	CreatedAt time.Time                 `json:"createdAt"`
+	Snapshot  json.RawMessage           `json:"snapshot" swaggertype:"object"`
+	Changes   []*RevisionChangeResponse `json:"changes"`
+}
+
+// RevisionChangeResponse is a changed field, the added or removed one has null on the other side.
+type RevisionChangeResponse struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type RoomParams struct {
+	RoomID string `uri:"roomId" binding:"required,uuid"`
+}
+
+type CreateRoomRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Icon Icon   `json:"icon" binding:"required"`
+}
+
+type UpdateRoomRequest struct {
+	Name *string      `json:"name" binding:"omitempty,min=1,max=100"`
+	Icon IconOptional `json:"icon"`
+}
+
+type CreateRoomResponse struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+}
+
+type GetRoomResponse struct {
+	ID        uuid.UUID `json:"id" format:"uuid"`
+	Name      string    `json:"name"`
+	Icon      Icon      `json:"icon"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type GetRoomStateResponse struct {
+	RoomID                uuid.UUID          `json:"roomId" format:"uuid"`
+	Status                enum.RoomStatus    `json:"status" enums:"online,partial,offline,unknown"`
+	DeviceCount           int                `json:"deviceCount"`
+	ControlCount          int                `json:"controlCount"`
+	AvailableControlCount int                `json:"availableControlCount"`
+	Brokers               RoomBrokerStatuses `json:"brokers"`
+}
+
+// RoomBrokerStatuses counts the brokers of the devices in the room by their status.
+type RoomBrokerStatuses struct {
+	Online  int `json:"online"`
+	Offline int `json:"offline"`
+	Unknown int `json:"unknown"`
+}
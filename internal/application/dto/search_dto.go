@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type SearchQuery struct {
+	Q     string   `form:"q" binding:"required,max=200"`
+	Mode  string   `form:"mode" binding:"omitempty,oneof=text topic"`
+	Types []string `form:"types" binding:"omitempty,dive,oneof=broker device control"`
+	Limit int      `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type SearchResultResponse struct {
+	Type      enum.SearchResultType `json:"type" enums:"broker,device,control"`
+	ID        uuid.UUID             `json:"id" format:"uuid"`
+	BrokerID  uuid.NullUUID         `json:"brokerId" swaggertype:"string" format:"uuid"`
+	DeviceID  uuid.NullUUID         `json:"deviceId" swaggertype:"string" format:"uuid"`
+	Name      string                `json:"name"`
+	Highlight string                `json:"highlight" example:"<mark>Kitchen</mark> humidity"`
+	Rank      float64               `json:"rank"`
+}
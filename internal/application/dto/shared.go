@@ -3,11 +3,19 @@ package dto
 import t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 
 type Icon struct {
-	Name            string `json:"name" binding:"required"`
-	BackgroundColor string `json:"backgroundColor" binding:"required,hexcolor"`
+	Name            string `json:"name" yaml:"name" binding:"required"`
+	BackgroundColor string `json:"backgroundColor" yaml:"backgroundColor" binding:"required,hexcolor"`
 }
 
 type IconOptional struct {
 	Name            t.String `json:"name" swaggertype:"string"`
 	BackgroundColor t.String `json:"backgroundColor" binding:"emptyhexcolor" swaggertype:"string"`
 }
+
+type PageQuery struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort   string `form:"sort"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Search string `form:"search" binding:"omitempty,max=100"`
+}
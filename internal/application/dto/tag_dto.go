@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TagParams struct {
+	TagID string `uri:"tagId" binding:"required,uuid"`
+}
+
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"required,hexcolor"`
+}
+
+type UpdateTagRequest struct {
+	Name  *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Color *string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+type CreateTagResponse struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+}
+
+type GetTagResponse struct {
+	ID        uuid.UUID `json:"id" format:"uuid"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SetTagsRequest replaces the tags of a device or a control, an empty list removes all of them.
+type SetTagsRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,unique" swaggertype:"array,string" format:"uuid"`
+}
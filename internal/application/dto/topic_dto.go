@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// TopicIssueResponse is a problem with the effective topics, the base path of the device joined with the topic
+// of the control. An overlap lists the control subscribed with a wildcard first.
+type TopicIssueResponse struct {
+	Kind     enum.TopicIssueKind          `json:"kind" enums:"invalid,duplicate,overlap"`
+	Topic    string                       `json:"topic"`
+	Controls []*TopicIssueControlResponse `json:"controls"`
+}
+
+type TopicIssueControlResponse struct {
+	ID       uuid.UUID `json:"id" format:"uuid"`
+	DeviceID uuid.UUID `json:"deviceId" format:"uuid"`
+	Name     string    `json:"name"`
+	Topic    string    `json:"topic"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type ExportQuery struct {
+	Format      enum.TransferFormat `form:"format" binding:"omitempty,oneof=json yaml"`
+	Credentials bool                `form:"credentials"`
+}
+
+type ImportQuery struct {
+	DryRun   bool                  `form:"dryRun"`
+	Strategy enum.TransferStrategy `form:"strategy" binding:"omitempty,oneof=skip overwrite rename"`
+}
+
+type TransferDocument struct {
+	Version    int               `json:"version" yaml:"version" binding:"required"`
+	ExportedAt *time.Time        `json:"exportedAt,omitempty" yaml:"exportedAt,omitempty"`
+	Brokers    []*TransferBroker `json:"brokers" yaml:"brokers" binding:"dive"`
+	Devices    []*TransferDevice `json:"devices" yaml:"devices" binding:"dive"`
+}
+
+type TransferBroker struct {
+	Ref             string               `json:"ref" yaml:"ref" binding:"required"`
+	Name            string               `json:"name" yaml:"name" binding:"required"`
+	Server          string               `json:"server" yaml:"server" binding:"required"`
+	Port            *uint16              `json:"port" yaml:"port" binding:"required"`
+	KeepAlive       *uint16              `json:"keepAlive" yaml:"keepAlive" binding:"required"`
+	Icon            Icon                 `json:"icon" yaml:"icon" binding:"required"`
+	IsSSL           *bool                `json:"isSsl" yaml:"isSsl" binding:"required"`
+	ClientID        *string              `json:"clientId" yaml:"clientId"`
+	Credentials     *TransferCredentials `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+	DiscoveryMode   *enum.DiscoveryMode  `json:"discoveryMode,omitempty" yaml:"discoveryMode,omitempty" binding:"omitempty,oneof=disabled propose auto"`
+	DiscoveryPrefix *string              `json:"discoveryPrefix,omitempty" yaml:"discoveryPrefix,omitempty"`
+	ProtocolVersion *enum.MQTTVersion    `json:"protocolVersion,omitempty" yaml:"protocolVersion,omitempty" binding:"omitempty,oneof=3.1.1 5"`
+	Transport       *enum.MQTTTransport  `json:"transport,omitempty" yaml:"transport,omitempty" binding:"omitempty,oneof=tcp ws wss"`
+	Path            *string              `json:"path,omitempty" yaml:"path,omitempty"`
+	CleanStart      *bool                `json:"cleanStart,omitempty" yaml:"cleanStart,omitempty"`
+	SessionExpiry   *uint32              `json:"sessionExpiry,omitempty" yaml:"sessionExpiry,omitempty"`
+	UserProperties  map[string]string    `json:"userProperties,omitempty" yaml:"userProperties,omitempty"`
+}
+
+type TransferCredentials struct {
+	Username *string `json:"username" yaml:"username"`
+	Password *string `json:"password" yaml:"password"`
+}
+
+type TransferDevice struct {
+	Ref       string             `json:"ref" yaml:"ref" binding:"required"`
+	BrokerRef *string            `json:"brokerRef" yaml:"brokerRef"`
+	Name      string             `json:"name" yaml:"name" binding:"required"`
+	Icon      Icon               `json:"icon" yaml:"icon" binding:"required"`
+	Placing   *string            `json:"placing" yaml:"placing"`
+	BasePath  *string            `json:"basePath" yaml:"basePath"`
+	Controls  []*TransferControl `json:"controls" yaml:"controls" binding:"dive"`
+}
+
+type TransferControl struct {
+	Ref                    string             `json:"ref" yaml:"ref" binding:"required"`
+	Name                   string             `json:"name" yaml:"name" binding:"required"`
+	Type                   *enum.ControlType  `json:"type" yaml:"type" binding:"required"`
+	Attributes             *ControlAttributes `json:"attributes" yaml:"attributes"`
+	Topic                  string             `json:"topic" yaml:"topic" binding:"required"`
+	Icon                   Icon               `json:"icon" yaml:"icon" binding:"required"`
+	QoS                    *enum.QoSLevel     `json:"qualityOfService" yaml:"qualityOfService" binding:"required,qos_level"`
+	IsConfirmationRequired *bool              `json:"isConfirmationRequired" yaml:"isConfirmationRequired" binding:"required"`
+	IsAvailable            *bool              `json:"isAvailable" yaml:"isAvailable" binding:"required"`
+	CanNotifyOnPublish     *bool              `json:"canNotifyOnPublish" yaml:"canNotifyOnPublish" binding:"required"`
+	CanDisplayName         *bool              `json:"canDisplayName" yaml:"canDisplayName" binding:"required"`
+}
+
+type TransferReportResponse struct {
+	DryRun   bool                  `json:"dryRun"`
+	Strategy enum.TransferStrategy `json:"strategy"`
+	Items    []TransferReportItem  `json:"items"`
+}
+
+type TransferReportItem struct {
+	Entity  enum.EventEntity    `json:"entity"`
+	Ref     string              `json:"ref"`
+	ID      *uuid.UUID          `json:"id" format:"uuid"`
+	Name    string              `json:"name"`
+	Action  enum.TransferAction `json:"action"`
+	Reason  *string             `json:"reason"`
+	Changes []TransferChange    `json:"changes"`
+}
+
+type TransferChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
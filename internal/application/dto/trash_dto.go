@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// TrashEntryResponse is a deleted entity, the devices detached from a broker have no deletedAt as they have not been deleted.
+type TrashEntryResponse struct {
+	Type       enum.TrashEntryType   `json:"type" enums:"broker,device,control"`
+	ID         uuid.UUID             `json:"id" format:"uuid"`
+	DeviceID   uuid.NullUUID         `json:"deviceId" swaggertype:"string" format:"uuid"`
+	Name       string                `json:"name"`
+	DeletedAt  *time.Time            `json:"deletedAt"`
+	PurgeAt    *time.Time            `json:"purgeAt"`
+	Dependents []*TrashEntryResponse `json:"dependents"`
+}
@@ -0,0 +1,26 @@
+package enum
+
+type AlertKind string
+
+const (
+	AlertThreshold          AlertKind = "threshold"
+	AlertStateChange        AlertKind = "stateChange"
+	AlertDeviceOffline      AlertKind = "deviceOffline"
+	AlertBrokerDisconnected AlertKind = "brokerDisconnected"
+)
+
+// AlertOperator is the side of the threshold on which the alert is raised.
+type AlertOperator string
+
+const (
+	AlertAbove AlertOperator = "above"
+	AlertBelow AlertOperator = "below"
+)
+
+type IncidentStatus string
+
+const (
+	IncidentOpen         IncidentStatus = "open"
+	IncidentAcknowledged IncidentStatus = "acknowledged"
+	IncidentResolved     IncidentStatus = "resolved"
+)
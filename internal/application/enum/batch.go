@@ -0,0 +1,17 @@
+package enum
+
+type BatchAction string
+
+const (
+	BatchCreateAction BatchAction = "create"
+	BatchUpdateAction BatchAction = "update"
+	BatchDeleteAction BatchAction = "delete"
+)
+
+type BatchEntity string
+
+const (
+	BatchBrokerEntity  BatchEntity = "broker"
+	BatchDeviceEntity  BatchEntity = "device"
+	BatchControlEntity BatchEntity = "control"
+)
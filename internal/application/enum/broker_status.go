@@ -0,0 +1,9 @@
+package enum
+
+type BrokerStatus string
+
+const (
+	BrokerUnknown BrokerStatus = "unknown"
+	BrokerOnline  BrokerStatus = "online"
+	BrokerOffline BrokerStatus = "offline"
+)
@@ -3,12 +3,17 @@ package enum
 type ControlType string
 
 const (
-	ControlButton   ControlType = "button"
-	ControlColor    ControlType = "color"
-	ControlDateTime ControlType = "date-time"
-	ControlSwitch   ControlType = "switch"
-	ControlSlider   ControlType = "slider"
-	ControlState    ControlType = "state"
-	ControlRadio    ControlType = "radio"
-	ControlTextOut  ControlType = "text-out"
+	ControlButton      ControlType = "button"
+	ControlColor       ControlType = "color"
+	ControlDateTime    ControlType = "date-time"
+	ControlSwitch      ControlType = "switch"
+	ControlSlider      ControlType = "slider"
+	ControlState       ControlType = "state"
+	ControlRadio       ControlType = "radio"
+	ControlTextOut     ControlType = "text-out"
+	ControlGauge       ControlType = "gauge"
+	ControlChart       ControlType = "chart"
+	ControlJSONState   ControlType = "json-state"
+	ControlNumberIn    ControlType = "number-in"
+	ControlMultiSwitch ControlType = "multi-switch"
 )
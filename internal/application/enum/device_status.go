@@ -0,0 +1,27 @@
+package enum
+
+type DeviceStatus string
+
+const (
+	DeviceUnknown DeviceStatus = "unknown"
+	DeviceOnline  DeviceStatus = "online"
+	DeviceOffline DeviceStatus = "offline"
+)
+
+// AvailabilityMode is how the status of a device is tracked, from the payloads of its last will topic
+// or from the messages it publishes within the heartbeat timeout.
+type AvailabilityMode string
+
+const (
+	AvailabilityLWT       AvailabilityMode = "lwt"
+	AvailabilityHeartbeat AvailabilityMode = "heartbeat"
+)
+
+// AvailabilityReason is what has changed the status of a device.
+type AvailabilityReason string
+
+const (
+	AvailabilityPayload AvailabilityReason = "payload"
+	AvailabilityMessage AvailabilityReason = "message"
+	AvailabilityTimeout AvailabilityReason = "timeout"
+)
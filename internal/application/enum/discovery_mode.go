@@ -0,0 +1,9 @@
+package enum
+
+type DiscoveryMode string
+
+const (
+	DiscoveryDisabled DiscoveryMode = "disabled"
+	DiscoveryPropose  DiscoveryMode = "propose"
+	DiscoveryAuto     DiscoveryMode = "auto"
+)
@@ -3,9 +3,17 @@ package enum
 type EventAction string
 
 const (
-	ChannelOpenedAction EventAction = "CHANNEL_OPENED"
-	ChannelClosedAction EventAction = "CHANNEL_CLOSED"
-	EntityCreatedAction EventAction = "ENTITY_CREATED"
-	EntityUpdatedAction EventAction = "ENTITY_UPDATED"
-	EntityDeletedAction EventAction = "ENTITY_DELETED"
+	ChannelOpenedAction        EventAction = "CHANNEL_OPENED"
+	ChannelClosedAction        EventAction = "CHANNEL_CLOSED"
+	EntityCreatedAction        EventAction = "ENTITY_CREATED"
+	EntityUpdatedAction        EventAction = "ENTITY_UPDATED"
+	EntityDeletedAction        EventAction = "ENTITY_DELETED"
+	BrokerOnlineAction         EventAction = "BROKER_ONLINE"
+	BrokerOfflineAction        EventAction = "BROKER_OFFLINE"
+	CertificateExpiringAction  EventAction = "CERTIFICATE_EXPIRING"
+	IncidentOpenedAction       EventAction = "INCIDENT_OPENED"
+	IncidentAcknowledgedAction EventAction = "INCIDENT_ACKNOWLEDGED"
+	IncidentResolvedAction     EventAction = "INCIDENT_RESOLVED"
+	DeviceOnlineAction         EventAction = "DEVICE_ONLINE"
+	DeviceOfflineAction        EventAction = "DEVICE_OFFLINE"
 )
@@ -3,8 +3,17 @@ package enum
 type EventEntity string
 
 const (
-	UserEntity           EventEntity = "USER"
-	BrokersEntity        EventEntity = "BROKERS"
-	DevicesEntity        EventEntity = "DEVICES"
-	DeviceControlsEntity EventEntity = "DEVICE_CONTROLS"
+	UserEntity             EventEntity = "USER"
+	BrokersEntity          EventEntity = "BROKERS"
+	DevicesEntity          EventEntity = "DEVICES"
+	DeviceControlsEntity   EventEntity = "DEVICE_CONTROLS"
+	DiscoveryEntity        EventEntity = "DISCOVERY"
+	DashboardsEntity       EventEntity = "DASHBOARDS"
+	DashboardTabsEntity    EventEntity = "DASHBOARD_TABS"
+	DashboardWidgetsEntity EventEntity = "DASHBOARD_WIDGETS"
+	RoomsEntity            EventEntity = "ROOMS"
+	TagsEntity             EventEntity = "TAGS"
+	GroupsEntity           EventEntity = "GROUPS"
+	AlertsEntity           EventEntity = "ALERTS"
+	AlertIncidentsEntity   EventEntity = "ALERT_INCIDENTS"
 )
@@ -0,0 +1,10 @@
+package enum
+
+type GroupState string
+
+const (
+	GroupUnknown GroupState = "unknown"
+	GroupAllOn   GroupState = "all-on"
+	GroupSomeOn  GroupState = "some-on"
+	GroupAllOff  GroupState = "all-off"
+)
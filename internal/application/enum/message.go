@@ -0,0 +1,33 @@
+package enum
+
+type MessageDirection string
+
+const (
+	MessageInbound  MessageDirection = "inbound"
+	MessageOutbound MessageDirection = "outbound"
+)
+
+// MessageSource is the kind of the actor which triggered an outbound message.
+type MessageSource string
+
+const (
+	MessageSourceUser       MessageSource = "user"
+	MessageSourceAPIKey     MessageSource = "apiKey"
+	MessageSourceAutomation MessageSource = "automation"
+)
+
+type MessageExportFormat string
+
+const (
+	MessageExportNDJSON MessageExportFormat = "ndjson"
+	MessageExportCSV    MessageExportFormat = "csv"
+)
+
+// PayloadEncoding is the encoding of the payload in the responses, the payloads which are not valid UTF-8
+// are encoded with base64.
+type PayloadEncoding string
+
+const (
+	PayloadUTF8   PayloadEncoding = "utf8"
+	PayloadBase64 PayloadEncoding = "base64"
+)
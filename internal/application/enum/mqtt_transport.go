@@ -0,0 +1,9 @@
+package enum
+
+type MQTTTransport string
+
+const (
+	TCPTransport MQTTTransport = "tcp"
+	WSTransport  MQTTTransport = "ws"
+	WSSTransport MQTTTransport = "wss"
+)
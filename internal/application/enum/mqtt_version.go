@@ -0,0 +1,8 @@
+package enum
+
+type MQTTVersion string
+
+const (
+	MQTTVersion311 MQTTVersion = "3.1.1"
+	MQTTVersion5   MQTTVersion = "5"
+)
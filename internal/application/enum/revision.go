@@ -0,0 +1,9 @@
+package enum
+
+type RevisionEntity string
+
+const (
+	RevisionBroker  RevisionEntity = "broker"
+	RevisionDevice  RevisionEntity = "device"
+	RevisionControl RevisionEntity = "control"
+)
@@ -0,0 +1,10 @@
+package enum
+
+type RoomStatus string
+
+const (
+	RoomUnknown RoomStatus = "unknown"
+	RoomOnline  RoomStatus = "online"
+	RoomPartial RoomStatus = "partial"
+	RoomOffline RoomStatus = "offline"
+)
@@ -0,0 +1,16 @@
+package enum
+
+type SearchMode string
+
+const (
+	SearchText  SearchMode = "text"
+	SearchTopic SearchMode = "topic"
+)
+
+type SearchResultType string
+
+const (
+	SearchBroker  SearchResultType = "broker"
+	SearchDevice  SearchResultType = "device"
+	SearchControl SearchResultType = "control"
+)
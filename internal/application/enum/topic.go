@@ -0,0 +1,9 @@
+package enum
+
+type TopicIssueKind string
+
+const (
+	TopicInvalid   TopicIssueKind = "invalid"
+	TopicDuplicate TopicIssueKind = "duplicate"
+	TopicOverlap   TopicIssueKind = "overlap"
+)
@@ -0,0 +1,10 @@
+package enum
+
+type TransferAction string
+
+const (
+	TransferCreateAction TransferAction = "create"
+	TransferUpdateAction TransferAction = "update"
+	TransferSkipAction   TransferAction = "skip"
+	TransferRenameAction TransferAction = "rename"
+)
@@ -0,0 +1,8 @@
+package enum
+
+type TransferFormat string
+
+const (
+	TransferJSON TransferFormat = "json"
+	TransferYAML TransferFormat = "yaml"
+)
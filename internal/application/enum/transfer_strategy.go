@@ -0,0 +1,9 @@
+package enum
+
+type TransferStrategy string
+
+const (
+	TransferSkip      TransferStrategy = "skip"
+	TransferOverwrite TransferStrategy = "overwrite"
+	TransferRename    TransferStrategy = "rename"
+)
@@ -0,0 +1,9 @@
+package enum
+
+type TrashEntryType string
+
+const (
+	TrashBroker  TrashEntryType = "broker"
+	TrashDevice  TrashEntryType = "device"
+	TrashControl TrashEntryType = "control"
+)
@@ -10,17 +10,47 @@ import (
 	"github.com/google/uuid"
 )
 
+// EventListener receives every published event in-process, it is called in a separate goroutine
+// so the listener must not rely on the context of the request which published the event.
+type EventListener func(ctx context.Context, userID uuid.UUID, event domain.Event)
+
 type EventService interface {
+	Listen(listener EventListener)
 	Subscribe(ctx context.Context, userID uuid.UUID) (*domain.EventChannels, uuid.UUID)
 	Unsubscribe(ctx context.Context, userID, channelID uuid.UUID, cause string)
 	Publish(ctx context.Context, event domain.Event, userID, channelID uuid.UUID)
+	Defer(ctx context.Context) (context.Context, func())
 	PublishBrokers(ctx context.Context, action enum.EventAction, userID, brokerID uuid.UUID)
 	PublishDevices(ctx context.Context, action enum.EventAction, userID, brokerID, deviceID uuid.UUID)
 	PublishDeviceControls(ctx context.Context, action enum.EventAction, userID, brokerID, deviceID, deviceControlID uuid.UUID)
+	PublishDashboards(ctx context.Context, action enum.EventAction, userID, dashboardID uuid.UUID)
+	PublishDashboardTabs(ctx context.Context, action enum.EventAction, userID, dashboardID, tabID uuid.UUID)
+	PublishDashboardWidgets(ctx context.Context, action enum.EventAction, userID, dashboardID, tabID, widgetID uuid.UUID)
+	PublishRooms(ctx context.Context, action enum.EventAction, userID, roomID uuid.UUID)
+	PublishTags(ctx context.Context, action enum.EventAction, userID, tagID uuid.UUID)
+	PublishGroups(ctx context.Context, action enum.EventAction, userID, groupID uuid.UUID)
+	PublishAlertIncidents(ctx context.Context, action enum.EventAction, userID, alertID, incidentID uuid.UUID)
+}
+
+type deferredEvent struct {
+	event  domain.Event
+	userID uuid.UUID
+}
+
+type deferredEvents struct {
+	events []deferredEvent
+	mutex  sync.Mutex
 }
 
+type deferredEventsKey struct{}
+
+// eventChannelSize is the count of the events buffered for a channel whose client is slow to read them.
+const eventChannelSize = 64
+
 type eventService struct {
-	users map[string]*domain.EventChannels
+	users     map[string]*domain.EventChannels
+	listeners []EventListener
+	mutex     sync.RWMutex
 }
 
 func NewEventService() EventService {
@@ -29,7 +59,16 @@ func NewEventService() EventService {
 	}
 }
 
+func (s *eventService) Listen(listener EventListener) {
+	s.mutex.Lock()
+	s.listeners = append(s.listeners, listener)
+	s.mutex.Unlock()
+}
+
 func (s *eventService) Subscribe(ctx context.Context, userID uuid.UUID) (*domain.EventChannels, uuid.UUID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	u, ok := s.users[userID.String()]
 	if !ok {
 		u = &domain.EventChannels{
@@ -43,14 +82,32 @@ func (s *eventService) Subscribe(ctx context.Context, userID uuid.UUID) (*domain
 	channelID := uuid.New()
 
 	u.Mutex.Lock()
-	u.Channels[channelID.String()] = make(chan domain.Event)
+	u.Channels[channelID.String()] = make(chan domain.Event, eventChannelSize)
 	u.Mutex.Unlock()
 
 	log.Printf("eventService.Subscribe: user %s, added a new channel %s", userID, channelID)
 	return u, channelID
 }
 
+// Publish never waits for the channels, the event is dropped for the channel whose buffer is full,
+// so a client which stopped reading does not hold back the publishers and the other clients.
 func (s *eventService) Publish(ctx context.Context, event domain.Event, userID, channelID uuid.UUID) {
+	if d, ok := ctx.Value(deferredEventsKey{}).(*deferredEvents); ok && channelID == uuid.Nil {
+		d.mutex.Lock()
+		d.events = append(d.events, deferredEvent{event, userID})
+		d.mutex.Unlock()
+		return
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if channelID == uuid.Nil {
+		for _, l := range s.listeners {
+			go l(context.Background(), userID, event)
+		}
+	}
+
 	u, ok := s.users[userID.String()]
 	if !ok {
 		log.Printf("eventService.Publish: user %s, action %s, no event channels has been found", userID, event.Data.Action)
@@ -59,18 +116,48 @@ func (s *eventService) Publish(ctx context.Context, event domain.Event, userID,
 
 	u.Mutex.Lock()
 	if channelID == uuid.Nil {
-		for _, ch := range u.Channels {
-			ch <- event
+		for id, ch := range u.Channels {
+			send(ch, event, userID, id)
 		}
 		log.Printf("eventService.Publish: user %s, action %s, published to all channels (len: %d)", userID, event.Data.Action, len(u.Channels))
 	} else {
-		u.Channels[channelID.String()] <- event
+		send(u.Channels[channelID.String()], event, userID, channelID.String())
 		log.Printf("eventService.Publish: user %s, action %s, published to channel %s", userID, event.Data.Action, channelID)
 	}
 	u.Mutex.Unlock()
 }
 
+func send(ch chan domain.Event, event domain.Event, userID uuid.UUID, channelID string) {
+	select {
+	case ch <- event:
+	default:
+		log.Printf("eventService.Publish: user %s, action %s, channel %s is full, event dropped", userID, event.Data.Action, channelID)
+	}
+}
+
+// Defer holds back the events published with the returned context until flush is called, so they are not sent
+// for changes of a transaction which is rolled back in the end.
+func (s *eventService) Defer(ctx context.Context) (context.Context, func()) {
+	d := &deferredEvents{}
+
+	flush := func() {
+		d.mutex.Lock()
+		events := d.events
+		d.events = nil
+		d.mutex.Unlock()
+
+		for _, e := range events {
+			s.Publish(ctx, e.event, e.userID, uuid.Nil)
+		}
+	}
+
+	return context.WithValue(ctx, deferredEventsKey{}, d), flush
+}
+
 func (s *eventService) Unsubscribe(ctx context.Context, userID, channelID uuid.UUID, cause string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	u, ok := s.users[userID.String()]
 	if !ok {
 		log.Printf("eventService.Unsubscribe: user %s, no event channels has been found", userID)
@@ -78,13 +165,16 @@ func (s *eventService) Unsubscribe(ctx context.Context, userID, channelID uuid.U
 	}
 
 	u.Mutex.Lock()
-	close(u.Channels[channelID.String()])
-	delete(u.Channels, channelID.String())
+	if ch, ok := u.Channels[channelID.String()]; ok {
+		close(ch)
+		delete(u.Channels, channelID.String())
+	}
+	empty := len(u.Channels) == 0
 	u.Mutex.Unlock()
 
 	log.Printf("eventService.Unsubscribe: user %s, channel %s -> %s", userID, channelID, cause)
 
-	if len(u.Channels) == 0 {
+	if empty {
 		delete(s.users, userID.String())
 		log.Printf("eventService.Unsubscribe: user %s, removed - no channels left", userID)
 	}
@@ -153,3 +243,122 @@ func (s *eventService) PublishDeviceControls(ctx context.Context, action enum.Ev
 		},
 	}, userID, uuid.Nil)
 }
+
+func (s *eventService) PublishDashboards(ctx context.Context, action enum.EventAction, userID, dashboardID uuid.UUID) {
+	s.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   dashboardID,
+				Name: enum.DashboardsEntity,
+			},
+		},
+	}, userID, uuid.Nil)
+}
+
+func (s *eventService) PublishDashboardTabs(ctx context.Context, action enum.EventAction, userID, dashboardID, tabID uuid.UUID) {
+	related := []domain.EventEntity{
+		{
+			ID:   dashboardID,
+			Name: enum.DashboardsEntity,
+		},
+	}
+
+	s.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   tabID,
+				Name: enum.DashboardTabsEntity,
+			},
+			Related: &related,
+		},
+	}, userID, uuid.Nil)
+}
+
+func (s *eventService) PublishDashboardWidgets(ctx context.Context, action enum.EventAction, userID, dashboardID, tabID, widgetID uuid.UUID) {
+	related := []domain.EventEntity{
+		{
+			ID:   tabID,
+			Name: enum.DashboardTabsEntity,
+		},
+		{
+			ID:   dashboardID,
+			Name: enum.DashboardsEntity,
+		},
+	}
+
+	s.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   widgetID,
+				Name: enum.DashboardWidgetsEntity,
+			},
+			Related: &related,
+		},
+	}, userID, uuid.Nil)
+}
+
+func (s *eventService) PublishRooms(ctx context.Context, action enum.EventAction, userID, roomID uuid.UUID) {
+	s.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   roomID,
+				Name: enum.RoomsEntity,
+			},
+		},
+	}, userID, uuid.Nil)
+}
+
+func (s *eventService) PublishTags(ctx context.Context, action enum.EventAction, userID, tagID uuid.UUID) {
+	s.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   tagID,
+				Name: enum.TagsEntity,
+			},
+		},
+	}, userID, uuid.Nil)
+}
+
+func (s *eventService) PublishGroups(ctx context.Context, action enum.EventAction, userID, groupID uuid.UUID) {
+	s.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   groupID,
+				Name: enum.GroupsEntity,
+			},
+		},
+	}, userID, uuid.Nil)
+}
+
+func (s *eventService) PublishAlertIncidents(ctx context.Context, action enum.EventAction, userID, alertID, incidentID uuid.UUID) {
+	related := []domain.EventEntity{
+		{
+			ID:   alertID,
+			Name: enum.AlertsEntity,
+		},
+	}
+
+	s.Publish(ctx, domain.Event{
+		ID: uuid.New(),
+		Data: domain.EventData{
+			Action: action,
+			Entity: &domain.EventEntity{
+				ID:   incidentID,
+				Name: enum.AlertIncidentsEntity,
+			},
+			Related: &related,
+		},
+	}, userID, uuid.Nil)
+}
@@ -0,0 +1,78 @@
+package application_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestEventPublish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should publish while the channels are subscribed and unsubscribed", func(t *testing.T) {
+		es := application.NewEventService()
+		userID := uuid.New()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					es.PublishBrokers(ctx, enum.EntityUpdatedAction, userID, uuid.New())
+				}
+			}()
+
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					_, channelID := es.Subscribe(ctx, userID)
+					es.Unsubscribe(ctx, userID, channelID, "test")
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("should not wait for the channel which is not read", func(t *testing.T) {
+		es := application.NewEventService()
+		userID := uuid.New()
+
+		_, channelID := es.Subscribe(ctx, userID)
+		defer es.Unsubscribe(ctx, userID, channelID, "test")
+
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < 1000; i++ {
+				es.PublishBrokers(ctx, enum.EntityUpdatedAction, userID, uuid.New())
+			}
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the publish has waited for the channel")
+		}
+	})
+
+	t.Run("should deliver the events to the subscribed channel", func(t *testing.T) {
+		es := application.NewEventService()
+		userID, brokerID := uuid.New(), uuid.New()
+
+		channels, channelID := es.Subscribe(ctx, userID)
+		defer es.Unsubscribe(ctx, userID, channelID, "test")
+
+		es.PublishBrokers(ctx, enum.EntityUpdatedAction, userID, brokerID)
+
+		evt := <-channels.Channels[channelID.String()]
+		assert.Equal(t, brokerID, evt.Data.Entity.ID)
+	})
+}
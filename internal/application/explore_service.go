@@ -0,0 +1,313 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+const (
+	exploreInterval            = time.Second
+	exploreIconBackgroundColor = "#41bdf5"
+)
+
+// ExploreService browses the topics of a broker through the server-side connection. The explorer subscribes
+// to the filter for the window and sends the topic tree whenever it changes, the last tree is sent when
+// the window ends.
+type ExploreService interface {
+	Explore(ctx context.Context, explore *domain.Explore) (<-chan *domain.TopicTree, error)
+	CreateControl(ctx context.Context, control *domain.ExploreControl) (uuid.UUID, error)
+}
+
+type exploreService struct {
+	c   *config.Config
+	bs  BrokerService
+	ds  DeviceService
+	dcs DeviceControlService
+	bgs BridgeService
+}
+
+func NewExploreService(
+	c *config.Config,
+	bs BrokerService,
+	ds DeviceService,
+	dcs DeviceControlService,
+	bgs BridgeService) ExploreService {
+	return &exploreService{c, bs, ds, dcs, bgs}
+}
+
+func (s *exploreService) Explore(ctx context.Context, explore *domain.Explore) (<-chan *domain.TopicTree, error) {
+	if _, err := s.bs.Get(ctx, explore.BrokerID, explore.UserID); err != nil {
+		return nil, err
+	}
+
+	filter := explore.Filter
+	if filter == "" {
+		filter = "#"
+	}
+
+	if !mqtt.ValidFilter(filter) {
+		return nil, &ae.ValidationError{
+			Err:    ae.ErrValidation,
+			Fields: []*ae.FieldError{{Field: "filter", Rule: "topic_filter", Message: "should be a valid MQTT topic filter"}},
+		}
+	}
+
+	window := explore.Window
+	if window == 0 {
+		window = time.Duration(s.c.Explore.WindowSeconds) * time.Second
+	}
+
+	if maxWindow := time.Duration(s.c.Explore.MaxWindowSeconds) * time.Second; window > maxWindow {
+		return nil, &ae.ValidationError{
+			Err: ae.ErrValidation,
+			Fields: []*ae.FieldError{{
+				Field:   "window",
+				Rule:    "max",
+				Param:   fmt.Sprint(s.c.Explore.MaxWindowSeconds),
+				Message: fmt.Sprintf("should be at most %d seconds", s.c.Explore.MaxWindowSeconds),
+			}},
+		}
+	}
+
+	tree := newTopicTree(int(s.c.Explore.MaxTopics), int(s.c.Explore.PreviewBytes))
+
+	subscriptionID, err := s.bgs.Subscribe(ctx, explore.UserID, explore.BrokerID, filter, enum.QoSZero, tree.add)
+	if err != nil {
+		return nil, err
+	}
+
+	trees := make(chan *domain.TopicTree)
+
+	go func() {
+		defer close(trees)
+		defer func() {
+			if err := s.bgs.Unsubscribe(context.Background(), explore.BrokerID, subscriptionID); err != nil {
+				log.Printf("exploreService.Explore: broker %s, %s", explore.BrokerID, err)
+			}
+		}()
+
+		timer := time.NewTimer(window)
+		defer timer.Stop()
+
+		ticker := time.NewTicker(exploreInterval)
+		defer ticker.Stop()
+
+		send := func(t *domain.TopicTree) bool {
+			select {
+			case trees <- t:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if !send(tree.snapshot()) {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				send(tree.snapshot())
+				return
+			case <-ticker.C:
+				if t, ok := tree.changes(); ok && !send(t) {
+					return
+				}
+			}
+		}
+	}()
+
+	return trees, nil
+}
+
+// CreateControl adds a control listening to the explored topic to a device of the broker,
+// the topic of the control is set relative to the base path of the device.
+func (s *exploreService) CreateControl(ctx context.Context, control *domain.ExploreControl) (uuid.UUID, error) {
+	if _, err := s.bs.Get(ctx, control.BrokerID, control.UserID); err != nil {
+		return uuid.Nil, err
+	}
+
+	device, err := s.ds.Get(ctx, control.DeviceID, control.UserID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if !device.BrokerID.Valid || device.BrokerID.UUID != control.BrokerID {
+		return uuid.Nil, ae.ErrDeviceBrokerMismatch
+	}
+
+	topic, ok := relativeTopic(device.BasePath, control.Topic)
+	if !mqtt.ValidTopic(control.Topic) || !ok {
+		return uuid.Nil, &ae.ValidationError{
+			Err:    ae.ErrValidation,
+			Fields: []*ae.FieldError{{Field: "topic", Rule: "topic", Message: "should be a topic under the base path of the device"}},
+		}
+	}
+
+	controlType := control.Type
+	if controlType == "" {
+		controlType = enum.ControlTextOut
+	}
+
+	attributes := control.Attributes
+	if attributes == nil {
+		attributes = domain.ControlAttributes{}
+	}
+
+	return s.dcs.Create(ctx, control.UserID, &domain.CreateDeviceControl{
+		DeviceID:            control.DeviceID,
+		Name:                control.Name,
+		Type:                controlType,
+		QoS:                 control.QoS,
+		IconName:            string(controlType),
+		IconBackgroundColor: exploreIconBackgroundColor,
+		IsAvailable:         true,
+		CanDisplayName:      true,
+		Topic:               topic,
+		Attributes:          attributes,
+	})
+}
+
+// relativeTopic is the reverse of controlTopic, it fails for the topics outside the base path.
+func relativeTopic(basePath *string, topic string) (string, bool) {
+	if basePath == nil || *basePath == "" {
+		return topic, true
+	}
+
+	prefix := strings.TrimSuffix(*basePath, "/") + "/"
+	if !strings.HasPrefix(topic, prefix) || len(topic) == len(prefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(topic, prefix), true
+}
+
+type topicTreeNode struct {
+	node     domain.TopicNode
+	children map[string]*topicTreeNode
+}
+
+// topicTree collects the messages received by the explorer, it is safe for concurrent use.
+type topicTree struct {
+	maxTopics    int
+	previewBytes int
+	root         *topicTreeNode
+	topics       int
+	messages     int
+	ignored      int
+	changed      bool
+	mutex        sync.Mutex
+}
+
+func newTopicTree(maxTopics int, previewBytes int) *topicTree {
+	return &topicTree{
+		maxTopics:    maxTopics,
+		previewBytes: previewBytes,
+		root:         &topicTreeNode{children: map[string]*topicTreeNode{}},
+	}
+}
+
+func (t *topicTree) add(message *domain.BridgeMessage) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	levels := strings.Split(message.Topic, "/")
+
+	n := t.root
+	for i, level := range levels {
+		child, ok := n.children[level]
+		if !ok {
+			if t.topics >= t.maxTopics {
+				t.ignored++
+				return
+			}
+
+			child = &topicTreeNode{
+				node:     domain.TopicNode{Name: level, Topic: strings.Join(levels[:i+1], "/")},
+				children: map[string]*topicTreeNode{},
+			}
+			n.children[level] = child
+			t.topics++
+		}
+		n = child
+	}
+
+	payload, truncated := payloadPreview(message.Payload, t.previewBytes)
+	receivedAt := message.ReceivedAt
+
+	n.node.Messages++
+	n.node.Payload = &payload
+	n.node.Truncated = truncated
+	n.node.Retained = message.Retained
+	n.node.ReceivedAt = &receivedAt
+
+	t.messages++
+	t.changed = true
+}
+
+// changes returns the tree when a message has been added since the last call.
+func (t *topicTree) changes() (*domain.TopicTree, bool) {
+	t.mutex.Lock()
+	changed := t.changed
+	t.mutex.Unlock()
+
+	if !changed {
+		return nil, false
+	}
+
+	return t.snapshot(), true
+}
+
+func (t *topicTree) snapshot() *domain.TopicTree {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.changed = false
+
+	return &domain.TopicTree{
+		Topics:   t.topics,
+		Messages: t.messages,
+		Ignored:  t.ignored,
+		Nodes:    t.copyChildren(t.root),
+	}
+}
+
+func (t *topicTree) copyChildren(n *topicTreeNode) []*domain.TopicNode {
+	nodes := make([]*domain.TopicNode, 0, len(n.children))
+	for _, child := range n.children {
+		node := child.node
+		node.Children = t.copyChildren(child)
+		nodes = append(nodes, &node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes
+}
+
+// payloadPreview cuts the payload to the size and replaces the bytes which are not valid UTF-8.
+func payloadPreview(payload []byte, size int) (string, bool) {
+	truncated := len(payload) > size
+	if truncated {
+		payload = payload[:size]
+	}
+
+	return strings.ToValidUTF8(string(payload), "�"), truncated
+}
@@ -0,0 +1,344 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+type GroupService interface {
+	Get(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*domain.Group, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Group, error)
+	Create(ctx context.Context, group *domain.CreateGroup) (uuid.UUID, error)
+	Update(ctx context.Context, group *domain.UpdateGroup) error
+	Delete(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error
+	SetControls(ctx context.Context, userID uuid.UUID, groupID uuid.UUID, controlIDs []uuid.UUID) error
+	Publish(ctx context.Context, userID uuid.UUID, groupID uuid.UUID, value interface{}) ([]*domain.GroupPublishResult, error)
+	GetState(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*domain.GroupState, error)
+}
+
+type groupService struct {
+	gr  repository.GroupRepository
+	dcr repository.DeviceControlRepository
+	cts ControlTypeService
+	bgs BridgeService
+	mls MessageLogService
+	es  EventService
+}
+
+func NewGroupService(
+	gr repository.GroupRepository,
+	dcr repository.DeviceControlRepository,
+	cts ControlTypeService,
+	bgs BridgeService,
+	mls MessageLogService,
+	es EventService,
+) GroupService {
+	return &groupService{gr, dcr, cts, bgs, mls, es}
+}
+
+func (g *groupService) Get(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*domain.Group, error) {
+	group, err := g.gr.Get(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.setControls(ctx, []*domain.Group{group}); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (g *groupService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Group, error) {
+	groups, err := g.gr.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.setControls(ctx, groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (g *groupService) Create(ctx context.Context, group *domain.CreateGroup) (uuid.UUID, error) {
+	groupID, err := g.gr.Create(ctx, group)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	g.es.PublishGroups(ctx, enum.EntityCreatedAction, group.UserID, groupID)
+
+	return groupID, nil
+}
+
+func (g *groupService) Update(ctx context.Context, group *domain.UpdateGroup) error {
+	if err := g.gr.Update(ctx, group); err != nil {
+		return err
+	}
+
+	g.es.PublishGroups(ctx, enum.EntityUpdatedAction, group.UserID, group.ID)
+
+	return nil
+}
+
+func (g *groupService) Delete(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error {
+	if err := g.gr.Delete(ctx, groupID, userID); err != nil {
+		return err
+	}
+
+	g.es.PublishGroups(ctx, enum.EntityDeletedAction, userID, groupID)
+
+	return nil
+}
+
+// SetControls replaces the members of the group, every control has to belong to the user
+// and be of the type of the group.
+func (g *groupService) SetControls(ctx context.Context, userID uuid.UUID, groupID uuid.UUID, controlIDs []uuid.UUID) error {
+	group, err := g.gr.Get(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+
+	members, err := g.gr.ListUserMembers(ctx, userID, controlIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(members) != len(controlIDs) {
+		return ae.ErrDeviceControlNotFound
+	}
+
+	for _, m := range members {
+		if m.Type != group.Type {
+			return ae.ErrGroupControlIncompatible
+		}
+	}
+
+	if err := g.gr.SetControls(ctx, groupID, controlIDs); err != nil {
+		return err
+	}
+
+	g.es.PublishGroups(ctx, enum.EntityUpdatedAction, userID, groupID)
+
+	return nil
+}
+
+// groupPublishBrokers bounds the brokers of a group published to at the same time.
+const groupPublishBrokers = 8
+
+// Publish fans out the value to every member of the group. The members are published independently,
+// the failure of one of them is reported in its result and does not stop the others. The brokers of
+// the members are published to concurrently, so a broker which can not be reached does not hold
+// back the members of the other brokers.
+func (g *groupService) Publish(ctx context.Context, userID uuid.UUID, groupID uuid.UUID, value interface{}) ([]*domain.GroupPublishResult, error) {
+	if _, err := g.gr.Get(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := g.gr.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*domain.GroupPublishResult, len(members))
+	brokers := map[uuid.UUID][]int{}
+	order := []uuid.UUID{}
+
+	for i, m := range members {
+		results[i] = &domain.GroupPublishResult{
+			ControlID: m.ControlID,
+			DeviceID:  m.DeviceID,
+		}
+
+		if !m.BrokerID.Valid {
+			results[i].Err = ae.ErrBrokerNotFound
+			continue
+		}
+
+		if _, ok := brokers[m.BrokerID.UUID]; !ok {
+			order = append(order, m.BrokerID.UUID)
+		}
+		brokers[m.BrokerID.UUID] = append(brokers[m.BrokerID.UUID], i)
+	}
+
+	var eg errgroup.Group
+	eg.SetLimit(groupPublishBrokers)
+
+	for _, brokerID := range order {
+		indexes := brokers[brokerID]
+
+		eg.Go(func() error {
+			g.publishBroker(ctx, userID, members, indexes, results, value)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	g.es.PublishGroups(ctx, enum.EntityUpdatedAction, userID, groupID)
+
+	return results, nil
+}
+
+// publishBroker publishes to the members of a single broker in their order. Once the broker has failed,
+// the members left get its error instead of waiting for it again.
+func (g *groupService) publishBroker(
+	ctx context.Context,
+	userID uuid.UUID,
+	members []*domain.GroupMember,
+	indexes []int,
+	results []*domain.GroupPublishResult,
+	value interface{}) {
+	var brokerErr error
+
+	for _, i := range indexes {
+		m := members[i]
+
+		message, err := g.message(m, value)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		if brokerErr != nil {
+			results[i].Err = brokerErr
+			continue
+		}
+
+		if err := g.bgs.Publish(ctx, userID, message); err != nil {
+			brokerErr = err
+			results[i].Err = err
+			continue
+		}
+
+		g.mls.RecordOutbound(ctx, message, m.DeviceID, m.ControlID, enum.MessageSourceUser, userID)
+
+		results[i].Err = g.dcr.SetLastValue(ctx, m.ControlID, &domain.ControlValue{Data: value})
+	}
+}
+
+// message encodes the value for the member with the payload of its control.
+func (g *groupService) message(m *domain.GroupMember, value interface{}) (*domain.BridgeMessage, error) {
+	topic := controlTopic(m.BasePath, m.Topic)
+
+	payload, err := g.cts.Encode(m.Type, m.Attributes, value, &domain.PayloadContext{
+		DeviceID:    m.DeviceID,
+		DeviceName:  m.DeviceName,
+		Placing:     m.Placing,
+		BasePath:    m.BasePath,
+		ControlID:   m.ControlID,
+		ControlName: m.ControlName,
+		Topic:       topic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BridgeMessage{
+		BrokerID: m.BrokerID.UUID,
+		Topic:    topic,
+		Payload:  payload,
+		QoS:      byte(m.QoS),
+	}, nil
+}
+
+// GetState derives the state of the group from the last values published to its members,
+// the buttons and the members without a value are unknown.
+func (g *groupService) GetState(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*domain.GroupState, error) {
+	if _, err := g.gr.Get(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := g.gr.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &domain.GroupState{GroupID: groupID}
+
+	for _, m := range members {
+		on, known := memberOn(m)
+		switch {
+		case !known:
+			state.UnknownCount++
+		case on:
+			state.OnCount++
+		default:
+			state.OffCount++
+		}
+	}
+
+	switch {
+	case state.OnCount == 0 && state.OffCount == 0:
+		state.State = enum.GroupUnknown
+	case state.OffCount == 0:
+		state.State = enum.GroupAllOn
+	case state.OnCount == 0:
+		state.State = enum.GroupAllOff
+	default:
+		state.State = enum.GroupSomeOn
+	}
+
+	return state, nil
+}
+
+func (g *groupService) setControls(ctx context.Context, groups []*domain.Group) error {
+	ids := make([]uuid.UUID, len(groups))
+	for i, group := range groups {
+		ids[i] = group.ID
+	}
+
+	controls, err := g.gr.ListControls(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		group.ControlIDs = controls[group.ID]
+		if group.ControlIDs == nil {
+			group.ControlIDs = []uuid.UUID{}
+		}
+	}
+
+	return nil
+}
+
+// memberOn tells whether the member is on, a switch by its value and a slider above its minimum.
+func memberOn(m *domain.GroupMember) (on bool, known bool) {
+	if m.LastValue == nil {
+		return false, false
+	}
+
+	switch m.Type {
+	case enum.ControlSwitch:
+		v, ok := m.LastValue.Data.(bool)
+		return v, ok
+	case enum.ControlSlider:
+		v, ok := m.LastValue.Data.(float64)
+		if !ok {
+			return false, false
+		}
+
+		min, _ := m.Attributes["minValue"].(float64)
+		return v > min, true
+	}
+
+	return false, false
+}
+
+// controlTopic prefixes the topic of the control with the base path of its device.
+func controlTopic(basePath *string, topic string) string {
+	if basePath == nil || *basePath == "" {
+		return topic
+	}
+
+	return strings.TrimSuffix(*basePath, "/") + "/" + strings.TrimPrefix(topic, "/")
+}
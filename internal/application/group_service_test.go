@@ -0,0 +1,115 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+type memberGroupRepository struct {
+	repository.GroupRepository
+	members []*domain.GroupMember
+}
+
+func (r *memberGroupRepository) Get(context.Context, uuid.UUID, uuid.UUID) (*domain.Group, error) {
+	return &domain.Group{}, nil
+}
+
+func (r *memberGroupRepository) ListMembers(context.Context, uuid.UUID) ([]*domain.GroupMember, error) {
+	return r.members, nil
+}
+
+type valueControlRepository struct {
+	repository.DeviceControlRepository
+}
+
+func (valueControlRepository) SetLastValue(context.Context, uuid.UUID, *domain.ControlValue) error {
+	return nil
+}
+
+type rawControlTypeService struct {
+	application.ControlTypeService
+}
+
+func (rawControlTypeService) Encode(enum.ControlType, domain.ControlAttributes, interface{}, *domain.PayloadContext) ([]byte, error) {
+	return []byte("1"), nil
+}
+
+type silentMessageLogService struct {
+	application.MessageLogService
+}
+
+func (silentMessageLogService) RecordOutbound(context.Context, *domain.BridgeMessage, uuid.UUID, uuid.UUID, enum.MessageSource, uuid.UUID) {
+}
+
+func (silentEventService) PublishGroups(context.Context, enum.EventAction, uuid.UUID, uuid.UUID) {}
+
+// unreachableBridgeService fails the publishes to the unreachable brokers after the delay.
+type unreachableBridgeService struct {
+	application.BridgeService
+	unreachable map[uuid.UUID]bool
+	delay       time.Duration
+	publishes   map[uuid.UUID]int
+	mutex       sync.Mutex
+}
+
+func (s *unreachableBridgeService) Publish(_ context.Context, _ uuid.UUID, message *domain.BridgeMessage) error {
+	s.mutex.Lock()
+	s.publishes[message.BrokerID]++
+	s.mutex.Unlock()
+
+	if !s.unreachable[message.BrokerID] {
+		return nil
+	}
+
+	time.Sleep(s.delay)
+	return errors.New("broker is unreachable")
+}
+
+func TestGroupPublish(t *testing.T) {
+	userID, slowID, fastID := uuid.New(), uuid.New(), uuid.New()
+
+	member := func(brokerID uuid.UUID) *domain.GroupMember {
+		return &domain.GroupMember{
+			ControlID: uuid.New(),
+			DeviceID:  uuid.New(),
+			BrokerID:  uuid.NullUUID{UUID: brokerID, Valid: true},
+			Topic:     "a/b",
+		}
+	}
+
+	t.Run("should not wait for the unreachable broker of the other members", func(t *testing.T) {
+		members := []*domain.GroupMember{member(slowID), member(slowID), member(fastID), member(slowID)}
+		bgs := &unreachableBridgeService{
+			unreachable: map[uuid.UUID]bool{slowID: true},
+			delay:       200 * time.Millisecond,
+			publishes:   map[uuid.UUID]int{},
+		}
+
+		gs := application.NewGroupService(&memberGroupRepository{members: members}, valueControlRepository{},
+			rawControlTypeService{}, bgs, silentMessageLogService{}, silentEventService{})
+
+		start := time.Now()
+		results, err := gs.Publish(context.Background(), userID, uuid.New(), true)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, time.Since(start) < 400*time.Millisecond)
+
+		assert.NotEqual(t, nil, results[0].Err)
+		assert.NotEqual(t, nil, results[1].Err)
+		assert.Equal(t, nil, results[2].Err)
+		assert.NotEqual(t, nil, results[3].Err)
+
+		// The unreachable broker is tried once for all of its members
+		assert.Equal(t, 1, bgs.publishes[slowID])
+		assert.Equal(t, 1, bgs.publishes[fastID])
+	})
+}
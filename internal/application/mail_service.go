@@ -3,6 +3,7 @@ package application
 import (
 	"log"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
 	"github.com/pkg/errors"
 )
@@ -10,6 +11,7 @@ import (
 type MailService interface {
 	SendConfirmAccount(receiver string, token string)
 	SendPasswordReset(receiver string, token string)
+	SendAlertIncident(receiver string, notification *domain.AlertNotification)
 }
 
 type mailService struct {
@@ -31,3 +33,9 @@ func (m *mailService) SendPasswordReset(receiver string, token string) {
 		log.Print(errors.Wrap(err, "mailService.SendPasswordReset"))
 	}
 }
+
+func (m *mailService) SendAlertIncident(receiver string, notification *domain.AlertNotification) {
+	if err := m.ma.SendAlertIncident(receiver, notification); err != nil {
+		log.Print(errors.Wrap(err, "mailService.SendAlertIncident"))
+	}
+}
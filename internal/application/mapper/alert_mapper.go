@@ -0,0 +1,116 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AlertMapper interface {
+	CreateDTOToModel(userID uuid.UUID, v *dto.SetAlertRequest) *domain.CreateAlert
+	UpdateDTOToModel(alertID uuid.UUID, userID uuid.UUID, v *dto.SetAlertRequest) *domain.UpdateAlert
+	ModelToDTO(v *domain.Alert) *dto.GetAlertResponse
+	QueryDTOToModel(userID uuid.UUID, v *dto.AlertIncidentQuery) *domain.ListAlertIncidentFilters
+	IncidentToDTO(v *domain.AlertIncident) *dto.GetAlertIncidentResponse
+}
+
+type alertMapper struct{}
+
+func NewAlertMapper() AlertMapper {
+	return &alertMapper{}
+}
+
+func (*alertMapper) CreateDTOToModel(userID uuid.UUID, v *dto.SetAlertRequest) *domain.CreateAlert {
+	alert := &domain.CreateAlert{
+		UserID:          userID,
+		Name:            v.Name,
+		Kind:            v.Kind,
+		BrokerID:        v.BrokerID,
+		DeviceID:        v.DeviceID,
+		ControlID:       v.ControlID,
+		Operator:        v.Operator,
+		Threshold:       v.Threshold,
+		Hysteresis:      v.Hysteresis,
+		OfflineMinutes:  v.OfflineMinutes,
+		CooldownMinutes: v.CooldownMinutes,
+		NotifyEmail:     v.NotifyEmail,
+		WebhookURL:      v.WebhookURL,
+		IsEnabled:       v.IsEnabled == nil || *v.IsEnabled,
+	}
+
+	if v.State != nil {
+		alert.State = &domain.ControlValue{Data: v.State}
+	}
+
+	return alert
+}
+
+func (m *alertMapper) UpdateDTOToModel(alertID uuid.UUID, userID uuid.UUID, v *dto.SetAlertRequest) *domain.UpdateAlert {
+	return &domain.UpdateAlert{
+		ID:          alertID,
+		CreateAlert: *m.CreateDTOToModel(userID, v),
+	}
+}
+
+func (*alertMapper) ModelToDTO(v *domain.Alert) *dto.GetAlertResponse {
+	r := &dto.GetAlertResponse{
+		ID:              v.ID,
+		Name:            v.Name,
+		Kind:            v.Kind,
+		BrokerID:        v.BrokerID,
+		DeviceID:        v.DeviceID,
+		ControlID:       v.ControlID,
+		Operator:        v.Operator,
+		Threshold:       v.Threshold,
+		Hysteresis:      v.Hysteresis,
+		OfflineMinutes:  v.OfflineMinutes,
+		CooldownMinutes: v.CooldownMinutes,
+		NotifyEmail:     v.NotifyEmail,
+		WebhookURL:      v.WebhookURL,
+		IsEnabled:       v.IsEnabled,
+		CreatedAt:       v.CreatedAt,
+		UpdatedAt:       v.UpdatedAt,
+	}
+
+	if v.State != nil {
+		r.State = v.State.Data
+	}
+
+	return r
+}
+
+func (*alertMapper) QueryDTOToModel(userID uuid.UUID, v *dto.AlertIncidentQuery) *domain.ListAlertIncidentFilters {
+	filters := &domain.ListAlertIncidentFilters{UserID: userID}
+
+	if v.AlertID != "" {
+		filters.AlertID = uuid.NullUUID{UUID: uuid.MustParse(v.AlertID), Valid: true}
+	}
+
+	if v.Status != "" {
+		status := enum.IncidentStatus(v.Status)
+		filters.Status = &status
+	}
+
+	return filters
+}
+
+func (*alertMapper) IncidentToDTO(v *domain.AlertIncident) *dto.GetAlertIncidentResponse {
+	r := &dto.GetAlertIncidentResponse{
+		ID:             v.ID,
+		AlertID:        v.AlertID,
+		AlertName:      v.AlertName,
+		Kind:           v.Kind,
+		Status:         v.Status,
+		Message:        v.Message,
+		OpenedAt:       v.OpenedAt,
+		AcknowledgedAt: v.AcknowledgedAt,
+		ResolvedAt:     v.ResolvedAt,
+	}
+
+	if v.Value != nil {
+		r.Value = v.Value.Data
+	}
+
+	return r
+}
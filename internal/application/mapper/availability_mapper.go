@@ -0,0 +1,61 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AvailabilityMapper interface {
+	SetDTOToModel(deviceID uuid.UUID, userID uuid.UUID, v *dto.SetAvailabilityRequest) *domain.SetDeviceAvailability
+	ModelToDTO(v *domain.DeviceAvailability) *dto.GetAvailabilityResponse
+	QueryDTOToModel(deviceID uuid.UUID, v *dto.AvailabilityTransitionQuery) *domain.ListAvailabilityTransitionFilters
+	TransitionToDTO(v *domain.AvailabilityTransition) *dto.AvailabilityTransitionResponse
+}
+
+type availabilityMapper struct{}
+
+func NewAvailabilityMapper() AvailabilityMapper {
+	return &availabilityMapper{}
+}
+
+func (*availabilityMapper) SetDTOToModel(deviceID uuid.UUID, userID uuid.UUID, v *dto.SetAvailabilityRequest) *domain.SetDeviceAvailability {
+	return &domain.SetDeviceAvailability{
+		UserID:         userID,
+		DeviceID:       deviceID,
+		Mode:           v.Mode,
+		Topic:          v.Topic,
+		OnlinePayload:  v.OnlinePayload,
+		OfflinePayload: v.OfflinePayload,
+		TimeoutSeconds: v.TimeoutSeconds,
+	}
+}
+
+func (*availabilityMapper) ModelToDTO(v *domain.DeviceAvailability) *dto.GetAvailabilityResponse {
+	return &dto.GetAvailabilityResponse{
+		Mode:           v.Mode,
+		Topic:          v.Topic,
+		OnlinePayload:  v.OnlinePayload,
+		OfflinePayload: v.OfflinePayload,
+		TimeoutSeconds: v.TimeoutSeconds,
+		CreatedAt:      v.CreatedAt,
+		UpdatedAt:      v.UpdatedAt,
+	}
+}
+
+func (*availabilityMapper) QueryDTOToModel(deviceID uuid.UUID, v *dto.AvailabilityTransitionQuery) *domain.ListAvailabilityTransitionFilters {
+	return &domain.ListAvailabilityTransitionFilters{
+		DeviceID: deviceID,
+		From:     v.From,
+		To:       v.To,
+	}
+}
+
+func (*availabilityMapper) TransitionToDTO(v *domain.AvailabilityTransition) *dto.AvailabilityTransitionResponse {
+	return &dto.AvailabilityTransitionResponse{
+		ID:        v.ID,
+		Status:    v.Status,
+		Reason:    v.Reason,
+		CreatedAt: v.CreatedAt,
+	}
+}
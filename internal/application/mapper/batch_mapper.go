@@ -0,0 +1,80 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type BatchMapper interface {
+	OperationDTOToModel(v *dto.BatchOperationRequest, data interface{}) *domain.BatchOperation
+	ResultsModelToDTO(v []*domain.BatchResult) *dto.BatchResponse
+}
+
+type batchMapper struct {
+	bm  BrokerMapper
+	dm  DeviceMapper
+	dcm DeviceControlMapper
+}
+
+func NewBatchMapper() BatchMapper {
+	return &batchMapper{NewBrokerMapper(), NewDeviceMapper(), NewDeviceControlMapper()}
+}
+
+// OperationDTOToModel maps the operation with its data bound to the request of the entity,
+// the references are left for the service to resolve.
+func (m *batchMapper) OperationDTOToModel(v *dto.BatchOperationRequest, data interface{}) *domain.BatchOperation {
+	r := &domain.BatchOperation{
+		Action:   v.Action,
+		Entity:   v.Entity,
+		Ref:      v.Ref,
+		ID:       batchRefDTOToModel(v.ID),
+		DeviceID: batchRefDTOToModel(v.DeviceID),
+		Version:  v.Version,
+	}
+
+	switch d := data.(type) {
+	case *dto.CreateBrokerRequest:
+		r.CreateBroker = m.bm.CreateDTOToCreateModel(d)
+	case *dto.UpdateBrokerRequest:
+		r.UpdateBroker = m.bm.UpdateDTOToUpdateModel(d)
+	case *dto.CreateDeviceRequest:
+		r.CreateDevice = m.dm.CreateDTOToCreateModel(d)
+	case *dto.UpdateDeviceRequest:
+		r.UpdateDevice = m.dm.UpdateDTOToUpdateModel(d)
+	case *dto.CreateDeviceControlRequest:
+		r.CreateControl = m.dcm.CreateDTOToCreateModel(d)
+	case *dto.UpdateDeviceControlRequest:
+		r.UpdateControl = m.dcm.UpdateDTOToUpdateModel(d)
+	}
+
+	return r
+}
+
+func (*batchMapper) ResultsModelToDTO(v []*domain.BatchResult) *dto.BatchResponse {
+	r := &dto.BatchResponse{
+		Results: make([]dto.BatchResultResponse, len(v)),
+	}
+
+	for i, result := range v {
+		r.Results[i] = dto.BatchResultResponse{
+			Action: result.Action,
+			Entity: result.Entity,
+			Ref:    result.Ref,
+			ID:     result.ID,
+		}
+	}
+
+	return r
+}
+
+func batchRefDTOToModel(v string) domain.BatchRef {
+	if ref, ok := strings.CutPrefix(v, "$"); ok {
+		return domain.BatchRef{Ref: ref}
+	}
+
+	id, _ := uuid.Parse(v)
+	return domain.BatchRef{ID: id}
+}
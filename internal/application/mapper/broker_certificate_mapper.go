@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+)
+
+type BrokerCertificateMapper interface {
+	ModelToDTO(v *domain.BrokerCertificatesInfo) *dto.GetBrokerCertificatesResponse
+}
+
+type brokerCertificateMapper struct{}
+
+func NewBrokerCertificateMapper() BrokerCertificateMapper {
+	return &brokerCertificateMapper{}
+}
+
+func (m *brokerCertificateMapper) ModelToDTO(v *domain.BrokerCertificatesInfo) *dto.GetBrokerCertificatesResponse {
+	r := &dto.GetBrokerCertificatesResponse{
+		CA:           []dto.CertificateResponse{},
+		HasClientKey: v.HasClientKey,
+		Warnings:     []dto.CertificateWarningResponse{},
+		UpdatedAt:    v.UpdatedAt,
+	}
+
+	for _, c := range v.CA {
+		r.CA = append(r.CA, *m.certificateToDTO(c))
+	}
+
+	if v.Client != nil {
+		r.Client = m.certificateToDTO(v.Client)
+	}
+
+	for _, w := range v.Warnings {
+		r.Warnings = append(r.Warnings, dto.CertificateWarningResponse{
+			Kind:          w.Kind,
+			Subject:       w.Subject,
+			NotAfter:      w.NotAfter,
+			ExpiresInDays: int(w.ExpiresIn.Hours() / 24),
+		})
+	}
+
+	return r
+}
+
+func (*brokerCertificateMapper) certificateToDTO(v *domain.CertificateInfo) *dto.CertificateResponse {
+	return &dto.CertificateResponse{
+		Subject:      v.Subject,
+		Issuer:       v.Issuer,
+		SerialNumber: v.SerialNumber,
+		Fingerprint:  v.Fingerprint,
+		NotBefore:    v.NotBefore,
+		NotAfter:     v.NotAfter,
+	}
+}
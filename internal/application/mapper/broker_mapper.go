@@ -3,6 +3,7 @@ package mapper
 import (
 	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 )
 
 type BrokerMapper interface {
@@ -10,7 +11,10 @@ type BrokerMapper interface {
 	ModelToCredentialsDTO(v *domain.Broker) *dto.GetBrokerCredentialsResponse
 	CreateDTOToCreateModel(v *dto.CreateBrokerRequest) *domain.CreateBroker
 	UpdateDTOToUpdateModel(v *dto.UpdateBrokerRequest) *domain.UpdateBroker
+	CloneDTOToCloneModel(v *dto.CloneBrokerRequest) *domain.CloneBroker
 	SetCredentialsDTOToUpdateModel(v *dto.SetBrokerCredentialsRequest) *domain.UpdateBroker
+	TestDTOToModel(v *dto.TestBrokerRequest) *domain.Broker
+	ProbeModelToDTO(v *domain.BrokerProbe) *dto.TestBrokerResponse
 }
 
 type brokerMapper struct{}
@@ -30,9 +34,18 @@ func (*brokerMapper) ModelToDTO(v *domain.Broker) *dto.GetBrokerResponse {
 			Name:            v.IconName,
 			BackgroundColor: v.IconBackgroundColor,
 		},
-		IsSSL:     v.IsSSL,
-		CreatedAt: v.CreatedAt,
-		UpdatedAt: v.UpdatedAt,
+		IsSSL:           v.IsSSL,
+		DiscoveryMode:   v.DiscoveryMode,
+		DiscoveryPrefix: v.DiscoveryPrefix,
+		ProtocolVersion: v.ProtocolVersion,
+		Transport:       v.Transport,
+		CleanStart:      v.CleanStart,
+		SessionExpiry:   v.SessionExpiry,
+		UserProperties:  v.UserProperties,
+		Status:          v.Status,
+		StatusChangedAt: v.StatusChangedAt,
+		CreatedAt:       v.CreatedAt,
+		UpdatedAt:       v.UpdatedAt,
 	}
 
 	if v.ClientID.Null {
@@ -41,6 +54,14 @@ func (*brokerMapper) ModelToDTO(v *domain.Broker) *dto.GetBrokerResponse {
 		r.ClientID = &v.ClientID.String
 	}
 
+	if !v.Path.Null {
+		r.Path = &v.Path.String
+	}
+
+	if r.UserProperties == nil {
+		r.UserProperties = map[string]string{}
+	}
+
 	return r
 }
 
@@ -65,7 +86,7 @@ func (*brokerMapper) ModelToCredentialsDTO(v *domain.Broker) *dto.GetBrokerCrede
 }
 
 func (*brokerMapper) CreateDTOToCreateModel(v *dto.CreateBrokerRequest) *domain.CreateBroker {
-	return &domain.CreateBroker{
+	r := &domain.CreateBroker{
 		Name:                v.Name,
 		Server:              v.Server,
 		Port:                *v.Port,
@@ -74,17 +95,44 @@ func (*brokerMapper) CreateDTOToCreateModel(v *dto.CreateBrokerRequest) *domain.
 		IconBackgroundColor: v.Icon.BackgroundColor,
 		IsSSL:               *v.IsSSL,
 		ClientID:            v.ClientID,
+		DiscoveryPrefix:     v.DiscoveryPrefix,
+		Path:                v.Path,
+		CleanStart:          v.CleanStart,
+		SessionExpiry:       v.SessionExpiry,
+		UserProperties:      v.UserProperties,
 	}
+
+	if v.DiscoveryMode != nil {
+		r.DiscoveryMode = *v.DiscoveryMode
+	}
+
+	if v.ProtocolVersion != nil {
+		r.ProtocolVersion = *v.ProtocolVersion
+	}
+
+	if v.Transport != nil {
+		r.Transport = *v.Transport
+	}
+
+	return r
 }
 
 func (*brokerMapper) UpdateDTOToUpdateModel(v *dto.UpdateBrokerRequest) *domain.UpdateBroker {
 	r := &domain.UpdateBroker{
-		Name:      v.Name,
-		Server:    v.Server,
-		Port:      v.Port,
-		KeepAlive: v.KeepAlive,
-		IsSSL:     v.IsSSL,
-		ClientID:  v.ClientID,
+		Name:            v.Name,
+		Server:          v.Server,
+		Port:            v.Port,
+		KeepAlive:       v.KeepAlive,
+		IsSSL:           v.IsSSL,
+		ClientID:        v.ClientID,
+		DiscoveryMode:   v.DiscoveryMode,
+		DiscoveryPrefix: v.DiscoveryPrefix,
+		ProtocolVersion: v.ProtocolVersion,
+		Transport:       v.Transport,
+		Path:            v.Path,
+		CleanStart:      v.CleanStart,
+		SessionExpiry:   v.SessionExpiry,
+		UserProperties:  v.UserProperties,
 	}
 
 	if v.Icon.Name.Set {
@@ -98,9 +146,86 @@ func (*brokerMapper) UpdateDTOToUpdateModel(v *dto.UpdateBrokerRequest) *domain.
 	return r
 }
 
+func (*brokerMapper) CloneDTOToCloneModel(v *dto.CloneBrokerRequest) *domain.CloneBroker {
+	return &domain.CloneBroker{
+		Name:   v.Name,
+		Server: v.Server,
+	}
+}
+
 func (*brokerMapper) SetCredentialsDTOToUpdateModel(v *dto.SetBrokerCredentialsRequest) *domain.UpdateBroker {
 	return &domain.UpdateBroker{
 		Username: v.Username,
 		Password: v.Password,
 	}
 }
+
+func (*brokerMapper) TestDTOToModel(v *dto.TestBrokerRequest) *domain.Broker {
+	r := &domain.Broker{
+		Server:   v.Server,
+		Port:     *v.Port,
+		IsSSL:    *v.IsSSL,
+		Username: v.Username,
+		Password: v.Password,
+	}
+
+	if v.KeepAlive != nil {
+		r.KeepAlive = *v.KeepAlive
+	}
+
+	if v.ProtocolVersion != nil {
+		r.ProtocolVersion = *v.ProtocolVersion
+	}
+
+	if v.Transport != nil {
+		r.Transport = *v.Transport
+	}
+
+	if v.Path != nil {
+		r.Path = t.NewString(*v.Path, false, true)
+	}
+
+	r.CleanStart = true
+	if v.CleanStart != nil {
+		r.CleanStart = *v.CleanStart
+	}
+
+	if v.SessionExpiry != nil {
+		r.SessionExpiry = *v.SessionExpiry
+	}
+
+	r.UserProperties = v.UserProperties
+
+	if v.CACertificate.Set || v.ClientCertificate.Set {
+		r.Certificates = &domain.BrokerCertificates{
+			CACertificate:     v.CACertificate,
+			ClientCertificate: v.ClientCertificate,
+			ClientKey:         v.ClientKey,
+		}
+	}
+
+	return r
+}
+
+func (*brokerMapper) ProbeModelToDTO(v *domain.BrokerProbe) *dto.TestBrokerResponse {
+	r := &dto.TestBrokerResponse{
+		Success: v.Success,
+		Steps:   []dto.TestBrokerStep{},
+	}
+
+	for _, s := range v.Steps {
+		step := dto.TestBrokerStep{
+			Name:      s.Name,
+			Success:   s.Success,
+			LatencyMs: float64(s.Latency.Microseconds()) / 1000,
+		}
+
+		if !s.Success {
+			step.Error = &s.Error
+		}
+
+		r.Steps = append(r.Steps, step)
+	}
+
+	return r
+}
@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+)
+
+type ControlTypeMapper interface {
+	ModelToDTO(v *domain.ControlType) *dto.GetControlTypeResponse
+	PreviewDTOToModel(v *dto.TransformPreviewRequest) *domain.TransformPreview
+	ResultToDTO(v *domain.TransformResult) *dto.TransformPreviewResponse
+}
+
+type controlTypeMapper struct{}
+
+func NewControlTypeMapper() ControlTypeMapper {
+	return &controlTypeMapper{}
+}
+
+func (*controlTypeMapper) ModelToDTO(v *domain.ControlType) *dto.GetControlTypeResponse {
+	return &dto.GetControlTypeResponse{
+		Type:        v.Name,
+		Description: v.Description,
+		Schema:      v.Schema,
+		CanPublish:  v.Encode != nil,
+		CanDisplay:  v.Decode != nil,
+	}
+}
+
+func (*controlTypeMapper) PreviewDTOToModel(v *dto.TransformPreviewRequest) *domain.TransformPreview {
+	d := &domain.TransformPreview{
+		Payload:   []byte(v.Payload),
+		Transform: v.Transform,
+	}
+
+	if v.Type != nil {
+		d.Type = *v.Type
+	}
+
+	if v.Attributes != nil {
+		d.Attributes = attributesDTOToModel(v.Attributes)
+	}
+
+	return d
+}
+
+func (*controlTypeMapper) ResultToDTO(v *domain.TransformResult) *dto.TransformPreviewResponse {
+	return &dto.TransformPreviewResponse{
+		Text:    v.Text,
+		Value:   v.Value,
+		Label:   v.Label,
+		Unit:    v.Unit,
+		Decoded: v.Decoded,
+	}
+}
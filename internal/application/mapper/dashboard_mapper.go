@@ -0,0 +1,185 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type DashboardMapper interface {
+	ModelToDTO(v *domain.Dashboard) *dto.GetDashboardResponse
+	ModelToDetailsDTO(v *domain.Dashboard) *dto.GetDashboardDetailsResponse
+	CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateDashboardRequest) *domain.CreateDashboard
+	UpdateDTOToUpdateModel(v *dto.UpdateDashboardRequest) *domain.UpdateDashboard
+	CreateTabDTOToCreateModel(dashboardID uuid.UUID, v *dto.CreateDashboardTabRequest) *domain.CreateDashboardTab
+	UpdateTabDTOToUpdateModel(v *dto.UpdateDashboardTabRequest) *domain.UpdateDashboardTab
+	CreateWidgetDTOToCreateModel(tabID uuid.UUID, v *dto.CreateDashboardWidgetRequest) *domain.CreateDashboardWidget
+	UpdateWidgetDTOToUpdateModel(v *dto.UpdateDashboardWidgetRequest) *domain.UpdateDashboardWidget
+	LayoutDTOToModel(v *dto.WidgetLayoutRequest) []*domain.WidgetLayout
+}
+
+type dashboardMapper struct{}
+
+func NewDashboardMapper() DashboardMapper {
+	return &dashboardMapper{}
+}
+
+func (*dashboardMapper) ModelToDTO(v *domain.Dashboard) *dto.GetDashboardResponse {
+	return &dto.GetDashboardResponse{
+		ID:   v.ID,
+		Name: v.Name,
+		Icon: dto.Icon{
+			Name:            v.IconName,
+			BackgroundColor: v.IconBackgroundColor,
+		},
+		Position:  v.Position,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func (m *dashboardMapper) ModelToDetailsDTO(v *domain.Dashboard) *dto.GetDashboardDetailsResponse {
+	r := &dto.GetDashboardDetailsResponse{
+		GetDashboardResponse: *m.ModelToDTO(v),
+		Tabs:                 make([]*dto.GetDashboardTabResponse, len(v.Tabs)),
+	}
+
+	for i, t := range v.Tabs {
+		tab := &dto.GetDashboardTabResponse{
+			ID:       t.ID,
+			Name:     t.Name,
+			Position: t.Position,
+			Widgets:  make([]*dto.GetDashboardWidgetResponse, len(t.Widgets)),
+		}
+
+		for j, w := range t.Widgets {
+			tab.Widgets[j] = &dto.GetDashboardWidgetResponse{
+				ID:        w.ID,
+				DeviceID:  w.DeviceID,
+				ControlID: w.ControlID,
+				X:         w.X,
+				Y:         w.Y,
+				Width:     w.Width,
+				Height:    w.Height,
+				Overrides: overridesModelToDTO(w.Overrides),
+			}
+		}
+
+		r.Tabs[i] = tab
+	}
+
+	return r
+}
+
+func (*dashboardMapper) CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateDashboardRequest) *domain.CreateDashboard {
+	return &domain.CreateDashboard{
+		UserID:              userID,
+		Name:                v.Name,
+		IconName:            v.Icon.Name,
+		IconBackgroundColor: v.Icon.BackgroundColor,
+	}
+}
+
+func (*dashboardMapper) UpdateDTOToUpdateModel(v *dto.UpdateDashboardRequest) *domain.UpdateDashboard {
+	d := &domain.UpdateDashboard{
+		Name: v.Name,
+	}
+
+	if v.Icon.Name.Set {
+		d.IconName = &v.Icon.Name.String
+	}
+
+	if v.Icon.BackgroundColor.Set {
+		d.IconBackgroundColor = &v.Icon.BackgroundColor.String
+	}
+
+	return d
+}
+
+func (*dashboardMapper) CreateTabDTOToCreateModel(dashboardID uuid.UUID, v *dto.CreateDashboardTabRequest) *domain.CreateDashboardTab {
+	return &domain.CreateDashboardTab{
+		DashboardID: dashboardID,
+		Name:        v.Name,
+	}
+}
+
+func (*dashboardMapper) UpdateTabDTOToUpdateModel(v *dto.UpdateDashboardTabRequest) *domain.UpdateDashboardTab {
+	return &domain.UpdateDashboardTab{
+		Name: v.Name,
+	}
+}
+
+func (*dashboardMapper) CreateWidgetDTOToCreateModel(tabID uuid.UUID, v *dto.CreateDashboardWidgetRequest) *domain.CreateDashboardWidget {
+	return &domain.CreateDashboardWidget{
+		TabID:     tabID,
+		DeviceID:  v.DeviceID,
+		ControlID: v.ControlID,
+		X:         *v.X,
+		Y:         *v.Y,
+		Width:     v.Width,
+		Height:    v.Height,
+		Overrides: overridesDTOToModel(&v.Overrides),
+	}
+}
+
+func (*dashboardMapper) UpdateWidgetDTOToUpdateModel(v *dto.UpdateDashboardWidgetRequest) *domain.UpdateDashboardWidget {
+	d := &domain.UpdateDashboardWidget{
+		X:      v.X,
+		Y:      v.Y,
+		Width:  v.Width,
+		Height: v.Height,
+	}
+
+	if v.Overrides != nil {
+		o := overridesDTOToModel(v.Overrides)
+		d.Overrides = &o
+	}
+
+	return d
+}
+
+func (*dashboardMapper) LayoutDTOToModel(v *dto.WidgetLayoutRequest) []*domain.WidgetLayout {
+	r := make([]*domain.WidgetLayout, len(v.Widgets))
+
+	for i, w := range v.Widgets {
+		r[i] = &domain.WidgetLayout{
+			ID:     w.ID,
+			X:      *w.X,
+			Y:      *w.Y,
+			Width:  w.Width,
+			Height: w.Height,
+		}
+	}
+
+	return r
+}
+
+func overridesModelToDTO(v domain.WidgetOverrides) dto.WidgetOverrides {
+	r := dto.WidgetOverrides{
+		Name:           v.Name,
+		CanDisplayName: v.CanDisplayName,
+	}
+
+	if v.IconName != nil || v.IconBackgroundColor != nil {
+		r.Icon = &dto.WidgetIconOverride{
+			Name:            v.IconName,
+			BackgroundColor: v.IconBackgroundColor,
+		}
+	}
+
+	return r
+}
+
+func overridesDTOToModel(v *dto.WidgetOverrides) domain.WidgetOverrides {
+	d := domain.WidgetOverrides{
+		Name:           v.Name,
+		CanDisplayName: v.CanDisplayName,
+	}
+
+	if v.Icon != nil {
+		d.IconName = v.Icon.Name
+		d.IconBackgroundColor = v.Icon.BackgroundColor
+	}
+
+	return d
+}
@@ -1,8 +1,8 @@
 package mapper
 
 import (
-	"reflect"
-	"unicode"
+	"encoding/base64"
+	"unicode/utf8"
 
 	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
@@ -13,6 +13,7 @@ type DeviceControlMapper interface {
 	ModelToDTO(v *domain.DeviceControl) *dto.GetDeviceControlResponse
 	CreateDTOToCreateModel(v *dto.CreateDeviceControlRequest) *domain.CreateDeviceControl
 	UpdateDTOToUpdateModel(v *dto.UpdateDeviceControlRequest) *domain.UpdateDeviceControl
+	RenderedToDTO(v *domain.RenderedPayload) *dto.RenderControlResponse
 }
 
 type deviceControlMapper struct{}
@@ -37,64 +38,9 @@ func (*deviceControlMapper) ModelToDTO(v *domain.DeviceControl) *dto.GetDeviceCo
 		IsAvailable:            v.IsAvailable,
 		CanNotifyOnPublish:     v.CanNotifyOnPublish,
 		CanDisplayName:         v.CanDisplayName,
-	}
-
-	for k, e := range v.Attributes {
-		if k == "colorFormat" {
-			t := e.(string)
-			r.Attributes.ColorFormat = &t
-		}
-
-		if k == "maxValue" {
-			t := float32(e.(float64))
-			r.Attributes.MaxValue = &t
-		}
-
-		if k == "minValue" {
-			t := float32(e.(float64))
-			r.Attributes.MinValue = &t
-		}
-
-		if k == "offPayload" {
-			t := e.(string)
-			r.Attributes.OffPayload = &t
-		}
-
-		if k == "onPayload" {
-			t := e.(string)
-			r.Attributes.OnPayload = &t
-		}
-
-		if k == "payload" {
-			t := e.(string)
-			r.Attributes.Payload = &t
-		}
-
-		if k == "payloadTemplate" {
-			t := e.(string)
-			r.Attributes.PayloadTemplate = &t
-		}
-
-		if k == "payloads" {
-			t := e.(map[string]interface{})
-			a := make(map[string]string)
-
-			for tk, te := range t {
-				a[tk] = te.(string)
-			}
-
-			r.Attributes.Payloads = &a
-		}
-
-		if k == "sendAsTicks" {
-			t := e.(bool)
-			r.Attributes.SendAsTicks = &t
-		}
-
-		if k == "value" {
-			t := e.(string)
-			r.Attributes.Value = &t
-		}
+		Attributes:             attributesModelToDTO(v.Attributes),
+		TagIDs:                 v.TagIDs,
+		Version:                v.Version,
 	}
 
 	return r
@@ -114,23 +60,7 @@ func (*deviceControlMapper) CreateDTOToCreateModel(v *dto.CreateDeviceControlReq
 		Topic:                  v.Topic,
 	}
 
-	a := map[string]interface{}{}
-
-	s := reflect.ValueOf(*v.Attributes)
-	g := s.Type()
-
-	for i := 0; i < s.NumField(); i++ {
-		if s.Field(i).IsNil() {
-			continue
-		}
-
-		n := g.Field(i).Name
-		k := string(unicode.ToLower([]rune(n)[0])) + n[1:]
-
-		a[k] = s.Field(i).Interface()
-	}
-
-	d.Attributes = a
+	d.Attributes = attributesDTOToModel(v.Attributes)
 
 	return d
 }
@@ -156,23 +86,7 @@ func (*deviceControlMapper) UpdateDTOToUpdateModel(v *dto.UpdateDeviceControlReq
 	}
 
 	if v.Attributes != nil {
-		a := map[string]interface{}{}
-
-		s := reflect.ValueOf(*v.Attributes)
-		g := s.Type()
-
-		for i := 0; i < s.NumField(); i++ {
-			if s.Field(i).IsNil() {
-				continue
-			}
-
-			n := g.Field(i).Name
-			k := string(unicode.ToLower([]rune(n)[0])) + n[1:]
-
-			a[k] = s.Field(i).Interface()
-		}
-
-		d.Attributes = a
+		d.Attributes = attributesDTOToModel(v.Attributes)
 	} else {
 		if v.Type != nil {
 			if *v.Type == enum.ControlTextOut {
@@ -183,3 +97,43 @@ func (*deviceControlMapper) UpdateDTOToUpdateModel(v *dto.UpdateDeviceControlReq
 
 	return d
 }
+
+func (*deviceControlMapper) RenderedToDTO(v *domain.RenderedPayload) *dto.RenderControlResponse {
+	r := &dto.RenderControlResponse{
+		Topic:    v.Topic,
+		QoS:      v.QoS,
+		Payload:  string(v.Payload),
+		Encoding: enum.PayloadUTF8,
+	}
+
+	if !utf8.Valid(v.Payload) {
+		r.Payload = base64.StdEncoding.EncodeToString(v.Payload)
+		r.Encoding = enum.PayloadBase64
+	}
+
+	return r
+}
+
+func attributesModelToDTO(v domain.ControlAttributes) dto.ControlAttributes {
+	r := dto.ControlAttributes{}
+
+	for k, e := range v {
+		r[k] = e
+	}
+
+	return r
+}
+
+func attributesDTOToModel(v *dto.ControlAttributes) domain.ControlAttributes {
+	a := domain.ControlAttributes{}
+
+	if v == nil {
+		return a
+	}
+
+	for k, e := range *v {
+		a[k] = e
+	}
+
+	return a
+}
@@ -9,6 +9,7 @@ type DeviceMapper interface {
 	ModelToDTO(v *domain.Device) *dto.GetDeviceResponse
 	CreateDTOToCreateModel(v *dto.CreateDeviceRequest) *domain.CreateDevice
 	UpdateDTOToUpdateModel(v *dto.UpdateDeviceRequest) *domain.UpdateDevice
+	CloneDTOToCloneModel(v *dto.CloneDeviceRequest) *domain.CloneDevice
 }
 
 type deviceMapper struct{}
@@ -21,15 +22,19 @@ func (*deviceMapper) ModelToDTO(v *domain.Device) *dto.GetDeviceResponse {
 	r := &dto.GetDeviceResponse{
 		ID:       v.ID,
 		BrokerID: v.BrokerID,
+		RoomID:   v.RoomID,
 		Name:     v.Name,
 		Icon: dto.Icon{
 			Name:            v.IconName,
 			BackgroundColor: v.IconBackgroundColor,
 		},
-		CreatedAt: v.CreatedAt,
-		UpdatedAt: v.UpdatedAt,
-		Placing:   v.Placing,
-		BasePath:  v.BasePath,
+		CreatedAt:  v.CreatedAt,
+		UpdatedAt:  v.UpdatedAt,
+		Placing:    v.Placing,
+		BasePath:   v.BasePath,
+		TagIDs:     v.TagIDs,
+		Status:     v.Status,
+		LastSeenAt: v.LastSeenAt,
 	}
 
 	return r
@@ -38,6 +43,7 @@ func (*deviceMapper) ModelToDTO(v *domain.Device) *dto.GetDeviceResponse {
 func (*deviceMapper) CreateDTOToCreateModel(v *dto.CreateDeviceRequest) *domain.CreateDevice {
 	return &domain.CreateDevice{
 		BrokerID:            v.BrokerID,
+		RoomID:              v.RoomID,
 		Name:                v.Name,
 		IconName:            v.Icon.Name,
 		IconBackgroundColor: v.Icon.BackgroundColor,
@@ -49,6 +55,7 @@ func (*deviceMapper) CreateDTOToCreateModel(v *dto.CreateDeviceRequest) *domain.
 func (*deviceMapper) UpdateDTOToUpdateModel(v *dto.UpdateDeviceRequest) *domain.UpdateDevice {
 	return &domain.UpdateDevice{
 		BrokerID:            v.BrokerID,
+		RoomID:              v.RoomID,
 		Name:                v.Name,
 		IconName:            v.Icon.Name,
 		IconBackgroundColor: v.Icon.BackgroundColor,
@@ -56,3 +63,11 @@ func (*deviceMapper) UpdateDTOToUpdateModel(v *dto.UpdateDeviceRequest) *domain.
 		BasePath:            v.BasePath,
 	}
 }
+
+func (*deviceMapper) CloneDTOToCloneModel(v *dto.CloneDeviceRequest) *domain.CloneDevice {
+	return &domain.CloneDevice{
+		BrokerID: v.BrokerID,
+		Name:     v.Name,
+		BasePath: v.BasePath,
+	}
+}
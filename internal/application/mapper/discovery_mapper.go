@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+)
+
+type DiscoveryMapper interface {
+	ModelToDTO(v *domain.DiscoveryProposal) *dto.GetDiscoveryProposalResponse
+	ResultModelToDTO(v *domain.DiscoveryResult) *dto.AcceptDiscoveryProposalResponse
+}
+
+type discoveryMapper struct{}
+
+func NewDiscoveryMapper() DiscoveryMapper {
+	return &discoveryMapper{}
+}
+
+func (*discoveryMapper) ModelToDTO(v *domain.DiscoveryProposal) *dto.GetDiscoveryProposalResponse {
+	return &dto.GetDiscoveryProposalResponse{
+		ID:          v.ID,
+		BrokerID:    v.BrokerID,
+		Component:   v.Component,
+		ConfigTopic: v.ConfigTopic,
+		Device: dto.DiscoveryDevice{
+			Identifier: v.DeviceIdentifier,
+			Name:       v.DeviceName,
+		},
+		Control: dto.DiscoveryControl{
+			Name:       v.Control.Name,
+			Type:       v.Control.Type,
+			Attributes: attributesModelToDTO(v.Control.Attributes),
+			Topic:      v.Control.Topic,
+			Icon: dto.Icon{
+				Name:            v.Control.IconName,
+				BackgroundColor: v.Control.IconBackgroundColor,
+			},
+			QoS: v.Control.QoS,
+		},
+		DiscoveredAt: v.DiscoveredAt,
+	}
+}
+
+func (*discoveryMapper) ResultModelToDTO(v *domain.DiscoveryResult) *dto.AcceptDiscoveryProposalResponse {
+	return &dto.AcceptDiscoveryProposalResponse{
+		DeviceID:  v.DeviceID,
+		ControlID: v.ControlID,
+	}
+}
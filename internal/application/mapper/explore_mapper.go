@@ -0,0 +1,80 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type ExploreMapper interface {
+	QueryDTOToModel(userID uuid.UUID, brokerID uuid.UUID, v *dto.ExploreQuery) *domain.Explore
+	TreeToDTO(v *domain.TopicTree) *dto.TopicTreeResponse
+	ControlDTOToModel(v *dto.ExploreControlRequest) *domain.ExploreControl
+}
+
+type exploreMapper struct{}
+
+func NewExploreMapper() ExploreMapper {
+	return &exploreMapper{}
+}
+
+func (*exploreMapper) QueryDTOToModel(userID uuid.UUID, brokerID uuid.UUID, v *dto.ExploreQuery) *domain.Explore {
+	return &domain.Explore{
+		UserID:   userID,
+		BrokerID: brokerID,
+		Filter:   v.Filter,
+		Window:   time.Duration(v.Window) * time.Second,
+	}
+}
+
+func (*exploreMapper) TreeToDTO(v *domain.TopicTree) *dto.TopicTreeResponse {
+	return &dto.TopicTreeResponse{
+		Topics:   v.Topics,
+		Messages: v.Messages,
+		Ignored:  v.Ignored,
+		Nodes:    topicNodesToDTO(v.Nodes),
+	}
+}
+
+func (*exploreMapper) ControlDTOToModel(v *dto.ExploreControlRequest) *domain.ExploreControl {
+	d := &domain.ExploreControl{
+		DeviceID: v.DeviceID,
+		Name:     v.Name,
+		Topic:    v.Topic,
+	}
+
+	if v.Type != nil {
+		d.Type = *v.Type
+	}
+
+	if v.QoS != nil {
+		d.QoS = *v.QoS
+	}
+
+	if v.Attributes != nil {
+		d.Attributes = attributesDTOToModel(v.Attributes)
+	}
+
+	return d
+}
+
+func topicNodesToDTO(v []*domain.TopicNode) []*dto.TopicNodeResponse {
+	r := make([]*dto.TopicNodeResponse, len(v))
+
+	for i, n := range v {
+		r[i] = &dto.TopicNodeResponse{
+			Name:       n.Name,
+			Topic:      n.Topic,
+			Messages:   n.Messages,
+			Payload:    n.Payload,
+			Truncated:  n.Truncated,
+			Retained:   n.Retained,
+			ReceivedAt: n.ReceivedAt,
+			Children:   topicNodesToDTO(n.Children),
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type GroupMapper interface {
+	ModelToDTO(v *domain.Group) *dto.GetGroupResponse
+	StateModelToDTO(v *domain.GroupState) *dto.GetGroupStateResponse
+	CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateGroupRequest) *domain.CreateGroup
+	UpdateDTOToUpdateModel(v *dto.UpdateGroupRequest) *domain.UpdateGroup
+}
+
+type groupMapper struct{}
+
+func NewGroupMapper() GroupMapper {
+	return &groupMapper{}
+}
+
+func (*groupMapper) ModelToDTO(v *domain.Group) *dto.GetGroupResponse {
+	return &dto.GetGroupResponse{
+		ID:   v.ID,
+		Name: v.Name,
+		Type: v.Type,
+		Icon: dto.Icon{
+			Name:            v.IconName,
+			BackgroundColor: v.IconBackgroundColor,
+		},
+		ControlIDs: v.ControlIDs,
+		CreatedAt:  v.CreatedAt,
+		UpdatedAt:  v.UpdatedAt,
+	}
+}
+
+func (*groupMapper) StateModelToDTO(v *domain.GroupState) *dto.GetGroupStateResponse {
+	return &dto.GetGroupStateResponse{
+		GroupID:      v.GroupID,
+		State:        v.State,
+		OnCount:      v.OnCount,
+		OffCount:     v.OffCount,
+		UnknownCount: v.UnknownCount,
+	}
+}
+
+func (*groupMapper) CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateGroupRequest) *domain.CreateGroup {
+	return &domain.CreateGroup{
+		UserID:              userID,
+		Name:                v.Name,
+		Type:                v.Type,
+		IconName:            v.Icon.Name,
+		IconBackgroundColor: v.Icon.BackgroundColor,
+	}
+}
+
+func (*groupMapper) UpdateDTOToUpdateModel(v *dto.UpdateGroupRequest) *domain.UpdateGroup {
+	d := &domain.UpdateGroup{
+		Name: v.Name,
+	}
+
+	if v.Icon.Name.Set {
+		d.IconName = &v.Icon.Name.String
+	}
+
+	if v.Icon.BackgroundColor.Set {
+		d.IconBackgroundColor = &v.Icon.BackgroundColor.String
+	}
+
+	return d
+}
@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"encoding/base64"
+	"unicode/utf8"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type MessageMapper interface {
+	QueryDTOToModel(deviceID uuid.UUID, v *dto.DeviceMessageQuery) *domain.ListDeviceMessageFilters
+	ModelToDTO(v *domain.DeviceMessage) *dto.DeviceMessageResponse
+}
+
+type messageMapper struct{}
+
+func NewMessageMapper() MessageMapper {
+	return &messageMapper{}
+}
+
+func (*messageMapper) QueryDTOToModel(deviceID uuid.UUID, v *dto.DeviceMessageQuery) *domain.ListDeviceMessageFilters {
+	return &domain.ListDeviceMessageFilters{
+		DeviceID: deviceID,
+		Topic:    v.Topic,
+		Contains: v.Contains,
+		From:     v.From,
+		To:       v.To,
+	}
+}
+
+func (*messageMapper) ModelToDTO(v *domain.DeviceMessage) *dto.DeviceMessageResponse {
+	r := &dto.DeviceMessageResponse{
+		ID:        v.ID,
+		DeviceID:  v.DeviceID,
+		ControlID: v.ControlID,
+		Direction: v.Direction,
+		Topic:     v.Topic,
+		Payload:   string(v.Payload),
+		Encoding:  enum.PayloadUTF8,
+		Truncated: v.Truncated,
+		QoS:       v.QoS,
+		Retained:  v.Retained,
+		CreatedAt: v.CreatedAt,
+	}
+
+	if !utf8.Valid(v.Payload) {
+		r.Payload = base64.StdEncoding.EncodeToString(v.Payload)
+		r.Encoding = enum.PayloadBase64
+	}
+
+	if v.SourceType != nil && v.SourceID.Valid {
+		r.Source = &dto.MessageSourceResponse{Type: *v.SourceType, ID: v.SourceID.UUID}
+	}
+
+	return r
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+)
+
+type RevisionMapper interface {
+	ModelToDTO(v *domain.Revision) *dto.RevisionResponse
+}
+
+type revisionMapper struct{}
+
+func NewRevisionMapper() RevisionMapper {
+	return &revisionMapper{}
+}
+
+func (m *revisionMapper) ModelToDTO(v *domain.Revision) *dto.RevisionResponse {
+	r := &dto.RevisionResponse{
+		Revision:  v.Revision,
+		CreatedAt: v.CreatedAt,
+		Snapshot:  v.Snapshot,
+		Changes:   make([]*dto.RevisionChangeResponse, len(v.Changes)),
+	}
+
+	for i, c := range v.Changes {
+		r.Changes[i] = &dto.RevisionChangeResponse{
+			Field: c.Field,
+			From:  c.From,
+			To:    c.To,
+		}
+	}
+
+	return r
+}
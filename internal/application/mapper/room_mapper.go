@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type RoomMapper interface {
+	ModelToDTO(v *domain.Room) *dto.GetRoomResponse
+	StateModelToDTO(v *domain.RoomState) *dto.GetRoomStateResponse
+	CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateRoomRequest) *domain.CreateRoom
+	UpdateDTOToUpdateModel(v *dto.UpdateRoomRequest) *domain.UpdateRoom
+}
+
+type roomMapper struct{}
+
+func NewRoomMapper() RoomMapper {
+	return &roomMapper{}
+}
+
+func (*roomMapper) ModelToDTO(v *domain.Room) *dto.GetRoomResponse {
+	return &dto.GetRoomResponse{
+		ID:   v.ID,
+		Name: v.Name,
+		Icon: dto.Icon{
+			Name:            v.IconName,
+			BackgroundColor: v.IconBackgroundColor,
+		},
+		Position:  v.Position,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func (*roomMapper) StateModelToDTO(v *domain.RoomState) *dto.GetRoomStateResponse {
+	return &dto.GetRoomStateResponse{
+		RoomID:                v.RoomID,
+		Status:                v.Status,
+		DeviceCount:           v.DeviceCount,
+		ControlCount:          v.ControlCount,
+		AvailableControlCount: v.AvailableControlCount,
+		Brokers: dto.RoomBrokerStatuses{
+			Online:  v.Brokers[enum.BrokerOnline],
+			Offline: v.Brokers[enum.BrokerOffline],
+			Unknown: v.Brokers[enum.BrokerUnknown],
+		},
+	}
+}
+
+func (*roomMapper) CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateRoomRequest) *domain.CreateRoom {
+	return &domain.CreateRoom{
+		UserID:              userID,
+		Name:                v.Name,
+		IconName:            v.Icon.Name,
+		IconBackgroundColor: v.Icon.BackgroundColor,
+	}
+}
+
+func (*roomMapper) UpdateDTOToUpdateModel(v *dto.UpdateRoomRequest) *domain.UpdateRoom {
+	d := &domain.UpdateRoom{
+		Name: v.Name,
+	}
+
+	if v.Icon.Name.Set {
+		d.IconName = &v.Icon.Name.String
+	}
+
+	if v.Icon.BackgroundColor.Set {
+		d.IconBackgroundColor = &v.Icon.BackgroundColor.String
+	}
+
+	return d
+}
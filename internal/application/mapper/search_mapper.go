@@ -0,0 +1,57 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+const defaultSearchLimit = 20
+
+type SearchMapper interface {
+	QueryDTOToModel(userID uuid.UUID, v *dto.SearchQuery) *domain.Search
+	ModelToDTO(v *domain.SearchResult) *dto.SearchResultResponse
+}
+
+type searchMapper struct{}
+
+func NewSearchMapper() SearchMapper {
+	return &searchMapper{}
+}
+
+func (*searchMapper) QueryDTOToModel(userID uuid.UUID, v *dto.SearchQuery) *domain.Search {
+	r := &domain.Search{
+		UserID: userID,
+		Text:   v.Q,
+		Mode:   enum.SearchText,
+		Types:  []enum.SearchResultType{},
+		Limit:  v.Limit,
+	}
+
+	if v.Mode != "" {
+		r.Mode = enum.SearchMode(v.Mode)
+	}
+
+	if r.Limit == 0 {
+		r.Limit = defaultSearchLimit
+	}
+
+	for _, t := range v.Types {
+		r.Types = append(r.Types, enum.SearchResultType(t))
+	}
+
+	return r
+}
+
+func (*searchMapper) ModelToDTO(v *domain.SearchResult) *dto.SearchResultResponse {
+	return &dto.SearchResultResponse{
+		Type:      v.Type,
+		ID:        v.ID,
+		BrokerID:  v.BrokerID,
+		DeviceID:  v.DeviceID,
+		Name:      v.Name,
+		Highlight: v.Highlight,
+		Rank:      v.Rank,
+	}
+}
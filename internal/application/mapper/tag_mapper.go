@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type TagMapper interface {
+	ModelToDTO(v *domain.Tag) *dto.GetTagResponse
+	CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateTagRequest) *domain.CreateTag
+	UpdateDTOToUpdateModel(v *dto.UpdateTagRequest) *domain.UpdateTag
+}
+
+type tagMapper struct{}
+
+func NewTagMapper() TagMapper {
+	return &tagMapper{}
+}
+
+func (*tagMapper) ModelToDTO(v *domain.Tag) *dto.GetTagResponse {
+	return &dto.GetTagResponse{
+		ID:        v.ID,
+		Name:      v.Name,
+		Color:     v.Color,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func (*tagMapper) CreateDTOToCreateModel(userID uuid.UUID, v *dto.CreateTagRequest) *domain.CreateTag {
+	return &domain.CreateTag{
+		UserID: userID,
+		Name:   v.Name,
+		Color:  v.Color,
+	}
+}
+
+func (*tagMapper) UpdateDTOToUpdateModel(v *dto.UpdateTagRequest) *domain.UpdateTag {
+	return &domain.UpdateTag{
+		Name:  v.Name,
+		Color: v.Color,
+	}
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+)
+
+type TopicMapper interface {
+	IssueToDTO(v *domain.TopicIssue) *dto.TopicIssueResponse
+}
+
+type topicMapper struct{}
+
+func NewTopicMapper() TopicMapper {
+	return &topicMapper{}
+}
+
+func (*topicMapper) IssueToDTO(v *domain.TopicIssue) *dto.TopicIssueResponse {
+	r := &dto.TopicIssueResponse{
+		Kind:     v.Kind,
+		Topic:    v.Topic,
+		Controls: make([]*dto.TopicIssueControlResponse, len(v.Controls)),
+	}
+
+	for i, c := range v.Controls {
+		r.Controls[i] = &dto.TopicIssueControlResponse{
+			ID:       c.ID,
+			DeviceID: c.DeviceID,
+			Name:     c.Name,
+			Topic:    c.Topic,
+		}
+	}
+
+	return r
+}
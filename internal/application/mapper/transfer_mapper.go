@@ -0,0 +1,272 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+type TransferMapper interface {
+	ModelToDTO(v *domain.Transfer) *dto.TransferDocument
+	DTOToModel(v *dto.TransferDocument) *domain.Transfer
+	ReportModelToDTO(v *domain.TransferReport) *dto.TransferReportResponse
+}
+
+type transferMapper struct{}
+
+func NewTransferMapper() TransferMapper {
+	return &transferMapper{}
+}
+
+func (*transferMapper) ModelToDTO(v *domain.Transfer) *dto.TransferDocument {
+	r := &dto.TransferDocument{
+		Version:    v.Version,
+		ExportedAt: &v.ExportedAt,
+		Brokers:    []*dto.TransferBroker{},
+		Devices:    []*dto.TransferDevice{},
+	}
+
+	for _, b := range v.Brokers {
+		port := b.Broker.Port
+		keepAlive := b.Broker.KeepAlive
+		isSSL := b.Broker.IsSSL
+
+		broker := &dto.TransferBroker{
+			Ref:       b.Ref,
+			Name:      b.Broker.Name,
+			Server:    b.Broker.Server,
+			Port:      &port,
+			KeepAlive: &keepAlive,
+			Icon: dto.Icon{
+				Name:            b.Broker.IconName,
+				BackgroundColor: b.Broker.IconBackgroundColor,
+			},
+			IsSSL:           &isSSL,
+			ClientID:        nullableToPtr(b.Broker.ClientID),
+			DiscoveryPrefix: nullableToPtr(b.Broker.DiscoveryPrefix),
+		}
+
+		if b.Broker.DiscoveryMode != "" {
+			mode := b.Broker.DiscoveryMode
+			broker.DiscoveryMode = &mode
+		}
+
+		if b.Broker.ProtocolVersion != "" {
+			version := b.Broker.ProtocolVersion
+			broker.ProtocolVersion = &version
+		}
+
+		if b.Broker.Transport != "" {
+			transport := b.Broker.Transport
+			broker.Transport = &transport
+		}
+
+		broker.Path = nullableToPtr(b.Broker.Path)
+		broker.CleanStart = b.Broker.CleanStart
+		broker.SessionExpiry = b.Broker.SessionExpiry
+		broker.UserProperties = b.Broker.UserProperties
+
+		if b.Username.Set || b.Password.Set {
+			broker.Credentials = &dto.TransferCredentials{
+				Username: nullableToPtr(b.Username),
+				Password: nullableToPtr(b.Password),
+			}
+		}
+
+		r.Brokers = append(r.Brokers, broker)
+	}
+
+	for _, d := range v.Devices {
+		device := &dto.TransferDevice{
+			Ref:       d.Ref,
+			BrokerRef: d.BrokerRef,
+			Name:      d.Device.Name,
+			Icon: dto.Icon{
+				Name:            d.Device.IconName,
+				BackgroundColor: d.Device.IconBackgroundColor,
+			},
+			Placing:  nullableToPtr(d.Device.Placing),
+			BasePath: nullableToPtr(d.Device.BasePath),
+			Controls: []*dto.TransferControl{},
+		}
+
+		for _, c := range d.Controls {
+			control := c.Control
+			attributes := attributesModelToDTO(control.Attributes)
+
+			device.Controls = append(device.Controls, &dto.TransferControl{
+				Ref:        c.Ref,
+				Name:       control.Name,
+				Type:       &control.Type,
+				Attributes: &attributes,
+				Topic:      control.Topic,
+				Icon: dto.Icon{
+					Name:            control.IconName,
+					BackgroundColor: control.IconBackgroundColor,
+				},
+				QoS:                    &control.QoS,
+				IsConfirmationRequired: &control.IsConfirmationRequired,
+				IsAvailable:            &control.IsAvailable,
+				CanNotifyOnPublish:     &control.CanNotifyOnPublish,
+				CanDisplayName:         &control.CanDisplayName,
+			})
+		}
+
+		r.Devices = append(r.Devices, device)
+	}
+
+	return r
+}
+
+func (*transferMapper) DTOToModel(v *dto.TransferDocument) *domain.Transfer {
+	r := &domain.Transfer{
+		Version: v.Version,
+	}
+
+	if v.ExportedAt != nil {
+		r.ExportedAt = *v.ExportedAt
+	} else {
+		r.ExportedAt = time.Now()
+	}
+
+	for _, b := range v.Brokers {
+		broker := &domain.TransferBroker{
+			Ref: b.Ref,
+			Broker: domain.CreateBroker{
+				Name:                b.Name,
+				Server:              b.Server,
+				Port:                *b.Port,
+				KeepAlive:           *b.KeepAlive,
+				IconName:            b.Icon.Name,
+				IconBackgroundColor: b.Icon.BackgroundColor,
+				IsSSL:               *b.IsSSL,
+				ClientID:            ptrToNullable(b.ClientID),
+			},
+		}
+
+		if b.DiscoveryMode != nil {
+			broker.Broker.DiscoveryMode = *b.DiscoveryMode
+		}
+
+		if b.DiscoveryPrefix != nil {
+			broker.Broker.DiscoveryPrefix = t.NewString(*b.DiscoveryPrefix, false, true)
+		}
+
+		if b.ProtocolVersion != nil {
+			broker.Broker.ProtocolVersion = *b.ProtocolVersion
+		}
+
+		if b.Transport != nil {
+			broker.Broker.Transport = *b.Transport
+		}
+
+		broker.Broker.Path = ptrToNullable(b.Path)
+		broker.Broker.CleanStart = b.CleanStart
+		broker.Broker.SessionExpiry = b.SessionExpiry
+		broker.Broker.UserProperties = b.UserProperties
+
+		if b.Credentials != nil {
+			broker.Username = ptrToNullable(b.Credentials.Username)
+			broker.Password = ptrToNullable(b.Credentials.Password)
+		}
+
+		r.Brokers = append(r.Brokers, broker)
+	}
+
+	for _, d := range v.Devices {
+		device := &domain.TransferDevice{
+			Ref:       d.Ref,
+			BrokerRef: d.BrokerRef,
+			Device: domain.CreateDevice{
+				Name:                d.Name,
+				IconName:            d.Icon.Name,
+				IconBackgroundColor: d.Icon.BackgroundColor,
+				Placing:             ptrToNullable(d.Placing),
+				BasePath:            ptrToNullable(d.BasePath),
+			},
+		}
+
+		for _, c := range d.Controls {
+			device.Controls = append(device.Controls, &domain.TransferControl{
+				Ref: c.Ref,
+				Control: domain.CreateDeviceControl{
+					Name:                   c.Name,
+					Type:                   *c.Type,
+					QoS:                    *c.QoS,
+					IconName:               c.Icon.Name,
+					IconBackgroundColor:    c.Icon.BackgroundColor,
+					IsAvailable:            *c.IsAvailable,
+					IsConfirmationRequired: *c.IsConfirmationRequired,
+					CanNotifyOnPublish:     *c.CanNotifyOnPublish,
+					CanDisplayName:         *c.CanDisplayName,
+					Topic:                  c.Topic,
+					Attributes:             attributesDTOToModel(c.Attributes),
+				},
+			})
+		}
+
+		r.Devices = append(r.Devices, device)
+	}
+
+	return r
+}
+
+func (*transferMapper) ReportModelToDTO(v *domain.TransferReport) *dto.TransferReportResponse {
+	r := &dto.TransferReportResponse{
+		DryRun:   v.DryRun,
+		Strategy: v.Strategy,
+		Items:    []dto.TransferReportItem{},
+	}
+
+	for _, i := range v.Items {
+		item := dto.TransferReportItem{
+			Entity:  i.Entity,
+			Ref:     i.Ref,
+			Name:    i.Name,
+			Action:  i.Action,
+			Changes: []dto.TransferChange{},
+		}
+
+		if i.ID != uuid.Nil {
+			id := i.ID
+			item.ID = &id
+		}
+
+		if i.Reason != "" {
+			reason := i.Reason
+			item.Reason = &reason
+		}
+
+		for _, c := range i.Changes {
+			item.Changes = append(item.Changes, dto.TransferChange{
+				Field: c.Field,
+				From:  c.From,
+				To:    c.To,
+			})
+		}
+
+		r.Items = append(r.Items, item)
+	}
+
+	return r
+}
+
+func nullableToPtr(v t.String) *string {
+	if !v.Set || v.Null {
+		return nil
+	}
+
+	s := v.String
+	return &s
+}
+
+func ptrToNullable(v *string) t.String {
+	if v == nil {
+		return t.NewString("", true, true)
+	}
+
+	return t.NewString(*v, false, true)
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+)
+
+type TrashMapper interface {
+	ModelToDTO(v *domain.TrashEntry) *dto.TrashEntryResponse
+}
+
+type trashMapper struct{}
+
+func NewTrashMapper() TrashMapper {
+	return &trashMapper{}
+}
+
+func (m *trashMapper) ModelToDTO(v *domain.TrashEntry) *dto.TrashEntryResponse {
+	r := &dto.TrashEntryResponse{
+		Type:       v.Type,
+		ID:         v.ID,
+		DeviceID:   v.DeviceID,
+		Name:       v.Name,
+		DeletedAt:  v.DeletedAt,
+		PurgeAt:    v.PurgeAt,
+		Dependents: make([]*dto.TrashEntryResponse, len(v.Dependents)),
+	}
+
+	for i, d := range v.Dependents {
+		r.Dependents[i] = m.ModelToDTO(d)
+	}
+
+	return r
+}
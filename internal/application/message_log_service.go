@@ -0,0 +1,422 @@
+package application
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMessageLogRetention    = 7 * 24 * time.Hour
+	defaultMessageLogLimit        = 1000
+	defaultMessageLogPayloadBytes = 4096
+	defaultMessageLogInterval     = 10 * time.Minute
+)
+
+// MessageLogService keeps the log of the messages of the devices. The topics of the controls are listened to
+// through the server-side connections of the brokers and the messages published by the server are recorded
+// along with the user who triggered them. The log is trimmed to the retention and to the count kept for a device.
+type MessageLogService interface {
+	Start(ctx context.Context)
+	List(ctx context.Context, userID uuid.UUID, filters *domain.ListDeviceMessageFilters) (*domain.List[*domain.DeviceMessage], error)
+	RecordOutbound(ctx context.Context, message *domain.BridgeMessage, deviceID uuid.UUID, controlID uuid.UUID, source enum.MessageSource, sourceID uuid.UUID)
+}
+
+type messageLogWatch struct {
+	userID        uuid.UUID
+	subscriptions map[string]uuid.UUID
+	controls      map[string][]*domain.ControlTopic
+	// deviceFilters are the sorted filters of every device, a message is recorded by the first filter
+	// of the device matching it, so it is not logged twice when more of them match.
+	deviceFilters map[uuid.UUID][]string
+}
+
+type messageLogService struct {
+	c         *config.Config
+	mr        repository.DeviceMessageRepository
+	br        repository.BrokerRepository
+	dcr       repository.DeviceControlRepository
+	ds        DeviceService
+	bgs       BridgeService
+	watches   map[uuid.UUID]*messageLogWatch
+	mutex     sync.Mutex
+	syncMutex sync.Mutex
+}
+
+func NewMessageLogService(
+	c *config.Config,
+	mr repository.DeviceMessageRepository,
+	br repository.BrokerRepository,
+	dcr repository.DeviceControlRepository,
+	ds DeviceService,
+	bgs BridgeService,
+	es EventService) MessageLogService {
+	s := &messageLogService{
+		c:       c,
+		mr:      mr,
+		br:      br,
+		dcr:     dcr,
+		ds:      ds,
+		bgs:     bgs,
+		watches: make(map[uuid.UUID]*messageLogWatch),
+	}
+
+	es.Listen(s.onEvent)
+
+	return s
+}
+
+// Start listens to the topics of every broker and trims the log right away and then every interval,
+// the brokers which could not be connected to are retried with the next interval.
+func (s *messageLogService) Start(ctx context.Context) {
+	if !s.enabled() {
+		return
+	}
+
+	interval := defaultMessageLogInterval
+	if s.c.MessageLog.IntervalMinutes > 0 {
+		interval = time.Duration(s.c.MessageLog.IntervalMinutes) * time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.syncAll(ctx)
+
+			purged, err := s.mr.Purge(ctx, time.Now().Add(-s.retention()), s.limit())
+			if err != nil {
+				log.Printf("messageLogService.Start: %s", err)
+			} else if purged > 0 {
+				log.Printf("messageLogService.Start: purged %d messages", purged)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *messageLogService) List(ctx context.Context, userID uuid.UUID, filters *domain.ListDeviceMessageFilters) (*domain.List[*domain.DeviceMessage], error) {
+	if _, err := s.ds.Get(ctx, filters.DeviceID, userID); err != nil {
+		return nil, err
+	}
+
+	if filters.Topic != "" && !mqtt.ValidFilter(filters.Topic) {
+		return nil, &ae.ValidationError{
+			Err:    ae.ErrValidation,
+			Fields: []*ae.FieldError{{Field: "topic", Rule: "topic_filter", Message: "should be a valid MQTT topic filter"}},
+		}
+	}
+
+	return s.mr.List(ctx, filters)
+}
+
+func (s *messageLogService) RecordOutbound(ctx context.Context, message *domain.BridgeMessage, deviceID uuid.UUID, controlID uuid.UUID, source enum.MessageSource, sourceID uuid.UUID) {
+	if !s.enabled() {
+		return
+	}
+
+	m := s.message(message, deviceID, controlID, enum.MessageOutbound)
+	m.SourceType = &source
+	m.SourceID = uuid.NullUUID{UUID: sourceID, Valid: true}
+
+	if err := s.mr.Create(ctx, m); err != nil {
+		log.Printf("messageLogService.RecordOutbound: device %s, %s", deviceID, err)
+	}
+}
+
+func (s *messageLogService) message(message *domain.BridgeMessage, deviceID uuid.UUID, controlID uuid.UUID, direction enum.MessageDirection) *domain.DeviceMessage {
+	size := defaultMessageLogPayloadBytes
+	if s.c.MessageLog.PayloadBytes > 0 {
+		size = int(s.c.MessageLog.PayloadBytes)
+	}
+
+	payload := message.Payload
+	truncated := len(payload) > size
+	if truncated {
+		// The text payloads are cut at the start of a rune to stay valid UTF-8
+		if utf8.Valid(payload) {
+			for size > 0 && !utf8.RuneStart(payload[size]) {
+				size--
+			}
+		}
+		payload = payload[:size]
+	}
+
+	createdAt := message.ReceivedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	return &domain.DeviceMessage{
+		DeviceID:  deviceID,
+		ControlID: uuid.NullUUID{UUID: controlID, Valid: controlID != uuid.Nil},
+		Direction: direction,
+		Topic:     message.Topic,
+		Payload:   payload,
+		Truncated: truncated,
+		QoS:       enum.QoSLevel(message.QoS),
+		Retained:  message.Retained,
+		CreatedAt: createdAt,
+	}
+}
+
+func (s *messageLogService) syncAll(ctx context.Context) {
+	brokers, err := s.br.ListAll(ctx)
+	if err != nil {
+		log.Printf("messageLogService.syncAll: %s", err)
+		return
+	}
+
+	listed := make(map[uuid.UUID]bool, len(brokers))
+	for _, broker := range brokers {
+		listed[broker.ID] = true
+
+		if err := s.sync(ctx, broker.UserID, broker.ID); err != nil {
+			log.Printf("messageLogService.syncAll: broker %s, %s", broker.ID, err)
+		}
+	}
+
+	s.mutex.Lock()
+	stale := []uuid.UUID{}
+	for brokerID := range s.watches {
+		if !listed[brokerID] {
+			stale = append(stale, brokerID)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, brokerID := range stale {
+		s.unwatch(ctx, brokerID)
+	}
+}
+
+// sync subscribes to the effective topics of the controls of the broker and drops the subscriptions
+// which are not used anymore.
+func (s *messageLogService) sync(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) error {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	topics, err := s.dcr.ListTopics(ctx, brokerID)
+	if err != nil {
+		return err
+	}
+
+	controls := map[string][]*domain.ControlTopic{}
+	deviceFilters := map[uuid.UUID][]string{}
+	for _, t := range topics {
+		filter := controlTopic(t.BasePath, t.Topic)
+		if !mqtt.ValidFilter(filter) {
+			continue
+		}
+
+		if !hasDevice(controls[filter], t.DeviceID) {
+			deviceFilters[t.DeviceID] = append(deviceFilters[t.DeviceID], filter)
+		}
+		controls[filter] = append(controls[filter], t)
+	}
+
+	for _, filters := range deviceFilters {
+		sort.Strings(filters)
+	}
+
+	s.mutex.Lock()
+	w, ok := s.watches[brokerID]
+	if !ok {
+		w = &messageLogWatch{userID: userID, subscriptions: map[string]uuid.UUID{}}
+		s.watches[brokerID] = w
+	}
+	w.controls = controls
+	w.deviceFilters = deviceFilters
+
+	subscribed := make(map[string]uuid.UUID, len(w.subscriptions))
+	for filter, subscriptionID := range w.subscriptions {
+		subscribed[filter] = subscriptionID
+	}
+	s.mutex.Unlock()
+
+	for filter, subscriptionID := range subscribed {
+		if _, ok := controls[filter]; ok {
+			continue
+		}
+
+		if err := s.bgs.Unsubscribe(ctx, brokerID, subscriptionID); err != nil {
+			log.Printf("messageLogService.sync: broker %s, %s", brokerID, err)
+		}
+
+		s.mutex.Lock()
+		delete(w.subscriptions, filter)
+		s.mutex.Unlock()
+	}
+
+	for filter := range controls {
+		if _, ok := subscribed[filter]; ok {
+			continue
+		}
+
+		// The messages are received with the QoS they are published with
+		subscriptionID, err := s.bgs.Subscribe(ctx, userID, brokerID, filter, enum.QoSTwo, s.handler(brokerID, filter))
+		if err != nil {
+			return err
+		}
+
+		s.mutex.Lock()
+		w.subscriptions[filter] = subscriptionID
+		s.mutex.Unlock()
+	}
+
+	if len(controls) == 0 {
+		s.mutex.Lock()
+		delete(s.watches, brokerID)
+		s.mutex.Unlock()
+	}
+
+	return nil
+}
+
+func (s *messageLogService) unwatch(ctx context.Context, brokerID uuid.UUID) {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	s.mutex.Lock()
+	w, ok := s.watches[brokerID]
+	delete(s.watches, brokerID)
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	for _, subscriptionID := range w.subscriptions {
+		if err := s.bgs.Unsubscribe(ctx, brokerID, subscriptionID); err != nil {
+			log.Printf("messageLogService.unwatch: broker %s, %s", brokerID, err)
+		}
+	}
+}
+
+func (s *messageLogService) handler(brokerID uuid.UUID, filter string) domain.BridgeHandler {
+	return func(message *domain.BridgeMessage) {
+		s.mutex.Lock()
+		messages := []*domain.DeviceMessage{}
+		if w, ok := s.watches[brokerID]; ok {
+			recorded := map[uuid.UUID]bool{}
+			for _, c := range w.controls[filter] {
+				if recorded[c.DeviceID] || firstMatch(w.deviceFilters[c.DeviceID], message.Topic) != filter {
+					continue
+				}
+
+				recorded[c.DeviceID] = true
+				messages = append(messages, s.message(message, c.DeviceID, c.ID, enum.MessageInbound))
+			}
+		}
+		s.mutex.Unlock()
+
+		for _, m := range messages {
+			if err := s.mr.Create(context.Background(), m); err != nil {
+				log.Printf("messageLogService.handle: device %s, %s", m.DeviceID, err)
+			}
+		}
+	}
+}
+
+func (s *messageLogService) onEvent(ctx context.Context, userID uuid.UUID, event domain.Event) {
+	if !s.enabled() || event.Data.Entity == nil || presenceAction(event.Data.Action) {
+		return
+	}
+
+	entity := event.Data.Entity
+	if entity.Name != enum.BrokersEntity && entity.Name != enum.DevicesEntity && entity.Name != enum.DeviceControlsEntity {
+		return
+	}
+
+	if entity.Name == enum.BrokersEntity && event.Data.Action == enum.EntityDeletedAction {
+		go s.unwatch(context.Background(), entity.ID)
+		return
+	}
+
+	// A device can be moved between the brokers, so every broker of the user already listened to is synced
+	brokerIDs := map[uuid.UUID]bool{}
+	if entity.Name == enum.BrokersEntity {
+		brokerIDs[entity.ID] = true
+	}
+
+	if event.Data.Related != nil {
+		for _, related := range *event.Data.Related {
+			if related.Name == enum.BrokersEntity && related.ID != uuid.Nil {
+				brokerIDs[related.ID] = true
+			}
+		}
+	}
+
+	s.mutex.Lock()
+	for brokerID, w := range s.watches {
+		if w.userID == userID {
+			brokerIDs[brokerID] = true
+		}
+	}
+	s.mutex.Unlock()
+
+	go func() {
+		for brokerID := range brokerIDs {
+			if err := s.sync(context.Background(), userID, brokerID); err != nil {
+				log.Printf("messageLogService.onEvent: broker %s, %s", brokerID, err)
+			}
+		}
+	}()
+}
+
+func (s *messageLogService) enabled() bool {
+	return s.c.MessageLog != nil && s.c.MessageLog.Enabled
+}
+
+func (s *messageLogService) retention() time.Duration {
+	if s.c.MessageLog.RetentionHours > 0 {
+		return time.Duration(s.c.MessageLog.RetentionHours) * time.Hour
+	}
+
+	return defaultMessageLogRetention
+}
+
+func (s *messageLogService) limit() int {
+	if s.c.MessageLog.MaxPerDevice > 0 {
+		return int(s.c.MessageLog.MaxPerDevice)
+	}
+
+	return defaultMessageLogLimit
+}
+
+func hasDevice(controls []*domain.ControlTopic, deviceID uuid.UUID) bool {
+	for _, c := range controls {
+		if c.DeviceID == deviceID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func firstMatch(filters []string, topic string) string {
+	for _, filter := range filters {
+		if mqtt.Match(filter, topic) {
+			return filter
+		}
+	}
+
+	return ""
+}
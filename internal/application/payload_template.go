@@ -0,0 +1,326 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+	"github.com/google/uuid"
+)
+
+// payloadTemplateMaxBytes limits the size of the rendered payloads.
+const payloadTemplateMaxBytes = 64 * 1024
+
+var errPayloadTemplateTooLarge = fmt.Errorf("payload is larger than %d bytes", payloadTemplateMaxBytes)
+
+func init() {
+	jsonschema.RegisterFormat("template", func(v string) bool {
+		_, err := parsePayloadTemplate(v, "")
+		return err == nil
+	})
+}
+
+// PayloadTemplateData is the dot of the payload templates. The value is the one set by the user, a number,
+// a string or, for the date-time controls, the time, while the value function returns it as it is published.
+type PayloadTemplateData struct {
+	Value     interface{}
+	Timestamp time.Time
+	Device    PayloadTemplateDevice
+	Control   PayloadTemplateControl
+}
+
+type PayloadTemplateDevice struct {
+	ID       uuid.UUID
+	Name     string
+	Placing  string
+	BasePath string
+}
+
+type PayloadTemplateControl struct {
+	ID    uuid.UUID
+	Name  string
+	Topic string
+}
+
+// parsePayloadTemplate parses the template in the sandbox, the templates can not loop, call functions
+// or define other templates and only the built-in functions of text/template and the helpers are available.
+func parsePayloadTemplate(text string, value string) (*template.Template, error) {
+	t, err := template.New("payload").Option("missingkey=error").Funcs(payloadTemplateFuncs(value)).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(t.Templates()) > 1 {
+		return nil, errors.New("templates can not be defined")
+	}
+
+	if err := checkTemplateNode(t.Tree.Root); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// renderPayloadTemplate renders the payload template of the attributes, the value is published
+// with the {{ value }} placeholder as the text and is available to the template as .Value.
+func renderPayloadTemplate(a domain.ControlAttributes, text string, value interface{}, pc *domain.PayloadContext) ([]byte, error) {
+	t, err := parsePayloadTemplate(a["payloadTemplate"].(string), text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ae.ErrControlAttributesInvalid, err.Error())
+	}
+
+	data := &PayloadTemplateData{Value: value, Timestamp: time.Now().UTC()}
+	if pc != nil {
+		data.Device = PayloadTemplateDevice{ID: pc.DeviceID, Name: pc.DeviceName, Placing: stringValue(pc.Placing), BasePath: stringValue(pc.BasePath)}
+		data.Control = PayloadTemplateControl{ID: pc.ControlID, Name: pc.ControlName, Topic: pc.Topic}
+		if !pc.Timestamp.IsZero() {
+			data.Timestamp = pc.Timestamp
+		}
+	}
+
+	w := &limitedBuffer{}
+	if err := t.Execute(w, data); err != nil {
+		return nil, fmt.Errorf("%w: %s", ae.ErrControlValueInvalid, err.Error())
+	}
+
+	return w.Bytes(), nil
+}
+
+func checkTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case nil, *parse.TextNode, *parse.CommentNode:
+		return nil
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkTemplateNode(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.ActionNode:
+		return checkTemplatePipe(n.Pipe)
+	case *parse.IfNode:
+		return checkTemplateBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkTemplateBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return errors.New("range is not allowed")
+	case *parse.TemplateNode:
+		return errors.New("templates can not be included")
+	}
+
+	return fmt.Errorf("%s is not allowed", node)
+}
+
+func checkTemplateBranch(n *parse.BranchNode) error {
+	if err := checkTemplatePipe(n.Pipe); err != nil {
+		return err
+	}
+	if err := checkTemplateNode(n.List); err != nil {
+		return err
+	}
+
+	return checkTemplateNode(n.ElseList)
+}
+
+func checkTemplatePipe(p *parse.PipeNode) error {
+	if p == nil {
+		return nil
+	}
+
+	for _, c := range p.Cmds {
+		for _, arg := range c.Args {
+			switch a := arg.(type) {
+			case *parse.IdentifierNode:
+				if a.Ident == "call" {
+					return errors.New("call is not allowed")
+				}
+			case *parse.PipeNode:
+				if err := checkTemplatePipe(a); err != nil {
+					return err
+				}
+			case *parse.ChainNode:
+				if p, ok := a.Node.(*parse.PipeNode); ok {
+					if err := checkTemplatePipe(p); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// limitedBuffer fails the template once the payload is over the limit, which also stops the long templates.
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > payloadTemplateMaxBytes {
+		return 0, errPayloadTemplateTooLarge
+	}
+
+	return b.Buffer.Write(p)
+}
+
+func payloadTemplateFuncs(value string) template.FuncMap {
+	return template.FuncMap{
+		"value": func() string { return value },
+		"hex": func(color string) (string, error) {
+			r, g, b, err := parseHexColor(color)
+			return fmt.Sprintf("#%02x%02x%02x", r, g, b), err
+		},
+		"rgb": func(color string) (string, error) {
+			r, g, b, err := parseHexColor(color)
+			return fmt.Sprintf("%d,%d,%d", r, g, b), err
+		},
+		"hsl": func(color string) (string, error) {
+			r, g, b, err := parseHexColor(color)
+			h, s, l := rgbToHSL(r, g, b)
+			return fmt.Sprintf("%d,%d,%d", h, s, l), err
+		},
+		"hsv": func(color string) (string, error) {
+			r, g, b, err := parseHexColor(color)
+			h, s, v := rgbToHSV(r, g, b)
+			return fmt.Sprintf("%d,%d,%d", h, s, v), err
+		},
+		"ticks": func(v interface{}) (int64, error) {
+			t, err := templateTime(v)
+			return t.UnixNano()/100 + ticksAtUnixEpoch, err
+		},
+		"unix": func(v interface{}) (int64, error) {
+			t, err := templateTime(v)
+			return t.Unix(), err
+		},
+		"unixMilli": func(v interface{}) (int64, error) {
+			t, err := templateTime(v)
+			return t.UnixMilli(), err
+		},
+		"formatTime": func(layout string, v interface{}) (string, error) {
+			t, err := templateTime(v)
+			return t.Format(layout), err
+		},
+		"round": func(places int, v interface{}) (string, error) {
+			n, err := templateNumber(v)
+			p := math.Pow10(places)
+			return formatNumber(math.Round(n*p) / p), err
+		},
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+	}
+}
+
+func parseHexColor(color string) (uint8, uint8, uint8, error) {
+	s := strings.TrimPrefix(color, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if len(s) != 6 || err != nil {
+		return 0, 0, 0, fmt.Errorf("%q is not a hex color", color)
+	}
+
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), nil
+}
+
+// rgbToHSL returns the hue in degrees and the saturation and the lightness in percents.
+func rgbToHSL(r, g, b uint8) (int, int, int) {
+	h, maxC, minC := hue(r, g, b)
+
+	l := (maxC + minC) / 2
+	s := 0.0
+	if d := maxC - minC; d > 0 {
+		s = d / (1 - math.Abs(2*l-1))
+	}
+
+	return int(math.Round(h)), int(math.Round(s * 100)), int(math.Round(l * 100))
+}
+
+// rgbToHSV returns the hue in degrees and the saturation and the value in percents.
+func rgbToHSV(r, g, b uint8) (int, int, int) {
+	h, maxC, minC := hue(r, g, b)
+
+	s := 0.0
+	if maxC > 0 {
+		s = (maxC - minC) / maxC
+	}
+
+	return int(math.Round(h)), int(math.Round(s * 100)), int(math.Round(maxC * 100))
+}
+
+func hue(r, g, b uint8) (float64, float64, float64) {
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+	maxC, minC := math.Max(rf, math.Max(gf, bf)), math.Min(rf, math.Min(gf, bf))
+
+	d := maxC - minC
+	h := 0.0
+	switch {
+	case d == 0:
+	case maxC == rf:
+		h = math.Mod((gf-bf)/d, 6)
+	case maxC == gf:
+		h = (bf-rf)/d + 2
+	default:
+		h = (rf-gf)/d + 4
+	}
+
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+
+	return h, maxC, minC
+}
+
+func templateTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, t)
+	}
+
+	return time.Time{}, fmt.Errorf("%v is not a time", v)
+}
+
+func templateNumber(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(n), 64)
+	}
+
+	return 0, fmt.Errorf("%v is not a number", v)
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+
+	return *v
+}
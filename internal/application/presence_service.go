@@ -0,0 +1,588 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPresenceInterval = 15 * time.Second
+	// presenceSaveInterval limits how often the last seen time of an online device is written.
+	presenceSaveInterval = time.Minute
+)
+
+// PresenceService tracks whether the devices with the availability are online. The topics are listened to
+// through the server-side connections of the brokers, the status and the last seen time are kept on the
+// devices, every change of the status is recorded in the history and published as a DEVICE_ONLINE
+// or DEVICE_OFFLINE event.
+type PresenceService interface {
+	Start(ctx context.Context)
+	Get(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) (*domain.DeviceAvailability, error)
+	Set(ctx context.Context, availability *domain.SetDeviceAvailability) error
+	Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error
+	ListTransitions(ctx context.Context, userID uuid.UUID, filters *domain.ListAvailabilityTransitionFilters) (*domain.List[*domain.AvailabilityTransition], error)
+}
+
+type presenceDevice struct {
+	*domain.TrackedDevice
+	// since is when the device started to be tracked, the heartbeat timeout is counted from it
+	// until the first message, as the messages sent earlier could not have been received.
+	since   time.Time
+	savedAt time.Time
+}
+
+type presenceWatch struct {
+	userID        uuid.UUID
+	subscriptions map[string]uuid.UUID
+	devices       map[string][]uuid.UUID
+}
+
+type presenceService struct {
+	c         *config.Config
+	ar        repository.DeviceAvailabilityRepository
+	br        repository.BrokerRepository
+	dcr       repository.DeviceControlRepository
+	ds        DeviceService
+	bgs       BridgeService
+	es        EventService
+	watches   map[uuid.UUID]*presenceWatch
+	devices   map[uuid.UUID]*presenceDevice
+	mutex     sync.Mutex
+	syncMutex sync.Mutex
+}
+
+func NewPresenceService(
+	c *config.Config,
+	ar repository.DeviceAvailabilityRepository,
+	br repository.BrokerRepository,
+	dcr repository.DeviceControlRepository,
+	ds DeviceService,
+	bgs BridgeService,
+	es EventService) PresenceService {
+	s := &presenceService{
+		c:       c,
+		ar:      ar,
+		br:      br,
+		dcr:     dcr,
+		ds:      ds,
+		bgs:     bgs,
+		es:      es,
+		watches: make(map[uuid.UUID]*presenceWatch),
+		devices: make(map[uuid.UUID]*presenceDevice),
+	}
+
+	es.Listen(s.onEvent)
+
+	return s
+}
+
+// Start listens to the topics of the tracked devices of every broker and checks the heartbeats every interval,
+// the brokers which could not be connected to are retried with the next interval.
+func (s *presenceService) Start(ctx context.Context) {
+	if !s.enabled() {
+		return
+	}
+
+	interval := defaultPresenceInterval
+	if s.c.Presence.IntervalSeconds > 0 {
+		interval = time.Duration(s.c.Presence.IntervalSeconds) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.syncAll(ctx)
+			s.checkHeartbeats(ctx, time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *presenceService) Get(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) (*domain.DeviceAvailability, error) {
+	if _, err := s.ds.Get(ctx, deviceID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.ar.Get(ctx, deviceID)
+}
+
+func (s *presenceService) Set(ctx context.Context, availability *domain.SetDeviceAvailability) error {
+	device, err := s.ds.Get(ctx, availability.DeviceID, availability.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateAvailability(availability); err != nil {
+		return err
+	}
+
+	if err := s.ar.Set(ctx, availability); err != nil {
+		return err
+	}
+
+	s.es.PublishDevices(ctx, enum.EntityUpdatedAction, availability.UserID, device.BrokerID.UUID, device.ID)
+
+	return nil
+}
+
+// Delete stops the tracking of the device, its status is unknown again while the history is kept.
+func (s *presenceService) Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error {
+	device, err := s.ds.Get(ctx, deviceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ar.Delete(ctx, deviceID); err != nil {
+		return err
+	}
+
+	if err := s.ar.SetStatus(ctx, deviceID, enum.DeviceUnknown, nil); err != nil {
+		return err
+	}
+
+	s.es.PublishDevices(ctx, enum.EntityUpdatedAction, userID, device.BrokerID.UUID, deviceID)
+
+	return nil
+}
+
+func (s *presenceService) ListTransitions(ctx context.Context, userID uuid.UUID, filters *domain.ListAvailabilityTransitionFilters) (*domain.List[*domain.AvailabilityTransition], error) {
+	if _, err := s.ds.Get(ctx, filters.DeviceID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.ar.ListTransitions(ctx, filters)
+}
+
+// AvailabilityStatus returns the status of the device which received the payload, it is false when the payload
+// does not change it. The lwt devices are online or offline with their payloads and the heartbeat devices
+// are online with any message.
+func AvailabilityStatus(a *domain.DeviceAvailability, payload []byte) (enum.DeviceStatus, bool) {
+	if a.Mode == enum.AvailabilityHeartbeat {
+		return enum.DeviceOnline, true
+	}
+
+	p := bytes.TrimSpace(payload)
+	if a.OnlinePayload != nil && bytes.Equal(p, bytes.TrimSpace([]byte(*a.OnlinePayload))) {
+		return enum.DeviceOnline, true
+	}
+
+	if a.OfflinePayload != nil && bytes.Equal(p, bytes.TrimSpace([]byte(*a.OfflinePayload))) {
+		return enum.DeviceOffline, true
+	}
+
+	return "", false
+}
+
+// validateAvailability checks the fields required by the mode and clears the ones of the other mode.
+func validateAvailability(a *domain.SetDeviceAvailability) error {
+	fields := []*ae.FieldError{}
+	require := func(field string, set bool) {
+		if !set {
+			fields = append(fields, &ae.FieldError{Field: field, Rule: "required_if", Param: "mode " + string(a.Mode), Message: "is required"})
+		}
+	}
+
+	switch a.Mode {
+	case enum.AvailabilityLWT:
+		require("topic", a.Topic != nil && *a.Topic != "")
+		require("onlinePayload", a.OnlinePayload != nil)
+		require("offlinePayload", a.OfflinePayload != nil)
+		a.TimeoutSeconds = nil
+
+		if a.OnlinePayload != nil && a.OfflinePayload != nil &&
+			bytes.Equal(bytes.TrimSpace([]byte(*a.OnlinePayload)), bytes.TrimSpace([]byte(*a.OfflinePayload))) {
+			fields = append(fields, &ae.FieldError{Field: "offlinePayload", Rule: "nefield", Param: "onlinePayload", Message: "should differ from the online payload"})
+		}
+	case enum.AvailabilityHeartbeat:
+		require("timeoutSeconds", a.TimeoutSeconds != nil)
+		a.OnlinePayload, a.OfflinePayload = nil, nil
+	}
+
+	if a.Topic != nil && *a.Topic == "" {
+		a.Topic = nil
+	}
+
+	if a.Topic != nil && !mqtt.ValidFilter(*a.Topic) {
+		fields = append(fields, &ae.FieldError{Field: "topic", Rule: "topic_filter", Message: "should be a valid MQTT topic filter"})
+	}
+
+	if len(fields) > 0 {
+		return &ae.ValidationError{Err: ae.ErrValidation, Fields: fields}
+	}
+
+	return nil
+}
+
+func (s *presenceService) syncAll(ctx context.Context) {
+	brokers, err := s.br.ListAll(ctx)
+	if err != nil {
+		log.Printf("presenceService.syncAll: %s", err)
+		return
+	}
+
+	listed := make(map[uuid.UUID]bool, len(brokers))
+	for _, broker := range brokers {
+		listed[broker.ID] = true
+
+		if err := s.sync(ctx, broker.UserID, broker.ID); err != nil {
+			log.Printf("presenceService.syncAll: broker %s, %s", broker.ID, err)
+		}
+	}
+
+	s.mutex.Lock()
+	stale := []uuid.UUID{}
+	for brokerID := range s.watches {
+		if !listed[brokerID] {
+			stale = append(stale, brokerID)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, brokerID := range stale {
+		s.unwatch(ctx, brokerID)
+	}
+}
+
+// sync subscribes to the topics of the tracked devices of the broker and drops the subscriptions
+// which are not used anymore. The heartbeat devices without a topic are seen on the topics of their controls.
+func (s *presenceService) sync(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) error {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	tracked, err := s.ar.ListTracked(ctx, brokerID)
+	if err != nil {
+		return err
+	}
+
+	var topics []*domain.ControlTopic
+	for _, d := range tracked {
+		if d.Topic == nil && topics == nil {
+			if topics, err = s.dcr.ListTopics(ctx, brokerID); err != nil {
+				return err
+			}
+		}
+	}
+
+	devices := map[string][]uuid.UUID{}
+	add := func(deviceID uuid.UUID, filter string) {
+		if !mqtt.ValidFilter(filter) {
+			return
+		}
+
+		for _, id := range devices[filter] {
+			if id == deviceID {
+				return
+			}
+		}
+		devices[filter] = append(devices[filter], deviceID)
+	}
+
+	for _, d := range tracked {
+		if d.Topic != nil {
+			add(d.DeviceID, controlTopic(d.BasePath, *d.Topic))
+			continue
+		}
+
+		for _, t := range topics {
+			if t.DeviceID == d.DeviceID {
+				add(d.DeviceID, controlTopic(t.BasePath, t.Topic))
+			}
+		}
+	}
+
+	now := time.Now()
+
+	s.mutex.Lock()
+	listed := make(map[uuid.UUID]bool, len(tracked))
+	for _, d := range tracked {
+		listed[d.DeviceID] = true
+
+		// The state of the devices already tracked is kept, as it is newer than the one which was read
+		if p, ok := s.devices[d.DeviceID]; ok {
+			p.DeviceAvailability, p.UserID, p.BrokerID, p.BasePath = d.DeviceAvailability, d.UserID, d.BrokerID, d.BasePath
+			continue
+		}
+
+		s.devices[d.DeviceID] = &presenceDevice{TrackedDevice: d, since: now, savedAt: now}
+	}
+
+	for deviceID, p := range s.devices {
+		if p.BrokerID == brokerID && !listed[deviceID] {
+			delete(s.devices, deviceID)
+		}
+	}
+
+	w, ok := s.watches[brokerID]
+	if !ok {
+		w = &presenceWatch{userID: userID, subscriptions: map[string]uuid.UUID{}}
+		s.watches[brokerID] = w
+	}
+	w.devices = devices
+
+	subscribed := make(map[string]uuid.UUID, len(w.subscriptions))
+	for filter, subscriptionID := range w.subscriptions {
+		subscribed[filter] = subscriptionID
+	}
+	s.mutex.Unlock()
+
+	for filter, subscriptionID := range subscribed {
+		if _, ok := devices[filter]; ok {
+			continue
+		}
+
+		if err := s.bgs.Unsubscribe(ctx, brokerID, subscriptionID); err != nil {
+			log.Printf("presenceService.sync: broker %s, %s", brokerID, err)
+		}
+
+		s.mutex.Lock()
+		delete(w.subscriptions, filter)
+		s.mutex.Unlock()
+	}
+
+	for filter := range devices {
+		if _, ok := subscribed[filter]; ok {
+			continue
+		}
+
+		// The topics can be shared with the message log, which would be downgraded by a lower QoS
+		subscriptionID, err := s.bgs.Subscribe(ctx, userID, brokerID, filter, enum.QoSTwo, s.handler(brokerID, filter))
+		if err != nil {
+			return err
+		}
+
+		s.mutex.Lock()
+		w.subscriptions[filter] = subscriptionID
+		s.mutex.Unlock()
+	}
+
+	if len(devices) == 0 {
+		s.mutex.Lock()
+		delete(s.watches, brokerID)
+		s.mutex.Unlock()
+	}
+
+	return nil
+}
+
+func (s *presenceService) unwatch(ctx context.Context, brokerID uuid.UUID) {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	s.mutex.Lock()
+	w, ok := s.watches[brokerID]
+	delete(s.watches, brokerID)
+	for deviceID, p := range s.devices {
+		if p.BrokerID == brokerID {
+			delete(s.devices, deviceID)
+		}
+	}
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	for _, subscriptionID := range w.subscriptions {
+		if err := s.bgs.Unsubscribe(ctx, brokerID, subscriptionID); err != nil {
+			log.Printf("presenceService.unwatch: broker %s, %s", brokerID, err)
+		}
+	}
+}
+
+func (s *presenceService) handler(brokerID uuid.UUID, filter string) domain.BridgeHandler {
+	return func(message *domain.BridgeMessage) {
+		s.mutex.Lock()
+		deviceIDs := []uuid.UUID{}
+		if w, ok := s.watches[brokerID]; ok {
+			deviceIDs = append(deviceIDs, w.devices[filter]...)
+		}
+		s.mutex.Unlock()
+
+		for _, deviceID := range deviceIDs {
+			s.seen(context.Background(), deviceID, message)
+		}
+	}
+}
+
+// seen updates the device with the message. The retained messages are not heartbeats, as they could
+// have been published long ago, while the retained last will payloads are the current status.
+func (s *presenceService) seen(ctx context.Context, deviceID uuid.UUID, message *domain.BridgeMessage) {
+	s.mutex.Lock()
+	d, ok := s.devices[deviceID]
+	if !ok || (message.Retained && d.Mode == enum.AvailabilityHeartbeat) {
+		s.mutex.Unlock()
+		return
+	}
+
+	status, ok := AvailabilityStatus(&d.DeviceAvailability, message.Payload)
+	if !ok {
+		s.mutex.Unlock()
+		return
+	}
+
+	at := message.ReceivedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	var lastSeenAt *time.Time
+	if status == enum.DeviceOnline {
+		lastSeenAt = &at
+		d.LastSeenAt = lastSeenAt
+	}
+
+	changed := d.Status != status
+	save := !changed && lastSeenAt != nil && at.Sub(d.savedAt) >= presenceSaveInterval
+	if changed || save {
+		d.savedAt = at
+	}
+	d.Status = status
+
+	reason := enum.AvailabilityMessage
+	if d.Mode == enum.AvailabilityLWT {
+		reason = enum.AvailabilityPayload
+	}
+
+	userID, brokerID := d.UserID, d.BrokerID
+	s.mutex.Unlock()
+
+	if changed {
+		s.transition(ctx, userID, brokerID, deviceID, status, reason, lastSeenAt, at)
+	} else if save {
+		if err := s.ar.SetLastSeen(ctx, deviceID, at); err != nil {
+			log.Printf("presenceService.seen: device %s, %s", deviceID, err)
+		}
+	}
+}
+
+// checkHeartbeats marks the heartbeat devices which have not been seen within their timeout as offline.
+func (s *presenceService) checkHeartbeats(ctx context.Context, now time.Time) {
+	type expired struct {
+		userID, brokerID, deviceID uuid.UUID
+	}
+
+	s.mutex.Lock()
+	devices := []expired{}
+	for deviceID, d := range s.devices {
+		if d.Mode != enum.AvailabilityHeartbeat || d.TimeoutSeconds == nil || d.Status == enum.DeviceOffline {
+			continue
+		}
+
+		last := d.since
+		if d.LastSeenAt != nil && d.LastSeenAt.After(last) {
+			last = *d.LastSeenAt
+		}
+
+		if now.Sub(last) < time.Duration(*d.TimeoutSeconds)*time.Second {
+			continue
+		}
+
+		d.Status = enum.DeviceOffline
+		devices = append(devices, expired{d.UserID, d.BrokerID, deviceID})
+	}
+	s.mutex.Unlock()
+
+	for _, d := range devices {
+		s.transition(ctx, d.userID, d.brokerID, d.deviceID, enum.DeviceOffline, enum.AvailabilityTimeout, nil, now)
+	}
+}
+
+func (s *presenceService) transition(
+	ctx context.Context,
+	userID uuid.UUID,
+	brokerID uuid.UUID,
+	deviceID uuid.UUID,
+	status enum.DeviceStatus,
+	reason enum.AvailabilityReason,
+	lastSeenAt *time.Time,
+	at time.Time) {
+	if err := s.ar.SetStatus(ctx, deviceID, status, lastSeenAt); err != nil {
+		log.Printf("presenceService.transition: device %s, %s", deviceID, err)
+		return
+	}
+
+	transition := &domain.AvailabilityTransition{DeviceID: deviceID, Status: status, Reason: reason, CreatedAt: at}
+	if err := s.ar.CreateTransition(ctx, transition); err != nil {
+		log.Printf("presenceService.transition: device %s, %s", deviceID, err)
+	}
+
+	action := enum.DeviceOfflineAction
+	if status == enum.DeviceOnline {
+		action = enum.DeviceOnlineAction
+	}
+
+	s.es.PublishDevices(ctx, action, userID, brokerID, deviceID)
+}
+
+func (s *presenceService) onEvent(ctx context.Context, userID uuid.UUID, event domain.Event) {
+	if !s.enabled() || event.Data.Entity == nil || presenceAction(event.Data.Action) {
+		return
+	}
+
+	entity := event.Data.Entity
+	if entity.Name != enum.BrokersEntity && entity.Name != enum.DevicesEntity && entity.Name != enum.DeviceControlsEntity {
+		return
+	}
+
+	if entity.Name == enum.BrokersEntity && event.Data.Action == enum.EntityDeletedAction {
+		go s.unwatch(context.Background(), entity.ID)
+		return
+	}
+
+	// A device can be moved between the brokers, so every broker of the user already listened to is synced
+	brokerIDs := map[uuid.UUID]bool{}
+	if entity.Name == enum.BrokersEntity {
+		brokerIDs[entity.ID] = true
+	}
+
+	if event.Data.Related != nil {
+		for _, related := range *event.Data.Related {
+			if related.Name == enum.BrokersEntity && related.ID != uuid.Nil {
+				brokerIDs[related.ID] = true
+			}
+		}
+	}
+
+	s.mutex.Lock()
+	for brokerID, w := range s.watches {
+		if w.userID == userID {
+			brokerIDs[brokerID] = true
+		}
+	}
+	s.mutex.Unlock()
+
+	go func() {
+		for brokerID := range brokerIDs {
+			if err := s.sync(context.Background(), userID, brokerID); err != nil {
+				log.Printf("presenceService.onEvent: broker %s, %s", brokerID, err)
+			}
+		}
+	}()
+}
+
+func (s *presenceService) enabled() bool {
+	return s.c.Presence != nil && s.c.Presence.Enabled
+}
+
+// presenceAction tells the events of the status of the devices, which do not change the topics listened to.
+func presenceAction(action enum.EventAction) bool {
+	return action == enum.DeviceOnlineAction || action == enum.DeviceOfflineAction
+}
@@ -0,0 +1,42 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/go-playground/assert"
+)
+
+func TestAvailabilityStatus(t *testing.T) {
+	online, offline := "online", "offline"
+	lwt := &domain.DeviceAvailability{Mode: enum.AvailabilityLWT, OnlinePayload: &online, OfflinePayload: &offline}
+
+	t.Run("should follow the payloads of the last will", func(t *testing.T) {
+		for _, c := range []struct {
+			payload string
+			status  enum.DeviceStatus
+			ok      bool
+		}{
+			{"online", enum.DeviceOnline, true},
+			{"offline\n", enum.DeviceOffline, true},
+			{"Online", "", false},
+			{"", "", false},
+		} {
+			status, ok := application.AvailabilityStatus(lwt, []byte(c.payload))
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.status, status)
+		}
+	})
+
+	t.Run("should be online with any heartbeat", func(t *testing.T) {
+		heartbeat := &domain.DeviceAvailability{Mode: enum.AvailabilityHeartbeat}
+
+		for _, payload := range []string{"offline", "", `{"temp":21}`} {
+			status, ok := application.AvailabilityStatus(heartbeat, []byte(payload))
+			assert.Equal(t, true, ok)
+			assert.Equal(t, enum.DeviceOnline, status)
+		}
+	})
+}
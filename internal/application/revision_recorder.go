@@ -0,0 +1,221 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// RevisionRecorder stores the snapshots of the changed brokers, devices and controls as their revisions,
+// a device is recorded along with its controls so a change of the controls is a revision of the device too.
+type RevisionRecorder interface {
+	RecordBroker(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) error
+	RecordDevice(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID) error
+	RecordControl(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) error
+	Defer(ctx context.Context) (context.Context, func(ctx context.Context) error)
+}
+
+type revisionRecorder struct {
+	rvr repository.RevisionRepository
+	br  repository.BrokerRepository
+	dr  repository.DeviceRepository
+	dcr repository.DeviceControlRepository
+}
+
+func NewRevisionRecorder(
+	rvr repository.RevisionRepository,
+	br repository.BrokerRepository,
+	dr repository.DeviceRepository,
+	dcr repository.DeviceControlRepository) RevisionRecorder {
+	return &revisionRecorder{rvr, br, dr, dcr}
+}
+
+type deferredRevision struct {
+	entity   enum.RevisionEntity
+	userID   uuid.UUID
+	deviceID uuid.UUID
+	id       uuid.UUID
+}
+
+type deferredRevisions struct {
+	revisions []deferredRevision
+	mutex     sync.Mutex
+}
+
+type deferredRevisionsKey struct{}
+
+func (r *revisionRecorder) RecordBroker(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) error {
+	if r.deferred(ctx, deferredRevision{enum.RevisionBroker, userID, uuid.Nil, brokerID}) {
+		return nil
+	}
+
+	broker, err := r.br.Get(ctx, brokerID, userID)
+	if err != nil {
+		return err
+	}
+
+	return r.rvr.Create(ctx, enum.RevisionBroker, brokerID, &domain.BrokerSnapshot{
+		Name:                broker.Name,
+		Server:              broker.Server,
+		Port:                broker.Port,
+		KeepAlive:           broker.KeepAlive,
+		IconName:            broker.IconName,
+		IconBackgroundColor: broker.IconBackgroundColor,
+		IsSSL:               broker.IsSSL,
+		DiscoveryMode:       broker.DiscoveryMode,
+		DiscoveryPrefix:     broker.DiscoveryPrefix,
+		ProtocolVersion:     broker.ProtocolVersion,
+		Transport:           broker.Transport,
+		Path:                stringPtr(broker.Path.String, broker.Path.Null || !broker.Path.Set),
+		CleanStart:          broker.CleanStart,
+		SessionExpiry:       broker.SessionExpiry,
+		UserProperties:      broker.UserProperties,
+	})
+}
+
+func (r *revisionRecorder) RecordDevice(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID) error {
+	if r.deferred(ctx, deferredRevision{enum.RevisionDevice, userID, deviceID, deviceID}) {
+		return nil
+	}
+
+	device, err := r.dr.Get(ctx, deviceID, userID)
+	if err != nil {
+		return err
+	}
+
+	controls, err := r.dcr.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	snapshot := &domain.DeviceSnapshot{
+		Name:                device.Name,
+		IconName:            device.IconName,
+		IconBackgroundColor: device.IconBackgroundColor,
+		Placing:             device.Placing,
+		BasePath:            device.BasePath,
+		Controls:            make([]*domain.ControlSnapshot, len(controls)),
+	}
+
+	if device.BrokerID.Valid {
+		snapshot.BrokerID = &device.BrokerID.UUID
+	}
+
+	if device.RoomID.Valid {
+		snapshot.RoomID = &device.RoomID.UUID
+	}
+
+	for i, control := range controls {
+		snapshot.Controls[i] = controlSnapshot(control)
+	}
+
+	return r.rvr.Create(ctx, enum.RevisionDevice, deviceID, snapshot)
+}
+
+func (r *revisionRecorder) RecordControl(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) error {
+	if r.deferred(ctx, deferredRevision{enum.RevisionControl, userID, deviceID, controlID}) {
+		return nil
+	}
+
+	controls, err := r.dcr.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	for _, control := range controls {
+		if control.ID == controlID {
+			return r.rvr.Create(ctx, enum.RevisionControl, controlID, controlSnapshot(control))
+		}
+	}
+
+	return ae.ErrDeviceControlNotFound
+}
+
+// Defer holds back the revisions recorded with the returned context until flush is called, so the entity
+// changed a few times in a transaction gets a single revision. Flush has to be called inside the transaction.
+func (r *revisionRecorder) Defer(ctx context.Context) (context.Context, func(ctx context.Context) error) {
+	d := &deferredRevisions{}
+
+	flush := func(ctx context.Context) error {
+		d.mutex.Lock()
+		revisions := d.revisions
+		d.revisions = nil
+		d.mutex.Unlock()
+
+		ctx = context.WithValue(ctx, deferredRevisionsKey{}, nil)
+
+		for _, rev := range revisions {
+			var err error
+			switch rev.entity {
+			case enum.RevisionBroker:
+				err = r.RecordBroker(ctx, rev.userID, rev.id)
+			case enum.RevisionDevice:
+				err = r.RecordDevice(ctx, rev.userID, rev.id)
+			case enum.RevisionControl:
+				err = r.RecordControl(ctx, rev.userID, rev.deviceID, rev.id)
+			}
+
+			// The entity could have been deleted later in the transaction.
+			if err != nil && !errors.Is(err, ae.ErrBrokerNotFound) && !errors.Is(err, ae.ErrDeviceNotFound) &&
+				!errors.Is(err, ae.ErrDeviceControlNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return context.WithValue(ctx, deferredRevisionsKey{}, d), flush
+}
+
+// deferred adds the revision to the deferred ones of the context, once for every entity.
+func (r *revisionRecorder) deferred(ctx context.Context, rev deferredRevision) bool {
+	d, ok := ctx.Value(deferredRevisionsKey{}).(*deferredRevisions)
+	if !ok || d == nil {
+		return false
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, v := range d.revisions {
+		if v.entity == rev.entity && v.id == rev.id {
+			return true
+		}
+	}
+
+	d.revisions = append(d.revisions, rev)
+
+	return true
+}
+
+func controlSnapshot(control *domain.DeviceControl) *domain.ControlSnapshot {
+	return &domain.ControlSnapshot{
+		ID:                     control.ID,
+		Name:                   control.Name,
+		Type:                   control.Type,
+		QoS:                    control.QoS,
+		IconName:               control.IconName,
+		IconBackgroundColor:    control.IconBackgroundColor,
+		IsAvailable:            control.IsAvailable,
+		IsConfirmationRequired: control.IsConfirmationRequired,
+		CanNotifyOnPublish:     control.CanNotifyOnPublish,
+		CanDisplayName:         control.CanDisplayName,
+		Topic:                  control.Topic,
+		Attributes:             control.Attributes,
+	}
+}
+
+func stringPtr(v string, null bool) *string {
+	if null {
+		return nil
+	}
+
+	return &v
+}
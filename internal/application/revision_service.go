@@ -0,0 +1,432 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+type RevisionService interface {
+	ListBroker(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) ([]*domain.Revision, error)
+	ListDevice(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID) ([]*domain.Revision, error)
+	ListControl(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) ([]*domain.Revision, error)
+	RestoreBroker(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, revision int, version *int64) error
+	RestoreDevice(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, revision int, version *int64) error
+	RestoreControl(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, revision int, version *int64) error
+}
+
+type revisionService struct {
+	tr  repository.Transactor
+	rvr repository.RevisionRepository
+	dcr repository.DeviceControlRepository
+	rec RevisionRecorder
+	bs  BrokerService
+	ds  DeviceService
+	dcs DeviceControlService
+	rs  RoomService
+	es  EventService
+}
+
+func NewRevisionService(
+	tr repository.Transactor,
+	rvr repository.RevisionRepository,
+	dcr repository.DeviceControlRepository,
+	rec RevisionRecorder,
+	bs BrokerService,
+	ds DeviceService,
+	dcs DeviceControlService,
+	rs RoomService,
+	es EventService) RevisionService {
+	return &revisionService{tr, rvr, dcr, rec, bs, ds, dcs, rs, es}
+}
+
+func (s *revisionService) ListBroker(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) ([]*domain.Revision, error) {
+	if _, err := s.bs.Get(ctx, brokerID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, enum.RevisionBroker, brokerID)
+}
+
+func (s *revisionService) ListDevice(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID) ([]*domain.Revision, error) {
+	if _, err := s.ds.Get(ctx, deviceID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, enum.RevisionDevice, deviceID)
+}
+
+func (s *revisionService) ListControl(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) ([]*domain.Revision, error) {
+	if _, err := s.control(ctx, userID, deviceID, controlID); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, enum.RevisionControl, controlID)
+}
+
+// RestoreBroker sets the settings of the broker back to the revision, the credentials are left as they are.
+func (s *revisionService) RestoreBroker(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, revision int, version *int64) error {
+	if _, err := s.bs.Get(ctx, brokerID, userID); err != nil {
+		return err
+	}
+
+	rev, err := s.rvr.Get(ctx, enum.RevisionBroker, brokerID, revision)
+	if err != nil {
+		return err
+	}
+
+	snapshot := &domain.BrokerSnapshot{}
+	if err := json.Unmarshal(rev.Snapshot, snapshot); err != nil {
+		return err
+	}
+
+	props := snapshot.UserProperties
+	if props == nil {
+		props = domain.BrokerUserProperties{}
+	}
+
+	return transaction(ctx, s.tr, s.rec, s.es, func(ctx context.Context) error {
+		return s.bs.Update(ctx, &domain.UpdateBroker{
+			ID:                  brokerID,
+			UserID:              userID,
+			Name:                t.NewString(snapshot.Name, false, true),
+			Server:              t.NewString(snapshot.Server, false, true),
+			Port:                t.NewUint16(snapshot.Port, false, true),
+			KeepAlive:           t.NewUint16(snapshot.KeepAlive, false, true),
+			IconName:            t.NewString(snapshot.IconName, false, true),
+			IconBackgroundColor: t.NewString(snapshot.IconBackgroundColor, false, true),
+			IsSSL:               t.NewBool(snapshot.IsSSL, false, true),
+			DiscoveryMode:       &snapshot.DiscoveryMode,
+			DiscoveryPrefix:     t.NewString(snapshot.DiscoveryPrefix, false, true),
+			ProtocolVersion:     &snapshot.ProtocolVersion,
+			Transport:           &snapshot.Transport,
+			Path:                stringPtrToNullable(snapshot.Path),
+			CleanStart:          t.NewBool(snapshot.CleanStart, false, true),
+			SessionExpiry:       &snapshot.SessionExpiry,
+			UserProperties:      props,
+			Version:             version,
+		})
+	})
+}
+
+// RestoreDevice sets the device and its control set back to the revision. The controls added since are deleted,
+// the deleted ones are restored from the trash and the ones purged already are created anew with a new ID.
+// The broker and the room which no longer exist are left unset.
+func (s *revisionService) RestoreDevice(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, revision int, version *int64) error {
+	if _, err := s.ds.Get(ctx, deviceID, userID); err != nil {
+		return err
+	}
+
+	rev, err := s.rvr.Get(ctx, enum.RevisionDevice, deviceID, revision)
+	if err != nil {
+		return err
+	}
+
+	snapshot := &domain.DeviceSnapshot{}
+	if err := json.Unmarshal(rev.Snapshot, snapshot); err != nil {
+		return err
+	}
+
+	return transaction(ctx, s.tr, s.rec, s.es, func(ctx context.Context) error {
+		device := &domain.UpdateDevice{
+			ID:                  deviceID,
+			UserID:              userID,
+			BrokerID:            t.NewNullable(uuid.Nil, true, true),
+			RoomID:              t.NewNullable(uuid.Nil, true, true),
+			Name:                t.NewString(snapshot.Name, false, true),
+			IconName:            t.NewString(snapshot.IconName, false, true),
+			IconBackgroundColor: t.NewString(snapshot.IconBackgroundColor, false, true),
+			Placing:             stringPtrToNullable(snapshot.Placing),
+			BasePath:            stringPtrToNullable(snapshot.BasePath),
+			Version:             version,
+		}
+
+		if snapshot.BrokerID != nil {
+			if _, err := s.bs.Get(ctx, *snapshot.BrokerID, userID); err == nil {
+				device.BrokerID = t.NewNullable(*snapshot.BrokerID, false, true)
+			} else if !errors.Is(err, ae.ErrBrokerNotFound) {
+				return err
+			}
+		}
+
+		if snapshot.RoomID != nil {
+			if _, err := s.rs.Get(ctx, *snapshot.RoomID, userID); err == nil {
+				device.RoomID = t.NewNullable(*snapshot.RoomID, false, true)
+			} else if !errors.Is(err, ae.ErrRoomNotFound) {
+				return err
+			}
+		}
+
+		if err := s.ds.Update(ctx, device); err != nil {
+			return err
+		}
+
+		return s.restoreControls(ctx, userID, deviceID, snapshot.Controls)
+	})
+}
+
+// RestoreControl sets the definition of the control back to the revision.
+func (s *revisionService) RestoreControl(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, revision int, version *int64) error {
+	if _, err := s.control(ctx, userID, deviceID, controlID); err != nil {
+		return err
+	}
+
+	rev, err := s.rvr.Get(ctx, enum.RevisionControl, controlID, revision)
+	if err != nil {
+		return err
+	}
+
+	snapshot := &domain.ControlSnapshot{}
+	if err := json.Unmarshal(rev.Snapshot, snapshot); err != nil {
+		return err
+	}
+
+	return transaction(ctx, s.tr, s.rec, s.es, func(ctx context.Context) error {
+		control := updateControl(deviceID, snapshot)
+		control.ID = controlID
+		control.Version = version
+
+		return s.dcs.Update(ctx, userID, control)
+	})
+}
+
+// restoreControls replaces the current control set of the device with the one of the snapshot.
+// The controls missing from the snapshot are deleted first, so a restored state control does not collide with them.
+func (s *revisionService) restoreControls(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controls []*domain.ControlSnapshot) error {
+	current, err := s.dcr.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	kept := map[uuid.UUID]bool{}
+	for _, control := range controls {
+		kept[control.ID] = true
+	}
+
+	existing := map[uuid.UUID]bool{}
+	for _, control := range current {
+		existing[control.ID] = true
+
+		if !kept[control.ID] {
+			if err := s.dcs.Delete(ctx, userID, deviceID, control.ID, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, control := range controls {
+		if !existing[control.ID] {
+			err := s.dcs.Restore(ctx, userID, deviceID, control.ID)
+			if errors.Is(err, ae.ErrDeviceControlNotFound) {
+				_, err = s.dcs.Create(ctx, userID, &domain.CreateDeviceControl{
+					DeviceID:               deviceID,
+					Name:                   control.Name,
+					Type:                   control.Type,
+					QoS:                    control.QoS,
+					IconName:               control.IconName,
+					IconBackgroundColor:    control.IconBackgroundColor,
+					IsAvailable:            control.IsAvailable,
+					IsConfirmationRequired: control.IsConfirmationRequired,
+					CanNotifyOnPublish:     control.CanNotifyOnPublish,
+					CanDisplayName:         control.CanDisplayName,
+					Topic:                  control.Topic,
+					Attributes:             control.Attributes,
+				})
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		update := updateControl(deviceID, control)
+		update.ID = control.ID
+
+		if err := s.dcs.Update(ctx, userID, update); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// transaction runs fn in a transaction, recording a single revision of every changed entity
+// and publishing the events once the transaction has been committed.
+func transaction(
+	ctx context.Context,
+	tr repository.Transactor,
+	rec RevisionRecorder,
+	es EventService,
+	fn func(ctx context.Context) error) error {
+	ctx, publish := es.Defer(ctx)
+	ctx, record := rec.Defer(ctx)
+
+	err := tr.Transaction(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		return record(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	publish()
+
+	return nil
+}
+
+// control checks that the control is one of the device of the user.
+func (s *revisionService) control(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID) (*domain.DeviceControl, error) {
+	if _, err := s.ds.Get(ctx, deviceID, userID); err != nil {
+		return nil, err
+	}
+
+	controls, err := s.dcr.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, control := range controls {
+		if control.ID == controlID {
+			return control, nil
+		}
+	}
+
+	return nil, ae.ErrDeviceControlNotFound
+}
+
+// list returns the revisions from the latest one along with their changes from the revision before.
+// The oldest revision kept has no changes.
+func (s *revisionService) list(ctx context.Context, entity enum.RevisionEntity, entityID uuid.UUID) ([]*domain.Revision, error) {
+	revisions, err := s.rvr.List(ctx, entity, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rev := range revisions {
+		rev.Changes = []*domain.RevisionChange{}
+		if i == len(revisions)-1 {
+			continue
+		}
+
+		var from, to interface{}
+		if err := json.Unmarshal(revisions[i+1].Snapshot, &from); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(rev.Snapshot, &to); err != nil {
+			return nil, err
+		}
+
+		rev.Changes = diffSnapshots("", from, to, rev.Changes)
+	}
+
+	return revisions, nil
+}
+
+// diffSnapshots appends the fields which differ between the snapshots, the nested fields are joined with dots
+// and the arrays of objects with an id are compared by the ids, like controls.<id>.name.
+func diffSnapshots(path string, from interface{}, to interface{}, changes []*domain.RevisionChange) []*domain.RevisionChange {
+	fromMap, fromOk := snapshotMap(from)
+	toMap, toOk := snapshotMap(to)
+
+	if !fromOk || !toOk {
+		if !reflect.DeepEqual(from, to) {
+			changes = append(changes, &domain.RevisionChange{Field: path, From: from, To: to})
+		}
+
+		return changes
+	}
+
+	keys := []string{}
+	for k := range fromMap {
+		keys = append(keys, k)
+	}
+	for k := range toMap {
+		if _, ok := fromMap[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		field := k
+		if path != "" {
+			field = path + "." + k
+		}
+
+		f, fok := fromMap[k]
+		v, tok := toMap[k]
+		if !fok || !tok {
+			changes = append(changes, &domain.RevisionChange{Field: field, From: f, To: v})
+			continue
+		}
+
+		changes = diffSnapshots(field, f, v, changes)
+	}
+
+	return changes
+}
+
+// snapshotMap returns the fields of an object, or of an array of objects with an id keyed by their ids.
+func snapshotMap(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case []interface{}:
+		m := map[string]interface{}{}
+		for _, item := range v {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			id, ok := obj["id"].(string)
+			if !ok {
+				return nil, false
+			}
+
+			m[id] = obj
+		}
+
+		return m, true
+	}
+
+	return nil, false
+}
+
+func updateControl(deviceID uuid.UUID, control *domain.ControlSnapshot) *domain.UpdateDeviceControl {
+	attributes := control.Attributes
+	if attributes == nil {
+		attributes = domain.ControlAttributes{}
+	}
+
+	return &domain.UpdateDeviceControl{
+		DeviceID:               deviceID,
+		Name:                   &control.Name,
+		Type:                   &control.Type,
+		QoS:                    &control.QoS,
+		IconName:               &control.IconName,
+		IconBackgroundColor:    &control.IconBackgroundColor,
+		IsAvailable:            &control.IsAvailable,
+		IsConfirmationRequired: &control.IsConfirmationRequired,
+		CanNotifyOnPublish:     &control.CanNotifyOnPublish,
+		CanDisplayName:         &control.CanDisplayName,
+		Topic:                  &control.Topic,
+		Attributes:             attributes,
+	}
+}
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type RoomService interface {
+	Get(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) (*domain.Room, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Room, error)
+	Create(ctx context.Context, room *domain.CreateRoom) (uuid.UUID, error)
+	Update(ctx context.Context, room *domain.UpdateRoom) error
+	Delete(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) error
+	Reorder(ctx context.Context, userID uuid.UUID, roomIDs []uuid.UUID) error
+	GetState(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) (*domain.RoomState, error)
+}
+
+type roomService struct {
+	rr  repository.RoomRepository
+	bhr repository.BrokerHealthRepository
+	es  EventService
+}
+
+func NewRoomService(rr repository.RoomRepository, bhr repository.BrokerHealthRepository, es EventService) RoomService {
+	return &roomService{rr, bhr, es}
+}
+
+func (r *roomService) Get(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) (*domain.Room, error) {
+	return r.rr.Get(ctx, roomID, userID)
+}
+
+func (r *roomService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Room, error) {
+	return r.rr.List(ctx, userID)
+}
+
+func (r *roomService) Create(ctx context.Context, room *domain.CreateRoom) (uuid.UUID, error) {
+	roomID, err := r.rr.Create(ctx, room)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	r.es.PublishRooms(ctx, enum.EntityCreatedAction, room.UserID, roomID)
+
+	return roomID, nil
+}
+
+func (r *roomService) Update(ctx context.Context, room *domain.UpdateRoom) error {
+	if err := r.rr.Update(ctx, room); err != nil {
+		return err
+	}
+
+	r.es.PublishRooms(ctx, enum.EntityUpdatedAction, room.UserID, room.ID)
+
+	return nil
+}
+
+// Delete removes the room, its devices are left without a room.
+func (r *roomService) Delete(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) error {
+	if err := r.rr.Delete(ctx, roomID, userID); err != nil {
+		return err
+	}
+
+	r.es.PublishRooms(ctx, enum.EntityDeletedAction, userID, roomID)
+
+	return nil
+}
+
+func (r *roomService) Reorder(ctx context.Context, userID uuid.UUID, roomIDs []uuid.UUID) error {
+	rooms, err := r.rr.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	current := make([]uuid.UUID, len(rooms))
+	for i, room := range rooms {
+		current[i] = room.ID
+	}
+
+	if !sameIDs(current, roomIDs) {
+		return ae.ErrRoomOrderInvalid
+	}
+
+	if err := r.rr.Reorder(ctx, userID, roomIDs); err != nil {
+		return err
+	}
+
+	for _, id := range roomIDs {
+		r.es.PublishRooms(ctx, enum.EntityUpdatedAction, userID, id)
+	}
+
+	return nil
+}
+
+// GetState aggregates the devices of the room, the room is online when the brokers of all its devices are online
+// and partially online when only some of them are.
+func (r *roomService) GetState(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) (*domain.RoomState, error) {
+	if _, err := r.rr.Get(ctx, roomID, userID); err != nil {
+		return nil, err
+	}
+
+	state, err := r.rr.GetState(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	health, err := r.bhr.List(ctx, state.BrokerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Brokers = map[enum.BrokerStatus]int{
+		enum.BrokerOnline:  0,
+		enum.BrokerOffline: 0,
+		enum.BrokerUnknown: 0,
+	}
+
+	for _, brokerID := range state.BrokerIDs {
+		status := enum.BrokerUnknown
+		if h, ok := health[brokerID]; ok {
+			status = h.Status
+		}
+
+		state.Brokers[status]++
+	}
+
+	switch online := state.Brokers[enum.BrokerOnline]; {
+	case len(state.BrokerIDs) > 0 && online == len(state.BrokerIDs):
+		state.Status = enum.RoomOnline
+	case online > 0:
+		state.Status = enum.RoomPartial
+	case state.Brokers[enum.BrokerOffline] > 0:
+		state.Status = enum.RoomOffline
+	default:
+		state.Status = enum.RoomUnknown
+	}
+
+	return state, nil
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+// SearchService finds the brokers, devices and controls of a user, either by the words of their names, servers,
+// placings, paths and topics, or by matching the topics of the controls with an MQTT topic filter.
+type SearchService interface {
+	Search(ctx context.Context, search *domain.Search) ([]*domain.SearchResult, error)
+}
+
+type searchService struct {
+	sr repository.SearchRepository
+}
+
+func NewSearchService(sr repository.SearchRepository) SearchService {
+	return &searchService{sr}
+}
+
+func (s *searchService) Search(ctx context.Context, search *domain.Search) ([]*domain.SearchResult, error) {
+	if search.Mode == enum.SearchTopic {
+		return s.searchTopics(ctx, search)
+	}
+
+	return s.sr.Search(ctx, search)
+}
+
+// searchTopics matches the filter with the topics of the controls, both as they are set
+// and prefixed with the base path of their devices.
+func (s *searchService) searchTopics(ctx context.Context, search *domain.Search) ([]*domain.SearchResult, error) {
+	results := []*domain.SearchResult{}
+
+	if !mqtt.ValidFilter(search.Text) {
+		return nil, &ae.ValidationError{
+			Err:    ae.ErrValidation,
+			Fields: []*ae.FieldError{{Field: "q", Rule: "topic_filter", Message: "should be a valid MQTT topic filter"}},
+		}
+	}
+
+	if !searchesType(search, enum.SearchControl) {
+		return results, nil
+	}
+
+	topics, err := s.sr.ListTopics(ctx, search.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range topics {
+		topic := t.Topic
+		if !mqtt.Match(search.Text, topic) {
+			if t.BasePath == nil || *t.BasePath == "" {
+				continue
+			}
+
+			topic = controlTopic(t.BasePath, t.Topic)
+			if !mqtt.Match(search.Text, topic) {
+				continue
+			}
+		}
+
+		results = append(results, &domain.SearchResult{
+			Type:      enum.SearchControl,
+			ID:        t.ID,
+			BrokerID:  t.BrokerID,
+			DeviceID:  uuid.NullUUID{UUID: t.DeviceID, Valid: true},
+			Name:      t.Name,
+			Highlight: "<mark>" + topic + "</mark>",
+			Rank:      1,
+		})
+
+		if len(results) == search.Limit {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+func searchesType(search *domain.Search, resultType enum.SearchResultType) bool {
+	if len(search.Types) == 0 {
+		return true
+	}
+
+	for _, t := range search.Types {
+		if t == resultType {
+			return true
+		}
+	}
+
+	return false
+}
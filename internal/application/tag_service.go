@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type TagService interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error)
+	Create(ctx context.Context, tag *domain.CreateTag) (uuid.UUID, error)
+	Update(ctx context.Context, tag *domain.UpdateTag) error
+	Delete(ctx context.Context, tagID uuid.UUID, userID uuid.UUID) error
+	SetDeviceTags(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, tagIDs []uuid.UUID) error
+	SetControlTags(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, tagIDs []uuid.UUID) error
+}
+
+type tagService struct {
+	tr  repository.TagRepository
+	dcr repository.DeviceControlRepository
+	ds  DeviceService
+	es  EventService
+}
+
+func NewTagService(tr repository.TagRepository, dcr repository.DeviceControlRepository, ds DeviceService, es EventService) TagService {
+	return &tagService{tr, dcr, ds, es}
+}
+
+func (t *tagService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error) {
+	return t.tr.List(ctx, userID)
+}
+
+func (t *tagService) Create(ctx context.Context, tag *domain.CreateTag) (uuid.UUID, error) {
+	tagID, err := t.tr.Create(ctx, tag)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	t.es.PublishTags(ctx, enum.EntityCreatedAction, tag.UserID, tagID)
+
+	return tagID, nil
+}
+
+func (t *tagService) Update(ctx context.Context, tag *domain.UpdateTag) error {
+	if err := t.tr.Update(ctx, tag); err != nil {
+		return err
+	}
+
+	t.es.PublishTags(ctx, enum.EntityUpdatedAction, tag.UserID, tag.ID)
+
+	return nil
+}
+
+func (t *tagService) Delete(ctx context.Context, tagID uuid.UUID, userID uuid.UUID) error {
+	if err := t.tr.Delete(ctx, tagID, userID); err != nil {
+		return err
+	}
+
+	t.es.PublishTags(ctx, enum.EntityDeletedAction, userID, tagID)
+
+	return nil
+}
+
+// SetDeviceTags replaces the tags of the device, an empty list removes all of them.
+func (t *tagService) SetDeviceTags(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, tagIDs []uuid.UUID) error {
+	device, err := t.ds.Get(ctx, deviceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := t.checkTags(ctx, userID, tagIDs); err != nil {
+		return err
+	}
+
+	if err := t.tr.SetDeviceTags(ctx, deviceID, tagIDs); err != nil {
+		return err
+	}
+
+	t.es.PublishDevices(ctx, enum.EntityUpdatedAction, userID, device.BrokerID.UUID, deviceID)
+
+	return nil
+}
+
+// SetControlTags replaces the tags of the control, an empty list removes all of them.
+func (t *tagService) SetControlTags(ctx context.Context, userID uuid.UUID, deviceID uuid.UUID, controlID uuid.UUID, tagIDs []uuid.UUID) error {
+	device, err := t.ds.Get(ctx, deviceID, userID)
+	if err != nil {
+		return err
+	}
+
+	controls, err := t.dcr.ListByDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, c := range controls {
+		if c.ID == controlID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return ae.ErrDeviceControlNotFound
+	}
+
+	if err := t.checkTags(ctx, userID, tagIDs); err != nil {
+		return err
+	}
+
+	if err := t.tr.SetControlTags(ctx, controlID, tagIDs); err != nil {
+		return err
+	}
+
+	t.es.PublishDeviceControls(ctx, enum.EntityUpdatedAction, userID, device.BrokerID.UUID, deviceID, controlID)
+
+	return nil
+}
+
+// checkTags checks that every tag belongs to the user.
+func (t *tagService) checkTags(ctx context.Context, userID uuid.UUID, tagIDs []uuid.UUID) error {
+	tags, err := t.tr.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[uuid.UUID]bool, len(tags))
+	for _, tag := range tags {
+		owned[tag.ID] = true
+	}
+
+	for _, id := range tagIDs {
+		if !owned[id] {
+			return ae.ErrTagNotFound
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+// TopicService analyses the effective topics of the controls of a broker.
+type TopicService interface {
+	Lint(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) ([]*domain.TopicIssue, error)
+	Check(ctx context.Context, device *domain.Device, control *domain.CreateDeviceControl) error
+}
+
+type topicService struct {
+	c   *config.Config
+	dcr repository.DeviceControlRepository
+	bs  BrokerService
+	cts ControlTypeService
+}
+
+func NewTopicService(c *config.Config, dcr repository.DeviceControlRepository, bs BrokerService, cts ControlTypeService) TopicService {
+	return &topicService{c, dcr, bs, cts}
+}
+
+func (s *topicService) Lint(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) ([]*domain.TopicIssue, error) {
+	if _, err := s.bs.Get(ctx, brokerID, userID); err != nil {
+		return nil, err
+	}
+
+	topics, err := s.dcr.ListTopics(ctx, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return LintTopics(s.cts, topics), nil
+}
+
+// Check rejects the control about to be created when blocking the conflicts is enabled and its topic
+// is invalid, duplicated or overlapping with a wildcard subscription on the broker of the device.
+func (s *topicService) Check(ctx context.Context, device *domain.Device, control *domain.CreateDeviceControl) error {
+	if !s.c.Topic.BlockConflicts || !device.BrokerID.Valid {
+		return nil
+	}
+
+	topics, err := s.dcr.ListTopics(ctx, device.BrokerID.UUID)
+	if err != nil {
+		return err
+	}
+
+	// The control is not created yet, so it is the only one without an ID
+	topics = append(topics, &domain.ControlTopic{
+		ID:       uuid.Nil,
+		BrokerID: device.BrokerID,
+		DeviceID: device.ID,
+		Name:     control.Name,
+		Type:     control.Type,
+		Topic:    control.Topic,
+		BasePath: device.BasePath,
+	})
+
+	for _, issue := range LintTopics(s.cts, topics) {
+		for _, c := range issue.Controls {
+			if c.ID == uuid.Nil {
+				return fmt.Errorf("%w: %s topic %s", ae.ErrTopicConflict, issue.Kind, issue.Topic)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LintTopics finds the invalid topics, the topics published to by more than one control and the wildcard
+// subscriptions receiving the messages of the other controls. The controls of the types which encode
+// a value publish to their topic and the ones which decode a payload subscribe to it.
+func LintTopics(cts ControlTypeService, topics []*domain.ControlTopic) []*domain.TopicIssue {
+	type entry struct {
+		control    *domain.TopicIssueControl
+		publishes  bool
+		subscribes bool
+	}
+
+	issues := []*domain.TopicIssue{}
+	entries := []*entry{}
+
+	for _, t := range topics {
+		e := &entry{
+			control: &domain.TopicIssueControl{
+				ID:       t.ID,
+				DeviceID: t.DeviceID,
+				Name:     t.Name,
+				Topic:    controlTopic(t.BasePath, t.Topic),
+			},
+		}
+
+		if ct, err := cts.Get(t.Type); err == nil {
+			e.publishes = ct.Encode != nil
+			e.subscribes = ct.Decode != nil
+		}
+
+		if !mqtt.ValidFilter(e.control.Topic) || (e.publishes && !mqtt.ValidTopic(e.control.Topic)) {
+			issues = append(issues, &domain.TopicIssue{
+				Kind:     enum.TopicInvalid,
+				Topic:    e.control.Topic,
+				Controls: []*domain.TopicIssueControl{e.control},
+			})
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	published := map[string][]*domain.TopicIssueControl{}
+	order := []string{}
+	for _, e := range entries {
+		if !e.publishes {
+			continue
+		}
+
+		if _, ok := published[e.control.Topic]; !ok {
+			order = append(order, e.control.Topic)
+		}
+		published[e.control.Topic] = append(published[e.control.Topic], e.control)
+	}
+
+	for _, topic := range order {
+		if len(published[topic]) > 1 {
+			issues = append(issues, &domain.TopicIssue{
+				Kind:     enum.TopicDuplicate,
+				Topic:    topic,
+				Controls: published[topic],
+			})
+		}
+	}
+
+	for _, s := range entries {
+		if !s.subscribes || !strings.ContainsAny(s.control.Topic, "+#") {
+			continue
+		}
+
+		issue := &domain.TopicIssue{
+			Kind:     enum.TopicOverlap,
+			Topic:    s.control.Topic,
+			Controls: []*domain.TopicIssueControl{s.control},
+		}
+
+		for _, o := range entries {
+			if o != s && mqtt.Overlap(s.control.Topic, o.control.Topic) {
+				issue.Controls = append(issue.Controls, o.control)
+			}
+		}
+
+		if len(issue.Controls) > 1 {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,62 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestLintTopics(t *testing.T) {
+	cts := application.NewControlTypeService()
+	home := "home"
+
+	topic := func(name string, controlType enum.ControlType, basePath *string, topic string) *domain.ControlTopic {
+		return &domain.ControlTopic{ID: uuid.New(), DeviceID: uuid.New(), Name: name, Type: controlType, Topic: topic, BasePath: basePath}
+	}
+
+	t.Run("should report the controls publishing to the same effective topic", func(t *testing.T) {
+		issues := application.LintTopics(cts, []*domain.ControlTopic{
+			topic("a", enum.ControlButton, &home, "light/set"),
+			topic("b", enum.ControlButton, nil, "home/light/set"),
+			topic("c", enum.ControlButton, &home, "fan/set"),
+		})
+
+		assert.Equal(t, 1, len(issues))
+		assert.Equal(t, enum.TopicDuplicate, issues[0].Kind)
+		assert.Equal(t, "home/light/set", issues[0].Topic)
+		assert.Equal(t, 2, len(issues[0].Controls))
+	})
+
+	t.Run("should report the wildcards in a published topic", func(t *testing.T) {
+		issues := application.LintTopics(cts, []*domain.ControlTopic{
+			topic("a", enum.ControlButton, &home, "+/set"),
+			topic("b", enum.ControlState, &home, "light/#/state"),
+			topic("c", enum.ControlState, &home, "light/+"),
+		})
+
+		assert.Equal(t, 2, len(issues))
+		assert.Equal(t, enum.TopicInvalid, issues[0].Kind)
+		assert.Equal(t, "home/+/set", issues[0].Topic)
+		assert.Equal(t, enum.TopicInvalid, issues[1].Kind)
+		assert.Equal(t, "home/light/#/state", issues[1].Topic)
+	})
+
+	t.Run("should report the wildcard subscription receiving the messages of other controls", func(t *testing.T) {
+		issues := application.LintTopics(cts, []*domain.ControlTopic{
+			topic("state", enum.ControlState, &home, "#"),
+			topic("a", enum.ControlButton, &home, "light/set"),
+			topic("b", enum.ControlButton, nil, "office/light/set"),
+		})
+
+		assert.Equal(t, 1, len(issues))
+		assert.Equal(t, enum.TopicOverlap, issues[0].Kind)
+		assert.Equal(t, "home/#", issues[0].Topic)
+		assert.Equal(t, 2, len(issues[0].Controls))
+		assert.Equal(t, "state", issues[0].Controls[0].Name)
+		assert.Equal(t, "a", issues[0].Controls[1].Name)
+	})
+}
@@ -0,0 +1,677 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+type TransferService interface {
+	Export(ctx context.Context, userID uuid.UUID, withCredentials bool) (*domain.Transfer, error)
+	Import(ctx context.Context, userID uuid.UUID, transfer *domain.Transfer, options *domain.TransferOptions) (*domain.TransferReport, error)
+}
+
+type transferService struct {
+	tr  repository.Transactor
+	bs  BrokerService
+	ds  DeviceService
+	dcs DeviceControlService
+	cts ControlTypeService
+	rec RevisionRecorder
+	es  EventService
+}
+
+func NewTransferService(
+	tr repository.Transactor,
+	bs BrokerService,
+	ds DeviceService,
+	dcs DeviceControlService,
+	cts ControlTypeService,
+	rec RevisionRecorder,
+	es EventService) TransferService {
+	return &transferService{tr, bs, ds, dcs, cts, rec, es}
+}
+
+// errTransferDryRun rolls back the transaction of a dry run once the import has been made.
+var errTransferDryRun = errors.New("transfer dry run")
+
+func (s *transferService) Export(ctx context.Context, userID uuid.UUID, withCredentials bool) (*domain.Transfer, error) {
+	transfer := &domain.Transfer{
+		Version:    domain.TransferVersion,
+		ExportedAt: time.Now().UTC(),
+		Brokers:    []*domain.TransferBroker{},
+		Devices:    []*domain.TransferDevice{},
+	}
+
+	brokers, err := s.bs.List(ctx, &domain.ListBrokerFilters{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range brokers.Items {
+		broker := &domain.TransferBroker{
+			Ref: b.ID.String(),
+			Broker: domain.CreateBroker{
+				Name:                b.Name,
+				Server:              b.Server,
+				Port:                b.Port,
+				KeepAlive:           b.KeepAlive,
+				IconName:            b.IconName,
+				IconBackgroundColor: b.IconBackgroundColor,
+				IsSSL:               b.IsSSL,
+				ClientID:            b.ClientID,
+				DiscoveryMode:       b.DiscoveryMode,
+				DiscoveryPrefix:     t.NewString(b.DiscoveryPrefix, false, true),
+				ProtocolVersion:     b.ProtocolVersion,
+				Transport:           b.Transport,
+				Path:                b.Path,
+				CleanStart:          &b.CleanStart,
+				SessionExpiry:       &b.SessionExpiry,
+				UserProperties:      b.UserProperties,
+			},
+		}
+
+		if withCredentials {
+			c, err := s.bs.GetCredentials(ctx, b.ID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			broker.Username = c.Username
+			broker.Password = c.Password
+		}
+
+		transfer.Brokers = append(transfer.Brokers, broker)
+	}
+
+	devices, err := s.ds.List(ctx, &domain.ListDeviceFilters{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range devices.Items {
+		device := &domain.TransferDevice{
+			Ref: d.ID.String(),
+			Device: domain.CreateDevice{
+				Name:                d.Name,
+				IconName:            d.IconName,
+				IconBackgroundColor: d.IconBackgroundColor,
+				Placing:             stringPtrToNullable(d.Placing),
+				BasePath:            stringPtrToNullable(d.BasePath),
+			},
+			Controls: []*domain.TransferControl{},
+		}
+
+		if d.BrokerID.Valid {
+			ref := d.BrokerID.UUID.String()
+			device.BrokerRef = &ref
+		}
+
+		controls, err := s.dcs.List(ctx, userID, &domain.ListDeviceControlFilters{DeviceID: d.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range controls.Items {
+			device.Controls = append(device.Controls, &domain.TransferControl{
+				Ref: c.ID.String(),
+				Control: domain.CreateDeviceControl{
+					Name:                   c.Name,
+					Type:                   c.Type,
+					QoS:                    c.QoS,
+					IconName:               c.IconName,
+					IconBackgroundColor:    c.IconBackgroundColor,
+					IsAvailable:            c.IsAvailable,
+					IsConfirmationRequired: c.IsConfirmationRequired,
+					CanNotifyOnPublish:     c.CanNotifyOnPublish,
+					CanDisplayName:         c.CanDisplayName,
+					Topic:                  c.Topic,
+					Attributes:             c.Attributes,
+				},
+			})
+		}
+
+		transfer.Devices = append(transfer.Devices, device)
+	}
+
+	return transfer, nil
+}
+
+func (s *transferService) Import(ctx context.Context, userID uuid.UUID, transfer *domain.Transfer, options *domain.TransferOptions) (*domain.TransferReport, error) {
+	if transfer.Version != domain.TransferVersion {
+		return nil, ae.ErrTransferVersion
+	}
+
+	if err := s.validateRefs(transfer); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateControls(transfer); err != nil {
+		return nil, err
+	}
+
+	report := &domain.TransferReport{
+		DryRun:   options.DryRun,
+		Strategy: options.Strategy,
+	}
+
+	err := transaction(ctx, s.tr, s.rec, s.es, func(ctx context.Context) error {
+		report.Items = []*domain.TransferReportItem{}
+
+		brokerIDs, err := s.importBrokers(ctx, userID, transfer.Brokers, options, report)
+		if err != nil {
+			return err
+		}
+
+		if err := s.importDevices(ctx, userID, transfer.Devices, brokerIDs, options, report); err != nil {
+			return err
+		}
+
+		if options.DryRun {
+			return errTransferDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errTransferDryRun) {
+		return nil, err
+	}
+
+	// The entities created by a dry run have been rolled back along with their ids
+	if options.DryRun {
+		for _, item := range report.Items {
+			if item.Action == enum.TransferCreateAction || item.Action == enum.TransferRenameAction {
+				item.ID = uuid.Nil
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (s *transferService) validateRefs(transfer *domain.Transfer) error {
+	refs := map[string]bool{}
+
+	use := func(ref string) error {
+		if refs[ref] {
+			return ae.ErrTransferRefDuplicated
+		}
+		refs[ref] = true
+		return nil
+	}
+
+	brokers := map[string]bool{}
+	for _, b := range transfer.Brokers {
+		if err := use(b.Ref); err != nil {
+			return err
+		}
+		brokers[b.Ref] = true
+	}
+
+	for _, d := range transfer.Devices {
+		if err := use(d.Ref); err != nil {
+			return err
+		}
+
+		if d.BrokerRef != nil && !brokers[*d.BrokerRef] {
+			return ae.ErrTransferRefNotFound
+		}
+
+		for _, c := range d.Controls {
+			if err := use(c.Ref); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateControls checks the attributes of all the controls up front, so their errors point at the controls of the document.
+func (s *transferService) validateControls(transfer *domain.Transfer) error {
+	for i, d := range transfer.Devices {
+		for j, c := range d.Controls {
+			err := s.cts.Validate(c.Control.Type, c.Control.Attributes)
+
+			var ve *ae.ValidationError
+			if errors.As(err, &ve) {
+				for _, f := range ve.Fields {
+					f.Field = fmt.Sprintf("devices[%d].controls[%d].%s", i, j, f.Field)
+				}
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// importBrokers matches brokers by their server, which is unique per user. Since two brokers
+// can not share a server, the rename strategy falls back to reusing the existing broker.
+func (s *transferService) importBrokers(
+	ctx context.Context,
+	userID uuid.UUID,
+	brokers []*domain.TransferBroker,
+	options *domain.TransferOptions,
+	report *domain.TransferReport) (map[string]uuid.UUID, error) {
+	existing, err := s.bs.List(ctx, &domain.ListBrokerFilters{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := map[string]*domain.Broker{}
+	for _, b := range existing.Items {
+		servers[b.Server] = b
+	}
+
+	ids := map[string]uuid.UUID{}
+
+	for _, b := range brokers {
+		b.Broker.UserID = userID
+
+		item := &domain.TransferReportItem{
+			Entity: enum.BrokersEntity,
+			Ref:    b.Ref,
+			Name:   b.Broker.Name,
+		}
+		report.Items = append(report.Items, item)
+
+		current, ok := servers[b.Broker.Server]
+		if !ok {
+			item.Action = enum.TransferCreateAction
+
+			brokerID, err := s.bs.Create(ctx, &b.Broker)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := s.setCredentials(ctx, userID, brokerID, b); err != nil {
+				return nil, err
+			}
+
+			item.ID = brokerID
+
+			ids[b.Ref] = item.ID
+			continue
+		}
+
+		item.ID = current.ID
+		ids[b.Ref] = current.ID
+
+		if options.Strategy != enum.TransferOverwrite {
+			item.Action = enum.TransferSkipAction
+			item.Reason = fmt.Sprintf("broker with server %s already exists", current.Server)
+			continue
+		}
+
+		item.Action = enum.TransferUpdateAction
+		item.Changes = brokerChanges(current, &b.Broker)
+		if b.Username.Set || b.Password.Set {
+			item.Changes = append(item.Changes, domain.TransferChange{Field: "credentials"})
+		}
+
+		update := &domain.UpdateBroker{
+			ID:                  current.ID,
+			UserID:              userID,
+			Name:                t.NewString(b.Broker.Name, false, true),
+			Port:                t.NewUint16(b.Broker.Port, false, true),
+			KeepAlive:           t.NewUint16(b.Broker.KeepAlive, false, true),
+			IconName:            t.NewString(b.Broker.IconName, false, true),
+			IconBackgroundColor: t.NewString(b.Broker.IconBackgroundColor, false, true),
+			IsSSL:               t.NewBool(b.Broker.IsSSL, false, true),
+			ClientID:            b.Broker.ClientID,
+			DiscoveryPrefix:     b.Broker.DiscoveryPrefix,
+		}
+
+		if b.Broker.DiscoveryMode != "" {
+			update.DiscoveryMode = &b.Broker.DiscoveryMode
+		}
+
+		if b.Broker.ProtocolVersion != "" {
+			update.ProtocolVersion = &b.Broker.ProtocolVersion
+		}
+
+		if b.Broker.Transport != "" {
+			update.Transport = &b.Broker.Transport
+		}
+
+		if b.Broker.Path.Set {
+			update.Path = b.Broker.Path
+		}
+
+		if b.Broker.CleanStart != nil {
+			update.CleanStart = t.NewBool(*b.Broker.CleanStart, false, true)
+		}
+
+		update.SessionExpiry = b.Broker.SessionExpiry
+		update.UserProperties = b.Broker.UserProperties
+
+		if err := s.bs.Update(ctx, update); err != nil {
+			return nil, err
+		}
+
+		if err := s.setCredentials(ctx, userID, current.ID, b); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+func (s *transferService) setCredentials(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID, b *domain.TransferBroker) error {
+	if !b.Username.Set && !b.Password.Set {
+		return nil
+	}
+
+	return s.bs.SetCredentials(ctx, &domain.UpdateBroker{
+		ID:       brokerID,
+		UserID:   userID,
+		Username: nullOrValue(b.Username),
+		Password: nullOrValue(b.Password),
+	})
+}
+
+// importDevices matches devices by their name within the same broker.
+func (s *transferService) importDevices(
+	ctx context.Context,
+	userID uuid.UUID,
+	devices []*domain.TransferDevice,
+	brokerIDs map[string]uuid.UUID,
+	options *domain.TransferOptions,
+	report *domain.TransferReport) error {
+	existing, err := s.ds.List(ctx, &domain.ListDeviceFilters{UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	names := map[string]*domain.Device{}
+	for _, d := range existing.Items {
+		names[deviceKey(d.BrokerID, d.Name)] = d
+	}
+
+	for _, d := range devices {
+		d.Device.UserID = userID
+		if d.BrokerRef != nil {
+			d.Device.BrokerID = uuid.NullUUID{UUID: brokerIDs[*d.BrokerRef], Valid: true}
+		}
+
+		item := &domain.TransferReportItem{
+			Entity: enum.DevicesEntity,
+			Ref:    d.Ref,
+			Name:   d.Device.Name,
+		}
+		report.Items = append(report.Items, item)
+
+		current, ok := names[deviceKey(d.Device.BrokerID, d.Device.Name)]
+		switch {
+		case !ok || options.Strategy == enum.TransferRename:
+			item.Action = enum.TransferCreateAction
+			if ok {
+				d.Device.Name = uniqueName(d.Device.Name, func(name string) bool {
+					_, ok := names[deviceKey(d.Device.BrokerID, name)]
+					return ok
+				})
+				item.Name = d.Device.Name
+				item.Action = enum.TransferRenameAction
+			}
+
+			deviceID, err := s.ds.Create(ctx, &d.Device)
+			if err != nil {
+				return err
+			}
+
+			item.ID = deviceID
+
+			names[deviceKey(d.Device.BrokerID, d.Device.Name)] = &domain.Device{
+				ID:       item.ID,
+				BrokerID: d.Device.BrokerID,
+				Name:     d.Device.Name,
+			}
+		case options.Strategy == enum.TransferOverwrite:
+			item.ID = current.ID
+			item.Action = enum.TransferUpdateAction
+			item.Changes = deviceChanges(current, &d.Device)
+
+			if err := s.ds.Update(ctx, &domain.UpdateDevice{
+				ID:                  current.ID,
+				UserID:              userID,
+				IconName:            t.NewString(d.Device.IconName, false, true),
+				IconBackgroundColor: t.NewString(d.Device.IconBackgroundColor, false, true),
+				Placing:             nullOrValue(d.Device.Placing),
+				BasePath:            nullOrValue(d.Device.BasePath),
+			}); err != nil {
+				return err
+			}
+		default:
+			item.ID = current.ID
+			item.Action = enum.TransferSkipAction
+			item.Reason = fmt.Sprintf("device named %s already exists", current.Name)
+		}
+
+		if err := s.importControls(ctx, userID, item.ID, d.Controls, options, report); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importControls matches controls by their name within the device.
+func (s *transferService) importControls(
+	ctx context.Context,
+	userID uuid.UUID,
+	deviceID uuid.UUID,
+	controls []*domain.TransferControl,
+	options *domain.TransferOptions,
+	report *domain.TransferReport) error {
+	names := map[string]*domain.DeviceControl{}
+
+	if deviceID != uuid.Nil {
+		existing, err := s.dcs.List(ctx, userID, &domain.ListDeviceControlFilters{DeviceID: deviceID})
+		if err != nil {
+			return err
+		}
+
+		for _, c := range existing.Items {
+			names[c.Name] = c
+		}
+	}
+
+	for _, c := range controls {
+		c.Control.DeviceID = deviceID
+
+		item := &domain.TransferReportItem{
+			Entity: enum.DeviceControlsEntity,
+			Ref:    c.Ref,
+			Name:   c.Control.Name,
+		}
+		report.Items = append(report.Items, item)
+
+		current, ok := names[c.Control.Name]
+		switch {
+		case !ok || options.Strategy == enum.TransferRename:
+			item.Action = enum.TransferCreateAction
+			if ok {
+				c.Control.Name = uniqueName(c.Control.Name, func(name string) bool {
+					_, ok := names[name]
+					return ok
+				})
+				item.Name = c.Control.Name
+				item.Action = enum.TransferRenameAction
+			}
+
+			controlID, err := s.dcs.Create(ctx, userID, &c.Control)
+			if err != nil {
+				return err
+			}
+
+			item.ID = controlID
+
+			names[c.Control.Name] = &domain.DeviceControl{ID: item.ID, Name: c.Control.Name}
+		case options.Strategy == enum.TransferOverwrite:
+			item.ID = current.ID
+			item.Action = enum.TransferUpdateAction
+			item.Changes = controlChanges(current, &c.Control)
+
+			control := c.Control
+			if err := s.dcs.Update(ctx, userID, &domain.UpdateDeviceControl{
+				ID:                     current.ID,
+				DeviceID:               deviceID,
+				Type:                   &control.Type,
+				QoS:                    &control.QoS,
+				IconName:               &control.IconName,
+				IconBackgroundColor:    &control.IconBackgroundColor,
+				IsAvailable:            &control.IsAvailable,
+				IsConfirmationRequired: &control.IsConfirmationRequired,
+				CanNotifyOnPublish:     &control.CanNotifyOnPublish,
+				CanDisplayName:         &control.CanDisplayName,
+				Topic:                  &control.Topic,
+				Attributes:             control.Attributes,
+			}); err != nil {
+				return err
+			}
+		default:
+			item.ID = current.ID
+			item.Action = enum.TransferSkipAction
+			item.Reason = fmt.Sprintf("control named %s already exists", current.Name)
+		}
+	}
+
+	return nil
+}
+
+func brokerChanges(from *domain.Broker, to *domain.CreateBroker) []domain.TransferChange {
+	changes := []domain.TransferChange{}
+	changes = appendChange(changes, "name", from.Name, to.Name)
+	changes = appendChange(changes, "port", from.Port, to.Port)
+	changes = appendChange(changes, "keepAlive", from.KeepAlive, to.KeepAlive)
+	changes = appendChange(changes, "icon.name", from.IconName, to.IconName)
+	changes = appendChange(changes, "icon.backgroundColor", from.IconBackgroundColor, to.IconBackgroundColor)
+	changes = appendChange(changes, "isSsl", from.IsSSL, to.IsSSL)
+	changes = appendChange(changes, "clientId", nullableValue(from.ClientID), nullableValue(to.ClientID))
+	if to.DiscoveryMode != "" {
+		changes = appendChange(changes, "discoveryMode", from.DiscoveryMode, to.DiscoveryMode)
+	}
+	if to.DiscoveryPrefix.Set {
+		changes = appendChange(changes, "discoveryPrefix", from.DiscoveryPrefix, to.DiscoveryPrefix.String)
+	}
+	if to.ProtocolVersion != "" {
+		changes = appendChange(changes, "protocolVersion", from.ProtocolVersion, to.ProtocolVersion)
+	}
+	if to.Transport != "" {
+		changes = appendChange(changes, "transport", from.Transport, to.Transport)
+	}
+	if to.Path.Set {
+		changes = appendChange(changes, "path", nullableValue(from.Path), nullableValue(to.Path))
+	}
+	if to.CleanStart != nil {
+		changes = appendChange(changes, "cleanStart", from.CleanStart, *to.CleanStart)
+	}
+	if to.SessionExpiry != nil {
+		changes = appendChange(changes, "sessionExpiry", from.SessionExpiry, *to.SessionExpiry)
+	}
+	if to.UserProperties != nil {
+		changes = appendChange(changes, "userProperties", map[string]string(from.UserProperties), map[string]string(to.UserProperties))
+	}
+	return changes
+}
+
+func deviceChanges(from *domain.Device, to *domain.CreateDevice) []domain.TransferChange {
+	changes := []domain.TransferChange{}
+	changes = appendChange(changes, "icon.name", from.IconName, to.IconName)
+	changes = appendChange(changes, "icon.backgroundColor", from.IconBackgroundColor, to.IconBackgroundColor)
+	changes = appendChange(changes, "placing", nullableValue(stringPtrToNullable(from.Placing)), nullableValue(to.Placing))
+	changes = appendChange(changes, "basePath", nullableValue(stringPtrToNullable(from.BasePath)), nullableValue(to.BasePath))
+	return changes
+}
+
+func controlChanges(from *domain.DeviceControl, to *domain.CreateDeviceControl) []domain.TransferChange {
+	changes := []domain.TransferChange{}
+	changes = appendChange(changes, "type", from.Type, to.Type)
+	changes = appendChange(changes, "qualityOfService", from.QoS, to.QoS)
+	changes = appendChange(changes, "icon.name", from.IconName, to.IconName)
+	changes = appendChange(changes, "icon.backgroundColor", from.IconBackgroundColor, to.IconBackgroundColor)
+	changes = appendChange(changes, "isAvailable", from.IsAvailable, to.IsAvailable)
+	changes = appendChange(changes, "isConfirmationRequired", from.IsConfirmationRequired, to.IsConfirmationRequired)
+	changes = appendChange(changes, "canNotifyOnPublish", from.CanNotifyOnPublish, to.CanNotifyOnPublish)
+	changes = appendChange(changes, "canDisplayName", from.CanDisplayName, to.CanDisplayName)
+	changes = appendChange(changes, "topic", from.Topic, to.Topic)
+	changes = appendChange(changes, "attributes", normalizeAttributes(from.Attributes), normalizeAttributes(to.Attributes))
+	return changes
+}
+
+func appendChange(changes []domain.TransferChange, field string, from interface{}, to interface{}) []domain.TransferChange {
+	if reflect.DeepEqual(from, to) {
+		return changes
+	}
+
+	return append(changes, domain.TransferChange{Field: field, From: from, To: to})
+}
+
+// normalizeAttributes round trips the attributes through JSON, so the values read from the database
+// and the ones parsed from the document can be compared.
+func normalizeAttributes(v domain.ControlAttributes) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return r
+	}
+
+	json.Unmarshal(b, &r)
+	return r
+}
+
+func uniqueName(name string, exists func(name string) bool) string {
+	for i := 2; ; i++ {
+		n := fmt.Sprintf("%s (%d)", name, i)
+		if !exists(n) {
+			return n
+		}
+	}
+}
+
+func deviceKey(brokerID uuid.NullUUID, name string) string {
+	if !brokerID.Valid {
+		return "/" + name
+	}
+
+	return brokerID.UUID.String() + "/" + name
+}
+
+func nullableValue(v t.String) interface{} {
+	if !v.Set || v.Null {
+		return nil
+	}
+
+	return v.String
+}
+
+func nullOrValue(v t.String) t.String {
+	if !v.Set {
+		return t.NewString("", true, true)
+	}
+
+	return v
+}
+
+func stringPtrToNullable(v *string) t.String {
+	if v == nil {
+		return t.NewString("", true, true)
+	}
+
+	return t.NewString(*v, false, true)
+}
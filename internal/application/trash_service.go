@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTrashRetention     = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval = time.Hour
+)
+
+// TrashService lists the deleted brokers, devices and controls and purges the ones
+// which have been in the trash for longer than the retention.
+type TrashService interface {
+	Start(ctx context.Context)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.TrashEntry, error)
+}
+
+type trashService struct {
+	c  *config.Config
+	tr repository.TrashRepository
+}
+
+func NewTrashService(c *config.Config, tr repository.TrashRepository) TrashService {
+	return &trashService{c, tr}
+}
+
+// Start purges the trash right away and then every interval until the context is done.
+func (s *trashService) Start(ctx context.Context) {
+	interval := defaultTrashPurgeInterval
+	if s.c.Trash != nil && s.c.Trash.PurgeIntervalMinutes > 0 {
+		interval = time.Duration(s.c.Trash.PurgeIntervalMinutes) * time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := s.tr.Purge(ctx, time.Now().Add(-s.retention()))
+			if err != nil {
+				log.Printf("trashService.Start: %s", err)
+			} else if purged > 0 {
+				log.Printf("trashService.Start: purged %d entities", purged)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// List nests the dependents in their entries, the entries are marked with the time they are going to be purged at.
+func (s *trashService) List(ctx context.Context, userID uuid.UUID) ([]*domain.TrashEntry, error) {
+	rows, err := s.tr.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*domain.TrashEntry{}
+	byID := make(map[uuid.UUID]*domain.TrashEntry, len(rows))
+
+	for _, row := range rows {
+		row.Dependents = []*domain.TrashEntry{}
+		if row.ParentID.Valid {
+			continue
+		}
+
+		if row.DeletedAt != nil {
+			purgeAt := row.DeletedAt.Add(s.retention())
+			row.PurgeAt = &purgeAt
+		}
+
+		entries = append(entries, row)
+		byID[row.ID] = row
+	}
+
+	for _, row := range rows {
+		if !row.ParentID.Valid {
+			continue
+		}
+
+		if parent, ok := byID[row.ParentID.UUID]; ok {
+			parent.Dependents = append(parent.Dependents, row)
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *trashService) retention() time.Duration {
+	if s.c.Trash != nil && s.c.Trash.RetentionDays > 0 {
+		return time.Duration(s.c.Trash.RetentionDays) * 24 * time.Hour
+	}
+
+	return defaultTrashRetention
+}
@@ -0,0 +1,349 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultValueInterval = time.Minute
+	// valueFlushInterval is how often the last values received meanwhile are saved, a control receiving
+	// many messages within it is saved once with the last of them.
+	valueFlushInterval = time.Second
+	valueWriteTimeout  = 5 * time.Second
+)
+
+// ValueService keeps the last values of the controls. The topics of the controls are listened to through
+// the server-side connections of the brokers, whether the message log is enabled or not, and the received
+// payloads are decoded, after the transforms of the controls, into their last values checked by the alerts.
+// The values are kept aside by the subscribers and saved every flush interval, so a busy topic does not
+// turn into a write per message nor hold back the other subscriptions of its broker.
+type ValueService interface {
+	Start(ctx context.Context)
+}
+
+type valueWatch struct {
+	userID        uuid.UUID
+	subscriptions map[string]uuid.UUID
+	controls      map[string][]*domain.ControlTopic
+}
+
+type valueService struct {
+	c            *config.Config
+	br           repository.BrokerRepository
+	dcr          repository.DeviceControlRepository
+	cts          ControlTypeService
+	bgs          BridgeService
+	as           AlertService
+	watches      map[uuid.UUID]*valueWatch
+	pending      map[uuid.UUID]interface{}
+	mutex        sync.Mutex
+	syncMutex    sync.Mutex
+	pendingMutex sync.Mutex
+}
+
+func NewValueService(
+	c *config.Config,
+	br repository.BrokerRepository,
+	dcr repository.DeviceControlRepository,
+	cts ControlTypeService,
+	bgs BridgeService,
+	as AlertService,
+	es EventService) ValueService {
+	s := &valueService{
+		c:       c,
+		br:      br,
+		dcr:     dcr,
+		cts:     cts,
+		bgs:     bgs,
+		as:      as,
+		watches: make(map[uuid.UUID]*valueWatch),
+		pending: make(map[uuid.UUID]interface{}),
+	}
+
+	es.Listen(s.onEvent)
+
+	return s
+}
+
+// Start listens to the topics of every broker right away and then every interval,
+// the brokers which could not be connected to are retried with the next interval.
+// The received values are saved every flush interval until the context is done.
+func (s *valueService) Start(ctx context.Context) {
+	if !s.enabled() {
+		return
+	}
+
+	interval := defaultValueInterval
+	if s.c.Value.IntervalSeconds > 0 {
+		interval = time.Duration(s.c.Value.IntervalSeconds) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.syncAll(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(valueFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.flush(ctx)
+			}
+		}
+	}()
+}
+
+func (s *valueService) syncAll(ctx context.Context) {
+	brokers, err := s.br.ListAll(ctx)
+	if err != nil {
+		log.Printf("valueService.syncAll: %s", err)
+		return
+	}
+
+	listed := make(map[uuid.UUID]bool, len(brokers))
+	for _, broker := range brokers {
+		listed[broker.ID] = true
+
+		if err := s.sync(ctx, broker.UserID, broker.ID); err != nil {
+			log.Printf("valueService.syncAll: broker %s, %s", broker.ID, err)
+		}
+	}
+
+	s.mutex.Lock()
+	stale := []uuid.UUID{}
+	for brokerID := range s.watches {
+		if !listed[brokerID] {
+			stale = append(stale, brokerID)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, brokerID := range stale {
+		s.unwatch(ctx, brokerID)
+	}
+}
+
+// sync subscribes to the effective topics of the controls of the broker and drops the subscriptions
+// which are not used anymore.
+func (s *valueService) sync(ctx context.Context, userID uuid.UUID, brokerID uuid.UUID) error {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	topics, err := s.dcr.ListTopics(ctx, brokerID)
+	if err != nil {
+		return err
+	}
+
+	controls := map[string][]*domain.ControlTopic{}
+	for _, t := range topics {
+		filter := controlTopic(t.BasePath, t.Topic)
+		if !mqtt.ValidFilter(filter) {
+			continue
+		}
+
+		controls[filter] = append(controls[filter], t)
+	}
+
+	s.mutex.Lock()
+	w, ok := s.watches[brokerID]
+	if !ok {
+		w = &valueWatch{userID: userID, subscriptions: map[string]uuid.UUID{}}
+		s.watches[brokerID] = w
+	}
+	w.controls = controls
+
+	subscribed := make(map[string]uuid.UUID, len(w.subscriptions))
+	for filter, subscriptionID := range w.subscriptions {
+		subscribed[filter] = subscriptionID
+	}
+	s.mutex.Unlock()
+
+	for filter, subscriptionID := range subscribed {
+		if _, ok := controls[filter]; ok {
+			continue
+		}
+
+		if err := s.bgs.Unsubscribe(ctx, brokerID, subscriptionID); err != nil {
+			log.Printf("valueService.sync: broker %s, %s", brokerID, err)
+		}
+
+		s.mutex.Lock()
+		delete(w.subscriptions, filter)
+		s.mutex.Unlock()
+	}
+
+	for filter := range controls {
+		if _, ok := subscribed[filter]; ok {
+			continue
+		}
+
+		// The QoS matches the one of the message log, so the shared subscriptions are not downgraded
+		subscriptionID, err := s.bgs.Subscribe(ctx, userID, brokerID, filter, enum.QoSTwo, s.handler(brokerID, filter))
+		if err != nil {
+			return err
+		}
+
+		s.mutex.Lock()
+		w.subscriptions[filter] = subscriptionID
+		s.mutex.Unlock()
+	}
+
+	if len(controls) == 0 {
+		s.mutex.Lock()
+		delete(s.watches, brokerID)
+		s.mutex.Unlock()
+	}
+
+	return nil
+}
+
+func (s *valueService) unwatch(ctx context.Context, brokerID uuid.UUID) {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	s.mutex.Lock()
+	w, ok := s.watches[brokerID]
+	delete(s.watches, brokerID)
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	for _, subscriptionID := range w.subscriptions {
+		if err := s.bgs.Unsubscribe(ctx, brokerID, subscriptionID); err != nil {
+			log.Printf("valueService.unwatch: broker %s, %s", brokerID, err)
+		}
+	}
+}
+
+func (s *valueService) handler(brokerID uuid.UUID, filter string) domain.BridgeHandler {
+	return func(message *domain.BridgeMessage) {
+		s.mutex.Lock()
+		controls := []*domain.ControlTopic{}
+		if w, ok := s.watches[brokerID]; ok {
+			controls = w.controls[filter]
+		}
+		s.mutex.Unlock()
+
+		for _, c := range controls {
+			s.decode(c, message.Payload)
+		}
+	}
+}
+
+// decode keeps aside the value of the payload received by the control, replacing the one not saved yet.
+// The payloads which do not match the control are skipped.
+func (s *valueService) decode(control *domain.ControlTopic, payload []byte) {
+	value, err := s.cts.Decode(control.Type, control.Attributes, payload)
+	if err != nil {
+		if !errors.Is(err, ae.ErrControlNotReadable) && !errors.Is(err, ae.ErrControlPayloadInvalid) {
+			log.Printf("valueService.decode: control %s, %s", control.ID, err)
+		}
+		return
+	}
+
+	s.pendingMutex.Lock()
+	s.pending[control.ID] = value
+	s.pendingMutex.Unlock()
+}
+
+// flush saves the last values received by the controls since the previous flush and checks their alerts.
+func (s *valueService) flush(ctx context.Context) {
+	s.pendingMutex.Lock()
+	pending := s.pending
+	s.pending = make(map[uuid.UUID]interface{}, len(pending))
+	s.pendingMutex.Unlock()
+
+	for controlID, value := range pending {
+		s.setLastValue(ctx, controlID, value)
+	}
+}
+
+func (s *valueService) setLastValue(ctx context.Context, controlID uuid.UUID, value interface{}) {
+	ctx, cancel := context.WithTimeout(ctx, valueWriteTimeout)
+	defer cancel()
+
+	if err := s.dcr.SetLastValue(ctx, controlID, &domain.ControlValue{Data: value}); err != nil {
+		log.Printf("valueService.setLastValue: control %s, %s", controlID, err)
+	}
+
+	s.as.OnValue(ctx, controlID, value)
+}
+
+func (s *valueService) onEvent(ctx context.Context, userID uuid.UUID, event domain.Event) {
+	if !s.enabled() || event.Data.Entity == nil || presenceAction(event.Data.Action) {
+		return
+	}
+
+	entity := event.Data.Entity
+	if entity.Name != enum.BrokersEntity && entity.Name != enum.DevicesEntity && entity.Name != enum.DeviceControlsEntity {
+		return
+	}
+
+	if entity.Name == enum.BrokersEntity && event.Data.Action == enum.EntityDeletedAction {
+		go s.unwatch(context.Background(), entity.ID)
+		return
+	}
+
+	// A device can be moved between the brokers, so every broker of the user already listened to is synced
+	brokerIDs := map[uuid.UUID]bool{}
+	if entity.Name == enum.BrokersEntity {
+		brokerIDs[entity.ID] = true
+	}
+
+	if event.Data.Related != nil {
+		for _, related := range *event.Data.Related {
+			if related.Name == enum.BrokersEntity && related.ID != uuid.Nil {
+				brokerIDs[related.ID] = true
+			}
+		}
+	}
+
+	s.mutex.Lock()
+	for brokerID, w := range s.watches {
+		if w.userID == userID {
+			brokerIDs[brokerID] = true
+		}
+	}
+	s.mutex.Unlock()
+
+	go func() {
+		for brokerID := range brokerIDs {
+			if err := s.sync(context.Background(), userID, brokerID); err != nil {
+				log.Printf("valueService.onEvent: broker %s, %s", brokerID, err)
+			}
+		}
+	}()
+}
+
+func (s *valueService) enabled() bool {
+	return s.c.Value != nil && s.c.Value.Enabled
+}
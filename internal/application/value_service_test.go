@@ -0,0 +1,99 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+type singleBrokerRepository struct {
+	repository.BrokerRepository
+	broker *domain.Broker
+}
+
+func (r *singleBrokerRepository) ListAll(context.Context) ([]*domain.Broker, error) {
+	return []*domain.Broker{r.broker}, nil
+}
+
+// savingControlRepository lists a single control and records the values saved for it.
+type savingControlRepository struct {
+	repository.DeviceControlRepository
+	control *domain.ControlTopic
+	values  []interface{}
+	mutex   sync.Mutex
+}
+
+func (r *savingControlRepository) ListTopics(context.Context, uuid.UUID) ([]*domain.ControlTopic, error) {
+	return []*domain.ControlTopic{r.control}, nil
+}
+
+func (r *savingControlRepository) SetLastValue(_ context.Context, _ uuid.UUID, value *domain.ControlValue) error {
+	r.mutex.Lock()
+	r.values = append(r.values, value.Data)
+	r.mutex.Unlock()
+	return nil
+}
+
+func (r *savingControlRepository) saved() []interface{} {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]interface{}{}, r.values...)
+}
+
+func (rawControlTypeService) Decode(_ enum.ControlType, _ domain.ControlAttributes, payload []byte) (interface{}, error) {
+	return string(payload), nil
+}
+
+// handlerBridgeService hands out the handler of the subscription.
+type handlerBridgeService struct {
+	application.BridgeService
+	handlers chan domain.BridgeHandler
+}
+
+func (s *handlerBridgeService) Subscribe(_ context.Context, _ uuid.UUID, _ uuid.UUID, _ string, _ enum.QoSLevel, handler domain.BridgeHandler) (uuid.UUID, error) {
+	s.handlers <- handler
+	return uuid.New(), nil
+}
+
+type silentAlertService struct {
+	application.AlertService
+}
+
+func (silentAlertService) OnValue(context.Context, uuid.UUID, interface{}) {}
+
+func TestValueFlush(t *testing.T) {
+	t.Run("should save the last of the values received within the flush interval", func(t *testing.T) {
+		broker := &domain.Broker{ID: uuid.New(), UserID: uuid.New()}
+		dcr := &savingControlRepository{control: &domain.ControlTopic{ID: uuid.New(), Topic: "a/b"}}
+		bgs := &handlerBridgeService{handlers: make(chan domain.BridgeHandler, 1)}
+		c := &config.Config{Value: &config.ValueConfig{Enabled: true, IntervalSeconds: 3600}}
+
+		vs := application.NewValueService(c, &singleBrokerRepository{broker: broker}, dcr, rawControlTypeService{},
+			bgs, silentAlertService{}, silentEventService{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		vs.Start(ctx)
+
+		handler := <-bgs.handlers
+		for i := 1; i <= 100; i++ {
+			handler(&domain.BridgeMessage{BrokerID: broker.ID, Topic: "a/b", Payload: []byte(fmt.Sprint(i))})
+		}
+		assert.Equal(t, 0, len(dcr.saved()))
+
+		for i := 0; i < 30 && len(dcr.saved()) == 0; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		assert.Equal(t, []interface{}{"100"}, dcr.saved())
+	})
+}
@@ -1,6 +1,9 @@
 package adapter
 
+import "github.com/Deve-Lite/DashboardX-API/internal/domain"
+
 type MailAdapter interface {
 	SendConfirmAccount(receiver string, token string) error
 	SendPasswordReset(receiver string, token string) error
+	SendAlertIncident(receiver string, notification *domain.AlertNotification) error
 }
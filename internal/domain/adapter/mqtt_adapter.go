@@ -0,0 +1,18 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type MQTTAdapter interface {
+	Connect(broker *domain.Broker, handler domain.BridgeHandler) error
+	Disconnect(brokerID uuid.UUID)
+	IsConnected(brokerID uuid.UUID) bool
+	Subscribe(brokerID uuid.UUID, topic string, qos byte) error
+	Unsubscribe(brokerID uuid.UUID, topics ...string) error
+	Publish(brokerID uuid.UUID, topic string, qos byte, retained bool, payload []byte) error
+	Probe(ctx context.Context, broker *domain.Broker) *domain.BrokerProbe
+}
@@ -0,0 +1,7 @@
+package adapter
+
+import "context"
+
+type WebhookAdapter interface {
+	Post(ctx context.Context, url string, body interface{}) error
+}
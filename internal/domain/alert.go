@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// Alert watches a condition of a control, a device or a broker. The threshold alerts compare the values
+// of the control with the threshold, the state change alerts wait for the control to reach the state,
+// the device offline alerts wait for the device to be silent for the minutes and the broker disconnected
+// alerts follow the health of the broker.
+type Alert struct {
+	ID              uuid.UUID           `db:"id"`
+	UserID          uuid.UUID           `db:"user_id"`
+	Name            string              `db:"name"`
+	Kind            enum.AlertKind      `db:"kind"`
+	BrokerID        uuid.NullUUID       `db:"broker_id"`
+	DeviceID        uuid.NullUUID       `db:"device_id"`
+	ControlID       uuid.NullUUID       `db:"control_id"`
+	Operator        *enum.AlertOperator `db:"operator"`
+	Threshold       *float64            `db:"threshold"`
+	Hysteresis      float64             `db:"hysteresis"`
+	State           *ControlValue       `db:"state"`
+	OfflineMinutes  *int                `db:"offline_minutes"`
+	CooldownMinutes int                 `db:"cooldown_minutes"`
+	NotifyEmail     bool                `db:"notify_email"`
+	WebhookURL      *string             `db:"webhook_url"`
+	IsEnabled       bool                `db:"is_enabled"`
+	CreatedAt       time.Time           `db:"created_at"`
+	UpdatedAt       time.Time           `db:"updated_at"`
+}
+
+type CreateAlert struct {
+	UserID          uuid.UUID           `db:"user_id"`
+	Name            string              `db:"name"`
+	Kind            enum.AlertKind      `db:"kind"`
+	BrokerID        uuid.NullUUID       `db:"broker_id"`
+	DeviceID        uuid.NullUUID       `db:"device_id"`
+	ControlID       uuid.NullUUID       `db:"control_id"`
+	Operator        *enum.AlertOperator `db:"operator"`
+	Threshold       *float64            `db:"threshold"`
+	Hysteresis      float64             `db:"hysteresis"`
+	State           *ControlValue       `db:"state"`
+	OfflineMinutes  *int                `db:"offline_minutes"`
+	CooldownMinutes int                 `db:"cooldown_minutes"`
+	NotifyEmail     bool                `db:"notify_email"`
+	WebhookURL      *string             `db:"webhook_url"`
+	IsEnabled       bool                `db:"is_enabled"`
+}
+
+// UpdateAlert replaces the whole definition of the alert, the fields of the other kinds are cleared.
+type UpdateAlert struct {
+	ID uuid.UUID `db:"id"`
+	CreateAlert
+}
+
+// AlertIncident is a period in which the condition of the alert held, at most one incident of an alert
+// is not resolved at a time. The acknowledged incidents are still resolved once the condition clears.
+type AlertIncident struct {
+	ID             uuid.UUID           `db:"id"`
+	AlertID        uuid.UUID           `db:"alert_id"`
+	AlertName      string              `db:"alert_name"`
+	Kind           enum.AlertKind      `db:"kind"`
+	UserID         uuid.UUID           `db:"user_id"`
+	Status         enum.IncidentStatus `db:"status"`
+	Value          *ControlValue       `db:"value"`
+	Message        string              `db:"message"`
+	OpenedAt       time.Time           `db:"opened_at"`
+	AcknowledgedAt *time.Time          `db:"acknowledged_at"`
+	ResolvedAt     *time.Time          `db:"resolved_at"`
+}
+
+type CreateAlertIncident struct {
+	AlertID uuid.UUID     `db:"alert_id"`
+	Value   *ControlValue `db:"value"`
+	Message string        `db:"message"`
+}
+
+type ListAlertIncidentFilters struct {
+	Page
+	UserID  uuid.UUID
+	AlertID uuid.NullUUID
+	Status  *enum.IncidentStatus
+}
+
+// AlertNotification is sent by email and to the webhook of the alert when its incident is opened or resolved.
+type AlertNotification struct {
+	Action     enum.EventAction    `json:"action"`
+	AlertID    uuid.UUID           `json:"alertId"`
+	AlertName  string              `json:"alertName"`
+	Kind       enum.AlertKind      `json:"kind"`
+	IncidentID uuid.UUID           `json:"incidentId"`
+	Status     enum.IncidentStatus `json:"status"`
+	Message    string              `json:"message"`
+	Value      interface{}         `json:"value,omitempty"`
+	OpenedAt   time.Time           `json:"openedAt"`
+	ResolvedAt *time.Time          `json:"resolvedAt,omitempty"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// BatchRef points at an entity by its id or by the reference of the operation which created it earlier in the batch.
+type BatchRef struct {
+	ID  uuid.UUID
+	Ref string
+}
+
+type BatchOperation struct {
+	Action    enum.BatchAction
+	Entity    enum.BatchEntity
+	Ref       string
+	ID        BatchRef
+	DeviceID  BatchRef
+	BrokerRef string
+	Version   *int64
+
+	CreateBroker  *CreateBroker
+	UpdateBroker  *UpdateBroker
+	CreateDevice  *CreateDevice
+	UpdateDevice  *UpdateDevice
+	CreateControl *CreateDeviceControl
+	UpdateControl *UpdateDeviceControl
+}
+
+type BatchResult struct {
+	Action enum.BatchAction
+	Entity enum.BatchEntity
+	Ref    string
+	ID     uuid.UUID
+}
+
+// BatchError tells which operation failed the batch, none of its operations is applied then.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d: %s", e.Index, e.Err.Error())
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
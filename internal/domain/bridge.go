@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BridgeMessage struct {
+	BrokerID   uuid.UUID
+	Topic      string
+	Payload    []byte
+	QoS        byte
+	Retained   bool
+	ReceivedAt time.Time
+}
+
+type BridgeHandler func(message *BridgeMessage)
@@ -1,54 +1,112 @@
 package domain
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/google/uuid"
 )
 
 type Broker struct {
-	ID                  uuid.UUID `db:"id"`
-	UserID              uuid.UUID `db:"user_id"`
-	Name                string    `db:"name"`
-	Server              string    `db:"server"`
-	Port                uint16    `db:"port"`
-	KeepAlive           uint16    `db:"keep_alive"`
-	IconName            string    `db:"icon_name"`
-	IconBackgroundColor string    `db:"icon_background_color"`
-	IsSSL               bool      `db:"is_ssl"`
-	Username            t.String  `db:"username"`
-	Password            t.String  `db:"password"`
-	ClientID            t.String  `db:"client_id"`
-	CreatedAt           time.Time `db:"created_at"`
-	UpdatedAt           time.Time `db:"updated_at"`
+	ID                  uuid.UUID            `db:"id"`
+	UserID              uuid.UUID            `db:"user_id"`
+	Name                string               `db:"name"`
+	Server              string               `db:"server"`
+	Port                uint16               `db:"port"`
+	KeepAlive           uint16               `db:"keep_alive"`
+	IconName            string               `db:"icon_name"`
+	IconBackgroundColor string               `db:"icon_background_color"`
+	IsSSL               bool                 `db:"is_ssl"`
+	Username            t.String             `db:"username"`
+	Password            t.String             `db:"password"`
+	ClientID            t.String             `db:"client_id"`
+	DiscoveryMode       enum.DiscoveryMode   `db:"discovery_mode"`
+	DiscoveryPrefix     string               `db:"discovery_prefix"`
+	ProtocolVersion     enum.MQTTVersion     `db:"protocol_version"`
+	Transport           enum.MQTTTransport   `db:"transport"`
+	Path                t.String             `db:"path"`
+	CleanStart          bool                 `db:"clean_start"`
+	SessionExpiry       uint32               `db:"session_expiry"`
+	UserProperties      BrokerUserProperties `db:"user_properties"`
+	Status              enum.BrokerStatus    `db:"-"`
+	StatusChangedAt     *time.Time           `db:"-"`
+	Certificates        *BrokerCertificates  `db:"-"`
+	Version             int64                `db:"version"`
+	CreatedAt           time.Time            `db:"created_at"`
+	UpdatedAt           time.Time            `db:"updated_at"`
 }
 
 type CreateBroker struct {
-	UserID              uuid.UUID `db:"user_id"`
-	Name                string    `db:"name"`
-	Server              string    `db:"server"`
-	Port                uint16    `db:"port"`
-	KeepAlive           uint16    `db:"keep_alive"`
-	IconName            string    `db:"icon_name"`
-	IconBackgroundColor string    `db:"icon_background_color"`
-	IsSSL               bool      `db:"is_ssl"`
-	Username            t.String  `db:"username"`
-	Password            t.String  `db:"password"`
-	ClientID            t.String  `db:"client_id"`
+	UserID              uuid.UUID            `db:"user_id"`
+	Name                string               `db:"name"`
+	Server              string               `db:"server"`
+	Port                uint16               `db:"port"`
+	KeepAlive           uint16               `db:"keep_alive"`
+	IconName            string               `db:"icon_name"`
+	IconBackgroundColor string               `db:"icon_background_color"`
+	IsSSL               bool                 `db:"is_ssl"`
+	Username            t.String             `db:"username"`
+	Password            t.String             `db:"password"`
+	ClientID            t.String             `db:"client_id"`
+	DiscoveryMode       enum.DiscoveryMode   `db:"discovery_mode"`
+	DiscoveryPrefix     t.String             `db:"discovery_prefix"`
+	ProtocolVersion     enum.MQTTVersion     `db:"protocol_version"`
+	Transport           enum.MQTTTransport   `db:"transport"`
+	Path                t.String             `db:"path"`
+	CleanStart          *bool                `db:"clean_start"`
+	SessionExpiry       *uint32              `db:"session_expiry"`
+	UserProperties      BrokerUserProperties `db:"user_properties"`
 }
 
 type UpdateBroker struct {
-	ID                  uuid.UUID `db:"user_id"`
-	UserID              uuid.UUID `db:"user_id"`
-	Name                t.String  `db:"name"`
-	Server              t.String  `db:"server"`
-	Port                t.Uint16  `db:"port"`
-	KeepAlive           t.Uint16  `db:"keep_alive"`
-	IconName            t.String  `db:"icon_name"`
-	IconBackgroundColor t.String  `db:"icon_background_color"`
-	IsSSL               t.Bool    `db:"is_ssl"`
-	Username            t.String  `db:"username"`
-	Password            t.String  `db:"password"`
-	ClientID            t.String  `db:"client_id"`
+	ID                  uuid.UUID            `db:"user_id"`
+	UserID              uuid.UUID            `db:"user_id"`
+	Name                t.String             `db:"name"`
+	Server              t.String             `db:"server"`
+	Port                t.Uint16             `db:"port"`
+	KeepAlive           t.Uint16             `db:"keep_alive"`
+	IconName            t.String             `db:"icon_name"`
+	IconBackgroundColor t.String             `db:"icon_background_color"`
+	IsSSL               t.Bool               `db:"is_ssl"`
+	Username            t.String             `db:"username"`
+	Password            t.String             `db:"password"`
+	ClientID            t.String             `db:"client_id"`
+	DiscoveryMode       *enum.DiscoveryMode  `db:"discovery_mode"`
+	DiscoveryPrefix     t.String             `db:"discovery_prefix"`
+	ProtocolVersion     *enum.MQTTVersion    `db:"protocol_version"`
+	Transport           *enum.MQTTTransport  `db:"transport"`
+	Path                t.String             `db:"path"`
+	CleanStart          t.Bool               `db:"clean_start"`
+	SessionExpiry       *uint32              `db:"session_expiry"`
+	UserProperties      BrokerUserProperties `db:"user_properties"`
+	Version             *int64               `db:"-"`
+}
+
+type ListBrokerFilters struct {
+	Page
+	UserID uuid.UUID
+}
+
+// BrokerUserProperties are the MQTT 5 user properties sent with the CONNECT packet.
+type BrokerUserProperties map[string]string
+
+func (p BrokerUserProperties) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(p)
+}
+
+func (p *BrokerUserProperties) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, p)
 }
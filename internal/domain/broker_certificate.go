@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+type BrokerCertificates struct {
+	BrokerID          uuid.UUID `db:"broker_id"`
+	CACertificate     t.String  `db:"ca_certificate"`
+	ClientCertificate t.String  `db:"client_certificate"`
+	ClientKey         t.String  `db:"client_key"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+// UpdateBrokerCertificates changes only the set fields, a null field removes the stored value.
+type UpdateBrokerCertificates struct {
+	BrokerID          uuid.UUID
+	UserID            uuid.UUID
+	CACertificate     t.String
+	ClientCertificate t.String
+	ClientKey         t.String
+}
+
+type CertificateInfo struct {
+	Subject      string
+	Issuer       string
+	SerialNumber string
+	Fingerprint  string
+	NotBefore    time.Time
+	NotAfter     time.Time
+}
+
+type CertificateWarning struct {
+	Kind      string
+	Subject   string
+	NotAfter  time.Time
+	ExpiresIn time.Duration
+}
+
+type BrokerCertificatesInfo struct {
+	BrokerID     uuid.UUID
+	CA           []*CertificateInfo
+	Client       *CertificateInfo
+	HasClientKey bool
+	Warnings     []*CertificateWarning
+	UpdatedAt    *time.Time
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type BrokerHealth struct {
+	BrokerID  uuid.UUID         `json:"brokerId"`
+	Status    enum.BrokerStatus `json:"status"`
+	Error     string            `json:"error"`
+	ChangedAt time.Time         `json:"changedAt"`
+	CheckedAt time.Time         `json:"checkedAt"`
+
+	// CertificateExpiresAt is the earliest expiry of the broker certificates which are about to expire.
+	CertificateExpiresAt *time.Time `json:"certificateExpiresAt"`
+}
+
+type BrokerProbe struct {
+	Success bool
+	Steps   []*BrokerProbeStep
+}
+
+type BrokerProbeStep struct {
+	Name    string
+	Success bool
+	Latency time.Duration
+	Error   string
+}
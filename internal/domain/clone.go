@@ -0,0 +1,24 @@
+package domain
+
+import (
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+// CloneDevice copies the device with its controls, the unset fields keep the values of the source device
+// and the null broker leaves the copy without one.
+type CloneDevice struct {
+	UserID   uuid.UUID
+	DeviceID uuid.UUID
+	BrokerID t.Nullable[uuid.UUID]
+	Name     t.String
+	BasePath t.String
+}
+
+// CloneBroker copies the broker with its credentials, certificates and devices to the new server.
+type CloneBroker struct {
+	UserID   uuid.UUID
+	BrokerID uuid.UUID
+	Name     string
+	Server   string
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+
+// TransformPreview runs the transform on the payload, and when the type is set,
+// decodes the result as a control of the type with the attributes would.
+type TransformPreview struct {
+	Payload    []byte
+	Transform  map[string]interface{}
+	Type       enum.ControlType
+	Attributes ControlAttributes
+}
+
+// TransformResult is the payload received by a control after its transform. The value is a number
+// once it is scaled or rounded, the text is the value or its label which is passed on to the control type.
+type TransformResult struct {
+	Text    string
+	Value   interface{}
+	Label   *string
+	Unit    *string
+	Decoded interface{}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+	"github.com/google/uuid"
+)
+
+// ControlType describes the attributes of a control type and how its values are sent and received.
+type ControlType struct {
+	Name        enum.ControlType
+	Description string
+	Schema      *jsonschema.Schema
+	// Check validates the rules between the attributes which the schema can not express,
+	// it is called with the attributes decoded from JSON once they match the schema.
+	Check func(attributes ControlAttributes) []*jsonschema.Error
+	// Encode turns the value set by the user into the published payload, nil for the read only types.
+	// The context describes the control for the payload templates, it is nil when the control is not known.
+	Encode func(attributes ControlAttributes, value interface{}, pc *PayloadContext) ([]byte, error)
+	// Decode turns the received payload into the displayed value, nil for the write only types.
+	Decode func(attributes ControlAttributes, payload []byte) (interface{}, error)
+}
+
+// PayloadContext is the control whose payload is encoded, its fields are available to the payload templates.
+type PayloadContext struct {
+	DeviceID    uuid.UUID
+	DeviceName  string
+	Placing     *string
+	BasePath    *string
+	ControlID   uuid.UUID
+	ControlName string
+	Topic       string
+	Timestamp   time.Time
+}
+
+// RenderedPayload is the payload which would be published to the topic for the value.
+type RenderedPayload struct {
+	Topic   string
+	QoS     enum.QoSLevel
+	Payload []byte
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Dashboard struct {
+	ID                  uuid.UUID       `db:"id"`
+	UserID              uuid.UUID       `db:"user_id"`
+	Name                string          `db:"name"`
+	IconName            string          `db:"icon_name"`
+	IconBackgroundColor string          `db:"icon_background_color"`
+	Position            int             `db:"position"`
+	Tabs                []*DashboardTab `db:"-"`
+	CreatedAt           time.Time       `db:"created_at"`
+	UpdatedAt           time.Time       `db:"updated_at"`
+}
+
+type CreateDashboard struct {
+	UserID              uuid.UUID `db:"user_id"`
+	Name                string    `db:"name"`
+	IconName            string    `db:"icon_name"`
+	IconBackgroundColor string    `db:"icon_background_color"`
+}
+
+type UpdateDashboard struct {
+	ID                  uuid.UUID `db:"id"`
+	UserID              uuid.UUID `db:"user_id"`
+	Name                *string   `db:"name"`
+	IconName            *string   `db:"icon_name"`
+	IconBackgroundColor *string   `db:"icon_background_color"`
+}
+
+type DashboardTab struct {
+	ID          uuid.UUID          `db:"id"`
+	DashboardID uuid.UUID          `db:"dashboard_id"`
+	Name        string             `db:"name"`
+	Position    int                `db:"position"`
+	Widgets     []*DashboardWidget `db:"-"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+}
+
+type CreateDashboardTab struct {
+	DashboardID uuid.UUID `db:"dashboard_id"`
+	Name        string    `db:"name"`
+}
+
+type UpdateDashboardTab struct {
+	ID          uuid.UUID `db:"id"`
+	DashboardID uuid.UUID `db:"dashboard_id"`
+	Name        *string   `db:"name"`
+}
+
+// DashboardWidget places a device, or one of its controls, on the grid of a tab.
+type DashboardWidget struct {
+	ID        uuid.UUID       `db:"id"`
+	TabID     uuid.UUID       `db:"tab_id"`
+	DeviceID  uuid.UUID       `db:"device_id"`
+	ControlID uuid.NullUUID   `db:"control_id"`
+	X         int             `db:"x"`
+	Y         int             `db:"y"`
+	Width     int             `db:"width"`
+	Height    int             `db:"height"`
+	Overrides WidgetOverrides `db:"overrides"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+type CreateDashboardWidget struct {
+	TabID     uuid.UUID       `db:"tab_id"`
+	DeviceID  uuid.UUID       `db:"device_id"`
+	ControlID uuid.NullUUID   `db:"control_id"`
+	X         int             `db:"x"`
+	Y         int             `db:"y"`
+	Width     int             `db:"width"`
+	Height    int             `db:"height"`
+	Overrides WidgetOverrides `db:"overrides"`
+}
+
+type UpdateDashboardWidget struct {
+	ID        uuid.UUID        `db:"id"`
+	TabID     uuid.UUID        `db:"tab_id"`
+	X         *int             `db:"x"`
+	Y         *int             `db:"y"`
+	Width     *int             `db:"width"`
+	Height    *int             `db:"height"`
+	Overrides *WidgetOverrides `db:"overrides"`
+}
+
+type WidgetLayout struct {
+	ID     uuid.UUID
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// WidgetOverrides replace how the device or control is displayed on a single widget, the unset ones are not changed.
+type WidgetOverrides struct {
+	Name                *string `json:"name,omitempty"`
+	IconName            *string `json:"iconName,omitempty"`
+	IconBackgroundColor *string `json:"iconBackgroundColor,omitempty"`
+	CanDisplayName      *bool   `json:"canDisplayName,omitempty"`
+}
+
+func (o WidgetOverrides) Value() (driver.Value, error) {
+	return json.Marshal(o)
+}
+
+func (o *WidgetOverrides) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, o)
+}
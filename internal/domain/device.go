@@ -3,6 +3,7 @@ package domain
 import (
 	"time"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/google/uuid"
 )
@@ -11,18 +12,26 @@ type Device struct {
 	ID                  uuid.UUID     `db:"id"`
 	UserID              uuid.UUID     `db:"user_id"`
 	BrokerID            uuid.NullUUID `db:"broker_id"`
+	RoomID              uuid.NullUUID `db:"room_id"`
 	Name                string        `db:"name"`
 	IconName            string        `db:"icon_name"`
 	IconBackgroundColor string        `db:"icon_background_color"`
 	Placing             *string       `db:"placing"`
 	BasePath            *string       `db:"base_path"`
+	TagIDs              []uuid.UUID   `db:"-"`
+	Version             int64         `db:"version"`
 	CreatedAt           time.Time     `db:"created_at"`
 	UpdatedAt           time.Time     `db:"updated_at"`
+
+	// Status is tracked by the server for the devices with the availability, the others are unknown.
+	Status     enum.DeviceStatus `db:"status"`
+	LastSeenAt *time.Time        `db:"last_seen_at"`
 }
 
 type CreateDevice struct {
 	UserID              uuid.UUID     `db:"user_id"`
 	BrokerID            uuid.NullUUID `db:"broker_id"`
+	RoomID              uuid.NullUUID `db:"room_id"`
 	Name                string        `db:"name"`
 	IconName            string        `db:"icon_name"`
 	IconBackgroundColor string        `db:"icon_background_color"`
@@ -34,14 +43,21 @@ type UpdateDevice struct {
 	ID                  uuid.UUID             `db:"id"`
 	UserID              uuid.UUID             `db:"user_id"`
 	BrokerID            t.Nullable[uuid.UUID] `db:"broker_id"`
+	RoomID              t.Nullable[uuid.UUID] `db:"room_id"`
 	Name                t.String              `db:"name"`
 	IconName            t.String              `db:"icon_name"`
 	IconBackgroundColor t.String              `db:"icon_background_color"`
 	Placing             t.String              `db:"placing"`
 	BasePath            t.String              `db:"base_path"`
+	Version             *int64                `db:"-"`
 }
 
 type ListDeviceFilters struct {
-	UserID   uuid.UUID
-	BrokerID uuid.NullUUID
+	Page
+	UserID      uuid.UUID
+	BrokerID    uuid.NullUUID
+	RoomID      uuid.NullUUID
+	TagID       uuid.NullUUID
+	Placing     *string
+	ControlType *enum.ControlType
 }
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// DeviceAvailability sets how the status of the device is tracked. The lwt devices go online and offline
+// with the payloads received on the topic, the heartbeat devices are online while they publish on the topic,
+// or on the topics of their controls when it is not set, within the timeout.
+type DeviceAvailability struct {
+	DeviceID       uuid.UUID             `db:"device_id"`
+	Mode           enum.AvailabilityMode `db:"mode"`
+	Topic          *string               `db:"topic"`
+	OnlinePayload  *string               `db:"online_payload"`
+	OfflinePayload *string               `db:"offline_payload"`
+	TimeoutSeconds *int                  `db:"timeout_seconds"`
+	CreatedAt      time.Time             `db:"created_at"`
+	UpdatedAt      time.Time             `db:"updated_at"`
+}
+
+type SetDeviceAvailability struct {
+	UserID         uuid.UUID             `db:"-"`
+	DeviceID       uuid.UUID             `db:"device_id"`
+	Mode           enum.AvailabilityMode `db:"mode"`
+	Topic          *string               `db:"topic"`
+	OnlinePayload  *string               `db:"online_payload"`
+	OfflinePayload *string               `db:"offline_payload"`
+	TimeoutSeconds *int                  `db:"timeout_seconds"`
+}
+
+// TrackedDevice is a device of the broker with the availability, along with its last known status.
+type TrackedDevice struct {
+	DeviceAvailability
+	UserID     uuid.UUID         `db:"user_id"`
+	BrokerID   uuid.UUID         `db:"broker_id"`
+	BasePath   *string           `db:"base_path"`
+	Status     enum.DeviceStatus `db:"status"`
+	LastSeenAt *time.Time        `db:"last_seen_at"`
+}
+
+// DevicePresence is the last known status of the device, ChangedAt is when it has last changed.
+type DevicePresence struct {
+	DeviceID   uuid.UUID         `db:"device_id"`
+	Status     enum.DeviceStatus `db:"status"`
+	LastSeenAt *time.Time        `db:"last_seen_at"`
+	ChangedAt  *time.Time        `db:"changed_at"`
+}
+
+type AvailabilityTransition struct {
+	ID        uuid.UUID               `db:"id"`
+	DeviceID  uuid.UUID               `db:"device_id"`
+	Status    enum.DeviceStatus       `db:"status"`
+	Reason    enum.AvailabilityReason `db:"reason"`
+	CreatedAt time.Time               `db:"created_at"`
+}
+
+type ListAvailabilityTransitionFilters struct {
+	Page
+	DeviceID uuid.UUID
+	From     *time.Time
+	To       *time.Time
+}
@@ -23,6 +23,8 @@ type DeviceControl struct {
 	CanDisplayName         bool              `db:"can_display_name"`
 	Topic                  string            `db:"topic"`
 	Attributes             ControlAttributes `db:"attributes"`
+	TagIDs                 []uuid.UUID       `db:"-"`
+	Version                int64             `db:"version"`
 }
 
 type CreateDeviceControl struct {
@@ -54,6 +56,15 @@ type UpdateDeviceControl struct {
 	CanDisplayName         *bool             `db:"can_display_name"`
 	Topic                  *string           `db:"topic"`
 	Attributes             ControlAttributes `db:"attributes"`
+	Version                *int64            `db:"-"`
+}
+
+type ListDeviceControlFilters struct {
+	Page
+	DeviceID    uuid.UUID
+	Type        *enum.ControlType
+	IsAvailable *bool
+	TagID       uuid.NullUUID
 }
 
 type DeviceControlFilters struct {
@@ -75,3 +86,21 @@ func (a *ControlAttributes) Scan(value interface{}) error {
 
 	return json.Unmarshal(b, &a)
 }
+
+// ControlValue is the value of the control as it was published, e.g. true for a switch or 21.5 for a slider.
+type ControlValue struct {
+	Data interface{}
+}
+
+func (v ControlValue) Value() (driver.Value, error) {
+	return json.Marshal(v.Data)
+}
+
+func (v *ControlValue) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &v.Data)
+}
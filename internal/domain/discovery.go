@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultDiscoveryPrefix is the topic prefix used by Home Assistant for the discovery messages.
+const DefaultDiscoveryPrefix = "homeassistant"
+
+type DiscoveryProposal struct {
+	ID               uuid.UUID
+	BrokerID         uuid.UUID
+	Component        string
+	ConfigTopic      string
+	DeviceIdentifier string
+	DeviceName       string
+	Control          CreateDeviceControl
+	DiscoveredAt     time.Time
+}
+
+type DiscoveryResult struct {
+	DeviceID  uuid.UUID
+	ControlID uuid.UUID
+}
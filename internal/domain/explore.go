@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type Explore struct {
+	UserID   uuid.UUID
+	BrokerID uuid.UUID
+	Filter   string
+	Window   time.Duration
+}
+
+// TopicTree is the state of the explorer, the topics over the limit are not added to the tree
+// and their messages are counted as ignored.
+type TopicTree struct {
+	Topics   int
+	Messages int
+	Ignored  int
+	Nodes    []*TopicNode
+}
+
+// TopicNode is a level of the topic tree, the message fields are set for the levels
+// which messages have been published to.
+type TopicNode struct {
+	Name       string
+	Topic      string
+	Messages   int
+	Payload    *string
+	Truncated  bool
+	Retained   bool
+	ReceivedAt *time.Time
+	Children   []*TopicNode
+}
+
+type ExploreControl struct {
+	UserID     uuid.UUID
+	BrokerID   uuid.UUID
+	DeviceID   uuid.UUID
+	Name       string
+	Type       enum.ControlType
+	Attributes ControlAttributes
+	Topic      string
+	QoS        enum.QoSLevel
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type Group struct {
+	ID                  uuid.UUID        `db:"id"`
+	UserID              uuid.UUID        `db:"user_id"`
+	Name                string           `db:"name"`
+	Type                enum.ControlType `db:"type"`
+	IconName            string           `db:"icon_name"`
+	IconBackgroundColor string           `db:"icon_background_color"`
+	CreatedAt           time.Time        `db:"created_at"`
+	UpdatedAt           time.Time        `db:"updated_at"`
+	ControlIDs          []uuid.UUID      `db:"-"`
+}
+
+type CreateGroup struct {
+	UserID              uuid.UUID        `db:"user_id"`
+	Name                string           `db:"name"`
+	Type                enum.ControlType `db:"type"`
+	IconName            string           `db:"icon_name"`
+	IconBackgroundColor string           `db:"icon_background_color"`
+}
+
+type UpdateGroup struct {
+	ID                  uuid.UUID `db:"id"`
+	UserID              uuid.UUID `db:"user_id"`
+	Name                *string   `db:"name"`
+	IconName            *string   `db:"icon_name"`
+	IconBackgroundColor *string   `db:"icon_background_color"`
+}
+
+// GroupMember is a control of the group with everything needed to publish to it.
+type GroupMember struct {
+	ControlID   uuid.UUID         `db:"control_id"`
+	ControlName string            `db:"control_name"`
+	DeviceID    uuid.UUID         `db:"device_id"`
+	DeviceName  string            `db:"device_name"`
+	Placing     *string           `db:"placing"`
+	BrokerID    uuid.NullUUID     `db:"broker_id"`
+	Type        enum.ControlType  `db:"type"`
+	QoS         enum.QoSLevel     `db:"quality_of_service"`
+	Topic       string            `db:"topic"`
+	BasePath    *string           `db:"base_path"`
+	Attributes  ControlAttributes `db:"attributes"`
+	LastValue   *ControlValue     `db:"last_value"`
+	LastValueAt *time.Time        `db:"last_value_at"`
+}
+
+// GroupPublishResult is the outcome of publishing the value to a single member of the group.
+type GroupPublishResult struct {
+	ControlID uuid.UUID
+	DeviceID  uuid.UUID
+	Err       error
+}
+
+// GroupState is derived from the last known values of the members, the members without one are unknown.
+type GroupState struct {
+	GroupID      uuid.UUID
+	State        enum.GroupState
+	OnCount      int
+	OffCount     int
+	UnknownCount int
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type DeviceMessage struct {
+	ID         uuid.UUID             `db:"id"`
+	DeviceID   uuid.UUID             `db:"device_id"`
+	ControlID  uuid.NullUUID         `db:"control_id"`
+	Direction  enum.MessageDirection `db:"direction"`
+	Topic      string                `db:"topic"`
+	Payload    []byte                `db:"payload"`
+	Truncated  bool                  `db:"truncated"`
+	QoS        enum.QoSLevel         `db:"quality_of_service"`
+	Retained   bool                  `db:"retained"`
+	SourceType *enum.MessageSource   `db:"source_type"`
+	SourceID   uuid.NullUUID         `db:"source_id"`
+	CreatedAt  time.Time             `db:"created_at"`
+}
+
+// ListDeviceMessageFilters selects the messages of a device, the topic is a filter which can contain wildcards
+// and the contains is searched for in the payload.
+type ListDeviceMessageFilters struct {
+	Page
+	DeviceID uuid.UUID
+	Topic    string
+	Contains string
+	From     *time.Time
+	To       *time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/pkg/pagination"
+)
+
+// Page selects a part of a list, the zero limit selects all the items. The empty sort uses the default one of the list.
+type Page struct {
+	Search string
+	Sort   string
+	Desc   bool
+	After  *pagination.Cursor
+	Limit  int
+}
+
+type List[T any] struct {
+	Items []T
+	Total int
+	Next  *pagination.Cursor
+}
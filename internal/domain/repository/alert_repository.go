@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AlertRepository interface {
+	Get(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (*domain.Alert, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Alert, error)
+	ListByControl(ctx context.Context, controlID uuid.UUID) ([]*domain.Alert, error)
+	ListByKind(ctx context.Context, kind enum.AlertKind) ([]*domain.Alert, error)
+	ListTrashedUnresolved(ctx context.Context, userID uuid.UUID) ([]*domain.Alert, error)
+	Create(ctx context.Context, alert *domain.CreateAlert) (uuid.UUID, error)
+	Update(ctx context.Context, alert *domain.UpdateAlert) error
+	Delete(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) error
+	ListIncidents(ctx context.Context, filters *domain.ListAlertIncidentFilters) (*domain.List[*domain.AlertIncident], error)
+	GetIncident(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID) (*domain.AlertIncident, error)
+	GetUnresolvedIncident(ctx context.Context, alertID uuid.UUID) (*domain.AlertIncident, error)
+	LastResolvedAt(ctx context.Context, alertID uuid.UUID) (*time.Time, error)
+	CreateIncident(ctx context.Context, incident *domain.CreateAlertIncident) (uuid.UUID, error)
+	AcknowledgeIncident(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID) error
+	ResolveIncident(ctx context.Context, incidentID uuid.UUID) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type BrokerCertificateRepository interface {
+	Get(ctx context.Context, brokerID uuid.UUID) (*domain.BrokerCertificates, error)
+	Set(ctx context.Context, certificates *domain.UpdateBrokerCertificates) error
+}
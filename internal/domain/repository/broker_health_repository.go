@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type BrokerHealthRepository interface {
+	List(ctx context.Context, brokerIDs []uuid.UUID) (map[uuid.UUID]*domain.BrokerHealth, error)
+	Set(ctx context.Context, health *domain.BrokerHealth) error
+	Delete(ctx context.Context, brokerID uuid.UUID) error
+}
@@ -9,8 +9,10 @@ import (
 
 type BrokerRepository interface {
 	Get(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) (*domain.Broker, error)
-	List(ctx context.Context, userID uuid.UUID) ([]*domain.Broker, error)
+	List(ctx context.Context, filters *domain.ListBrokerFilters) (*domain.List[*domain.Broker], error)
+	ListAll(ctx context.Context) ([]*domain.Broker, error)
 	Create(ctx context.Context, broker *domain.CreateBroker) (uuid.UUID, error)
 	Update(ctx context.Context, broker *domain.UpdateBroker) error
-	Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error
+	Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID, version *int64) error
+	Restore(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error
 }
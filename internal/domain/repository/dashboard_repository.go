@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type DashboardRepository interface {
+	Get(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) (*domain.Dashboard, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Dashboard, error)
+	Create(ctx context.Context, dashboard *domain.CreateDashboard) (uuid.UUID, error)
+	Update(ctx context.Context, dashboard *domain.UpdateDashboard) error
+	Delete(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) error
+	Reorder(ctx context.Context, userID uuid.UUID, dashboardIDs []uuid.UUID) error
+	ListTabs(ctx context.Context, dashboardID uuid.UUID) ([]*domain.DashboardTab, error)
+	CreateTab(ctx context.Context, tab *domain.CreateDashboardTab) (uuid.UUID, error)
+	UpdateTab(ctx context.Context, tab *domain.UpdateDashboardTab) error
+	DeleteTab(ctx context.Context, tabID uuid.UUID, dashboardID uuid.UUID) error
+	ReorderTabs(ctx context.Context, dashboardID uuid.UUID, tabIDs []uuid.UUID) error
+	ListWidgets(ctx context.Context, dashboardID uuid.UUID) ([]*domain.DashboardWidget, error)
+	CreateWidget(ctx context.Context, widget *domain.CreateDashboardWidget) (uuid.UUID, error)
+	UpdateWidget(ctx context.Context, widget *domain.UpdateDashboardWidget) error
+	DeleteWidget(ctx context.Context, widgetID uuid.UUID, tabID uuid.UUID) error
+	SetLayout(ctx context.Context, tabID uuid.UUID, layout []*domain.WidgetLayout) error
+}
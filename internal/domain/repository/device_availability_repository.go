@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type DeviceAvailabilityRepository interface {
+	Get(ctx context.Context, deviceID uuid.UUID) (*domain.DeviceAvailability, error)
+	Set(ctx context.Context, availability *domain.SetDeviceAvailability) error
+	Delete(ctx context.Context, deviceID uuid.UUID) error
+	ListTracked(ctx context.Context, brokerID uuid.UUID) ([]*domain.TrackedDevice, error)
+	SetStatus(ctx context.Context, deviceID uuid.UUID, status enum.DeviceStatus, lastSeenAt *time.Time) error
+	SetLastSeen(ctx context.Context, deviceID uuid.UUID, lastSeenAt time.Time) error
+	ListPresence(ctx context.Context, deviceIDs []uuid.UUID) (map[uuid.UUID]*domain.DevicePresence, error)
+	CreateTransition(ctx context.Context, transition *domain.AvailabilityTransition) error
+	ListTransitions(ctx context.Context, filters *domain.ListAvailabilityTransitionFilters) (*domain.List[*domain.AvailabilityTransition], error)
+}
@@ -10,8 +10,12 @@ import (
 type DeviceControlRepository interface {
 	ListByType(ctx context.Context, filters *domain.DeviceControlFilters) ([]*domain.DeviceControl, error)
 	ListByDevice(ctx context.Context, deviceID uuid.UUID) ([]*domain.DeviceControl, error)
+	ListTopics(ctx context.Context, brokerID uuid.UUID) ([]*domain.ControlTopic, error)
+	List(ctx context.Context, filters *domain.ListDeviceControlFilters) (*domain.List[*domain.DeviceControl], error)
 	Create(ctx context.Context, control *domain.CreateDeviceControl) (uuid.UUID, error)
 	Exist(ctx context.Context, filters *domain.DeviceControlFilters) (bool, error)
 	Update(ctx context.Context, control *domain.UpdateDeviceControl) error
-	Delete(ctx context.Context, deviceID uuid.UUID, controlID uuid.UUID) error
+	Delete(ctx context.Context, deviceID uuid.UUID, controlID uuid.UUID, version *int64) error
+	Restore(ctx context.Context, deviceID uuid.UUID, controlID uuid.UUID) error
+	SetLastValue(ctx context.Context, controlID uuid.UUID, value *domain.ControlValue) error
 }
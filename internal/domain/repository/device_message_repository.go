@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+)
+
+type DeviceMessageRepository interface {
+	List(ctx context.Context, filters *domain.ListDeviceMessageFilters) (*domain.List[*domain.DeviceMessage], error)
+	Create(ctx context.Context, message *domain.DeviceMessage) error
+	Purge(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -9,8 +9,9 @@ import (
 
 type DeviceRepository interface {
 	Get(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) (*domain.Device, error)
-	List(ctx context.Context, filters *domain.ListDeviceFilters) ([]*domain.Device, error)
+	List(ctx context.Context, filters *domain.ListDeviceFilters) (*domain.List[*domain.Device], error)
 	Create(ctx context.Context, device *domain.CreateDevice) (uuid.UUID, error)
 	Update(ctx context.Context, device *domain.UpdateDevice) error
-	Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error
+	Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID, version *int64) error
+	Restore(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error
 }
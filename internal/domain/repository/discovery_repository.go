@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type DiscoveryRepository interface {
+	Get(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) (*domain.DiscoveryProposal, error)
+	List(ctx context.Context, brokerID uuid.UUID) ([]*domain.DiscoveryProposal, error)
+	Set(ctx context.Context, proposal *domain.DiscoveryProposal) error
+	Delete(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) error
+	Dismiss(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) error
+	IsDismissed(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) (bool, error)
+	Clear(ctx context.Context, brokerID uuid.UUID) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type GroupRepository interface {
+	Get(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*domain.Group, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Group, error)
+	Create(ctx context.Context, group *domain.CreateGroup) (uuid.UUID, error)
+	Update(ctx context.Context, group *domain.UpdateGroup) error
+	Delete(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error
+	ListControls(ctx context.Context, groupIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error)
+	SetControls(ctx context.Context, groupID uuid.UUID, controlIDs []uuid.UUID) error
+	ListMembers(ctx context.Context, groupID uuid.UUID) ([]*domain.GroupMember, error)
+	ListUserMembers(ctx context.Context, userID uuid.UUID, controlIDs []uuid.UUID) ([]*domain.GroupMember, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type RevisionRepository interface {
+	List(ctx context.Context, entity enum.RevisionEntity, entityID uuid.UUID) ([]*domain.Revision, error)
+	Get(ctx context.Context, entity enum.RevisionEntity, entityID uuid.UUID, revision int) (*domain.Revision, error)
+	Create(ctx context.Context, entity enum.RevisionEntity, entityID uuid.UUID, snapshot interface{}) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type RoomRepository interface {
+	Get(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) (*domain.Room, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Room, error)
+	Create(ctx context.Context, room *domain.CreateRoom) (uuid.UUID, error)
+	Update(ctx context.Context, room *domain.UpdateRoom) error
+	Delete(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) error
+	Reorder(ctx context.Context, userID uuid.UUID, roomIDs []uuid.UUID) error
+	GetState(ctx context.Context, roomID uuid.UUID) (*domain.RoomState, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type SearchRepository interface {
+	Search(ctx context.Context, search *domain.Search) ([]*domain.SearchResult, error)
+	ListTopics(ctx context.Context, userID uuid.UUID) ([]*domain.ControlTopic, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type TagRepository interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error)
+	Create(ctx context.Context, tag *domain.CreateTag) (uuid.UUID, error)
+	Update(ctx context.Context, tag *domain.UpdateTag) error
+	Delete(ctx context.Context, tagID uuid.UUID, userID uuid.UUID) error
+	ListByDevices(ctx context.Context, deviceIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error)
+	ListByControls(ctx context.Context, controlIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error)
+	SetDeviceTags(ctx context.Context, deviceID uuid.UUID, tagIDs []uuid.UUID) error
+	SetControlTags(ctx context.Context, controlID uuid.UUID, tagIDs []uuid.UUID) error
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "context"
+
+// Transactor runs the statements of the repositories called with the context given to fn in a single transaction.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
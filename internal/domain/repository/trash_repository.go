@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/google/uuid"
+)
+
+type TrashRepository interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.TrashEntry, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// Revision is the snapshot of an entity taken after one of its changes, the changes are the fields
+// which differ from the previous revision.
+type Revision struct {
+	Revision  int             `db:"revision"`
+	Snapshot  json.RawMessage `db:"snapshot"`
+	CreatedAt time.Time       `db:"created_at"`
+	Changes   []*RevisionChange
+}
+
+// RevisionChange is a changed field, the path of a control of the device is controls.<id>.
+// A field which has been added or removed has a nil value on the other side.
+type RevisionChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+// BrokerSnapshot are the settings of the broker, the credentials are not kept in the revisions.
+type BrokerSnapshot struct {
+	Name                string               `json:"name"`
+	Server              string               `json:"server"`
+	Port                uint16               `json:"port"`
+	KeepAlive           uint16               `json:"keepAlive"`
+	IconName            string               `json:"iconName"`
+	IconBackgroundColor string               `json:"iconBackgroundColor"`
+	IsSSL               bool                 `json:"isSsl"`
+	DiscoveryMode       enum.DiscoveryMode   `json:"discoveryMode"`
+	DiscoveryPrefix     string               `json:"discoveryPrefix"`
+	ProtocolVersion     enum.MQTTVersion     `json:"protocolVersion"`
+	Transport           enum.MQTTTransport   `json:"transport"`
+	Path                *string              `json:"path"`
+	CleanStart          bool                 `json:"cleanStart"`
+	SessionExpiry       uint32               `json:"sessionExpiry"`
+	UserProperties      BrokerUserProperties `json:"userProperties"`
+}
+
+// DeviceSnapshot is the device along with the definitions of its controls.
+type DeviceSnapshot struct {
+	BrokerID            *uuid.UUID         `json:"brokerId"`
+	RoomID              *uuid.UUID         `json:"roomId"`
+	Name                string             `json:"name"`
+	IconName            string             `json:"iconName"`
+	IconBackgroundColor string             `json:"iconBackgroundColor"`
+	Placing             *string            `json:"placing"`
+	BasePath            *string            `json:"basePath"`
+	Controls            []*ControlSnapshot `json:"controls"`
+}
+
+type ControlSnapshot struct {
+	ID                     uuid.UUID         `json:"id"`
+	Name                   string            `json:"name"`
+	Type                   enum.ControlType  `json:"type"`
+	QoS                    enum.QoSLevel     `json:"qualityOfService"`
+	IconName               string            `json:"iconName"`
+	IconBackgroundColor    string            `json:"iconBackgroundColor"`
+	IsAvailable            bool              `json:"isAvailable"`
+	IsConfirmationRequired bool              `json:"isConfirmationRequired"`
+	CanNotifyOnPublish     bool              `json:"canNotifyOnPublish"`
+	CanDisplayName         bool              `json:"canDisplayName"`
+	Topic                  string            `json:"topic"`
+	Attributes             ControlAttributes `json:"attributes"`
+}
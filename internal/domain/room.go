@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type Room struct {
+	ID                  uuid.UUID `db:"id"`
+	UserID              uuid.UUID `db:"user_id"`
+	Name                string    `db:"name"`
+	IconName            string    `db:"icon_name"`
+	IconBackgroundColor string    `db:"icon_background_color"`
+	Position            int       `db:"position"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
+}
+
+type CreateRoom struct {
+	UserID              uuid.UUID `db:"user_id"`
+	Name                string    `db:"name"`
+	IconName            string    `db:"icon_name"`
+	IconBackgroundColor string    `db:"icon_background_color"`
+}
+
+type UpdateRoom struct {
+	ID                  uuid.UUID `db:"id"`
+	UserID              uuid.UUID `db:"user_id"`
+	Name                *string   `db:"name"`
+	IconName            *string   `db:"icon_name"`
+	IconBackgroundColor *string   `db:"icon_background_color"`
+}
+
+// RoomState aggregates the devices of the room, the status is based on the health of their brokers.
+type RoomState struct {
+	RoomID                uuid.UUID                 `db:"room_id"`
+	DeviceCount           int                       `db:"device_count"`
+	ControlCount          int                       `db:"control_count"`
+	AvailableControlCount int                       `db:"available_control_count"`
+	BrokerIDs             []uuid.UUID               `db:"-"`
+	Brokers               map[enum.BrokerStatus]int `db:"-"`
+	Status                enum.RoomStatus           `db:"-"`
+}
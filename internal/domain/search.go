@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+type Search struct {
+	UserID uuid.UUID
+	Text   string
+	Mode   enum.SearchMode
+	Types  []enum.SearchResultType
+	Limit  int
+}
+
+// SearchResult is a broker, device or control matching the search, the highlight is the matched text
+// with the matches wrapped in <mark> tags and the rank orders the results by relevance.
+type SearchResult struct {
+	Type      enum.SearchResultType `db:"type"`
+	ID        uuid.UUID             `db:"id"`
+	BrokerID  uuid.NullUUID         `db:"broker_id"`
+	DeviceID  uuid.NullUUID         `db:"device_id"`
+	Name      string                `db:"name"`
+	Highlight string                `db:"highlight"`
+	Rank      float64               `db:"rank"`
+}
+
+type ControlTopic struct {
+	ID         uuid.UUID         `db:"id"`
+	BrokerID   uuid.NullUUID     `db:"broker_id"`
+	DeviceID   uuid.UUID         `db:"device_id"`
+	Name       string            `db:"name"`
+	Type       enum.ControlType  `db:"type"`
+	Topic      string            `db:"topic"`
+	BasePath   *string           `db:"base_path"`
+	Attributes ControlAttributes `db:"attributes"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Tag struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Name      string    `db:"name"`
+	Color     string    `db:"color"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type CreateTag struct {
+	UserID uuid.UUID `db:"user_id"`
+	Name   string    `db:"name"`
+	Color  string    `db:"color"`
+}
+
+type UpdateTag struct {
+	ID     uuid.UUID `db:"id"`
+	UserID uuid.UUID `db:"user_id"`
+	Name   *string   `db:"name"`
+	Color  *string   `db:"color"`
+}
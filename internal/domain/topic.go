@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// TopicIssue is a problem with the topics of the controls of a broker, the topic is the effective one,
+// the base path of the device joined with the topic of the control. An overlap lists the control
+// subscribed with a wildcard first, followed by the controls whose messages it receives.
+type TopicIssue struct {
+	Kind     enum.TopicIssueKind
+	Topic    string
+	Controls []*TopicIssueControl
+}
+
+type TopicIssueControl struct {
+	ID       uuid.UUID
+	DeviceID uuid.UUID
+	Name     string
+	Topic    string
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+)
+
+// TransferVersion is the version of the configuration document produced by the export,
+// imports of documents with a different version are rejected.
+const TransferVersion = 1
+
+type Transfer struct {
+	Version    int
+	ExportedAt time.Time
+	Brokers    []*TransferBroker
+	Devices    []*TransferDevice
+}
+
+type TransferBroker struct {
+	Ref      string
+	Broker   CreateBroker
+	Username t.String
+	Password t.String
+}
+
+type TransferDevice struct {
+	Ref       string
+	BrokerRef *string
+	Device    CreateDevice
+	Controls  []*TransferControl
+}
+
+type TransferControl struct {
+	Ref     string
+	Control CreateDeviceControl
+}
+
+type TransferOptions struct {
+	DryRun   bool
+	Strategy enum.TransferStrategy
+}
+
+type TransferReport struct {
+	DryRun   bool
+	Strategy enum.TransferStrategy
+	Items    []*TransferReportItem
+}
+
+type TransferReportItem struct {
+	Entity  enum.EventEntity
+	Ref     string
+	ID      uuid.UUID
+	Name    string
+	Action  enum.TransferAction
+	Reason  string
+	Changes []TransferChange
+}
+
+type TransferChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/google/uuid"
+)
+
+// TrashEntry is a deleted broker, device or control. Its dependents are the devices detached from the broker
+// and the controls deleted along with the device, they come back when the entry is restored.
+type TrashEntry struct {
+	Type       enum.TrashEntryType `db:"type"`
+	ID         uuid.UUID           `db:"id"`
+	DeviceID   uuid.NullUUID       `db:"device_id"`
+	ParentID   uuid.NullUUID       `db:"parent_id"`
+	Name       string              `db:"name"`
+	DeletedAt  *time.Time          `db:"deleted_at"`
+	PurgeAt    *time.Time
+	Dependents []*TrashEntry
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+type brokerHealthRepository struct {
+	ch *redis.Client
+}
+
+func NewBrokerHealthRepository(ch *redis.Client) repository.BrokerHealthRepository {
+	return &brokerHealthRepository{ch}
+}
+
+func (*brokerHealthRepository) key(brokerID uuid.UUID) string {
+	return fmt.Sprintf("broker:health:%s", brokerID.String())
+}
+
+// List returns the recorded health of the brokers, brokers which have not been checked yet are left out.
+func (r *brokerHealthRepository) List(ctx context.Context, brokerIDs []uuid.UUID) (map[uuid.UUID]*domain.BrokerHealth, error) {
+	health := make(map[uuid.UUID]*domain.BrokerHealth)
+	if len(brokerIDs) == 0 {
+		return health, nil
+	}
+
+	keys := make([]string, len(brokerIDs))
+	for i, brokerID := range brokerIDs {
+		keys[i] = r.key(brokerID)
+	}
+
+	v, err := r.ch.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "brokerHealthRepository.List.MGet")
+	}
+
+	for _, h := range v {
+		s, ok := h.(string)
+		if !ok {
+			continue
+		}
+
+		bh := &domain.BrokerHealth{}
+		if err := json.Unmarshal([]byte(s), bh); err != nil {
+			return nil, errors.Wrap(err, "brokerHealthRepository.List.Unmarshal")
+		}
+
+		health[bh.BrokerID] = bh
+	}
+
+	return health, nil
+}
+
+func (r *brokerHealthRepository) Set(ctx context.Context, health *domain.BrokerHealth) error {
+	v, err := json.Marshal(health)
+	if err != nil {
+		return errors.Wrap(err, "brokerHealthRepository.Set.Marshal")
+	}
+
+	if err := r.ch.Set(ctx, r.key(health.BrokerID), v, 0).Err(); err != nil {
+		return errors.Wrap(err, "brokerHealthRepository.Set.Set")
+	}
+
+	return nil
+}
+
+func (r *brokerHealthRepository) Delete(ctx context.Context, brokerID uuid.UUID) error {
+	if err := r.ch.Del(ctx, r.key(brokerID)).Err(); err != nil {
+		return errors.Wrap(err, "brokerHealthRepository.Delete.Del")
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+type discoveryRepository struct {
+	ch *redis.Client
+}
+
+func NewDiscoveryRepository(ch *redis.Client) repository.DiscoveryRepository {
+	return &discoveryRepository{ch}
+}
+
+func (*discoveryRepository) key(brokerID uuid.UUID) string {
+	return fmt.Sprintf("discovery:%s", brokerID.String())
+}
+
+func (*discoveryRepository) dismissedKey(brokerID uuid.UUID) string {
+	return fmt.Sprintf("discovery:dismissed:%s", brokerID.String())
+}
+
+func (r *discoveryRepository) Get(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) (*domain.DiscoveryProposal, error) {
+	v, err := r.ch.HGet(ctx, r.key(brokerID), proposalID.String()).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ae.ErrDiscoveryProposalNotFound
+		}
+
+		return nil, errors.Wrap(err, "discoveryRepository.Get.HGet")
+	}
+
+	proposal := &domain.DiscoveryProposal{}
+	if err := json.Unmarshal([]byte(v), proposal); err != nil {
+		return nil, errors.Wrap(err, "discoveryRepository.Get.Unmarshal")
+	}
+
+	return proposal, nil
+}
+
+func (r *discoveryRepository) List(ctx context.Context, brokerID uuid.UUID) ([]*domain.DiscoveryProposal, error) {
+	v, err := r.ch.HGetAll(ctx, r.key(brokerID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "discoveryRepository.List.HGetAll")
+	}
+
+	proposals := []*domain.DiscoveryProposal{}
+	for _, p := range v {
+		proposal := &domain.DiscoveryProposal{}
+		if err := json.Unmarshal([]byte(p), proposal); err != nil {
+			return nil, errors.Wrap(err, "discoveryRepository.List.Unmarshal")
+		}
+
+		proposals = append(proposals, proposal)
+	}
+
+	return proposals, nil
+}
+
+func (r *discoveryRepository) Set(ctx context.Context, proposal *domain.DiscoveryProposal) error {
+	v, err := json.Marshal(proposal)
+	if err != nil {
+		return errors.Wrap(err, "discoveryRepository.Set.Marshal")
+	}
+
+	if err := r.ch.HSet(ctx, r.key(proposal.BrokerID), proposal.ID.String(), v).Err(); err != nil {
+		return errors.Wrap(err, "discoveryRepository.Set.HSet")
+	}
+
+	return nil
+}
+
+func (r *discoveryRepository) Delete(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) error {
+	if err := r.ch.HDel(ctx, r.key(brokerID), proposalID.String()).Err(); err != nil {
+		return errors.Wrap(err, "discoveryRepository.Delete.HDel")
+	}
+
+	return nil
+}
+
+func (r *discoveryRepository) Dismiss(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) error {
+	pipe := r.ch.TxPipeline()
+
+	pipe.HDel(ctx, r.key(brokerID), proposalID.String())
+	pipe.SAdd(ctx, r.dismissedKey(brokerID), proposalID.String())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "discoveryRepository.Dismiss.Exec")
+	}
+
+	return nil
+}
+
+func (r *discoveryRepository) IsDismissed(ctx context.Context, brokerID uuid.UUID, proposalID uuid.UUID) (bool, error) {
+	v, err := r.ch.SIsMember(ctx, r.dismissedKey(brokerID), proposalID.String()).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "discoveryRepository.IsDismissed.SIsMember")
+	}
+
+	return v, nil
+}
+
+func (r *discoveryRepository) Clear(ctx context.Context, brokerID uuid.UUID) error {
+	if err := r.ch.Del(ctx, r.key(brokerID), r.dismissedKey(brokerID)).Err(); err != nil {
+		return errors.Wrap(err, "discoveryRepository.Clear.Del")
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+)
+
+type mqttAdapter struct {
+	clients map[uuid.UUID]mqtt.Client
+	mutex   sync.Mutex
+}
+
+func NewMQTTAdapter() adapter.MQTTAdapter {
+	return &mqttAdapter{
+		clients: make(map[uuid.UUID]mqtt.Client),
+	}
+}
+
+// Connect opens a connection to the broker, the broker credentials have to be decrypted.
+// The client ID of the broker is not used, it belongs to the user's clients and
+// reusing it would make the broker drop their connections. The broker is dialed without
+// the mutex held, so the other brokers are not stalled by one which can not be reached.
+func (a *mqttAdapter) Connect(broker *domain.Broker, handler domain.BridgeHandler) error {
+	if _, ok := a.client(broker.ID); ok {
+		return nil
+	}
+
+	brokerID := broker.ID
+	c, err := mqtt.NewClient(a.options(broker), func(topic string, payload []byte, retained bool) {
+		handler(&domain.BridgeMessage{
+			BrokerID:   brokerID,
+			Topic:      topic,
+			Payload:    payload,
+			Retained:   retained,
+			ReceivedAt: time.Now(),
+		})
+	})
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", ae.ErrBridgeConnection, err.Error())
+	}
+
+	if err := c.Connect(); err != nil {
+		return fmt.Errorf("%w: %s", ae.ErrBridgeConnection, err.Error())
+	}
+
+	a.mutex.Lock()
+	_, ok := a.clients[broker.ID]
+	if !ok {
+		a.clients[broker.ID] = c
+	}
+	a.mutex.Unlock()
+
+	// The broker has been connected to by another call meanwhile
+	if ok {
+		c.Disconnect()
+	}
+
+	return nil
+}
+
+func (a *mqttAdapter) Disconnect(brokerID uuid.UUID) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if c, ok := a.clients[brokerID]; ok {
+		c.Disconnect()
+		delete(a.clients, brokerID)
+	}
+}
+
+func (a *mqttAdapter) IsConnected(brokerID uuid.UUID) bool {
+	c, ok := a.client(brokerID)
+	return ok && c.IsConnected()
+}
+
+func (a *mqttAdapter) Subscribe(brokerID uuid.UUID, topic string, qos byte) error {
+	c, ok := a.client(brokerID)
+	if !ok {
+		return ae.ErrBridgeConnection
+	}
+
+	return c.Subscribe(topic, qos)
+}
+
+func (a *mqttAdapter) Unsubscribe(brokerID uuid.UUID, topics ...string) error {
+	c, ok := a.client(brokerID)
+	if !ok {
+		return nil
+	}
+
+	return c.Unsubscribe(topics...)
+}
+
+func (a *mqttAdapter) Publish(brokerID uuid.UUID, topic string, qos byte, retained bool, payload []byte) error {
+	c, ok := a.client(brokerID)
+	if !ok {
+		return ae.ErrBridgeConnection
+	}
+
+	return c.Publish(topic, qos, retained, payload)
+}
+
+// Probe checks whether the broker accepts a connection, the broker credentials have to be decrypted.
+func (a *mqttAdapter) Probe(ctx context.Context, broker *domain.Broker) *domain.BrokerProbe {
+	r := mqtt.Probe(ctx, a.options(broker))
+
+	probe := &domain.BrokerProbe{
+		Success: r.Ok(),
+		Steps:   []*domain.BrokerProbeStep{},
+	}
+
+	for _, s := range r.Steps {
+		step := &domain.BrokerProbeStep{
+			Name:    s.Name,
+			Success: s.Err == nil,
+			Latency: s.Latency,
+		}
+
+		if s.Err != nil {
+			step.Error = s.Err.Error()
+		}
+
+		probe.Steps = append(probe.Steps, step)
+	}
+
+	return probe
+}
+
+// options builds the options of the connections of the server, which use a new client id every time. Their
+// sessions are always clean and expire with the connection, the clean start and the session expiry of the
+// broker are meant for the clients of the user and would leave a persistent session behind every reconnect.
+func (*mqttAdapter) options(broker *domain.Broker) *mqtt.Options {
+	o := &mqtt.Options{
+		Server:    broker.Server,
+		Port:      broker.Port,
+		IsSSL:     broker.IsSSL,
+		ClientID:  fmt.Sprintf("dashboardx-%s", uuid.NewString()),
+		KeepAlive: broker.KeepAlive,
+
+		Transport:       string(broker.Transport),
+		ProtocolVersion: 4,
+		CleanStart:      true,
+		SessionExpiry:   0,
+		UserProperties:  broker.UserProperties,
+	}
+
+	if broker.Path.Set && !broker.Path.Null {
+		o.Path = broker.Path.String
+	}
+
+	if broker.ProtocolVersion == enum.MQTTVersion5 {
+		o.ProtocolVersion = 5
+	}
+
+	if broker.Username.Set && !broker.Username.Null {
+		o.Username = broker.Username.String
+	}
+
+	if broker.Password.Set && !broker.Password.Null {
+		o.Password = broker.Password.String
+	}
+
+	if c := broker.Certificates; c != nil {
+		if c.CACertificate.Set && !c.CACertificate.Null {
+			o.CACertificate = []byte(c.CACertificate.String)
+		}
+
+		if c.ClientCertificate.Set && !c.ClientCertificate.Null && c.ClientKey.Set && !c.ClientKey.Null {
+			o.ClientCertificate = []byte(c.ClientCertificate.String)
+			o.ClientKey = []byte(c.ClientKey.String)
+		}
+	}
+
+	return o
+}
+
+func (a *mqttAdapter) client(brokerID uuid.UUID) (mqtt.Client, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	c, ok := a.clients[brokerID]
+	return c, ok
+}
@@ -0,0 +1,299 @@
+package persistance
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const alertColumns = `a."id", a."user_id", a."name", a."kind", a."broker_id", a."device_id", a."control_id", a."operator",
+	a."threshold", a."hysteresis", a."state", a."offline_minutes", a."cooldown_minutes", a."notify_email",
+	a."webhook_url", a."is_enabled", a."created_at", a."updated_at"`
+
+// alertTargets skips the alerts whose control, device or broker is in the trash.
+const alertTargets = `
+	LEFT JOIN "brokers" b ON b."id" = a."broker_id"
+	LEFT JOIN "devices" d ON d."id" = a."device_id"
+	LEFT JOIN "device_controls" c ON c."id" = a."control_id"
+	WHERE a."is_enabled" AND b."deleted_at" IS NULL AND d."deleted_at" IS NULL AND c."deleted_at" IS NULL
+`
+
+type alertRepository struct {
+	db *sqlx.DB
+}
+
+func NewAlertRepository(db *sqlx.DB) repository.AlertRepository {
+	return &alertRepository{db}
+}
+
+func (r *alertRepository) Get(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (*domain.Alert, error) {
+	alert := &domain.Alert{}
+
+	sqls := `SELECT ` + alertColumns + ` FROM "alerts" a WHERE a."id" = $1 AND a."user_id" = $2`
+
+	if err := conn(ctx, r.db).GetContext(ctx, alert, sqls, alertID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrAlertNotFound
+		}
+
+		return nil, errors.Wrap(err, "alertRepository.Get.GetContext")
+	}
+
+	return alert, nil
+}
+
+func (r *alertRepository) List(ctx context.Context, userID uuid.UUID) ([]*domain.Alert, error) {
+	alerts := []*domain.Alert{}
+
+	sqls := `SELECT ` + alertColumns + ` FROM "alerts" a WHERE a."user_id" = $1 ORDER BY lower(a."name"), a."created_at"`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &alerts, sqls, userID); err != nil {
+		return nil, errors.Wrap(err, "alertRepository.List.SelectContext")
+	}
+
+	return alerts, nil
+}
+
+// ListByControl returns the enabled alerts watching the values of the control.
+func (r *alertRepository) ListByControl(ctx context.Context, controlID uuid.UUID) ([]*domain.Alert, error) {
+	alerts := []*domain.Alert{}
+
+	sqls := `SELECT ` + alertColumns + ` FROM "alerts" a ` + alertTargets + ` AND a."control_id" = $1`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &alerts, sqls, controlID); err != nil {
+		return nil, errors.Wrap(err, "alertRepository.ListByControl.SelectContext")
+	}
+
+	return alerts, nil
+}
+
+// ListByKind returns the enabled alerts of the kind of all the users.
+func (r *alertRepository) ListByKind(ctx context.Context, kind enum.AlertKind) ([]*domain.Alert, error) {
+	alerts := []*domain.Alert{}
+
+	sqls := `SELECT ` + alertColumns + ` FROM "alerts" a ` + alertTargets + ` AND a."kind" = $1`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &alerts, sqls, kind); err != nil {
+		return nil, errors.Wrap(err, "alertRepository.ListByKind.SelectContext")
+	}
+
+	return alerts, nil
+}
+
+// ListTrashedUnresolved returns the alerts of the user with an unresolved incident whose control, device or broker is in the trash.
+func (r *alertRepository) ListTrashedUnresolved(ctx context.Context, userID uuid.UUID) ([]*domain.Alert, error) {
+	alerts := []*domain.Alert{}
+
+	sqls := `
+		SELECT ` + alertColumns + ` FROM "alerts" a
+		LEFT JOIN "brokers" b ON b."id" = a."broker_id"
+		LEFT JOIN "devices" d ON d."id" = a."device_id"
+		LEFT JOIN "device_controls" c ON c."id" = a."control_id"
+		WHERE a."user_id" = $1
+			AND (b."deleted_at" IS NOT NULL OR d."deleted_at" IS NOT NULL OR c."deleted_at" IS NOT NULL)
+			AND EXISTS (SELECT 1 FROM "alert_incidents" i WHERE i."alert_id" = a."id" AND i."status" <> $2)
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &alerts, sqls, userID, enum.IncidentResolved); err != nil {
+		return nil, errors.Wrap(err, "alertRepository.ListTrashedUnresolved.SelectContext")
+	}
+
+	return alerts, nil
+}
+
+func (r *alertRepository) Create(ctx context.Context, alert *domain.CreateAlert) (uuid.UUID, error) {
+	var alertID uuid.UUID
+
+	sqls := `
+		INSERT INTO "alerts" ("user_id", "name", "kind", "broker_id", "device_id", "control_id", "operator", "threshold",
+			"hysteresis", "state", "offline_minutes", "cooldown_minutes", "notify_email", "webhook_url", "is_enabled")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING "id"
+	`
+
+	err := conn(ctx, r.db).GetContext(ctx, &alertID, sqls, alert.UserID, alert.Name, alert.Kind, alert.BrokerID, alert.DeviceID,
+		alert.ControlID, alert.Operator, alert.Threshold, alert.Hysteresis, alert.State, alert.OfflineMinutes,
+		alert.CooldownMinutes, alert.NotifyEmail, alert.WebhookURL, alert.IsEnabled)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "alertRepository.Create.GetContext")
+	}
+
+	return alertID, nil
+}
+
+func (r *alertRepository) Update(ctx context.Context, alert *domain.UpdateAlert) error {
+	sqls := `
+		UPDATE "alerts" SET "name" = :name, "kind" = :kind, "broker_id" = :broker_id, "device_id" = :device_id,
+			"control_id" = :control_id, "operator" = :operator, "threshold" = :threshold, "hysteresis" = :hysteresis,
+			"state" = :state, "offline_minutes" = :offline_minutes, "cooldown_minutes" = :cooldown_minutes,
+			"notify_email" = :notify_email, "webhook_url" = :webhook_url, "is_enabled" = :is_enabled, "updated_at" = now()
+		WHERE "id" = :id AND "user_id" = :user_id
+	`
+
+	sr, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), sqls, alert)
+	if err != nil {
+		return errors.Wrap(err, "alertRepository.Update.NamedExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrAlertNotFound
+	}
+	return nil
+}
+
+func (r *alertRepository) Delete(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) error {
+	sqls := `DELETE FROM "alerts" WHERE "id" = $1 AND "user_id" = $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, alertID, userID)
+	if err != nil {
+		return errors.Wrap(err, "alertRepository.Delete.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrAlertNotFound
+	}
+	return nil
+}
+
+const alertIncidentColumns = `"id", "alert_id", "alert_name", "kind", "user_id", "status", "value", "message",
+	"opened_at", "acknowledged_at", "resolved_at"`
+
+// alertIncidents joins the incidents with their alerts, so the list can use the columns of both without a prefix.
+const alertIncidents = `(
+	SELECT i."id", i."alert_id", a."name" AS "alert_name", a."kind", a."user_id", i."status", i."value", i."message",
+		i."opened_at", i."acknowledged_at", i."resolved_at"
+	FROM "alert_incidents" i JOIN "alerts" a ON a."id" = i."alert_id"
+) AS "incidents"`
+
+var alertIncidentList = &listSpec[*domain.AlertIncident]{
+	name:        "alertRepository.ListIncidents",
+	columns:     alertIncidentColumns,
+	from:        alertIncidents,
+	defaultSort: "openedAt",
+	sorts: map[string]sortColumn[*domain.AlertIncident]{
+		"openedAt": {`"opened_at"`, "timestamptz", func(i *domain.AlertIncident) string { return i.OpenedAt.Format(time.RFC3339Nano) }},
+		"status":   {`"status"`, "text", func(i *domain.AlertIncident) string { return string(i.Status) }},
+	},
+	id: func(i *domain.AlertIncident) uuid.UUID { return i.ID },
+}
+
+func (r *alertRepository) ListIncidents(ctx context.Context, filters *domain.ListAlertIncidentFilters) (*domain.List[*domain.AlertIncident], error) {
+	q := &listQuery{}
+	q.and(`"user_id" = ?`, filters.UserID)
+
+	if filters.AlertID.Valid {
+		q.and(`"alert_id" = ?`, filters.AlertID.UUID)
+	}
+
+	if filters.Status != nil {
+		q.and(`"status" = ?`, *filters.Status)
+	}
+
+	return list(ctx, r.db, alertIncidentList, q, &filters.Page)
+}
+
+func (r *alertRepository) GetIncident(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID) (*domain.AlertIncident, error) {
+	incident := &domain.AlertIncident{}
+
+	sqls := `SELECT ` + alertIncidentColumns + ` FROM ` + alertIncidents + ` WHERE "id" = $1 AND "user_id" = $2`
+
+	if err := conn(ctx, r.db).GetContext(ctx, incident, sqls, incidentID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrAlertIncidentNotFound
+		}
+
+		return nil, errors.Wrap(err, "alertRepository.GetIncident.GetContext")
+	}
+
+	return incident, nil
+}
+
+// GetUnresolvedIncident returns the open or acknowledged incident of the alert.
+func (r *alertRepository) GetUnresolvedIncident(ctx context.Context, alertID uuid.UUID) (*domain.AlertIncident, error) {
+	incident := &domain.AlertIncident{}
+
+	sqls := `SELECT ` + alertIncidentColumns + ` FROM ` + alertIncidents + ` WHERE "alert_id" = $1 AND "status" <> $2`
+
+	if err := conn(ctx, r.db).GetContext(ctx, incident, sqls, alertID, enum.IncidentResolved); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrAlertIncidentNotFound
+		}
+
+		return nil, errors.Wrap(err, "alertRepository.GetUnresolvedIncident.GetContext")
+	}
+
+	return incident, nil
+}
+
+// LastResolvedAt returns when the last incident of the alert has been resolved, nil when there is none.
+func (r *alertRepository) LastResolvedAt(ctx context.Context, alertID uuid.UUID) (*time.Time, error) {
+	var resolvedAt *time.Time
+
+	sqls := `SELECT max("resolved_at") FROM "alert_incidents" WHERE "alert_id" = $1`
+
+	if err := conn(ctx, r.db).GetContext(ctx, &resolvedAt, sqls, alertID); err != nil {
+		return nil, errors.Wrap(err, "alertRepository.LastResolvedAt.GetContext")
+	}
+
+	return resolvedAt, nil
+}
+
+// CreateIncident opens the incident of the alert, uuid.Nil is returned when the alert already has an unresolved one.
+func (r *alertRepository) CreateIncident(ctx context.Context, incident *domain.CreateAlertIncident) (uuid.UUID, error) {
+	var incidentID uuid.UUID
+
+	sqls := `
+		INSERT INTO "alert_incidents" ("alert_id", "status", "value", "message")
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("alert_id") WHERE "status" <> 'resolved' DO NOTHING
+		RETURNING "id"
+	`
+
+	err := conn(ctx, r.db).GetContext(ctx, &incidentID, sqls, incident.AlertID, enum.IncidentOpen, incident.Value, incident.Message)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, nil
+		}
+
+		return uuid.Nil, errors.Wrap(err, "alertRepository.CreateIncident.GetContext")
+	}
+
+	return incidentID, nil
+}
+
+// AcknowledgeIncident marks the open incident of the user as acknowledged, acknowledging it again does nothing.
+func (r *alertRepository) AcknowledgeIncident(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID) error {
+	sqls := `
+		UPDATE "alert_incidents" i SET "status" = $3, "acknowledged_at" = now()
+		FROM "alerts" a
+		WHERE a."id" = i."alert_id" AND i."id" = $1 AND a."user_id" = $2 AND i."status" = $4
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, incidentID, userID, enum.IncidentAcknowledged, enum.IncidentOpen); err != nil {
+		return errors.Wrap(err, "alertRepository.AcknowledgeIncident.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *alertRepository) ResolveIncident(ctx context.Context, incidentID uuid.UUID) error {
+	sqls := `UPDATE "alert_incidents" SET "status" = $2, "resolved_at" = now() WHERE "id" = $1 AND "status" <> $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, incidentID, enum.IncidentResolved)
+	if err != nil {
+		return errors.Wrap(err, "alertRepository.ResolveIncident.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrAlertIncidentNotFound
+	}
+	return nil
+}
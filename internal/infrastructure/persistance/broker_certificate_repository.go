@@ -0,0 +1,90 @@
+package persistance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type brokerCertificateRepository struct {
+	db *sqlx.DB
+}
+
+func NewBrokerCertificateRepository(db *sqlx.DB) repository.BrokerCertificateRepository {
+	return &brokerCertificateRepository{db}
+}
+
+func (r *brokerCertificateRepository) Get(ctx context.Context, brokerID uuid.UUID) (*domain.BrokerCertificates, error) {
+	certificates := &domain.BrokerCertificates{}
+
+	sqls := `
+		SELECT "broker_id", "ca_certificate", "client_certificate", "client_key", "created_at", "updated_at"
+		FROM "broker_certificates"
+		WHERE "broker_id" = $1
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, certificates, sqls, brokerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrBrokerCertificatesNotFound
+		}
+
+		return nil, errors.Wrap(err, "brokerCertificateRepository.Get.GetContext")
+	}
+
+	return certificates, nil
+}
+
+// Set inserts the certificates of a broker or updates the set fields of the stored ones.
+// PEM blocks span multiple lines, so unlike other repositories the values are passed as parameters.
+func (r *brokerCertificateRepository) Set(ctx context.Context, certificates *domain.UpdateBrokerCertificates) error {
+	var p []string
+
+	fields := []struct {
+		name  string
+		value t.String
+	}{
+		{"ca_certificate", certificates.CACertificate},
+		{"client_certificate", certificates.ClientCertificate},
+		{"client_key", certificates.ClientKey},
+	}
+
+	args := []interface{}{certificates.BrokerID}
+	for _, f := range fields {
+		if f.value.Set && !f.value.Null {
+			args = append(args, f.value.String)
+		} else {
+			args = append(args, nil)
+		}
+
+		if f.value.Set {
+			p = append(p, fmt.Sprintf(`"%s" = EXCLUDED."%s"`, f.name, f.name))
+		}
+	}
+
+	if len(p) == 0 {
+		return ae.ErrMissingParams
+	}
+
+	p = append(p, `"updated_at" = now()`)
+
+	sql := fmt.Sprintf(`
+		INSERT INTO "broker_certificates" ("broker_id", "ca_certificate", "client_certificate", "client_key")
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("broker_id") DO UPDATE SET %s
+	`, strings.Join(p, ","))
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sql, args...); err != nil {
+		return errors.Wrap(err, "brokerCertificateRepository.Set.ExecContext")
+	}
+
+	return nil
+}
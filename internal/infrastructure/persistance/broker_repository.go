@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
@@ -29,12 +30,13 @@ func (r *brokerRepository) Get(ctx context.Context, brokerID uuid.UUID, userID u
 
 	sqls := `
 		SELECT "id", "user_id", "name", "server", "port", "keep_alive", "icon_name", "icon_background_color", "is_ssl", "username",
-			"password", "client_id", "created_at", "updated_at"
+			"password", "client_id", "discovery_mode", "discovery_prefix", "protocol_version", "transport", "path", "clean_start",
+			"session_expiry", "user_properties", "version", "created_at", "updated_at"
 		FROM "brokers"
-		WHERE "id" = $1 AND "user_id" = $2
+		WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NULL
 	`
 
-	if err := r.db.GetContext(ctx, broker, sqls, brokerID, userID); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, broker, sqls, brokerID, userID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ae.ErrBrokerNotFound
 		}
@@ -45,18 +47,43 @@ func (r *brokerRepository) Get(ctx context.Context, brokerID uuid.UUID, userID u
 	return broker, nil
 }
 
-func (r *brokerRepository) List(ctx context.Context, userID uuid.UUID) ([]*domain.Broker, error) {
+var brokerList = &listSpec[*domain.Broker]{
+	name: "brokerRepository.List",
+	columns: `"id", "user_id", "name", "server", "port", "keep_alive", "icon_name", "icon_background_color", "is_ssl", "username",
+		"password", "client_id", "discovery_mode", "discovery_prefix", "protocol_version", "transport", "path", "clean_start",
+		"session_expiry", "user_properties", "version", "created_at", "updated_at"`,
+	from:        `"brokers"`,
+	defaultSort: "createdAt",
+	sorts: map[string]sortColumn[*domain.Broker]{
+		"name":      {`"name"`, "text", func(b *domain.Broker) string { return b.Name }},
+		"server":    {`"server"`, "text", func(b *domain.Broker) string { return b.Server }},
+		"createdAt": {`"created_at"`, "timestamptz", func(b *domain.Broker) string { return b.CreatedAt.Format(time.RFC3339Nano) }},
+		"updatedAt": {`"updated_at"`, "timestamptz", func(b *domain.Broker) string { return b.UpdatedAt.Format(time.RFC3339Nano) }},
+	},
+	id: func(b *domain.Broker) uuid.UUID { return b.ID },
+}
+
+func (r *brokerRepository) List(ctx context.Context, filters *domain.ListBrokerFilters) (*domain.List[*domain.Broker], error) {
+	q := &listQuery{}
+	q.and(`"user_id" = ? AND "deleted_at" IS NULL`, filters.UserID)
+	q.search(`"name"`, filters.Search)
+
+	return list(ctx, r.db, brokerList, q, &filters.Page)
+}
+
+func (r *brokerRepository) ListAll(ctx context.Context) ([]*domain.Broker, error) {
 	var brokers []*domain.Broker
 
 	sql := `
 		SELECT "id", "user_id", "name", "server", "port", "keep_alive", "icon_name", "icon_background_color", "is_ssl", "username",
-			"password", "client_id", "created_at", "updated_at"
+			"password", "client_id", "discovery_mode", "discovery_prefix", "protocol_version", "transport", "path", "clean_start",
+			"session_expiry", "user_properties", "version", "created_at", "updated_at"
 		FROM "brokers"
-		WHERE "user_id" = $1
+		WHERE "deleted_at" IS NULL
 	`
 
-	if err := r.db.SelectContext(ctx, &brokers, sql, userID); err != nil {
-		return nil, errors.Wrap(err, "brokerRepository.List.SelectContext")
+	if err := conn(ctx, r.db).SelectContext(ctx, &brokers, sql); err != nil {
+		return nil, errors.Wrap(err, "brokerRepository.ListAll.SelectContext")
 	}
 
 	return brokers, nil
@@ -102,11 +129,63 @@ func (r *brokerRepository) Create(ctx context.Context, broker *domain.CreateBrok
 		}
 	}
 
+	if broker.DiscoveryMode != "" {
+		f.WriteString(`, "discovery_mode"`)
+		p = fmt.Sprintf("%s, '%s'", p, broker.DiscoveryMode)
+	}
+
+	args := []interface{}{}
+
+	if broker.DiscoveryPrefix.Set && !broker.DiscoveryPrefix.Null {
+		args = append(args, broker.DiscoveryPrefix.String)
+		f.WriteString(`, "discovery_prefix"`)
+		p = fmt.Sprintf("%s, $%d", p, len(args))
+	}
+
+	if broker.ProtocolVersion != "" {
+		f.WriteString(`, "protocol_version"`)
+		p = fmt.Sprintf("%s, '%s'", p, broker.ProtocolVersion)
+	}
+
+	if broker.Transport != "" {
+		f.WriteString(`, "transport"`)
+		p = fmt.Sprintf("%s, '%s'", p, broker.Transport)
+	}
+
+	if broker.Path.Set {
+		f.WriteString(`, "path"`)
+		if broker.Path.Null {
+			p = fmt.Sprintf("%s, NULL", p)
+		} else {
+			p = fmt.Sprintf("%s, %s", p, pq.QuoteLiteral(broker.Path.String))
+		}
+	}
+
+	if broker.CleanStart != nil {
+		f.WriteString(`, "clean_start"`)
+		p = fmt.Sprintf("%s, %v", p, *broker.CleanStart)
+	}
+
+	if broker.SessionExpiry != nil {
+		f.WriteString(`, "session_expiry"`)
+		p = fmt.Sprintf("%s, %d", p, *broker.SessionExpiry)
+	}
+
+	if broker.UserProperties != nil {
+		props, err := broker.UserProperties.Value()
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, "brokerRepository.Create.UserProperties.Value")
+		}
+
+		f.WriteString(`, "user_properties"`)
+		p = fmt.Sprintf("%s, %s", p, pq.QuoteLiteral(string(props.([]byte))))
+	}
+
 	created := &domain.Broker{}
 
 	sql := fmt.Sprintf(`INSERT INTO "brokers" (%s) VALUES (%s) RETURNING "id"`, f.String(), p)
 
-	if err := r.db.GetContext(ctx, created, sql); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, created, sql, args...); err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
 			if pgErr.Code == postgres.DuplicatedKey && pgErr.Constraint == postgres.BrokerUserIDServerConstraint {
 				return uuid.Nil, ae.ErrBrokerServerExists
@@ -174,15 +253,60 @@ func (r *brokerRepository) Update(ctx context.Context, broker *domain.UpdateBrok
 		}
 	}
 
+	if broker.DiscoveryMode != nil {
+		p = append(p, fmt.Sprintf(`"discovery_mode" = '%s'`, *broker.DiscoveryMode))
+	}
+
+	args := []interface{}{}
+
+	if broker.DiscoveryPrefix.Set && !broker.DiscoveryPrefix.Null {
+		args = append(args, broker.DiscoveryPrefix.String)
+		p = append(p, fmt.Sprintf(`"discovery_prefix" = $%d`, len(args)))
+	}
+
+	if broker.ProtocolVersion != nil {
+		p = append(p, fmt.Sprintf(`"protocol_version" = '%s'`, *broker.ProtocolVersion))
+	}
+
+	if broker.Transport != nil {
+		p = append(p, fmt.Sprintf(`"transport" = '%s'`, *broker.Transport))
+	}
+
+	if broker.Path.Set {
+		if broker.Path.Null {
+			p = append(p, `"path" = NULL`)
+		} else {
+			p = append(p, fmt.Sprintf(`"path" = %s`, pq.QuoteLiteral(broker.Path.String)))
+		}
+	}
+
+	if broker.CleanStart.Set {
+		p = append(p, fmt.Sprintf(`"clean_start" = %v`, broker.CleanStart.Bool))
+	}
+
+	if broker.SessionExpiry != nil {
+		p = append(p, fmt.Sprintf(`"session_expiry" = %d`, *broker.SessionExpiry))
+	}
+
+	if broker.UserProperties != nil {
+		props, err := broker.UserProperties.Value()
+		if err != nil {
+			return errors.Wrap(err, "brokerRepository.Update.UserProperties.Value")
+		}
+
+		p = append(p, fmt.Sprintf(`"user_properties" = %s`, pq.QuoteLiteral(string(props.([]byte)))))
+	}
+
 	if len(p) == 0 {
 		return ae.ErrMissingParams
 	}
 
-	p = append(p, `"updated_at" = now()`)
+	p = append(p, `"version" = "version" + 1`, `"updated_at" = now()`)
 
-	sql := fmt.Sprintf(`UPDATE "brokers" SET %s WHERE "id" = '%s' AND "user_id" = '%s'`, strings.Join(p, ","), broker.ID.String(), broker.UserID.String())
+	sql := fmt.Sprintf(`UPDATE "brokers" SET %s WHERE "id" = '%s' AND "user_id" = '%s' AND "deleted_at" IS NULL%s`,
+		strings.Join(p, ","), broker.ID.String(), broker.UserID.String(), versionCondition(broker.Version))
 
-	sr, err := r.db.ExecContext(ctx, sql)
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql, args...)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
 			if pgErr.Code == postgres.DuplicatedKey && pgErr.Constraint == postgres.BrokerUserIDServerConstraint {
@@ -194,26 +318,74 @@ func (r *brokerRepository) Update(ctx context.Context, broker *domain.UpdateBrok
 	}
 
 	if af, _ := sr.RowsAffected(); af == 0 {
-		return ae.ErrBrokerNotFound
+		return r.notAffected(ctx, broker.ID, broker.UserID, broker.Version)
 	}
 
 	return nil
 }
 
-func (r *brokerRepository) Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error {
+// Delete moves the broker to the trash, its devices are detached from it until it is restored.
+func (r *brokerRepository) Delete(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID, version *int64) error {
 	sql := `
-		DELETE FROM "brokers"
-		WHERE "id" = $1 AND "user_id" = $2 
+		WITH "deleted" AS (
+			UPDATE "brokers" SET "deleted_at" = now(), "version" = "version" + 1
+			WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NULL` + versionCondition(version) + `
+			RETURNING "id"
+		), "detached" AS (
+			UPDATE "devices" SET "broker_id" = NULL, "deleted_broker_id" = "deleted"."id",
+				"version" = "devices"."version" + 1, "updated_at" = now()
+			FROM "deleted"
+			WHERE "devices"."broker_id" = "deleted"."id"
+		)
+		SELECT COUNT(*) FROM "deleted"
 	`
 
-	sr, err := r.db.ExecContext(ctx, sql, brokerID, userID)
-	if err != nil {
-		return errors.Wrap(err, "brokerRepository.Delete.ExecContext")
+	var af int64
+	if err := conn(ctx, r.db).GetContext(ctx, &af, sql, brokerID, userID); err != nil {
+		return errors.Wrap(err, "brokerRepository.Delete.GetContext")
 	}
 
-	if af, _ := sr.RowsAffected(); af == 0 {
+	if af == 0 {
+		return r.notAffected(ctx, brokerID, userID, version)
+	}
+
+	return nil
+}
+
+// Restore takes the broker out of the trash and attaches back the devices detached by its deletion.
+func (r *brokerRepository) Restore(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID) error {
+	sql := `
+		WITH "restored" AS (
+			UPDATE "brokers" SET "deleted_at" = NULL, "version" = "version" + 1, "updated_at" = now()
+			WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NOT NULL
+			RETURNING "id"
+		), "attached" AS (
+			UPDATE "devices" SET "broker_id" = "restored"."id", "deleted_broker_id" = NULL,
+				"version" = "devices"."version" + 1, "updated_at" = now()
+			FROM "restored"
+			WHERE "devices"."deleted_broker_id" = "restored"."id" AND "devices"."broker_id" IS NULL
+		)
+		SELECT COUNT(*) FROM "restored"
+	`
+
+	var af int64
+	if err := conn(ctx, r.db).GetContext(ctx, &af, sql, brokerID, userID); err != nil {
+		if isDuplicate(err, postgres.BrokerUserIDServerConstraint) {
+			return ae.ErrBrokerServerExists
+		}
+
+		return errors.Wrap(err, "brokerRepository.Restore.GetContext")
+	}
+
+	if af == 0 {
 		return ae.ErrBrokerNotFound
 	}
 
 	return nil
 }
+
+func (r *brokerRepository) notAffected(ctx context.Context, brokerID uuid.UUID, userID uuid.UUID, version *int64) error {
+	sql := `SELECT EXISTS (SELECT 1 FROM "brokers" WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NULL)`
+
+	return notAffected(ctx, r.db, version, ae.ErrBrokerNotFound, sql, brokerID, userID)
+}
@@ -0,0 +1,313 @@
+package persistance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type dashboardRepository struct {
+	db *sqlx.DB
+}
+
+func NewDashboardRepository(db *sqlx.DB) repository.DashboardRepository {
+	return &dashboardRepository{db}
+}
+
+func (r *dashboardRepository) Get(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) (*domain.Dashboard, error) {
+	dashboard := &domain.Dashboard{}
+
+	sqls := `
+		SELECT "id", "user_id", "name", "icon_name", "icon_background_color", "position", "created_at", "updated_at"
+		FROM "dashboards"
+		WHERE "id" = $1 AND "user_id" = $2
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, dashboard, sqls, dashboardID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrDashboardNotFound
+		}
+
+		return nil, errors.Wrap(err, "dashboardRepository.Get.GetContext")
+	}
+
+	return dashboard, nil
+}
+
+func (r *dashboardRepository) List(ctx context.Context, userID uuid.UUID) ([]*domain.Dashboard, error) {
+	dashboards := []*domain.Dashboard{}
+
+	sqls := `
+		SELECT "id", "user_id", "name", "icon_name", "icon_background_color", "position", "created_at", "updated_at"
+		FROM "dashboards"
+		WHERE "user_id" = $1
+		ORDER BY "position", "created_at"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &dashboards, sqls, userID); err != nil {
+		return nil, errors.Wrap(err, "dashboardRepository.List.SelectContext")
+	}
+
+	return dashboards, nil
+}
+
+func (r *dashboardRepository) Create(ctx context.Context, dashboard *domain.CreateDashboard) (uuid.UUID, error) {
+	var dashboardID uuid.UUID
+
+	sqls := `
+		INSERT INTO "dashboards" ("user_id", "name", "icon_name", "icon_background_color", "position")
+		VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX("position") + 1, 0) FROM "dashboards" WHERE "user_id" = $1))
+		RETURNING "id"
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, &dashboardID, sqls,
+		dashboard.UserID, dashboard.Name, dashboard.IconName, dashboard.IconBackgroundColor); err != nil {
+		return uuid.Nil, errors.Wrap(err, "dashboardRepository.Create.GetContext")
+	}
+
+	return dashboardID, nil
+}
+
+func (r *dashboardRepository) Update(ctx context.Context, dashboard *domain.UpdateDashboard) error {
+	s := &updateSet{args: []interface{}{dashboard.ID, dashboard.UserID}}
+	s.add(`"name"`, dashboard.Name)
+	s.add(`"icon_name"`, dashboard.IconName)
+	s.add(`"icon_background_color"`, dashboard.IconBackgroundColor)
+
+	if s.empty() {
+		return ae.ErrMissingParams
+	}
+
+	sqls := fmt.Sprintf(`UPDATE "dashboards" SET %s WHERE "id" = $1 AND "user_id" = $2`, s.String())
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, s.args...)
+	if err != nil {
+		return errors.Wrap(err, "dashboardRepository.Update.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrDashboardNotFound
+	}
+	return nil
+}
+
+func (r *dashboardRepository) Delete(ctx context.Context, dashboardID uuid.UUID, userID uuid.UUID) error {
+	sqls := `DELETE FROM "dashboards" WHERE "id" = $1 AND "user_id" = $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, dashboardID, userID)
+	if err != nil {
+		return errors.Wrap(err, "dashboardRepository.Delete.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrDashboardNotFound
+	}
+	return nil
+}
+
+// Reorder sets the positions of the dashboards to their indexes in the list.
+func (r *dashboardRepository) Reorder(ctx context.Context, userID uuid.UUID, dashboardIDs []uuid.UUID) error {
+	sqls := `
+		UPDATE "dashboards" SET "position" = o."position" - 1, "updated_at" = now()
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS o("id", "position")
+		WHERE "dashboards"."id" = o."id" AND "dashboards"."user_id" = $2
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, pq.Array(dashboardIDs), userID); err != nil {
+		return errors.Wrap(err, "dashboardRepository.Reorder.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *dashboardRepository) ListTabs(ctx context.Context, dashboardID uuid.UUID) ([]*domain.DashboardTab, error) {
+	tabs := []*domain.DashboardTab{}
+
+	sqls := `
+		SELECT "id", "dashboard_id", "name", "position", "created_at", "updated_at"
+		FROM "dashboard_tabs"
+		WHERE "dashboard_id" = $1
+		ORDER BY "position", "created_at"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &tabs, sqls, dashboardID); err != nil {
+		return nil, errors.Wrap(err, "dashboardRepository.ListTabs.SelectContext")
+	}
+
+	return tabs, nil
+}
+
+func (r *dashboardRepository) CreateTab(ctx context.Context, tab *domain.CreateDashboardTab) (uuid.UUID, error) {
+	var tabID uuid.UUID
+
+	sqls := `
+		INSERT INTO "dashboard_tabs" ("dashboard_id", "name", "position")
+		VALUES ($1, $2, (SELECT COALESCE(MAX("position") + 1, 0) FROM "dashboard_tabs" WHERE "dashboard_id" = $1))
+		RETURNING "id"
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, &tabID, sqls, tab.DashboardID, tab.Name); err != nil {
+		return uuid.Nil, errors.Wrap(err, "dashboardRepository.CreateTab.GetContext")
+	}
+
+	return tabID, nil
+}
+
+func (r *dashboardRepository) UpdateTab(ctx context.Context, tab *domain.UpdateDashboardTab) error {
+	s := &updateSet{args: []interface{}{tab.ID, tab.DashboardID}}
+	s.add(`"name"`, tab.Name)
+
+	if s.empty() {
+		return ae.ErrMissingParams
+	}
+
+	sqls := fmt.Sprintf(`UPDATE "dashboard_tabs" SET %s WHERE "id" = $1 AND "dashboard_id" = $2`, s.String())
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, s.args...)
+	if err != nil {
+		return errors.Wrap(err, "dashboardRepository.UpdateTab.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrDashboardTabNotFound
+	}
+	return nil
+}
+
+func (r *dashboardRepository) DeleteTab(ctx context.Context, tabID uuid.UUID, dashboardID uuid.UUID) error {
+	sqls := `DELETE FROM "dashboard_tabs" WHERE "id" = $1 AND "dashboard_id" = $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, tabID, dashboardID)
+	if err != nil {
+		return errors.Wrap(err, "dashboardRepository.DeleteTab.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrDashboardTabNotFound
+	}
+	return nil
+}
+
+// ReorderTabs sets the positions of the tabs to their indexes in the list.
+func (r *dashboardRepository) ReorderTabs(ctx context.Context, dashboardID uuid.UUID, tabIDs []uuid.UUID) error {
+	sqls := `
+		UPDATE "dashboard_tabs" SET "position" = o."position" - 1, "updated_at" = now()
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS o("id", "position")
+		WHERE "dashboard_tabs"."id" = o."id" AND "dashboard_tabs"."dashboard_id" = $2
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, pq.Array(tabIDs), dashboardID); err != nil {
+		return errors.Wrap(err, "dashboardRepository.ReorderTabs.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *dashboardRepository) ListWidgets(ctx context.Context, dashboardID uuid.UUID) ([]*domain.DashboardWidget, error) {
+	widgets := []*domain.DashboardWidget{}
+
+	sqls := `
+		SELECT w."id", w."tab_id", w."device_id", w."control_id", w."x", w."y", w."width", w."height",
+			w."overrides", w."created_at", w."updated_at"
+		FROM "dashboard_widgets" w JOIN "dashboard_tabs" t ON t."id" = w."tab_id"
+		WHERE t."dashboard_id" = $1
+		ORDER BY w."y", w."x", w."created_at"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &widgets, sqls, dashboardID); err != nil {
+		return nil, errors.Wrap(err, "dashboardRepository.ListWidgets.SelectContext")
+	}
+
+	return widgets, nil
+}
+
+func (r *dashboardRepository) CreateWidget(ctx context.Context, widget *domain.CreateDashboardWidget) (uuid.UUID, error) {
+	var widgetID uuid.UUID
+
+	sqls := `
+		INSERT INTO "dashboard_widgets" ("tab_id", "device_id", "control_id", "x", "y", "width", "height", "overrides")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING "id"
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, &widgetID, sqls, widget.TabID, widget.DeviceID, widget.ControlID,
+		widget.X, widget.Y, widget.Width, widget.Height, widget.Overrides); err != nil {
+		return uuid.Nil, errors.Wrap(err, "dashboardRepository.CreateWidget.GetContext")
+	}
+
+	return widgetID, nil
+}
+
+func (r *dashboardRepository) UpdateWidget(ctx context.Context, widget *domain.UpdateDashboardWidget) error {
+	s := &updateSet{args: []interface{}{widget.ID, widget.TabID}}
+	s.add(`"x"`, widget.X)
+	s.add(`"y"`, widget.Y)
+	s.add(`"width"`, widget.Width)
+	s.add(`"height"`, widget.Height)
+
+	if widget.Overrides != nil {
+		s.add(`"overrides"`, *widget.Overrides)
+	}
+
+	if s.empty() {
+		return ae.ErrMissingParams
+	}
+
+	sqls := fmt.Sprintf(`UPDATE "dashboard_widgets" SET %s WHERE "id" = $1 AND "tab_id" = $2`, s.String())
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, s.args...)
+	if err != nil {
+		return errors.Wrap(err, "dashboardRepository.UpdateWidget.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrDashboardWidgetNotFound
+	}
+	return nil
+}
+
+func (r *dashboardRepository) DeleteWidget(ctx context.Context, widgetID uuid.UUID, tabID uuid.UUID) error {
+	sqls := `DELETE FROM "dashboard_widgets" WHERE "id" = $1 AND "tab_id" = $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, widgetID, tabID)
+	if err != nil {
+		return errors.Wrap(err, "dashboardRepository.DeleteWidget.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrDashboardWidgetNotFound
+	}
+	return nil
+}
+
+// SetLayout moves and resizes the widgets of the tab at once.
+func (r *dashboardRepository) SetLayout(ctx context.Context, tabID uuid.UUID, layout []*domain.WidgetLayout) error {
+	ids := make([]uuid.UUID, len(layout))
+	xs, ys, widths, heights := make([]int64, len(layout)), make([]int64, len(layout)), make([]int64, len(layout)), make([]int64, len(layout))
+
+	for i, l := range layout {
+		ids[i], xs[i], ys[i], widths[i], heights[i] = l.ID, int64(l.X), int64(l.Y), int64(l.Width), int64(l.Height)
+	}
+
+	sqls := `
+		UPDATE "dashboard_widgets"
+		SET "x" = l."x", "y" = l."y", "width" = l."width", "height" = l."height", "updated_at" = now()
+		FROM unnest($1::uuid[], $2::integer[], $3::integer[], $4::integer[], $5::integer[]) AS l("id", "x", "y", "width", "height")
+		WHERE "dashboard_widgets"."id" = l."id" AND "dashboard_widgets"."tab_id" = $6
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls,
+		pq.Array(ids), pq.Array(xs), pq.Array(ys), pq.Array(widths), pq.Array(heights), tabID); err != nil {
+		return errors.Wrap(err, "dashboardRepository.SetLayout.ExecContext")
+	}
+
+	return nil
+}
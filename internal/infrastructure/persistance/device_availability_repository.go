@@ -0,0 +1,180 @@
+package persistance
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type deviceAvailabilityRepository struct {
+	db *sqlx.DB
+}
+
+func NewDeviceAvailabilityRepository(db *sqlx.DB) repository.DeviceAvailabilityRepository {
+	return &deviceAvailabilityRepository{db}
+}
+
+func (r *deviceAvailabilityRepository) Get(ctx context.Context, deviceID uuid.UUID) (*domain.DeviceAvailability, error) {
+	availability := &domain.DeviceAvailability{}
+
+	sqls := `
+		SELECT "device_id", "mode", "topic", "online_payload", "offline_payload", "timeout_seconds", "created_at", "updated_at"
+		FROM "device_availability" WHERE "device_id" = $1
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, availability, sqls, deviceID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrAvailabilityNotFound
+		}
+
+		return nil, errors.Wrap(err, "deviceAvailabilityRepository.Get.GetContext")
+	}
+
+	return availability, nil
+}
+
+func (r *deviceAvailabilityRepository) Set(ctx context.Context, availability *domain.SetDeviceAvailability) error {
+	sqls := `
+		INSERT INTO "device_availability" ("device_id", "mode", "topic", "online_payload", "offline_payload", "timeout_seconds")
+		VALUES (:device_id, :mode, :topic, :online_payload, :offline_payload, :timeout_seconds)
+		ON CONFLICT ("device_id") DO UPDATE SET "mode" = EXCLUDED."mode", "topic" = EXCLUDED."topic",
+			"online_payload" = EXCLUDED."online_payload", "offline_payload" = EXCLUDED."offline_payload",
+			"timeout_seconds" = EXCLUDED."timeout_seconds", "updated_at" = now()
+	`
+
+	if _, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), sqls, availability); err != nil {
+		return errors.Wrap(err, "deviceAvailabilityRepository.Set.NamedExecContext")
+	}
+
+	return nil
+}
+
+func (r *deviceAvailabilityRepository) Delete(ctx context.Context, deviceID uuid.UUID) error {
+	sqls := `DELETE FROM "device_availability" WHERE "device_id" = $1`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, deviceID)
+	if err != nil {
+		return errors.Wrap(err, "deviceAvailabilityRepository.Delete.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrAvailabilityNotFound
+	}
+	return nil
+}
+
+// ListTracked returns the devices of the broker with the availability, the ones in the trash are skipped.
+func (r *deviceAvailabilityRepository) ListTracked(ctx context.Context, brokerID uuid.UUID) ([]*domain.TrackedDevice, error) {
+	devices := []*domain.TrackedDevice{}
+
+	sqls := `
+		SELECT a."device_id", a."mode", a."topic", a."online_payload", a."offline_payload", a."timeout_seconds",
+			a."created_at", a."updated_at", d."user_id", d."broker_id", d."base_path", d."status", d."last_seen_at"
+		FROM "device_availability" a JOIN "devices" d ON d."id" = a."device_id"
+		WHERE d."broker_id" = $1 AND d."deleted_at" IS NULL
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &devices, sqls, brokerID); err != nil {
+		return nil, errors.Wrap(err, "deviceAvailabilityRepository.ListTracked.SelectContext")
+	}
+
+	return devices, nil
+}
+
+// SetStatus changes the status of the device, the last seen time is kept when it is nil.
+func (r *deviceAvailabilityRepository) SetStatus(ctx context.Context, deviceID uuid.UUID, status enum.DeviceStatus, lastSeenAt *time.Time) error {
+	sqls := `UPDATE "devices" SET "status" = $2, "last_seen_at" = COALESCE($3, "last_seen_at") WHERE "id" = $1`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, deviceID, status, lastSeenAt); err != nil {
+		return errors.Wrap(err, "deviceAvailabilityRepository.SetStatus.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *deviceAvailabilityRepository) SetLastSeen(ctx context.Context, deviceID uuid.UUID, lastSeenAt time.Time) error {
+	sqls := `UPDATE "devices" SET "last_seen_at" = $2 WHERE "id" = $1`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, deviceID, lastSeenAt); err != nil {
+		return errors.Wrap(err, "deviceAvailabilityRepository.SetLastSeen.ExecContext")
+	}
+
+	return nil
+}
+
+// ListPresence returns the status of the devices along with when it has last changed.
+func (r *deviceAvailabilityRepository) ListPresence(ctx context.Context, deviceIDs []uuid.UUID) (map[uuid.UUID]*domain.DevicePresence, error) {
+	presence := make(map[uuid.UUID]*domain.DevicePresence, len(deviceIDs))
+	if len(deviceIDs) == 0 {
+		return presence, nil
+	}
+
+	sqls := `
+		SELECT d."id" AS "device_id", d."status", d."last_seen_at",
+			(SELECT max(t."created_at") FROM "device_availability_transitions" t WHERE t."device_id" = d."id") AS "changed_at"
+		FROM "devices" d
+		WHERE d."id" = ANY($1::uuid[])
+	`
+
+	rows := []*domain.DevicePresence{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, sqls, pq.Array(deviceIDs)); err != nil {
+		return nil, errors.Wrap(err, "deviceAvailabilityRepository.ListPresence.SelectContext")
+	}
+
+	for _, row := range rows {
+		presence[row.DeviceID] = row
+	}
+
+	return presence, nil
+}
+
+func (r *deviceAvailabilityRepository) CreateTransition(ctx context.Context, transition *domain.AvailabilityTransition) error {
+	sqls := `
+		INSERT INTO "device_availability_transitions" ("device_id", "status", "reason", "created_at")
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, sqls, transition.DeviceID, transition.Status, transition.Reason, transition.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "deviceAvailabilityRepository.CreateTransition.ExecContext")
+	}
+
+	return nil
+}
+
+var availabilityTransitionList = &listSpec[*domain.AvailabilityTransition]{
+	name:        "deviceAvailabilityRepository.ListTransitions",
+	columns:     `"id", "device_id", "status", "reason", "created_at"`,
+	from:        `"device_availability_transitions"`,
+	defaultSort: "createdAt",
+	sorts: map[string]sortColumn[*domain.AvailabilityTransition]{
+		"createdAt": {`"created_at"`, "timestamptz", func(t *domain.AvailabilityTransition) string {
+			return t.CreatedAt.Format(time.RFC3339Nano)
+		}},
+	},
+	id: func(t *domain.AvailabilityTransition) uuid.UUID { return t.ID },
+}
+
+func (r *deviceAvailabilityRepository) ListTransitions(ctx context.Context, filters *domain.ListAvailabilityTransitionFilters) (*domain.List[*domain.AvailabilityTransition], error) {
+	q := &listQuery{}
+	q.and(`"device_id" = ?`, filters.DeviceID)
+
+	if filters.From != nil {
+		q.and(`"created_at" >= ?`, *filters.From)
+	}
+
+	if filters.To != nil {
+		q.and(`"created_at" < ?`, *filters.To)
+	}
+
+	return list(ctx, r.db, availabilityTransitionList, q, &filters.Page)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
@@ -27,10 +28,10 @@ func (r *deviceControlRepository) Exist(ctx context.Context, filters *domain.Dev
 	sql := `
 		SELECT "id"
 		FROM "device_controls"
-		WHERE "device_id" = $1 AND "type" = $2
+		WHERE "device_id" = $1 AND "type" = $2 AND "deleted_at" IS NULL
 	`
 
-	if err := r.db.SelectContext(ctx, &controls, sql, filters.DeviceID, filters.Type); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &controls, sql, filters.DeviceID, filters.Type); err != nil {
 		return false, errors.Wrap(err, "deviceControlRepository.Exist.SelectContext")
 	}
 
@@ -44,28 +45,84 @@ func (r *deviceControlRepository) ListByDevice(ctx context.Context, deviceID uui
 		SELECT
 			"id", "device_id", "name", "type", "quality_of_service", "icon_name", "icon_background_color",
 			"is_available", "is_confirmation_required", "can_notify_on_publish", "can_display_name",
-			"topic", "attributes"
+			"topic", "attributes", "version"
 		FROM "device_controls"
-		WHERE "device_id" = $1
+		WHERE "device_id" = $1 AND "deleted_at" IS NULL
 	`
 
-	if err := r.db.SelectContext(ctx, &controls, sql, deviceID); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &controls, sql, deviceID); err != nil {
 		return nil, errors.Wrap(err, "deviceControlRepository.List.SelectContext")
 	}
 
 	return controls, nil
 }
 
+// ListTopics returns the topics of the controls of all the devices of the broker.
+func (r *deviceControlRepository) ListTopics(ctx context.Context, brokerID uuid.UUID) ([]*domain.ControlTopic, error) {
+	topics := []*domain.ControlTopic{}
+
+	sql := `
+		SELECT c."id", d."broker_id", c."device_id", c."name", c."type", c."topic", d."base_path", c."attributes"
+		FROM "device_controls" c JOIN "devices" d ON d."id" = c."device_id"
+		WHERE d."broker_id" = $1 AND d."deleted_at" IS NULL AND c."deleted_at" IS NULL
+		ORDER BY c."topic", c."id"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &topics, sql, brokerID); err != nil {
+		return nil, errors.Wrap(err, "deviceControlRepository.ListTopics.SelectContext")
+	}
+
+	return topics, nil
+}
+
+var deviceControlList = &listSpec[*domain.DeviceControl]{
+	name: "deviceControlRepository.List",
+	columns: `"id", "device_id", "name", "type", "quality_of_service", "icon_name", "icon_background_color",
+		"is_available", "is_confirmation_required", "can_notify_on_publish", "can_display_name",
+		"topic", "attributes", "version"`,
+	from:        `"device_controls"`,
+	defaultSort: "name",
+	sorts: map[string]sortColumn[*domain.DeviceControl]{
+		"name":  {`"name"`, "text", func(c *domain.DeviceControl) string { return c.Name }},
+		"type":  {`"type"`, "text", func(c *domain.DeviceControl) string { return string(c.Type) }},
+		"topic": {`"topic"`, "text", func(c *domain.DeviceControl) string { return c.Topic }},
+	},
+	id: func(c *domain.DeviceControl) uuid.UUID { return c.ID },
+}
+
+func (r *deviceControlRepository) List(ctx context.Context, filters *domain.ListDeviceControlFilters) (*domain.List[*domain.DeviceControl], error) {
+	q := &listQuery{}
+	q.and(`"device_id" = ? AND "deleted_at" IS NULL`, filters.DeviceID)
+	q.search(`"name"`, filters.Search)
+
+	if filters.Type != nil {
+		q.and(`"type" = ?`, *filters.Type)
+	}
+
+	if filters.IsAvailable != nil {
+		q.and(`"is_available" = ?`, *filters.IsAvailable)
+	}
+
+	if filters.TagID.Valid {
+		q.and(`EXISTS (
+			SELECT 1 FROM "device_control_tags"
+			WHERE "device_control_tags"."control_id" = "device_controls"."id" AND "device_control_tags"."tag_id" = ?
+		)`, filters.TagID.UUID)
+	}
+
+	return list(ctx, r.db, deviceControlList, q, &filters.Page)
+}
+
 func (r *deviceControlRepository) ListByType(ctx context.Context, filters *domain.DeviceControlFilters) ([]*domain.DeviceControl, error) {
 	var controls []*domain.DeviceControl
 
 	sql := `
 		SELECT "id", "device_id", "type"
 		FROM "device_controls"
-		WHERE "device_id" = $1 AND "type" = $2
+		WHERE "device_id" = $1 AND "type" = $2 AND "deleted_at" IS NULL
 	`
 
-	if err := r.db.SelectContext(ctx, &controls, sql, filters.DeviceID, filters.Type); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &controls, sql, filters.DeviceID, filters.Type); err != nil {
 		return nil, errors.Wrap(err, "deviceControlRepository.ListByType.SelectContext")
 	}
 
@@ -86,7 +143,7 @@ func (r *deviceControlRepository) Create(ctx context.Context, control *domain.Cr
 			"is_confirmation_required", "can_notify_on_publish", "can_display_name",
 			"quality_of_service", "topic", "attributes")
 		VALUES (
-			'%s', '%s', '%s'::"control_type", '%s', '%s', %v, %v, %v, %v, '%d'::"qos_level", '%s', $1			 
+			'%s', '%s', '%s', '%s', '%s', %v, %v, %v, %v, '%d'::"qos_level", '%s', $1			 
 		) RETURNING "id"`,
 		control.DeviceID,
 		control.Name,
@@ -101,7 +158,7 @@ func (r *deviceControlRepository) Create(ctx context.Context, control *domain.Cr
 		control.Topic,
 	)
 
-	if err := r.db.GetContext(ctx, created, sql, attr); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, created, sql, attr); err != nil {
 		return uuid.Nil, errors.Wrap(err, "deviceControlRepository.Create.GetContext")
 	}
 
@@ -124,7 +181,7 @@ func (r *deviceControlRepository) Update(ctx context.Context, control *domain.Up
 	}
 
 	if control.Type != nil {
-		f = append(f, fmt.Sprintf(`"type" = '%s'::"control_type"`, *control.Type))
+		f = append(f, fmt.Sprintf(`"type" = '%s'`, *control.Type))
 	}
 
 	if control.CanDisplayName != nil {
@@ -160,28 +217,86 @@ func (r *deviceControlRepository) Update(ctx context.Context, control *domain.Up
 		f = append(f, fmt.Sprintf(`"topic" = '%s'`, *control.Topic))
 	}
 
-	sql := fmt.Sprintf(`UPDATE "device_controls" SET %s WHERE "id" = $1 AND "device_id" = $2`, strings.Join(f, ","))
+	f = append(f, `"version" = "version" + 1`)
+
+	sql := fmt.Sprintf(`UPDATE "device_controls" SET %s WHERE "id" = $1 AND "device_id" = $2 AND "deleted_at" IS NULL%s`,
+		strings.Join(f, ","), versionCondition(control.Version))
 
-	sr, err := r.db.ExecContext(ctx, sql, control.ID, control.DeviceID)
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql, control.ID, control.DeviceID)
 	if err != nil {
 		return errors.Wrap(err, "deviceControlRepository.Update.ExecContext")
 	}
 
 	if af, _ := sr.RowsAffected(); af == 0 {
-		return ae.ErrDeviceControlNotFound
+		return r.notAffected(ctx, control.DeviceID, control.ID, control.Version)
 	}
 	return nil
 }
 
-func (r *deviceControlRepository) Delete(ctx context.Context, deviceID uuid.UUID, controlID uuid.UUID) error {
-
-	sql := `DELETE FROM "device_controls" WHERE "id" = $1 AND "device_id" = $2`
+// Delete moves the control to the trash.
+func (r *deviceControlRepository) Delete(ctx context.Context, deviceID uuid.UUID, controlID uuid.UUID, version *int64) error {
+	sql := `
+		UPDATE "device_controls" SET "deleted_at" = now(), "version" = "version" + 1
+		WHERE "id" = $1 AND "device_id" = $2 AND "deleted_at" IS NULL
+	` + versionCondition(version)
 
-	sr, err := r.db.ExecContext(ctx, sql, controlID, deviceID)
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql, controlID, deviceID)
 	if err != nil {
 		return errors.Wrap(err, "deviceControlRepository.Delete.ExecContext")
 	}
 
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return r.notAffected(ctx, deviceID, controlID, version)
+	}
+	return nil
+}
+
+// Restore takes the control out of the trash, a state control is restored only when the device has no other one.
+func (r *deviceControlRepository) Restore(ctx context.Context, deviceID uuid.UUID, controlID uuid.UUID) error {
+	sql := `
+		UPDATE "device_controls" c SET "deleted_at" = NULL, "version" = c."version" + 1
+		WHERE c."id" = $1 AND c."device_id" = $2 AND c."deleted_at" IS NOT NULL
+			AND (c."type" <> $3 OR NOT EXISTS (
+				SELECT 1 FROM "device_controls" s
+				WHERE s."device_id" = $2 AND s."type" = $3 AND s."deleted_at" IS NULL
+			))
+	`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql, controlID, deviceID, enum.ControlState)
+	if err != nil {
+		return errors.Wrap(err, "deviceControlRepository.Restore.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af > 0 {
+		return nil
+	}
+
+	var trashed bool
+	sql = `SELECT EXISTS (SELECT 1 FROM "device_controls" WHERE "id" = $1 AND "device_id" = $2 AND "deleted_at" IS NOT NULL)`
+	if err := conn(ctx, r.db).GetContext(ctx, &trashed, sql, controlID, deviceID); err != nil {
+		return errors.Wrap(err, "deviceControlRepository.Restore.GetContext")
+	}
+
+	if trashed {
+		return ae.ErrControlStateExists
+	}
+	return ae.ErrDeviceControlNotFound
+}
+
+func (r *deviceControlRepository) notAffected(ctx context.Context, deviceID uuid.UUID, controlID uuid.UUID, version *int64) error {
+	sql := `SELECT EXISTS (SELECT 1 FROM "device_controls" WHERE "id" = $1 AND "device_id" = $2 AND "deleted_at" IS NULL)`
+
+	return notAffected(ctx, r.db, version, ae.ErrDeviceControlNotFound, sql, controlID, deviceID)
+}
+
+func (r *deviceControlRepository) SetLastValue(ctx context.Context, controlID uuid.UUID, value *domain.ControlValue) error {
+	sql := `UPDATE "device_controls" SET "last_value" = $2, "last_value_at" = now() WHERE "id" = $1 AND "deleted_at" IS NULL`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql, controlID, value)
+	if err != nil {
+		return errors.Wrap(err, "deviceControlRepository.SetLastValue.ExecContext")
+	}
+
 	if af, _ := sr.RowsAffected(); af == 0 {
 		return ae.ErrDeviceControlNotFound
 	}
@@ -0,0 +1,98 @@
+package persistance
+
+import (
+	"context"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type deviceMessageRepository struct {
+	db *sqlx.DB
+}
+
+func NewDeviceMessageRepository(db *sqlx.DB) repository.DeviceMessageRepository {
+	return &deviceMessageRepository{db}
+}
+
+var deviceMessageList = &listSpec[*domain.DeviceMessage]{
+	name: "deviceMessageRepository.List",
+	columns: `"id", "device_id", "control_id", "direction", "topic", "payload", "truncated",
+		"quality_of_service", "retained", "source_type", "source_id", "created_at"`,
+	from:        `"device_messages"`,
+	defaultSort: "createdAt",
+	sorts: map[string]sortColumn[*domain.DeviceMessage]{
+		"createdAt": {`"created_at"`, "timestamptz", func(m *domain.DeviceMessage) string { return m.CreatedAt.Format(time.RFC3339Nano) }},
+		"topic":     {`"topic"`, "text", func(m *domain.DeviceMessage) string { return m.Topic }},
+	},
+	id: func(m *domain.DeviceMessage) uuid.UUID { return m.ID },
+}
+
+func (r *deviceMessageRepository) List(ctx context.Context, filters *domain.ListDeviceMessageFilters) (*domain.List[*domain.DeviceMessage], error) {
+	q := &listQuery{}
+	q.and(`"device_id" = ?`, filters.DeviceID)
+
+	if filters.Topic != "" {
+		q.and(`"topic" ~ ?`, mqtt.FilterRegexp(filters.Topic))
+	}
+
+	if filters.Contains != "" {
+		q.and(`position(convert_to(?, 'UTF8') in "payload") > 0`, filters.Contains)
+	}
+
+	if filters.From != nil {
+		q.and(`"created_at" >= ?`, *filters.From)
+	}
+
+	if filters.To != nil {
+		q.and(`"created_at" < ?`, *filters.To)
+	}
+
+	return list(ctx, r.db, deviceMessageList, q, &filters.Page)
+}
+
+func (r *deviceMessageRepository) Create(ctx context.Context, message *domain.DeviceMessage) error {
+	sql := `
+		INSERT INTO "device_messages" ("device_id", "control_id", "direction", "topic", "payload", "truncated",
+			"quality_of_service", "retained", "source_type", "source_id", "created_at")
+		VALUES (:device_id, :control_id, :direction, :topic, :payload, :truncated,
+			:quality_of_service, :retained, :source_type, :source_id, :created_at)
+	`
+
+	if _, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), sql, message); err != nil {
+		return errors.Wrap(err, "deviceMessageRepository.Create.NamedExecContext")
+	}
+
+	return nil
+}
+
+// Purge removes the messages older than the time and the ones over the limit of a device, starting with the oldest.
+func (r *deviceMessageRepository) Purge(ctx context.Context, before time.Time, limit int) (int64, error) {
+	sql := `
+		DELETE FROM "device_messages"
+		WHERE "created_at" < $1 OR "id" IN (
+			SELECT "id" FROM (
+				SELECT "id", row_number() OVER (PARTITION BY "device_id" ORDER BY "created_at" DESC, "id" DESC) AS "n"
+				FROM "device_messages"
+			) AS "m"
+			WHERE "n" > $2
+		)
+	`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, sql, before, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "deviceMessageRepository.Purge.ExecContext")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "deviceMessageRepository.Purge.RowsAffected")
+	}
+
+	return n, nil
+}
@@ -2,8 +2,10 @@ package persistance
 
 import (
 	"context"
+	dsql "database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
@@ -25,36 +27,74 @@ func (r *deviceRepository) Get(ctx context.Context, deviceID uuid.UUID, userID u
 	device := &domain.Device{}
 
 	sql := `
-		SELECT "id", "broker_id", "name", "icon_name", "icon_background_color",
-			"placing", "base_path", "created_at", "updated_at"
-		FROM "devices" WHERE "id" = $1 AND "user_id" = $2
+		SELECT "id", "broker_id", "room_id", "name", "icon_name", "icon_background_color",
+			"placing", "base_path", "version", "created_at", "updated_at", "status", "last_seen_at"
+		FROM "devices" WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NULL
 	`
 
-	if err := r.db.GetContext(ctx, device, sql, deviceID, userID); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, device, sql, deviceID, userID); err != nil {
+		if errors.Is(err, dsql.ErrNoRows) {
+			return nil, ae.ErrDeviceNotFound
+		}
+
 		return nil, errors.Wrap(err, "deviceRepository.Get.GetContext")
 	}
 
 	return device, nil
 }
 
-func (r *deviceRepository) List(ctx context.Context, filters *domain.ListDeviceFilters) ([]*domain.Device, error) {
-	var devices []*domain.Device
+var deviceList = &listSpec[*domain.Device]{
+	name: "deviceRepository.List",
+	columns: `"id", "broker_id", "room_id", "name", "icon_name", "icon_background_color",
+		"placing", "base_path", "version", "created_at", "updated_at", "status", "last_seen_at"`,
+	from:        `"devices"`,
+	defaultSort: "createdAt",
+	sorts: map[string]sortColumn[*domain.Device]{
+		"name": {`"name"`, "text", func(d *domain.Device) string { return d.Name }},
+		"placing": {`COALESCE("placing", '')`, "text", func(d *domain.Device) string {
+			if d.Placing == nil {
+				return ""
+			}
+			return *d.Placing
+		}},
+		"createdAt": {`"created_at"`, "timestamptz", func(d *domain.Device) string { return d.CreatedAt.Format(time.RFC3339Nano) }},
+		"updatedAt": {`"updated_at"`, "timestamptz", func(d *domain.Device) string { return d.UpdatedAt.Format(time.RFC3339Nano) }},
+	},
+	id: func(d *domain.Device) uuid.UUID { return d.ID },
+}
 
-	sql := `
-		SELECT "id", "broker_id", "name", "icon_name", "icon_background_color",
-			"placing", "base_path", "created_at", "updated_at"
-		FROM "devices" WHERE "user_id" = $1
-	`
+func (r *deviceRepository) List(ctx context.Context, filters *domain.ListDeviceFilters) (*domain.List[*domain.Device], error) {
+	q := &listQuery{}
+	q.and(`"user_id" = ? AND "deleted_at" IS NULL`, filters.UserID)
+	q.search(`"name"`, filters.Search)
 
 	if filters.BrokerID.Valid {
-		sql = fmt.Sprintf(`%s AND "broker_id" = '%s'`, sql, filters.BrokerID.UUID.String())
+		q.and(`"broker_id" = ?`, filters.BrokerID.UUID)
+	}
+
+	if filters.RoomID.Valid {
+		q.and(`"room_id" = ?`, filters.RoomID.UUID)
+	}
+
+	if filters.TagID.Valid {
+		q.and(`EXISTS (
+			SELECT 1 FROM "device_tags" WHERE "device_tags"."device_id" = "devices"."id" AND "device_tags"."tag_id" = ?
+		)`, filters.TagID.UUID)
+	}
+
+	if filters.Placing != nil {
+		q.and(`"placing" = ?`, *filters.Placing)
 	}
 
-	if err := r.db.SelectContext(ctx, &devices, sql, filters.UserID); err != nil {
-		return nil, errors.Wrap(err, "deviceRepository.List.SelectContext")
+	if filters.ControlType != nil {
+		q.and(`EXISTS (
+			SELECT 1 FROM "device_controls"
+			WHERE "device_controls"."device_id" = "devices"."id" AND "device_controls"."type" = ?
+				AND "device_controls"."deleted_at" IS NULL
+		)`, *filters.ControlType)
 	}
 
-	return devices, nil
+	return list(ctx, r.db, deviceList, q, &filters.Page)
 }
 
 func (r *deviceRepository) Create(ctx context.Context, device *domain.CreateDevice) (uuid.UUID, error) {
@@ -72,6 +112,11 @@ func (r *deviceRepository) Create(ctx context.Context, device *domain.CreateDevi
 		p = fmt.Sprintf("%s, NULL", p)
 	}
 
+	if device.RoomID.Valid {
+		f.WriteString(`, "room_id"`)
+		p = fmt.Sprintf("%s, '%s'", p, device.RoomID.UUID)
+	}
+
 	if device.BasePath.Set {
 		f.WriteString(`, "base_path"`)
 		if device.BasePath.Null {
@@ -94,7 +139,7 @@ func (r *deviceRepository) Create(ctx context.Context, device *domain.CreateDevi
 
 	sql := fmt.Sprintf(`INSERT INTO "devices" (%s) VALUES (%s) RETURNING id`, f.String(), p)
 
-	if err := r.db.GetContext(ctx, created, sql); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, created, sql); err != nil {
 		return uuid.Nil, errors.Wrap(err, "deviceRepository.Create.GetContext")
 	}
 
@@ -110,6 +155,15 @@ func (r *deviceRepository) Update(ctx context.Context, device *domain.UpdateDevi
 		} else {
 			p = append(p, fmt.Sprintf(`"broker_id" = '%s'`, device.BrokerID.Value))
 		}
+		p = append(p, `"deleted_broker_id" = NULL`)
+	}
+
+	if device.RoomID.Set {
+		if device.RoomID.Null {
+			p = append(p, `"room_id" = NULL`)
+		} else {
+			p = append(p, fmt.Sprintf(`"room_id" = '%s'`, device.RoomID.Value))
+		}
 	}
 
 	if device.Name.Set && !device.Name.Null {
@@ -144,34 +198,80 @@ func (r *deviceRepository) Update(ctx context.Context, device *domain.UpdateDevi
 		return ae.ErrMissingParams
 	}
 
-	p = append(p, `"updated_at" = now()`)
+	p = append(p, `"version" = "version" + 1`, `"updated_at" = now()`)
 
-	sql := fmt.Sprintf(`UPDATE "devices" SET %s WHERE "id" = $1 AND "user_id" = $2`, strings.Join(p, ","))
+	sql := fmt.Sprintf(`UPDATE "devices" SET %s WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NULL%s`,
+		strings.Join(p, ","), versionCondition(device.Version))
 
-	sr, err := r.db.ExecContext(ctx, sql, device.ID, device.UserID)
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql, device.ID, device.UserID)
 	if err != nil {
 		return errors.Wrap(err, "deviceRepository.Update.ExecContext")
 	}
 
 	if af, _ := sr.RowsAffected(); af == 0 {
-		return ae.ErrDeviceNotFound
+		return r.notAffected(ctx, device.ID, device.UserID, device.Version)
 	}
 	return nil
 }
 
-func (r *deviceRepository) Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error {
+// Delete moves the device to the trash along with its controls, they are marked with the same time
+// so the restore can tell them from the controls deleted before.
+func (r *deviceRepository) Delete(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID, version *int64) error {
 	sql := `
-		DELETE FROM "devices"
-		WHERE "id" = $1 AND "user_id" = $2
+		WITH "deleted" AS (
+			UPDATE "devices" SET "deleted_at" = now(), "version" = "version" + 1
+			WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NULL` + versionCondition(version) + `
+			RETURNING "id", "deleted_at"
+		), "controls" AS (
+			UPDATE "device_controls" SET "deleted_at" = "deleted"."deleted_at"
+			FROM "deleted"
+			WHERE "device_controls"."device_id" = "deleted"."id" AND "device_controls"."deleted_at" IS NULL
+		)
+		SELECT COUNT(*) FROM "deleted"
 	`
 
-	sr, err := r.db.ExecContext(ctx, sql, deviceID, userID)
-	if err != nil {
-		return errors.Wrap(err, "deviceRepository.Delete.ExecContext")
+	var af int64
+	if err := conn(ctx, r.db).GetContext(ctx, &af, sql, deviceID, userID); err != nil {
+		return errors.Wrap(err, "deviceRepository.Delete.GetContext")
 	}
 
-	if af, _ := sr.RowsAffected(); af == 0 {
+	if af == 0 {
+		return r.notAffected(ctx, deviceID, userID, version)
+	}
+	return nil
+}
+
+// Restore takes the device out of the trash along with the controls deleted by its deletion.
+func (r *deviceRepository) Restore(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID) error {
+	sql := `
+		WITH "trashed" AS (
+			SELECT "id", "deleted_at" FROM "devices"
+			WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NOT NULL
+		), "restored" AS (
+			UPDATE "devices" SET "deleted_at" = NULL, "version" = "devices"."version" + 1, "updated_at" = now()
+			FROM "trashed"
+			WHERE "devices"."id" = "trashed"."id"
+		), "controls" AS (
+			UPDATE "device_controls" SET "deleted_at" = NULL, "version" = "device_controls"."version" + 1
+			FROM "trashed"
+			WHERE "device_controls"."device_id" = "trashed"."id" AND "device_controls"."deleted_at" = "trashed"."deleted_at"
+		)
+		SELECT COUNT(*) FROM "trashed"
+	`
+
+	var af int64
+	if err := conn(ctx, r.db).GetContext(ctx, &af, sql, deviceID, userID); err != nil {
+		return errors.Wrap(err, "deviceRepository.Restore.GetContext")
+	}
+
+	if af == 0 {
 		return ae.ErrDeviceNotFound
 	}
 	return nil
 }
+
+func (r *deviceRepository) notAffected(ctx context.Context, deviceID uuid.UUID, userID uuid.UUID, version *int64) error {
+	sql := `SELECT EXISTS (SELECT 1 FROM "devices" WHERE "id" = $1 AND "user_id" = $2 AND "deleted_at" IS NULL)`
+
+	return notAffected(ctx, r.db, version, ae.ErrDeviceNotFound, sql, deviceID, userID)
+}
@@ -0,0 +1,205 @@
+package persistance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type groupRepository struct {
+	db *sqlx.DB
+}
+
+func NewGroupRepository(db *sqlx.DB) repository.GroupRepository {
+	return &groupRepository{db}
+}
+
+func (r *groupRepository) Get(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*domain.Group, error) {
+	group := &domain.Group{}
+
+	sqls := `
+		SELECT "id", "user_id", "name", "type", "icon_name", "icon_background_color", "created_at", "updated_at"
+		FROM "device_groups"
+		WHERE "id" = $1 AND "user_id" = $2
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, group, sqls, groupID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrGroupNotFound
+		}
+
+		return nil, errors.Wrap(err, "groupRepository.Get.GetContext")
+	}
+
+	return group, nil
+}
+
+func (r *groupRepository) List(ctx context.Context, userID uuid.UUID) ([]*domain.Group, error) {
+	groups := []*domain.Group{}
+
+	sqls := `
+		SELECT "id", "user_id", "name", "type", "icon_name", "icon_background_color", "created_at", "updated_at"
+		FROM "device_groups"
+		WHERE "user_id" = $1
+		ORDER BY lower("name"), "created_at"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &groups, sqls, userID); err != nil {
+		return nil, errors.Wrap(err, "groupRepository.List.SelectContext")
+	}
+
+	return groups, nil
+}
+
+func (r *groupRepository) Create(ctx context.Context, group *domain.CreateGroup) (uuid.UUID, error) {
+	var groupID uuid.UUID
+
+	sqls := `
+		INSERT INTO "device_groups" ("user_id", "name", "type", "icon_name", "icon_background_color")
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING "id"
+	`
+
+	err := conn(ctx, r.db).GetContext(ctx, &groupID, sqls, group.UserID, group.Name, group.Type, group.IconName, group.IconBackgroundColor)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "groupRepository.Create.GetContext")
+	}
+
+	return groupID, nil
+}
+
+func (r *groupRepository) Update(ctx context.Context, group *domain.UpdateGroup) error {
+	s := &updateSet{args: []interface{}{group.ID, group.UserID}}
+	s.add(`"name"`, group.Name)
+	s.add(`"icon_name"`, group.IconName)
+	s.add(`"icon_background_color"`, group.IconBackgroundColor)
+
+	if s.empty() {
+		return ae.ErrMissingParams
+	}
+
+	sqls := fmt.Sprintf(`UPDATE "device_groups" SET %s WHERE "id" = $1 AND "user_id" = $2`, s.String())
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, s.args...)
+	if err != nil {
+		return errors.Wrap(err, "groupRepository.Update.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrGroupNotFound
+	}
+	return nil
+}
+
+func (r *groupRepository) Delete(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error {
+	sqls := `DELETE FROM "device_groups" WHERE "id" = $1 AND "user_id" = $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, groupID, userID)
+	if err != nil {
+		return errors.Wrap(err, "groupRepository.Delete.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrGroupNotFound
+	}
+	return nil
+}
+
+type groupControlRow struct {
+	GroupID   uuid.UUID `db:"group_id"`
+	ControlID uuid.UUID `db:"control_id"`
+}
+
+// ListControls groups the control ids by the groups.
+func (r *groupRepository) ListControls(ctx context.Context, groupIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	controls := make(map[uuid.UUID][]uuid.UUID, len(groupIDs))
+	if len(groupIDs) == 0 {
+		return controls, nil
+	}
+
+	sqls := `
+		SELECT g."group_id", g."control_id"
+		FROM "device_group_controls" g
+		JOIN "device_controls" c ON c."id" = g."control_id"
+		WHERE g."group_id" = ANY($1::uuid[]) AND c."deleted_at" IS NULL
+	`
+
+	rows := []groupControlRow{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, sqls, pq.Array(groupIDs)); err != nil {
+		return nil, errors.Wrap(err, "groupRepository.ListControls.SelectContext")
+	}
+
+	for _, row := range rows {
+		controls[row.GroupID] = append(controls[row.GroupID], row.ControlID)
+	}
+
+	return controls, nil
+}
+
+// SetControls replaces the members of the group.
+func (r *groupRepository) SetControls(ctx context.Context, groupID uuid.UUID, controlIDs []uuid.UUID) error {
+	sqls := `
+		WITH "removed" AS (
+			DELETE FROM "device_group_controls" WHERE "group_id" = $1 AND NOT ("control_id" = ANY($2::uuid[]))
+		)
+		INSERT INTO "device_group_controls" ("group_id", "control_id")
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, groupID, pq.Array(controlIDs)); err != nil {
+		return errors.Wrap(err, "groupRepository.SetControls.ExecContext")
+	}
+
+	return nil
+}
+
+const groupMemberColumns = `
+	c."id" AS "control_id", c."name" AS "control_name", c."device_id", d."name" AS "device_name", d."placing",
+	d."broker_id", c."type", c."quality_of_service", c."topic", d."base_path", c."attributes", c."last_value", c."last_value_at"
+`
+
+func (r *groupRepository) ListMembers(ctx context.Context, groupID uuid.UUID) ([]*domain.GroupMember, error) {
+	members := []*domain.GroupMember{}
+
+	sqls := `
+		SELECT ` + groupMemberColumns + `
+		FROM "device_group_controls" g
+		JOIN "device_controls" c ON c."id" = g."control_id"
+		JOIN "devices" d ON d."id" = c."device_id"
+		WHERE g."group_id" = $1 AND c."deleted_at" IS NULL AND d."deleted_at" IS NULL
+		ORDER BY d."name", c."name", c."id"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &members, sqls, groupID); err != nil {
+		return nil, errors.Wrap(err, "groupRepository.ListMembers.SelectContext")
+	}
+
+	return members, nil
+}
+
+// ListUserMembers returns the controls of the user which can become the members of a group.
+func (r *groupRepository) ListUserMembers(ctx context.Context, userID uuid.UUID, controlIDs []uuid.UUID) ([]*domain.GroupMember, error) {
+	members := []*domain.GroupMember{}
+
+	sqls := `
+		SELECT ` + groupMemberColumns + `
+		FROM "device_controls" c
+		JOIN "devices" d ON d."id" = c."device_id"
+		WHERE d."user_id" = $1 AND c."id" = ANY($2::uuid[]) AND c."deleted_at" IS NULL AND d."deleted_at" IS NULL
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &members, sqls, userID, pq.Array(controlIDs)); err != nil {
+		return nil, errors.Wrap(err, "groupRepository.ListUserMembers.SelectContext")
+	}
+
+	return members, nil
+}
@@ -0,0 +1,143 @@
+package persistance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// listQuery collects the conditions of a list, each ? in them is replaced with the next numbered parameter.
+type listQuery struct {
+	conds []string
+	args  []interface{}
+}
+
+func (q *listQuery) and(cond string, args ...interface{}) {
+	var b strings.Builder
+	for _, c := range cond {
+		if c == '?' && len(args) > 0 {
+			q.args = append(q.args, args[0])
+			args = args[1:]
+			fmt.Fprintf(&b, "$%d", len(q.args))
+			continue
+		}
+
+		b.WriteRune(c)
+	}
+
+	q.conds = append(q.conds, b.String())
+}
+
+// search matches the column case insensitively against the text, the LIKE wildcards in the text are matched literally.
+func (q *listQuery) search(column string, text string) {
+	if text == "" {
+		return
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+	q.and(column+` ILIKE ?`, "%"+escaped+"%")
+}
+
+func (q *listQuery) where() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(q.conds, " AND ")
+}
+
+// sortColumn is a key the list can be sorted by, the value of the last item is kept in the cursor of the next page
+// and compared as the given SQL type.
+type sortColumn[T any] struct {
+	expr  string
+	cast  string
+	value func(T) string
+}
+
+type listSpec[T any] struct {
+	name        string
+	columns     string
+	from        string
+	defaultSort string
+	sorts       map[string]sortColumn[T]
+	id          func(T) uuid.UUID
+}
+
+// list selects the page of the items matching the query, ordered by the sort column and the id.
+func list[T any](ctx context.Context, db *sqlx.DB, s *listSpec[T], q *listQuery, page *domain.Page) (*domain.List[T], error) {
+	key := page.Sort
+	if key == "" {
+		key = s.defaultSort
+	}
+
+	col, ok := s.sorts[key]
+	if !ok {
+		return nil, s.sortError()
+	}
+
+	r := &domain.List[T]{Items: []T{}}
+
+	sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, s.from, q.where())
+	if err := conn(ctx, db).GetContext(ctx, &r.Total, sql, q.args...); err != nil {
+		return nil, errors.Wrap(err, s.name+".GetContext")
+	}
+
+	dir, cmp := "ASC", ">"
+	if page.Desc {
+		dir, cmp = "DESC", "<"
+	}
+
+	if c := page.After; c != nil {
+		if c.Sort != key || c.Desc != page.Desc {
+			return nil, ae.ErrInvalidCursor
+		}
+
+		q.and(fmt.Sprintf(`(%s, "id") %s (?::%s, ?)`, col.expr, cmp, col.cast), c.Value, c.ID)
+	}
+
+	sql = fmt.Sprintf(`SELECT %s FROM %s %s ORDER BY %s %s, "id" %s`, s.columns, s.from, q.where(), col.expr, dir, dir)
+
+	if page.Limit > 0 {
+		q.args = append(q.args, page.Limit+1)
+		sql = fmt.Sprintf(`%s LIMIT $%d`, sql, len(q.args))
+	}
+
+	if err := conn(ctx, db).SelectContext(ctx, &r.Items, sql, q.args...); err != nil {
+		return nil, errors.Wrap(err, s.name+".SelectContext")
+	}
+
+	if page.Limit > 0 && len(r.Items) > page.Limit {
+		r.Items = r.Items[:page.Limit]
+
+		last := r.Items[page.Limit-1]
+		r.Next = &pagination.Cursor{Sort: key, Desc: page.Desc, Value: col.value(last), ID: s.id(last)}
+	}
+
+	return r, nil
+}
+
+func (s *listSpec[T]) sortError() error {
+	keys := make([]string, 0, len(s.sorts))
+	for k := range s.sorts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return &ae.ValidationError{
+		Err: ae.ErrValidation,
+		Fields: []*ae.FieldError{{
+			Field:   "sort",
+			Rule:    "oneof",
+			Param:   strings.Join(keys, " "),
+			Message: "should be one of " + strings.Join(keys, " "),
+		}},
+	}
+}
@@ -0,0 +1,104 @@
+package persistance
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// revisionLimit is the count of the revisions kept for an entity, the older ones are removed.
+const revisionLimit = 50
+
+type revisionRepository struct {
+	db *sqlx.DB
+}
+
+func NewRevisionRepository(db *sqlx.DB) repository.RevisionRepository {
+	return &revisionRepository{db}
+}
+
+// revisionTable is the table of the revisions of the entity and its column referencing the entity.
+type revisionTable struct {
+	name   string
+	column string
+}
+
+var revisionTables = map[enum.RevisionEntity]revisionTable{
+	enum.RevisionBroker:  {`"broker_revisions"`, `"broker_id"`},
+	enum.RevisionDevice:  {`"device_revisions"`, `"device_id"`},
+	enum.RevisionControl: {`"device_control_revisions"`, `"control_id"`},
+}
+
+func (r *revisionRepository) List(ctx context.Context, entity enum.RevisionEntity, entityID uuid.UUID) ([]*domain.Revision, error) {
+	revisions := []*domain.Revision{}
+	t := revisionTables[entity]
+
+	sqls := fmt.Sprintf(`
+		SELECT "revision", "snapshot", "created_at"
+		FROM %s
+		WHERE %s = $1
+		ORDER BY "revision" DESC
+	`, t.name, t.column)
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &revisions, sqls, entityID); err != nil {
+		return nil, errors.Wrap(err, "revisionRepository.List.SelectContext")
+	}
+
+	return revisions, nil
+}
+
+func (r *revisionRepository) Get(ctx context.Context, entity enum.RevisionEntity, entityID uuid.UUID, revision int) (*domain.Revision, error) {
+	rev := &domain.Revision{}
+	t := revisionTables[entity]
+
+	sqls := fmt.Sprintf(`SELECT "revision", "snapshot", "created_at" FROM %s WHERE %s = $1 AND "revision" = $2`, t.name, t.column)
+
+	if err := conn(ctx, r.db).GetContext(ctx, rev, sqls, entityID, revision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrRevisionNotFound
+		}
+
+		return nil, errors.Wrap(err, "revisionRepository.Get.GetContext")
+	}
+
+	return rev, nil
+}
+
+// Create stores the snapshot as the next revision of the entity unless it is the same as the last one.
+func (r *revisionRepository) Create(ctx context.Context, entity enum.RevisionEntity, entityID uuid.UUID, snapshot interface{}) error {
+	t := revisionTables[entity]
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "revisionRepository.Create.Marshal")
+	}
+
+	sqls := fmt.Sprintf(`
+		WITH "created" AS (
+			INSERT INTO %[1]s (%[2]s, "revision", "snapshot")
+			SELECT $1, COALESCE(MAX("revision"), 0) + 1, $2::jsonb
+			FROM %[1]s
+			WHERE %[2]s = $1
+			HAVING (array_agg("snapshot" ORDER BY "revision" DESC))[1] IS DISTINCT FROM $2::jsonb
+			RETURNING "revision"
+		)
+		DELETE FROM %[1]s
+		USING "created"
+		WHERE %[2]s = $1 AND %[1]s."revision" <= "created"."revision" - $3
+	`, t.name, t.column)
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, entityID, data, revisionLimit); err != nil {
+		return errors.Wrap(err, "revisionRepository.Create.ExecContext")
+	}
+
+	return nil
+}
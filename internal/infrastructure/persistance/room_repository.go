@@ -0,0 +1,172 @@
+package persistance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type roomRepository struct {
+	db *sqlx.DB
+}
+
+func NewRoomRepository(db *sqlx.DB) repository.RoomRepository {
+	return &roomRepository{db}
+}
+
+func (r *roomRepository) Get(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) (*domain.Room, error) {
+	room := &domain.Room{}
+
+	sqls := `
+		SELECT "id", "user_id", "name", "icon_name", "icon_background_color", "position", "created_at", "updated_at"
+		FROM "rooms"
+		WHERE "id" = $1 AND "user_id" = $2
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, room, sqls, roomID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ae.ErrRoomNotFound
+		}
+
+		return nil, errors.Wrap(err, "roomRepository.Get.GetContext")
+	}
+
+	return room, nil
+}
+
+func (r *roomRepository) List(ctx context.Context, userID uuid.UUID) ([]*domain.Room, error) {
+	rooms := []*domain.Room{}
+
+	sqls := `
+		SELECT "id", "user_id", "name", "icon_name", "icon_background_color", "position", "created_at", "updated_at"
+		FROM "rooms"
+		WHERE "user_id" = $1
+		ORDER BY "position", "created_at"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &rooms, sqls, userID); err != nil {
+		return nil, errors.Wrap(err, "roomRepository.List.SelectContext")
+	}
+
+	return rooms, nil
+}
+
+func (r *roomRepository) Create(ctx context.Context, room *domain.CreateRoom) (uuid.UUID, error) {
+	var roomID uuid.UUID
+
+	sqls := `
+		INSERT INTO "rooms" ("user_id", "name", "icon_name", "icon_background_color", "position")
+		VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX("position") + 1, 0) FROM "rooms" WHERE "user_id" = $1))
+		RETURNING "id"
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, &roomID, sqls, room.UserID, room.Name, room.IconName, room.IconBackgroundColor); err != nil {
+		if isDuplicate(err, postgres.RoomUserIDNameConstraint) {
+			return uuid.Nil, ae.ErrRoomExists
+		}
+
+		return uuid.Nil, errors.Wrap(err, "roomRepository.Create.GetContext")
+	}
+
+	return roomID, nil
+}
+
+func (r *roomRepository) Update(ctx context.Context, room *domain.UpdateRoom) error {
+	s := &updateSet{args: []interface{}{room.ID, room.UserID}}
+	s.add(`"name"`, room.Name)
+	s.add(`"icon_name"`, room.IconName)
+	s.add(`"icon_background_color"`, room.IconBackgroundColor)
+
+	if s.empty() {
+		return ae.ErrMissingParams
+	}
+
+	sqls := fmt.Sprintf(`UPDATE "rooms" SET %s WHERE "id" = $1 AND "user_id" = $2`, s.String())
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, s.args...)
+	if err != nil {
+		if isDuplicate(err, postgres.RoomUserIDNameConstraint) {
+			return ae.ErrRoomExists
+		}
+
+		return errors.Wrap(err, "roomRepository.Update.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrRoomNotFound
+	}
+	return nil
+}
+
+func (r *roomRepository) Delete(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) error {
+	sqls := `DELETE FROM "rooms" WHERE "id" = $1 AND "user_id" = $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, roomID, userID)
+	if err != nil {
+		return errors.Wrap(err, "roomRepository.Delete.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrRoomNotFound
+	}
+	return nil
+}
+
+// Reorder sets the positions of the rooms to their indexes in the list.
+func (r *roomRepository) Reorder(ctx context.Context, userID uuid.UUID, roomIDs []uuid.UUID) error {
+	sqls := `
+		UPDATE "rooms" SET "position" = o."position" - 1, "updated_at" = now()
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS o("id", "position")
+		WHERE "rooms"."id" = o."id" AND "rooms"."user_id" = $2
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, pq.Array(roomIDs), userID); err != nil {
+		return errors.Wrap(err, "roomRepository.Reorder.ExecContext")
+	}
+
+	return nil
+}
+
+// GetState counts the devices and controls of the room and lists the brokers of its devices.
+func (r *roomRepository) GetState(ctx context.Context, roomID uuid.UUID) (*domain.RoomState, error) {
+	state := &domain.RoomState{}
+
+	sqls := `
+		SELECT $1::uuid AS "room_id",
+			COUNT(DISTINCT d."id") AS "device_count",
+			COUNT(c."id") AS "control_count",
+			COUNT(c."id") FILTER (WHERE c."is_available") AS "available_control_count"
+		FROM "devices" d
+		LEFT JOIN "device_controls" c ON c."device_id" = d."id" AND c."deleted_at" IS NULL
+		WHERE d."room_id" = $1 AND d."deleted_at" IS NULL
+	`
+
+	if err := conn(ctx, r.db).GetContext(ctx, state, sqls, roomID); err != nil {
+		return nil, errors.Wrap(err, "roomRepository.GetState.GetContext")
+	}
+
+	state.BrokerIDs = []uuid.UUID{}
+
+	sqls = `SELECT DISTINCT "broker_id" FROM "devices" WHERE "room_id" = $1 AND "broker_id" IS NOT NULL AND "deleted_at" IS NULL`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &state.BrokerIDs, sqls, roomID); err != nil {
+		return nil, errors.Wrap(err, "roomRepository.GetState.SelectContext")
+	}
+
+	return state, nil
+}
+
+// isDuplicate reports whether the error is a violation of the unique constraint.
+func isDuplicate(err error, constraint string) bool {
+	var pgErr *pq.Error
+	return errors.As(err, &pgErr) && pgErr.Code == postgres.DuplicatedKey && pgErr.Constraint == constraint
+}
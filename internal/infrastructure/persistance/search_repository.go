@@ -0,0 +1,111 @@
+package persistance
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+var searchSeparators = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+type searchRepository struct {
+	db *sqlx.DB
+}
+
+func NewSearchRepository(db *sqlx.DB) repository.SearchRepository {
+	return &searchRepository{db}
+}
+
+// Search ranks the brokers, devices and controls of the user by the words of the text, the last word
+// is matched as a prefix so the results can be shown while typing.
+func (r *searchRepository) Search(ctx context.Context, search *domain.Search) ([]*domain.SearchResult, error) {
+	results := []*domain.SearchResult{}
+
+	query := tsQuery(search.Text)
+	if query == "" {
+		return results, nil
+	}
+
+	types := make([]string, len(search.Types))
+	for i, t := range search.Types {
+		types[i] = string(t)
+	}
+
+	sql := `
+		WITH "q" AS (
+			SELECT to_tsquery('simple', $2) AS "query",
+				'StartSel=<mark>, StopSel=</mark>, HighlightAll=true' AS "options"
+		)
+		SELECT "type", "id", "broker_id", "device_id", "name", "highlight", "rank"
+		FROM (
+			SELECT 'broker' AS "type", b."id", NULL::uuid AS "broker_id", NULL::uuid AS "device_id", b."name",
+				ts_headline('simple', b."name" || ' ' || translate(b."server", './:-_', '     '), q."query", q."options") AS "highlight",
+				ts_rank(b."search", q."query") AS "rank"
+			FROM "brokers" b CROSS JOIN "q" q
+			WHERE b."user_id" = $1 AND b."deleted_at" IS NULL AND b."search" @@ q."query"
+			UNION ALL
+			SELECT 'device', d."id", d."broker_id", NULL, d."name",
+				ts_headline('simple', concat_ws(' ', d."name", d."placing", translate(d."base_path", '/-_', '   ')), q."query", q."options"),
+				ts_rank(d."search", q."query")
+			FROM "devices" d CROSS JOIN "q" q
+			WHERE d."user_id" = $1 AND d."deleted_at" IS NULL AND d."search" @@ q."query"
+			UNION ALL
+			SELECT 'control', c."id", d."broker_id", d."id", c."name",
+				ts_headline('simple', c."name" || ' ' || translate(c."topic", '/-_', '   '), q."query", q."options"),
+				ts_rank(c."search", q."query")
+			FROM "device_controls" c JOIN "devices" d ON d."id" = c."device_id" CROSS JOIN "q" q
+			WHERE d."user_id" = $1 AND c."deleted_at" IS NULL AND c."search" @@ q."query"
+		) "results"
+		WHERE cardinality($3::text[]) = 0 OR "type" = ANY($3::text[])
+		ORDER BY "rank" DESC, "name", "id"
+		LIMIT $4
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &results, sql, search.UserID, query, pq.Array(types), search.Limit); err != nil {
+		return nil, errors.Wrap(err, "searchRepository.Search.SelectContext")
+	}
+
+	return results, nil
+}
+
+func (r *searchRepository) ListTopics(ctx context.Context, userID uuid.UUID) ([]*domain.ControlTopic, error) {
+	topics := []*domain.ControlTopic{}
+
+	sql := `
+		SELECT c."id", d."broker_id", c."device_id", c."name", c."topic", d."base_path"
+		FROM "device_controls" c JOIN "devices" d ON d."id" = c."device_id"
+		WHERE d."user_id" = $1 AND c."deleted_at" IS NULL
+		ORDER BY c."topic", c."id"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &topics, sql, userID); err != nil {
+		return nil, errors.Wrap(err, "searchRepository.ListTopics.SelectContext")
+	}
+
+	return topics, nil
+}
+
+// tsQuery joins the words of the text into a query matching all of them, the words are passed
+// as the lexemes only so the operators of tsquery in the text are not interpreted.
+func tsQuery(text string) string {
+	words := []string{}
+	for _, w := range searchSeparators.Split(strings.ToLower(text), -1) {
+		if w != "" {
+			words = append(words, w)
+		}
+	}
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	words[len(words)-1] += ":*"
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,174 @@
+package persistance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type tagRepository struct {
+	db *sqlx.DB
+}
+
+func NewTagRepository(db *sqlx.DB) repository.TagRepository {
+	return &tagRepository{db}
+}
+
+func (r *tagRepository) List(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error) {
+	tags := []*domain.Tag{}
+
+	sqls := `
+		SELECT "id", "user_id", "name", "color", "created_at", "updated_at"
+		FROM "tags"
+		WHERE "user_id" = $1
+		ORDER BY lower("name")
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &tags, sqls, userID); err != nil {
+		return nil, errors.Wrap(err, "tagRepository.List.SelectContext")
+	}
+
+	return tags, nil
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *domain.CreateTag) (uuid.UUID, error) {
+	var tagID uuid.UUID
+
+	sqls := `INSERT INTO "tags" ("user_id", "name", "color") VALUES ($1, $2, $3) RETURNING "id"`
+
+	if err := conn(ctx, r.db).GetContext(ctx, &tagID, sqls, tag.UserID, tag.Name, tag.Color); err != nil {
+		if isDuplicate(err, postgres.TagUserIDNameConstraint) {
+			return uuid.Nil, ae.ErrTagExists
+		}
+
+		return uuid.Nil, errors.Wrap(err, "tagRepository.Create.GetContext")
+	}
+
+	return tagID, nil
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *domain.UpdateTag) error {
+	s := &updateSet{args: []interface{}{tag.ID, tag.UserID}}
+	s.add(`"name"`, tag.Name)
+	s.add(`"color"`, tag.Color)
+
+	if s.empty() {
+		return ae.ErrMissingParams
+	}
+
+	sqls := fmt.Sprintf(`UPDATE "tags" SET %s WHERE "id" = $1 AND "user_id" = $2`, s.String())
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, s.args...)
+	if err != nil {
+		if isDuplicate(err, postgres.TagUserIDNameConstraint) {
+			return ae.ErrTagExists
+		}
+
+		return errors.Wrap(err, "tagRepository.Update.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrTagNotFound
+	}
+	return nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, tagID uuid.UUID, userID uuid.UUID) error {
+	sqls := `DELETE FROM "tags" WHERE "id" = $1 AND "user_id" = $2`
+
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sqls, tagID, userID)
+	if err != nil {
+		return errors.Wrap(err, "tagRepository.Delete.ExecContext")
+	}
+
+	if af, _ := sr.RowsAffected(); af == 0 {
+		return ae.ErrTagNotFound
+	}
+	return nil
+}
+
+type taggedRow struct {
+	EntityID uuid.UUID `db:"entity_id"`
+	TagID    uuid.UUID `db:"tag_id"`
+}
+
+func (r *tagRepository) ListByDevices(ctx context.Context, deviceIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	sqls := `SELECT "device_id" AS "entity_id", "tag_id" FROM "device_tags" WHERE "device_id" = ANY($1::uuid[])`
+
+	return r.listBy(ctx, "tagRepository.ListByDevices", sqls, deviceIDs)
+}
+
+func (r *tagRepository) ListByControls(ctx context.Context, controlIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	sqls := `SELECT "control_id" AS "entity_id", "tag_id" FROM "device_control_tags" WHERE "control_id" = ANY($1::uuid[])`
+
+	return r.listBy(ctx, "tagRepository.ListByControls", sqls, controlIDs)
+}
+
+// listBy groups the tag ids by the tagged entities.
+func (r *tagRepository) listBy(ctx context.Context, name string, sqls string, ids []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	tagged := make(map[uuid.UUID][]uuid.UUID, len(ids))
+	if len(ids) == 0 {
+		return tagged, nil
+	}
+
+	rows := []taggedRow{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, sqls, pq.Array(ids)); err != nil {
+		return nil, errors.Wrap(err, name+".SelectContext")
+	}
+
+	for _, row := range rows {
+		tagged[row.EntityID] = append(tagged[row.EntityID], row.TagID)
+	}
+
+	return tagged, nil
+}
+
+// SetDeviceTags replaces the tags of the device, they are a part of it so its version is increased.
+func (r *tagRepository) SetDeviceTags(ctx context.Context, deviceID uuid.UUID, tagIDs []uuid.UUID) error {
+	sqls := `
+		WITH "removed" AS (
+			DELETE FROM "device_tags" WHERE "device_id" = $1 AND NOT ("tag_id" = ANY($2::uuid[]))
+		),
+		"touched" AS (
+			UPDATE "devices" SET "version" = "version" + 1, "updated_at" = now() WHERE "id" = $1
+		)
+		INSERT INTO "device_tags" ("device_id", "tag_id")
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, deviceID, pq.Array(tagIDs)); err != nil {
+		return errors.Wrap(err, "tagRepository.SetDeviceTags.ExecContext")
+	}
+
+	return nil
+}
+
+// SetControlTags replaces the tags of the control, they are a part of it so its version is increased.
+func (r *tagRepository) SetControlTags(ctx context.Context, controlID uuid.UUID, tagIDs []uuid.UUID) error {
+	sqls := `
+		WITH "removed" AS (
+			DELETE FROM "device_control_tags" WHERE "control_id" = $1 AND NOT ("tag_id" = ANY($2::uuid[]))
+		),
+		"touched" AS (
+			UPDATE "device_controls" SET "version" = "version" + 1 WHERE "id" = $1
+		)
+		INSERT INTO "device_control_tags" ("control_id", "tag_id")
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqls, controlID, pq.Array(tagIDs)); err != nil {
+		return errors.Wrap(err, "tagRepository.SetControlTags.ExecContext")
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package persistance
+
+import (
+	"context"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type txKey struct{}
+
+// executor runs the statements of the repositories, it is either the database or the transaction in progress.
+type executor interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// conn returns the transaction carried by the context, the statements run directly on the database without it.
+func conn(ctx context.Context, db *sqlx.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+type transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) repository.Transactor {
+	return &transactor{db}
+}
+
+// Transaction runs fn in a transaction committed when fn succeeds and rolled back otherwise,
+// fn joins the transaction of the context when there is one already.
+func (t *transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "transactor.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "transactor.Commit")
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package persistance
+
+import (
+	"context"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type trashRepository struct {
+	db *sqlx.DB
+}
+
+func NewTrashRepository(db *sqlx.DB) repository.TrashRepository {
+	return &trashRepository{db}
+}
+
+// List returns the deleted entities of the user along with their dependents, the dependents point
+// at their entry with the parent id.
+func (r *trashRepository) List(ctx context.Context, userID uuid.UUID) ([]*domain.TrashEntry, error) {
+	entries := []*domain.TrashEntry{}
+
+	sql := `
+		SELECT "type", "id", "device_id", "parent_id", "name", "deleted_at"
+		FROM (
+			SELECT 'broker' AS "type", b."id", NULL::uuid AS "device_id", NULL::uuid AS "parent_id", b."name", b."deleted_at"
+			FROM "brokers" b
+			WHERE b."user_id" = $1 AND b."deleted_at" IS NOT NULL
+			UNION ALL
+			SELECT 'device', d."id", NULL, CASE WHEN d."deleted_at" IS NULL THEN d."deleted_broker_id" END, d."name", d."deleted_at"
+			FROM "devices" d
+			LEFT JOIN "brokers" b ON b."id" = d."deleted_broker_id" AND b."deleted_at" IS NOT NULL
+			WHERE d."user_id" = $1 AND (d."deleted_at" IS NOT NULL OR (d."broker_id" IS NULL AND b."id" IS NOT NULL))
+			UNION ALL
+			SELECT 'control', c."id", c."device_id", CASE WHEN c."deleted_at" = d."deleted_at" THEN d."id" END, c."name", c."deleted_at"
+			FROM "device_controls" c JOIN "devices" d ON d."id" = c."device_id"
+			WHERE d."user_id" = $1 AND c."deleted_at" IS NOT NULL
+		) "trash"
+		ORDER BY "deleted_at" DESC NULLS LAST, "name", "id"
+	`
+
+	if err := conn(ctx, r.db).SelectContext(ctx, &entries, sql, userID); err != nil {
+		return nil, errors.Wrap(err, "trashRepository.List.SelectContext")
+	}
+
+	return entries, nil
+}
+
+// Purge deletes the entities which have been in the trash since before the given time for good.
+func (r *trashRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	for _, table := range []string{`"device_controls"`, `"devices"`, `"brokers"`} {
+		sr, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM `+table+` WHERE "deleted_at" < $1`, before)
+		if err != nil {
+			return purged, errors.Wrap(err, "trashRepository.Purge.ExecContext")
+		}
+
+		af, _ := sr.RowsAffected()
+		purged += af
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,71 @@
+package persistance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// updateSet collects the assignments of the set fields, the parameters are numbered after the given arguments.
+type updateSet struct {
+	fields []string
+	args   []interface{}
+}
+
+func (s *updateSet) add(column string, v interface{}) {
+	switch p := v.(type) {
+	case *string:
+		if p == nil {
+			return
+		}
+		v = *p
+	case *int:
+		if p == nil {
+			return
+		}
+		v = *p
+	}
+
+	s.args = append(s.args, v)
+	s.fields = append(s.fields, fmt.Sprintf(`%s = $%d`, column, len(s.args)))
+}
+
+func (s *updateSet) empty() bool {
+	return len(s.fields) == 0
+}
+
+func (s *updateSet) String() string {
+	return strings.Join(append(s.fields, `"updated_at" = now()`), ", ")
+}
+
+// versionCondition limits the statement to the expected version of the row, a nil version matches every one.
+func versionCondition(version *int64) string {
+	if version == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(` AND "version" = %d`, *version)
+}
+
+// notAffected tells the missing row from the modified one when a conditional statement did not affect any rows,
+// the query selects whether the row exists.
+func notAffected(ctx context.Context, db *sqlx.DB, version *int64, notFound error, query string, args ...interface{}) error {
+	if version == nil {
+		return notFound
+	}
+
+	var exists bool
+	if err := conn(ctx, db).GetContext(ctx, &exists, query, args...); err != nil {
+		return errors.Wrap(err, "notAffected.GetContext")
+	}
+
+	if exists {
+		return ae.ErrPreconditionFailed
+	}
+
+	return notFound
+}
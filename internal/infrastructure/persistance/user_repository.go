@@ -31,7 +31,7 @@ func (r *userRepository) ExistsByEmail(ctx context.Context, email string) bool {
 	`
 
 	var count int
-	r.db.QueryRowxContext(ctx, sqls, email).Scan(&count)
+	conn(ctx, r.db).QueryRowxContext(ctx, sqls, email).Scan(&count)
 
 	return count != 0
 }
@@ -45,7 +45,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 		WHERE "email" = $1
 	`
 
-	if err := r.db.GetContext(ctx, user, sqls, email); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, user, sqls, email); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ae.ErrUserNotFound
 		}
@@ -65,7 +65,7 @@ func (r *userRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.Use
 		WHERE "id" = $1
 	`
 
-	if err := r.db.GetContext(ctx, user, sqls, userID); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, user, sqls, userID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ae.ErrUserNotFound
 		}
@@ -97,7 +97,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.CreateUser) (u
 		RETURNING "id"
 	`, f, v)
 
-	if err := r.db.GetContext(ctx, created, sql); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, created, sql); err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
 			if pgErr.Code == postgres.DuplicatedKey && pgErr.Constraint == postgres.UserEmailConstraint {
 				return uuid.Nil, ae.ErrEmailExists
@@ -143,7 +143,7 @@ func (r *userRepository) Update(ctx context.Context, user *domain.UpdateUser) er
 
 	sql := fmt.Sprintf(`UPDATE "users" SET %s WHERE "id" = '%s'`, strings.Join(p, ","), user.ID.String())
 
-	sr, err := r.db.ExecContext(ctx, sql)
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
 			if pgErr.Code == postgres.DuplicatedKey && pgErr.Constraint == postgres.UserEmailConstraint {
@@ -164,7 +164,7 @@ func (r *userRepository) Update(ctx context.Context, user *domain.UpdateUser) er
 func (r *userRepository) Delete(ctx context.Context, userID uuid.UUID) error {
 	sql := `DELETE FROM "users" WHERE "id" = $1`
 
-	sr, err := r.db.ExecContext(ctx, sql, userID)
+	sr, err := conn(ctx, r.db).ExecContext(ctx, sql, userID)
 	if err != nil {
 		return errors.Wrap(err, "userRepository.Delete.ExecContext")
 	}
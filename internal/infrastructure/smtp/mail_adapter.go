@@ -2,9 +2,12 @@ package smtp
 
 import (
 	"fmt"
+	"html"
 	"strings"
 
 	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
 	"github.com/Deve-Lite/DashboardX-API/pkg/smtp"
 )
@@ -54,6 +57,30 @@ func (a *mailAdapter) SendPasswordReset(receiver string, token string) error {
 	return nil
 }
 
+func (a *mailAdapter) SendAlertIncident(receiver string, notification *domain.AlertNotification) error {
+	title := "Alert Triggered"
+	if notification.Action == enum.IncidentResolvedAction {
+		title = "Alert Resolved"
+	}
+
+	content := fmt.Sprintf(`
+		<h2>%s</h2>
+		<p><b>%s</b></p>
+		<p>%s</p>
+		<p>Opened at %s</p>
+	`, title, html.EscapeString(notification.AlertName), html.EscapeString(notification.Message),
+		notification.OpenedAt.UTC().Format("2006-01-02 15:04:05 MST"))
+
+	message := a.createMessage("alert", receiver, content)
+
+	err := a.s.SendMail(a.c.MailAddress.Default, receiver, message)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (a *mailAdapter) createMessage(mailType string, receiver string, content string) []byte {
 	msg := strings.Builder{}
 	defer msg.Reset()
@@ -66,6 +93,8 @@ func (a *mailAdapter) createMessage(mailType string, receiver string, content st
 		msg.WriteString("Subject: [DashboardX] Confirm Account\r\n")
 	} else if mailType == "reset" {
 		msg.WriteString("Subject: [DashboardX] Reset Password\r\n")
+	} else if mailType == "alert" {
+		msg.WriteString("Subject: [DashboardX] Alert\r\n")
 	}
 	msg.WriteString("\r\n")
 	msg.WriteString("<div style=\"font-family: Verdana, sans-serif;\">")
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain/adapter"
+)
+
+const defaultTimeout = 10 * time.Second
+
+type webhookAdapter struct {
+	client *http.Client
+}
+
+func NewWebhookAdapter(c *config.Config) adapter.WebhookAdapter {
+	timeout := defaultTimeout
+	if c.Alert != nil && c.Alert.WebhookTimeoutSeconds > 0 {
+		timeout = time.Duration(c.Alert.WebhookTimeoutSeconds) * time.Second
+	}
+
+	return &webhookAdapter{newClient(timeout, public)}
+}
+
+// newClient dials only the addresses accepted by allowed, they are checked once resolved so a host name
+// can not point the webhook at the server's own network. The redirects are not followed, the response
+// with the redirect is returned as it is.
+func newClient(timeout time.Duration, allowed func(ip net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return fmt.Errorf("webhook address %s is not allowed", host)
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// public rejects the loopback, private, link-local, multicast and unspecified addresses,
+// the link-local ones include the metadata services of the clouds.
+func public(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// Post sends the body as JSON, the responses with other statuses than 2xx, the redirects included, are errors.
+func (a *webhookAdapter) Post(ctx context.Context, url string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DashboardX-Webhook")
+
+	res, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// The body is drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", res.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/go-playground/assert"
+)
+
+func TestPost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reject the addresses of the server's own network", func(t *testing.T) {
+		var hit atomic.Bool
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit.Store(true) }))
+		defer srv.Close()
+
+		a := NewWebhookAdapter(&config.Config{Alert: &config.AlertConfig{WebhookTimeoutSeconds: 1}})
+
+		urls := []string{
+			srv.URL,
+			"http://localhost:" + srv.URL[strings.LastIndex(srv.URL, ":")+1:],
+			"http://0.0.0.0/",
+			"http://[::1]/",
+			"http://10.0.0.1/",
+			"http://172.16.0.1/",
+			"http://192.168.1.1/",
+			"http://169.254.169.254/latest/meta-data/",
+			"http://[fe80::1]/",
+			"http://[fd00::1]/",
+			"http://[::ffff:127.0.0.1]/",
+		}
+
+		for _, url := range urls {
+			err := a.Post(ctx, url, map[string]string{})
+			assert.NotEqual(t, nil, err)
+			assert.Equal(t, true, strings.Contains(err.Error(), "is not allowed"))
+		}
+		assert.Equal(t, false, hit.Load())
+	})
+
+	t.Run("should not follow the redirects", func(t *testing.T) {
+		var hit atomic.Bool
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit.Store(true) }))
+		defer target.Close()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer srv.Close()
+
+		// The loopback address of the test servers is allowed here to reach the redirect
+		a := &webhookAdapter{newClient(time.Second, func(net.IP) bool { return true })}
+
+		err := a.Post(ctx, srv.URL, map[string]string{})
+		assert.NotEqual(t, nil, err)
+		assert.Equal(t, "webhook responded with 307 Temporary Redirect", err.Error())
+		assert.Equal(t, false, hit.Load())
+	})
+
+	t.Run("should accept the public addresses", func(t *testing.T) {
+		for _, ip := range []string{"1.1.1.1", "8.8.8.8", "2606:4700:4700::1111"} {
+			assert.Equal(t, true, public(net.ParseIP(ip)))
+		}
+	})
+}
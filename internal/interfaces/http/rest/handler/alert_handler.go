@@ -0,0 +1,356 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AlertHandler interface {
+	Get(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	ListIncidents(ctx *gin.Context)
+	Acknowledge(ctx *gin.Context)
+}
+
+type alertHandler struct {
+	as application.AlertService
+	m  mapper.AlertMapper
+}
+
+func NewAlertHandler(as application.AlertService, m mapper.AlertMapper) AlertHandler {
+	return &alertHandler{as, m}
+}
+
+// AlertGet godoc
+//
+//	@Summary	Get a single alert
+//	@Tags		Alerts
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		alertId	path		string	true	"Alert UUID"
+//	@Success	200		{object}	dto.GetAlertResponse
+//	@Failure	400		{object}	errors.HTTPError
+//	@Failure	401		{object}	errors.HTTPError
+//	@Failure	404		{object}	errors.HTTPError
+//	@Failure	500		{object}	errors.HTTPError
+//	@Router		/alerts/{alertId} [get]
+func (h *alertHandler) Get(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	alertID, err := h.getAlertID(ctx)
+	if err != nil {
+		return
+	}
+
+	alert, err := h.as.Get(ctx, alertID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(alert))
+}
+
+// AlertList godoc
+//
+//	@Summary	List alerts
+//	@Tags		Alerts
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		dto.GetAlertResponse
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/alerts [get]
+func (h *alertHandler) List(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	alerts, err := h.as.List(ctx, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := []dto.GetAlertResponse{}
+	for _, alert := range alerts {
+		r = append(r, *h.m.ModelToDTO(alert))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// AlertCreate godoc
+//
+//	@Summary		Create an alert
+//	@Description	The threshold alerts are raised when the value of the control gets above or below the threshold
+//	@Description	and resolved once it gets back past the threshold by the hysteresis. The state change alerts are
+//	@Description	raised while the value of the control is the state, the device offline alerts when the device with
+//	@Description	the availability has been offline for the minutes and the broker disconnected alerts when the broker
+//	@Description	fails its health check. The threshold and state change alerts need the values of the controls to be
+//	@Description	decoded and the device offline alerts need the presence to be tracked, they are rejected otherwise.
+//	@Description	A new incident is not opened until the cooldown has passed since the last one was resolved. The incident
+//	@Description	is resolved when the control, the device or the broker of the alert is moved to the trash.
+//	@Description	The incidents are published as events and can be sent by email and to a webhook. The webhooks are
+//	@Description	sent to public addresses only and their redirects are not followed.
+//	@Tags			Alerts
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body		dto.SetAlertRequest	true	"Alert data"
+//	@Success		201		{object}	dto.CreateAlertResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/alerts [post]
+func (h *alertHandler) Create(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.SetAlertRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	alertID, err := h.as.Create(ctx, h.m.CreateDTOToModel(userID, body))
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateAlertResponse{
+		ID: alertID,
+	})
+}
+
+// AlertUpdate godoc
+//
+//	@Summary		Replace an alert
+//	@Description	Replaces the whole definition of the alert. The unresolved incident is resolved when the alert
+//	@Description	is disabled or its condition changes.
+//	@Tags			Alerts
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			alertId	path	string				true	"Alert UUID"
+//	@Param			data	body	dto.SetAlertRequest	true	"Alert data"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/alerts/{alertId} [put]
+func (h *alertHandler) Update(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	alertID, err := h.getAlertID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.SetAlertRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.as.Update(ctx, h.m.UpdateDTOToModel(alertID, userID, body)); err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AlertDelete godoc
+//
+//	@Summary		Delete an alert
+//	@Description	The incidents of the alert are deleted along with it.
+//	@Tags			Alerts
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			alertId	path	string	true	"Alert UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/alerts/{alertId} [delete]
+func (h *alertHandler) Delete(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	alertID, err := h.getAlertID(ctx)
+	if err != nil {
+		return
+	}
+
+	if err := h.as.Delete(ctx, alertID, userID); err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AlertListIncidents godoc
+//
+//	@Summary		List alert incidents
+//	@Description	Lists the incidents of all the alerts of the user, the unresolved ones can be listed with the status.
+//	@Tags			Alerts
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			alertId	query		string	false	"Alert UUID"
+//	@Param			status	query		string	false	"Incident status"	Enums(open, acknowledged, resolved)
+//	@Param			cursor	query		string	false	"Cursor of the next page, sent in the Link header"
+//	@Param			limit	query		int		false	"Page size"		minimum(1)	maximum(100)	default(50)
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort	query		string	false	"Sort key"		Enums(openedAt, status)	default(openedAt)
+//	@Success		200		{array}		dto.GetAlertIncidentResponse
+//	@Header			200		{integer}	X-Total-Count	"Count of all the matching incidents"
+//	@Header			200		{string}	Link			"Link to the next page"
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/alerts/incidents [get]
+func (h *alertHandler) ListIncidents(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	query := &dto.AlertIncidentQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filters := h.m.QueryDTOToModel(userID, query)
+
+	filters.Page, err = bindPage(ctx)
+	if err != nil {
+		return
+	}
+
+	incidents, err := h.as.ListIncidents(ctx, filters)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := []*dto.GetAlertIncidentResponse{}
+	for _, i := range incidents.Items {
+		r = append(r, h.m.IncidentToDTO(i))
+	}
+
+	setPageHeaders(ctx, incidents)
+	ctx.JSON(http.StatusOK, r)
+}
+
+// AlertAcknowledge godoc
+//
+//	@Summary		Acknowledge an alert incident
+//	@Description	Silences the open incident, it is still resolved once the condition of the alert clears.
+//	@Description	Acknowledging an acknowledged incident does nothing.
+//	@Tags			Alerts
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			incidentId	path		string	true	"Incident UUID"
+//	@Success		200			{object}	dto.GetAlertIncidentResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		409			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/alerts/incidents/{incidentId}/acknowledge [post]
+func (h *alertHandler) Acknowledge(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.AlertIncidentParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	incident, err := h.as.Acknowledge(ctx, uuid.MustParse(params.IncidentID), userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.IncidentToDTO(incident))
+}
+
+func (h *alertHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrAlertNotFound) || errors.Is(err, ae.ErrAlertIncidentNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrAlertIncidentResolved) {
+		code = http.StatusConflict
+	} else if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrInvalidCursor) ||
+		errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDeviceNotFound) || errors.Is(err, ae.ErrDeviceControlNotFound) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *alertHandler) getAlertID(ctx *gin.Context) (uuid.UUID, error) {
+	params := &dto.AlertParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	var alertID uuid.UUID
+	alertID, err = uuid.Parse(params.AlertID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return alertID, nil
+}
+
+func (h *alertHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestAlerts(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	cID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+	other := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	otherDID := tt.CreateDevice(a, other.ID, tt.CreateBroker(a, other.ID))
+
+	threshold := fmt.Sprintf(`{"name":"Too hot","kind":"threshold","deviceId":"%s","controlId":"%s","operator":"above","threshold":30,"hysteresis":2}`, dID, cID)
+
+	w := tt.MakeRequest(g, "POST", "/api/v1/alerts", strings.NewReader(threshold), &usr.AccessToken)
+	assert.Equal(t, 201, w.Code)
+
+	created := &dto.CreateAlertResponse{}
+	json.Unmarshal(w.Body.Bytes(), created)
+	url := "/api/v1/alerts/" + created.ID.String()
+
+	incidents := func(query string) []dto.GetAlertIncidentResponse {
+		w := tt.MakeRequest(g, "GET", "/api/v1/alerts/incidents"+query, nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []dto.GetAlertIncidentResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		return r
+	}
+
+	t.Run("should return the alert", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.GetAlertResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, enum.AlertThreshold, r.Kind)
+		assert.Equal(t, 30.0, *r.Threshold)
+		assert.Equal(t, true, r.IsEnabled)
+	})
+
+	t.Run("should return 400 without the fields of the kind", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/alerts", strings.NewReader(`{"name":"Offline","kind":"deviceOffline"}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"deviceId"`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"offlineMinutes"`))
+	})
+
+	t.Run("should return 400 for a device of another user", func(t *testing.T) {
+		body := fmt.Sprintf(`{"name":"Offline","kind":"deviceOffline","deviceId":"%s","offlineMinutes":5}`, otherDID)
+		w := tt.MakeRequest(g, "POST", "/api/v1/alerts", strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 400 for the device offline alerts without the availability", func(t *testing.T) {
+		body := fmt.Sprintf(`{"name":"Offline","kind":"deviceOffline","deviceId":"%s","offlineMinutes":5}`, dID)
+		w := tt.MakeRequest(g, "POST", "/api/v1/alerts", strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"availability"`))
+
+		availability := `{"mode":"lwt","topic":"status","onlinePayload":"online","offlinePayload":"offline"}`
+		w = tt.MakeRequest(g, "PUT", "/api/v1/devices/"+dID.String()+"/availability", strings.NewReader(availability), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "POST", "/api/v1/alerts", strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+	})
+
+	t.Run("should return 400 for webhooks which are not http", func(t *testing.T) {
+		body := fmt.Sprintf(`{"name":"Broker","kind":"brokerDisconnected","brokerId":"%s","webhookUrl":"ftp://example.com"}`, bID)
+		w := tt.MakeRequest(g, "POST", "/api/v1/alerts", strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should open and resolve the incidents with the values", func(t *testing.T) {
+		a.AlertSrv.OnValue(context.Background(), cID, 31.0)
+
+		r := incidents("?status=open")
+		assert.Equal(t, 1, len(r))
+		assert.Equal(t, created.ID, r[0].AlertID)
+		assert.Equal(t, "Too hot", r[0].AlertName)
+		assert.Equal(t, 31.0, r[0].Value)
+
+		// The incident is kept open within the hysteresis
+		a.AlertSrv.OnValue(context.Background(), cID, 29.0)
+		assert.Equal(t, 1, len(incidents("?status=open")))
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/alerts/incidents/"+r[0].ID.String()+"/acknowledge", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		acknowledged := &dto.GetAlertIncidentResponse{}
+		json.Unmarshal(w.Body.Bytes(), acknowledged)
+		assert.Equal(t, enum.IncidentAcknowledged, acknowledged.Status)
+		assert.NotEqual(t, nil, acknowledged.AcknowledgedAt)
+
+		a.AlertSrv.OnValue(context.Background(), cID, 27.0)
+
+		resolved := incidents("?status=resolved")
+		assert.Equal(t, 1, len(resolved))
+		assert.NotEqual(t, nil, resolved[0].ResolvedAt)
+
+		w = tt.MakeRequest(g, "POST", "/api/v1/alerts/incidents/"+r[0].ID.String()+"/acknowledge", nil, &usr.AccessToken)
+		assert.Equal(t, 409, w.Code)
+	})
+
+	t.Run("should not open an incident within the cooldown", func(t *testing.T) {
+		body := strings.Replace(threshold, `"hysteresis":2`, `"hysteresis":2,"cooldownMinutes":60`, 1)
+		w := tt.MakeRequest(g, "PUT", url, strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		a.AlertSrv.OnValue(context.Background(), cID, 35.0)
+		assert.Equal(t, 0, len(incidents("?status=open")))
+	})
+
+	t.Run("should return 404 for the incidents of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/alerts/incidents/"+uuid.New().String()+"/acknowledge", nil, &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+
+		r := incidents("")
+		w = tt.MakeRequest(g, "POST", "/api/v1/alerts/incidents/"+r[0].ID.String()+"/acknowledge", nil, &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 404 for the alerts of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should delete the alert with its incidents", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", url, nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		assert.Equal(t, 0, len(incidents("")))
+
+		w = tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should resolve the incident once the device is moved to the trash", func(t *testing.T) {
+		trashedDID := tt.CreateDevice(a, usr.ID, bID)
+		trashedCID := tt.CreateDeviceControl(a, usr.ID, trashedDID)
+
+		body := fmt.Sprintf(`{"name":"Trashed","kind":"threshold","deviceId":"%s","controlId":"%s","operator":"above","threshold":30}`, trashedDID, trashedCID)
+		w := tt.MakeRequest(g, "POST", "/api/v1/alerts", strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		a.AlertSrv.OnValue(context.Background(), trashedCID, 31.0)
+		assert.Equal(t, 1, len(incidents("?status=open")))
+
+		w = tt.MakeRequest(g, "DELETE", "/api/v1/devices/"+trashedDID.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		// The incident is resolved by the listener of the events in the background
+		for i := 0; i < 20 && len(incidents("?status=open")) > 0; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		assert.Equal(t, 0, len(incidents("?status=open")))
+		assert.Equal(t, 1, len(incidents("?status=resolved")))
+	})
+
+	t.Run("should return 401 without a token", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/alerts", nil, nil)
+		assert.Equal(t, 401, w.Code)
+	})
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type BatchHandler interface {
+	Execute(ctx *gin.Context)
+}
+
+type batchHandler struct {
+	bs application.BatchService
+	m  mapper.BatchMapper
+}
+
+func NewBatchHandler(bs application.BatchService, m mapper.BatchMapper) BatchHandler {
+	return &batchHandler{bs, m}
+}
+
+// BatchExecute godoc
+//
+//	@Summary		Apply a batch of changes to brokers, devices and controls
+//	@Description	The operations are applied in their order in a single transaction, so either all or none of them are.
+//	@Description	The data of an operation is the body of the matching create or update endpoint. Entities created
+//	@Description	earlier in the batch are referenced by their ref prefixed with $ in id, deviceId and the brokerId
+//	@Description	of the device data. The version is compared like the If-Match header of the single endpoints.
+//	@Description	The errors of the failed operation point at it, e.g. operations[2] or operations[2].data.name.
+//	@Tags			Batch
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body		dto.BatchRequest	true	"Operations"
+//	@Success		200		{object}	dto.BatchResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		404		{object}	errors.HTTPError
+//	@Failure		409		{object}	errors.HTTPError
+//	@Failure		412		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/batch [post]
+func (h *batchHandler) Execute(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.BatchRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	operations := make([]*domain.BatchOperation, len(body.Operations))
+	for i := range body.Operations {
+		operations[i], err = h.bindOperation(i, &body.Operations[i])
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	var results []*domain.BatchResult
+	results, err = h.bs.Execute(ctx, userID, operations)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ResultsModelToDTO(results))
+}
+
+// bindOperation binds the data of the operation to the request of its entity, a broker referenced by a device
+// is taken out of the data as it is not an id yet.
+func (h *batchHandler) bindOperation(index int, v *dto.BatchOperationRequest) (*domain.BatchOperation, error) {
+	path := fmt.Sprintf("operations[%d].data", index)
+
+	var data interface{}
+	switch {
+	case v.Action == enum.BatchDeleteAction:
+	case v.Entity == enum.BatchBrokerEntity && v.Action == enum.BatchCreateAction:
+		data = &dto.CreateBrokerRequest{}
+	case v.Entity == enum.BatchBrokerEntity:
+		data = &dto.UpdateBrokerRequest{}
+	case v.Entity == enum.BatchDeviceEntity && v.Action == enum.BatchCreateAction:
+		data = &dto.CreateDeviceRequest{}
+	case v.Entity == enum.BatchDeviceEntity:
+		data = &dto.UpdateDeviceRequest{}
+	case v.Action == enum.BatchCreateAction:
+		data = &dto.CreateDeviceControlRequest{}
+	default:
+		data = &dto.UpdateDeviceControlRequest{}
+	}
+
+	var brokerRef string
+	if data != nil {
+		raw := v.Data
+
+		if v.Entity == enum.BatchDeviceEntity {
+			var err error
+			raw, brokerRef, err = takeBrokerRef(raw)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := json.Unmarshal(raw, data); err != nil {
+			var te *json.UnmarshalTypeError
+			if errors.As(err, &te) {
+				te.Field = path + "." + te.Field
+			}
+			return nil, err
+		}
+
+		if err := binding.Validator.ValidateStruct(data); err != nil {
+			return nil, prefixFields(err, path)
+		}
+	}
+
+	op := h.m.OperationDTOToModel(v, data)
+	op.BrokerRef = brokerRef
+
+	return op, nil
+}
+
+func (h *batchHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDeviceNotFound) ||
+		errors.Is(err, ae.ErrDeviceControlNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrBrokerServerExists) || errors.Is(err, ae.ErrControlStateExists) ||
+		errors.Is(err, ae.ErrTopicConflict) {
+		code = http.StatusConflict
+	} else if errors.Is(err, ae.ErrPreconditionFailed) {
+		code = http.StatusPreconditionFailed
+	} else if errors.Is(err, ae.ErrBatchRefNotFound) || errors.Is(err, ae.ErrBatchRefDuplicated) ||
+		errors.Is(err, ae.ErrRoomNotFound) || errors.Is(err, ae.ErrBrokerPathInvalid) || errors.Is(err, ae.ErrBrokerPrefixInvalid) ||
+		errors.Is(err, ae.ErrBrokerMQTT5Required) || errors.Is(err, ae.ErrControlTypeUnknown) ||
+		errors.Is(err, ae.ErrControlAttributesInvalid) || errors.Is(err, ae.ErrMissingParams) {
+		code = http.StatusBadRequest
+	}
+
+	var be *domain.BatchError
+	if errors.As(err, &be) && code != http.StatusInternalServerError {
+		err = operationError(ctx, be, code)
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *batchHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
+
+// takeBrokerRef removes the brokerId of the device data when it references a broker of the batch.
+func takeBrokerRef(data json.RawMessage) (json.RawMessage, string, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, "", err
+	}
+
+	var brokerID string
+	if err := json.Unmarshal(fields["brokerId"], &brokerID); err != nil || !strings.HasPrefix(brokerID, "$") {
+		return data, "", nil
+	}
+
+	delete(fields, "brokerId")
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, strings.TrimPrefix(brokerID, "$"), nil
+}
+
+// operationFieldError reports the invalid field of the operation data under the path of the operation.
+type operationFieldError struct {
+	validator.FieldError
+	path string
+}
+
+func (e operationFieldError) Namespace() string {
+	ns := e.FieldError.Namespace()
+	if i := strings.IndexByte(ns, '.'); i >= 0 {
+		ns = ns[i+1:]
+	}
+
+	return "BatchRequest." + e.path + "." + ns
+}
+
+func prefixFields(err error, path string) error {
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return err
+	}
+
+	prefixed := make(validator.ValidationErrors, len(ves))
+	for i, fe := range ves {
+		prefixed[i] = operationFieldError{fe, path}
+	}
+
+	return prefixed
+}
+
+// operationError points at the failed operation in the errors of the problem.
+func operationError(ctx *gin.Context, be *domain.BatchError, status int) error {
+	path := fmt.Sprintf("operations[%d]", be.Index)
+
+	var ve *ae.ValidationError
+	if errors.As(be.Err, &ve) {
+		fields := make([]*ae.FieldError, len(ve.Fields))
+		for i, f := range ve.Fields {
+			field := *f
+			field.Field = path + ".data." + f.Field
+			fields[i] = &field
+		}
+
+		return &ae.ValidationError{Err: be, Fields: fields}
+	}
+
+	p := ae.NewProblem(be.Err, status, problem.Language(ctx))
+
+	return &ae.ValidationError{Err: be, Fields: []*ae.FieldError{{Field: path, Rule: p.Code, Message: p.Detail}}}
+}
@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func TestBatch(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+
+	icon := `"icon":{"name":"home","backgroundColor":"#ffffff"}`
+	control := `{"name":"lamp",` + icon + `,"type":"button","topic":"lamp","attributes":{"payload":"on"},
+		"isConfirmationRequired":false,"isAvailable":true,"canNotifyOnPublish":false,"canDisplayName":true}`
+
+	brokers := func() string {
+		w := tt.MakeRequest(g, "GET", "/api/v1/brokers", nil, &usr.AccessToken)
+		return w.Header().Get("X-Total-Count")
+	}
+
+	t.Run("should create a broker with a device and its controls", func(t *testing.T) {
+		p := strings.NewReader(`{"operations":[
+			{"action":"create","entity":"broker","ref":"home","data":{"name":"home",` + icon + `,"server":"batch.server.com",
+				"port":1883,"keepAlive":60,"isSsl":false}},
+			{"action":"create","entity":"device","ref":"hall","data":{"name":"hall","brokerId":"$home",` + icon + `}},
+			{"action":"create","entity":"control","ref":"lamp","deviceId":"$hall","data":` + control + `},
+			{"action":"create","entity":"control","deviceId":"$hall","data":` + control + `},
+			{"action":"update","entity":"control","id":"$lamp","deviceId":"$hall","version":1,"data":{"topic":"hall/lamp"}}
+		]}`)
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/batch", p, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.BatchResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 5, len(r.Results))
+		assert.Equal(t, r.Results[2].ID, r.Results[4].ID)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/controls", r.Results[1].ID), nil, &usr.AccessToken)
+		assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), "hall/lamp"))
+	})
+
+	t.Run("should roll back all the operations when one fails", func(t *testing.T) {
+		before := brokers()
+
+		p := strings.NewReader(`{"operations":[
+			{"action":"create","entity":"broker","ref":"garage","data":{"name":"garage",` + icon + `,"server":"garage.server.com",
+				"port":1883,"keepAlive":60,"isSsl":false}},
+			{"action":"create","entity":"control","deviceId":"$garage","data":` + control + `}
+		]}`)
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/batch", p, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+
+		r := &ae.HTTPError{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, "BATCH_REF_NOT_FOUND", r.Code)
+		assert.Equal(t, "operations[1]", r.Errors[0].Field)
+
+		assert.Equal(t, before, brokers())
+	})
+
+	t.Run("should return 412 when an entity has been modified", func(t *testing.T) {
+		p := strings.NewReader(fmt.Sprintf(`{"operations":[
+			{"action":"update","entity":"device","id":"%s","version":7,"data":{"name":"renamed"}}
+		]}`, dID))
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/batch", p, &usr.AccessToken)
+		assert.Equal(t, 412, w.Code)
+	})
+
+	t.Run("should return 400 with the path of the invalid data", func(t *testing.T) {
+		p := strings.NewReader(fmt.Sprintf(`{"operations":[
+			{"action":"delete","entity":"device","id":"%s"},
+			{"action":"create","entity":"device","data":{` + icon + `}}
+		]}`, dID))
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/batch", p, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+
+		r := &ae.HTTPError{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, "operations[1].data.name", r.Errors[0].Field)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+	})
+}
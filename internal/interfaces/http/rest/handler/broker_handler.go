@@ -8,6 +8,7 @@ import (
 	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,33 +20,41 @@ type BrokerHandler interface {
 	Create(ctx *gin.Context)
 	Update(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	Restore(ctx *gin.Context)
 	GetCredentials(ctx *gin.Context)
 	SetCredentials(ctx *gin.Context)
+	Test(ctx *gin.Context)
+	TestSettings(ctx *gin.Context)
 }
 
 type brokerHandler struct {
-	bs application.BrokerService
-	m  mapper.BrokerMapper
+	bs  application.BrokerService
+	bms application.BrokerMonitorService
+	m   mapper.BrokerMapper
 }
 
-func NewBrokerHandler(bs application.BrokerService, m mapper.BrokerMapper) BrokerHandler {
-	return &brokerHandler{bs, m}
+func NewBrokerHandler(bs application.BrokerService, bms application.BrokerMonitorService, m mapper.BrokerMapper) BrokerHandler {
+	return &brokerHandler{bs, bms, m}
 }
 
 // BrokerGet godoc
 //
-//	@Summary	Get a broker
-//	@Tags		Brokers
-//	@Security	BearerAuth
-//	@Accept		json
-//	@Produce	json
-//	@Param		brokerId	path		string	true	"Broker UUID"
-//	@Success	200			{object}	dto.GetBrokerResponse
-//	@Failure	400			{object}	errors.HTTPError
-//	@Failure	401			{object}	errors.HTTPError
-//	@Failure	404			{object}	errors.HTTPError
-//	@Failure	500			{object}	errors.HTTPError
-//	@Router		/brokers/{brokerId} [get]
+//	@Summary		Get a broker
+//	@Description	The ETag follows the version of the broker settings along with its status.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId		path		string	true	"Broker UUID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached broker"
+//	@Success		200				{object}	dto.GetBrokerResponse
+//	@Header			200				{string}	ETag	"Version and status of the broker"
+//	@Success		304
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId} [get]
 func (h *brokerHandler) Get(ctx *gin.Context) {
 	var err error
 	var brokerID, userID uuid.UUID
@@ -70,7 +79,11 @@ func (h *brokerHandler) Get(ctx *gin.Context) {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
+		return
+	}
+
+	if notModified(ctx, broker.Version, broker.Status, broker.StatusChangedAt) {
 		return
 	}
 
@@ -84,10 +97,18 @@ func (h *brokerHandler) Get(ctx *gin.Context) {
 //	@Security	BearerAuth
 //	@Accept		json
 //	@Produce	json
-//	@Success	200	{array}		dto.GetBrokerResponse
-//	@Failure	401	{object}	errors.HTTPError
-//	@Failure	404	{object}	errors.HTTPError
-//	@Failure	500	{object}	errors.HTTPError
+//	@Param		cursor	query		string	false	"Cursor of the next page, sent in the Link header"
+//	@Param		limit	query		int		false	"Page size"		minimum(1)	maximum(100)	default(50)
+//	@Param		order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param		search	query		string	false	"Search by name"
+//	@Param		sort	query		string	false	"Sort key"	Enums(name, server, createdAt, updatedAt)	default(createdAt)
+//	@Success	200		{array}		dto.GetBrokerResponse
+//	@Header		200		{integer}	X-Total-Count	"Count of all the matching brokers"
+//	@Header		200		{string}	Link			"Link to the next page"
+//	@Failure	400		{object}	errors.HTTPError
+//	@Failure	401		{object}	errors.HTTPError
+//	@Failure	404		{object}	errors.HTTPError
+//	@Failure	500		{object}	errors.HTTPError
 //	@Router		/brokers [get]
 func (h *brokerHandler) List(ctx *gin.Context) {
 	var err error
@@ -98,19 +119,32 @@ func (h *brokerHandler) List(ctx *gin.Context) {
 		return
 	}
 
-	var brokers []*domain.Broker
-	brokers, err = h.bs.List(ctx, userID)
+	filters := &domain.ListBrokerFilters{UserID: userID}
+
+	filters.Page, err = bindPage(ctx)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		return
+	}
+
+	var brokers *domain.List[*domain.Broker]
+	brokers, err = h.bs.List(ctx, filters)
+	if err != nil {
+		if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrInvalidCursor) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	r := []dto.GetBrokerResponse{}
 
-	for _, broker := range brokers {
+	for _, broker := range brokers.Items {
 		r = append(r, *h.m.ModelToDTO(broker))
 	}
 
+	setPageHeaders(ctx, brokers)
 	ctx.JSON(http.StatusOK, r)
 }
 
@@ -140,7 +174,7 @@ func (h *brokerHandler) Create(ctx *gin.Context) {
 
 	body := &dto.CreateBrokerRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -151,11 +185,16 @@ func (h *brokerHandler) Create(ctx *gin.Context) {
 	brokerID, err = h.bs.Create(ctx, broker)
 	if err != nil {
 		if errors.Is(err, ae.ErrBrokerServerExists) {
-			ctx.AbortWithStatusJSON(http.StatusConflict, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusConflict, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		if errors.Is(err, ae.ErrBrokerPathInvalid) || errors.Is(err, ae.ErrBrokerPrefixInvalid) || errors.Is(err, ae.ErrBrokerMQTT5Required) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -172,12 +211,14 @@ func (h *brokerHandler) Create(ctx *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		brokerId	path	string					true	"Broker UUID"
+//	@Param		If-Match	header	string					false	"ETag of the broker the update is based on"
 //	@Param		data		body	dto.UpdateBrokerRequest	true	"Update data"
 //	@Success	204
 //	@Failure	400	{object}	errors.HTTPError
 //	@Failure	401	{object}	errors.HTTPError
 //	@Failure	404	{object}	errors.HTTPError
 //	@Failure	409	{object}	errors.HTTPError
+//	@Failure	412	{object}	errors.HTTPError
 //	@Failure	500	{object}	errors.HTTPError
 //	@Router		/brokers/{brokerId} [patch]
 func (h *brokerHandler) Update(ctx *gin.Context) {
@@ -196,7 +237,7 @@ func (h *brokerHandler) Update(ctx *gin.Context) {
 
 	body := &dto.UpdateBrokerRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -204,6 +245,11 @@ func (h *brokerHandler) Update(ctx *gin.Context) {
 	broker.UserID = userID
 	broker.ID = brokerID
 
+	broker.Version, err = ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
 	err = h.bs.Update(ctx, broker)
 	if err != nil {
 		code := http.StatusInternalServerError
@@ -211,11 +257,15 @@ func (h *brokerHandler) Update(ctx *gin.Context) {
 			code = http.StatusNotFound
 		} else if errors.Is(err, ae.ErrBrokerServerExists) {
 			code = http.StatusConflict
+		} else if errors.Is(err, ae.ErrBrokerPathInvalid) || errors.Is(err, ae.ErrBrokerPrefixInvalid) || errors.Is(err, ae.ErrBrokerMQTT5Required) {
+			code = http.StatusBadRequest
+		} else if errors.Is(err, ae.ErrPreconditionFailed) {
+			code = http.StatusPreconditionFailed
 		} else {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
 		return
 	}
 
@@ -230,10 +280,12 @@ func (h *brokerHandler) Update(ctx *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		brokerId	path	string	true	"Broker UUID"
+//	@Param		If-Match	header	string	false	"ETag of the broker to delete"
 //	@Success	204
 //	@Failure	400	{object}	errors.HTTPError
 //	@Failure	401	{object}	errors.HTTPError
 //	@Failure	404	{object}	errors.HTTPError
+//	@Failure	412	{object}	errors.HTTPError
 //	@Failure	500	{object}	errors.HTTPError
 //	@Router		/brokers/{brokerId} [delete]
 func (h *brokerHandler) Delete(ctx *gin.Context) {
@@ -250,16 +302,70 @@ func (h *brokerHandler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err = h.bs.Delete(ctx, brokerID, userID)
+	var version *int64
+	version, err = ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.bs.Delete(ctx, brokerID, userID, version)
 	if err != nil {
 		code := http.StatusInternalServerError
 		if errors.Is(err, ae.ErrBrokerNotFound) {
 			code = http.StatusNotFound
+		} else if errors.Is(err, ae.ErrPreconditionFailed) {
+			code = http.StatusPreconditionFailed
 		} else {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// BrokerRestore godoc
+//
+//	@Summary		Restore a deleted broker
+//	@Description	The devices detached from the broker by its deletion are attached back to it.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path	string	true	"Broker UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		409	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/restore [post]
+func (h *brokerHandler) Restore(ctx *gin.Context) {
+	var err error
+	var userID, brokerID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	brokerID, err = h.getBrokerID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.bs.Restore(ctx, brokerID, userID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ae.ErrBrokerNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, ae.ErrBrokerServerExists) {
+			code = http.StatusConflict
+		}
+
+		problem.Abort(ctx, code, err)
 		return
 	}
 
@@ -273,12 +379,15 @@ func (h *brokerHandler) Delete(ctx *gin.Context) {
 //	@Security	BearerAuth
 //	@Accept		json
 //	@Produce	json
-//	@Param		brokerId	path		string	true	"Broker UUID"
-//	@Success	200			{object}	dto.GetBrokerCredentialsResponse
-//	@Failure	400			{object}	errors.HTTPError
-//	@Failure	401			{object}	errors.HTTPError
-//	@Failure	404			{object}	errors.HTTPError
-//	@Failure	500			{object}	errors.HTTPError
+//	@Param		brokerId		path		string	true	"Broker UUID"
+//	@Param		If-None-Match	header		string	false	"ETag of the cached credentials"
+//	@Success	200				{object}	dto.GetBrokerCredentialsResponse
+//	@Header		200				{string}	ETag	"Version of the broker"
+//	@Success	304
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
 //	@Router		/brokers/{brokerId}/credentials [get]
 func (h *brokerHandler) GetCredentials(ctx *gin.Context) {
 	var err error
@@ -304,7 +413,11 @@ func (h *brokerHandler) GetCredentials(ctx *gin.Context) {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
+		return
+	}
+
+	if notModified(ctx, broker.Version) {
 		return
 	}
 
@@ -319,11 +432,13 @@ func (h *brokerHandler) GetCredentials(ctx *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		brokerId	path	string							true	"Broker UUID"
+//	@Param		If-Match	header	string							false	"ETag of the broker the credentials are based on"
 //	@Param		data		body	dto.SetBrokerCredentialsRequest	true	"Overwrite data"
 //	@Success	204
 //	@Failure	400	{object}	errors.HTTPError
 //	@Failure	401	{object}	errors.HTTPError
 //	@Failure	404	{object}	errors.HTTPError
+//	@Failure	412	{object}	errors.HTTPError
 //	@Failure	500	{object}	errors.HTTPError
 //	@Router		/brokers/{brokerId}/credentials [put]
 func (h *brokerHandler) SetCredentials(ctx *gin.Context) {
@@ -342,7 +457,7 @@ func (h *brokerHandler) SetCredentials(ctx *gin.Context) {
 
 	body := &dto.SetBrokerCredentialsRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -350,35 +465,123 @@ func (h *brokerHandler) SetCredentials(ctx *gin.Context) {
 	broker.UserID = userID
 	broker.ID = brokerID
 
+	broker.Version, err = ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
 	err = h.bs.SetCredentials(ctx, broker)
 	if err != nil {
 		code := http.StatusInternalServerError
 		if errors.Is(err, ae.ErrBrokerNotFound) {
 			code = http.StatusNotFound
+		} else if errors.Is(err, ae.ErrPreconditionFailed) {
+			code = http.StatusPreconditionFailed
 		} else {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
 		return
 	}
 
 	ctx.Status(http.StatusNoContent)
 }
 
+// BrokerTest godoc
+//
+//	@Summary		Test a broker connection
+//	@Description	Connects to the broker with the stored credentials and reports the DNS, TCP, TLS and CONNACK steps.
+//					The test stops at the first failing step.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string	true	"Broker UUID"
+//	@Success		200			{object}	dto.TestBrokerResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/test [post]
+func (h *brokerHandler) Test(ctx *gin.Context) {
+	var err error
+	var brokerID, userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	brokerID, err = h.getBrokerID(ctx)
+	if err != nil {
+		return
+	}
+
+	var probe *domain.BrokerProbe
+	probe, err = h.bms.Test(ctx, userID, brokerID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ae.ErrBrokerNotFound) {
+			code = http.StatusNotFound
+		} else {
+			code = http.StatusInternalServerError
+		}
+
+		problem.Abort(ctx, code, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ProbeModelToDTO(probe))
+}
+
+// BrokerTestSettings godoc
+//
+//	@Summary		Test broker settings
+//	@Description	Connects to a broker which has not been saved yet and reports the DNS, TCP, TLS and CONNACK steps.
+//					The test stops at the first failing step.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body		dto.TestBrokerRequest	true	"Broker settings"
+//	@Success		200		{object}	dto.TestBrokerResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/brokers/test [post]
+func (h *brokerHandler) TestSettings(ctx *gin.Context) {
+	var err error
+
+	_, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.TestBrokerRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	probe := h.bms.TestSettings(ctx, h.m.TestDTOToModel(body))
+
+	ctx.JSON(http.StatusOK, h.m.ProbeModelToDTO(probe))
+}
+
 func (h *brokerHandler) getBrokerID(ctx *gin.Context) (uuid.UUID, error) {
 	params := &dto.BrokerParams{}
 
 	err := ctx.BindUri(params)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, err
 	}
 
 	var brokerID uuid.UUID
 	brokerID, err = uuid.Parse(params.BrokerID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, err
 	}
 
@@ -388,7 +591,7 @@ func (h *brokerHandler) getBrokerID(ctx *gin.Context) (uuid.UUID, error) {
 func (h *brokerHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
 	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.HTTPError{Message: "could not retrieve user info"})
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, err
 	}
 
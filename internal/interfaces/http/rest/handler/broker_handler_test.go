@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
 	n "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/Deve-Lite/DashboardX-API/test"
@@ -89,6 +92,35 @@ func TestBrokerCreate(t *testing.T) {
 
 		w = tt.MakeRequest(g, "POST", "/api/v1/brokers", p, &u.AccessToken)
 		assert.Equal(t, 409, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"code":"BROKER_SERVER_EXISTS"`))
+	})
+
+	t.Run("should return 400 with the invalid fields", func(t *testing.T) {
+		p := strings.NewReader(`
+			{
+				"server": "broker.hivemq.com",
+				"port": 8884,
+				"keepAlive": 60,
+				"icon": {
+				  "name": "Home",
+				  "backgroundColor": "red"
+				},
+				"isSsl": true
+			}
+		`)
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/brokers", p, &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"code":"VALIDATION_FAILED"`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"field":"name","rule":"required"`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"field":"icon.backgroundColor","rule":"hexcolor"`))
+	})
+
+	t.Run("should return 400 when the body is not valid JSON", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/brokers", strings.NewReader(`{"name":`), &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"code":"MALFORMED_BODY"`))
 	})
 }
 
@@ -166,6 +198,59 @@ func TestBrokerUpdate(t *testing.T) {
 	})
 }
 
+func TestBrokerConditionalRequests(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	u := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bid := tt.CreateBroker(a, u.ID)
+
+	request := func(method, url, body, header, etag string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", u.AccessToken)
+		req.Header.Set(header, etag)
+		g.ServeHTTP(w, req)
+		return w
+	}
+
+	w := tt.MakeRequest(g, "GET", patchURL(bid.String()), nil, &u.AccessToken)
+	assert.Equal(t, 200, w.Code)
+	etag := w.Header().Get("ETag")
+
+	t.Run("should return 304 when the broker has not been modified", func(t *testing.T) {
+		w := request("GET", patchURL(bid.String()), "", "If-None-Match", etag)
+		assert.Equal(t, 304, w.Code)
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("should return 412 when the broker has been modified since it was fetched", func(t *testing.T) {
+		w := request("PATCH", patchURL(bid.String()), `{"name": "first"}`, "If-Match", etag)
+		assert.Equal(t, 204, w.Code)
+
+		w = request("PATCH", patchURL(bid.String()), `{"name": "second"}`, "If-Match", etag)
+		assert.Equal(t, 412, w.Code)
+
+		w = request("DELETE", patchURL(bid.String()), "", "If-Match", etag)
+		assert.Equal(t, 412, w.Code)
+
+		w = request("GET", patchURL(bid.String()), "", "If-None-Match", etag)
+		assert.Equal(t, 200, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("should return 412 when the ETag is not a version of the broker", func(t *testing.T) {
+		w := request("PATCH", patchURL(bid.String()), `{"name": "third"}`, "If-Match", `W/"1"`)
+		assert.Equal(t, 412, w.Code)
+	})
+
+	t.Run("should return 404 when the broker does not exist", func(t *testing.T) {
+		w := request("DELETE", patchURL(uuid.NewString()), "", "If-Match", etag)
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
 func TestBrokerSetCredentials(t *testing.T) {
 	tt := test.NewTest()
 	defer tt.Teardown()
@@ -412,3 +497,155 @@ func TestBrokerGetCredentials(t *testing.T) {
 		assert.Equal(t, 404, w.Code)
 	})
 }
+
+func TestBrokerTest(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	u := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	u2 := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	bid := tt.CreateBroker(a, u.ID)
+
+	t.Run("should return 200 and stop at dns when the server does not resolve", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/test", bid), nil, &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		data := dto.TestBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &data)
+
+		assert.Equal(t, false, data.Success)
+		assert.Equal(t, 1, len(data.Steps))
+		assert.Equal(t, "dns", data.Steps[0].Name)
+	})
+
+	t.Run("should return 404 when broker belongs to another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/test", bid), nil, &u2.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 200 when testing unsaved settings", func(t *testing.T) {
+		body := `{"server": "127.0.0.1", "port": 1, "isSsl": false}`
+		w := tt.MakeRequest(g, "POST", "/api/v1/brokers/test", strings.NewReader(body), &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		data := dto.TestBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &data)
+
+		assert.Equal(t, false, data.Success)
+		assert.Equal(t, "tcp", data.Steps[len(data.Steps)-1].Name)
+	})
+
+	t.Run("should return 400 when settings are missing", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/brokers/test", strings.NewReader(`{"port": 1883}`), &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return the broker status", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s", bid), nil, &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		data := dto.GetBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &data)
+
+		assert.NotEqual(t, "", data.Status)
+	})
+}
+
+func TestBrokerTransport(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	u := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bid := tt.CreateBroker(a, u.ID)
+
+	t.Run("should return 201 when a MQTT 5 websocket broker has been created", func(t *testing.T) {
+		p := strings.NewReader(`
+			{
+				"name": "Cloud",
+				"server": "cloud.broker.com",
+				"port": 443,
+				"keepAlive": 60,
+				"icon": {
+				  "name": "Home",
+				  "backgroundColor": "#ff00ff"
+				},
+				"isSsl": true,
+				"protocolVersion": "5",
+				"transport": "wss",
+				"path": "/mqtt",
+				"cleanStart": false,
+				"sessionExpiry": 3600,
+				"userProperties": {"region": "eu"}
+			}
+		`)
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/brokers", p, &u.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		created := dto.CreateBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &created)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s", created.ID), nil, &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		data := dto.GetBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &data)
+
+		assert.Equal(t, "5", string(data.ProtocolVersion))
+		assert.Equal(t, "wss", string(data.Transport))
+		assert.Equal(t, "/mqtt", *data.Path)
+		assert.Equal(t, false, data.CleanStart)
+		assert.Equal(t, uint32(3600), data.SessionExpiry)
+		assert.Equal(t, "eu", data.UserProperties["region"])
+	})
+
+	t.Run("should return defaults of an existing broker", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s", bid), nil, &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		data := dto.GetBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &data)
+
+		assert.Equal(t, "3.1.1", string(data.ProtocolVersion))
+		assert.Equal(t, "tcp", string(data.Transport))
+		assert.Equal(t, true, data.Path == nil)
+		assert.Equal(t, true, data.CleanStart)
+	})
+
+	t.Run("should return 400 when transport is not supported", func(t *testing.T) {
+		p := strings.NewReader(`{"transport": "quic"}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bid), p, &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 400 when user properties are set without MQTT 5", func(t *testing.T) {
+		p := strings.NewReader(`{"userProperties": {"region": "eu"}}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bid), p, &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 400 when path is set for tcp transport", func(t *testing.T) {
+		p := strings.NewReader(`{"path": "/mqtt"}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bid), p, &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 204 when switched to websocket with a path", func(t *testing.T) {
+		p := strings.NewReader(`{"transport": "ws", "path": "/ws"}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bid), p, &u.AccessToken)
+		assert.Equal(t, 204, w.Code)
+	})
+
+	t.Run("should save the quoted path", func(t *testing.T) {
+		p := strings.NewReader(`{"path": "/o'hara"}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bid), p, &u.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s", bid), nil, &u.AccessToken)
+		r := dto.GetBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.Equal(t, "/o'hara", *r.Path)
+	})
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CertificateHandler interface {
+	Get(ctx *gin.Context)
+	SetCA(ctx *gin.Context)
+	DeleteCA(ctx *gin.Context)
+	SetClient(ctx *gin.Context)
+	DeleteClient(ctx *gin.Context)
+}
+
+type certificateHandler struct {
+	cs application.BrokerCertificateService
+	m  mapper.BrokerCertificateMapper
+}
+
+func NewCertificateHandler(cs application.BrokerCertificateService, m mapper.BrokerCertificateMapper) CertificateHandler {
+	return &certificateHandler{cs, m}
+}
+
+// CertificateGet godoc
+//
+//	@Summary		Get broker's certificates
+//	@Description	Describes the stored CA bundle and client certificate, the private key is never returned.
+//					Certificates expiring within 30 days are listed in the warnings.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string	true	"Broker UUID"
+//	@Success		200			{object}	dto.GetBrokerCertificatesResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/certificates [get]
+func (h *certificateHandler) Get(ctx *gin.Context) {
+	var err error
+	var userID, brokerID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	brokerID, err = h.getBrokerID(ctx)
+	if err != nil {
+		return
+	}
+
+	var info *domain.BrokerCertificatesInfo
+	info, err = h.cs.Get(ctx, userID, brokerID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(info))
+}
+
+// CertificateSetCA godoc
+//
+//	@Summary		Upload or rotate broker's CA bundle
+//	@Description	The PEM bundle replaces the system roots when connecting to the broker over TLS.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string						true	"Broker UUID"
+//	@Param			data		body		dto.SetCACertificateRequest	true	"CA bundle"
+//	@Success		200			{object}	dto.GetBrokerCertificatesResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/certificates/ca [put]
+func (h *certificateHandler) SetCA(ctx *gin.Context) {
+	body := &dto.SetCACertificateRequest{}
+
+	h.set(ctx, body, func(c *domain.UpdateBrokerCertificates) {
+		c.CACertificate = t.NewString(body.Certificate, false, true)
+	})
+}
+
+// CertificateDeleteCA godoc
+//
+//	@Summary	Remove broker's CA bundle
+//	@Tags		Brokers
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		brokerId	path		string	true	"Broker UUID"
+//	@Success	200			{object}	dto.GetBrokerCertificatesResponse
+//	@Failure	400			{object}	errors.HTTPError
+//	@Failure	401			{object}	errors.HTTPError
+//	@Failure	404			{object}	errors.HTTPError
+//	@Failure	500			{object}	errors.HTTPError
+//	@Router		/brokers/{brokerId}/certificates/ca [delete]
+func (h *certificateHandler) DeleteCA(ctx *gin.Context) {
+	h.set(ctx, nil, func(c *domain.UpdateBrokerCertificates) {
+		c.CACertificate = t.NewString("", true, true)
+	})
+}
+
+// CertificateSetClient godoc
+//
+//	@Summary		Upload or rotate broker's client certificate
+//	@Description	The certificate and its private key are used for mutual TLS, the key is stored encrypted.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string							true	"Broker UUID"
+//	@Param			data		body		dto.SetClientCertificateRequest	true	"Client certificate and key"
+//	@Success		200			{object}	dto.GetBrokerCertificatesResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/certificates/client [put]
+func (h *certificateHandler) SetClient(ctx *gin.Context) {
+	body := &dto.SetClientCertificateRequest{}
+
+	h.set(ctx, body, func(c *domain.UpdateBrokerCertificates) {
+		c.ClientCertificate = t.NewString(body.Certificate, false, true)
+		c.ClientKey = t.NewString(body.Key, false, true)
+	})
+}
+
+// CertificateDeleteClient godoc
+//
+//	@Summary	Remove broker's client certificate
+//	@Tags		Brokers
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		brokerId	path		string	true	"Broker UUID"
+//	@Success	200			{object}	dto.GetBrokerCertificatesResponse
+//	@Failure	400			{object}	errors.HTTPError
+//	@Failure	401			{object}	errors.HTTPError
+//	@Failure	404			{object}	errors.HTTPError
+//	@Failure	500			{object}	errors.HTTPError
+//	@Router		/brokers/{brokerId}/certificates/client [delete]
+func (h *certificateHandler) DeleteClient(ctx *gin.Context) {
+	h.set(ctx, nil, func(c *domain.UpdateBrokerCertificates) {
+		c.ClientCertificate = t.NewString("", true, true)
+		c.ClientKey = t.NewString("", true, true)
+	})
+}
+
+// set binds the body when given and stores the certificates changed by apply.
+func (h *certificateHandler) set(ctx *gin.Context, body interface{}, apply func(c *domain.UpdateBrokerCertificates)) {
+	var err error
+	var userID, brokerID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	brokerID, err = h.getBrokerID(ctx)
+	if err != nil {
+		return
+	}
+
+	if body != nil {
+		if err := ctx.ShouldBindJSON(body); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	certificates := &domain.UpdateBrokerCertificates{
+		BrokerID: brokerID,
+		UserID:   userID,
+	}
+	apply(certificates)
+
+	var info *domain.BrokerCertificatesInfo
+	info, err = h.cs.Set(ctx, certificates)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(info))
+}
+
+func (h *certificateHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrBrokerNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrCertificateInvalid) ||
+		errors.Is(err, ae.ErrCertificateExpired) ||
+		errors.Is(err, ae.ErrCertificateKeyInvalid) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *certificateHandler) getBrokerID(ctx *gin.Context) (uuid.UUID, error) {
+	params := &dto.BrokerParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	var brokerID uuid.UUID
+	brokerID, err = uuid.Parse(params.BrokerID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return brokerID, nil
+}
+
+func (h *certificateHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
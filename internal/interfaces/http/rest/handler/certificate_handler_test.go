@@ -0,0 +1,123 @@
+package handler_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func generateCertificate(notAfter time.Time) (string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dashboardx-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, _ := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestCertificates(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	u := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	u2 := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	bid := tt.CreateBroker(a, u.ID)
+
+	url := fmt.Sprintf("/api/v1/brokers/%s/certificates", bid)
+	cert, key := generateCertificate(time.Now().Add(365 * 24 * time.Hour))
+	soon, _ := generateCertificate(time.Now().Add(7 * 24 * time.Hour))
+	expired, _ := generateCertificate(time.Now().Add(-time.Minute))
+	_, otherKey := generateCertificate(time.Now().Add(365 * 24 * time.Hour))
+
+	body := func(v interface{}) *strings.Reader {
+		b, _ := json.Marshal(v)
+		return strings.NewReader(string(b))
+	}
+
+	t.Run("should return 200 and no certificates", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := dto.GetBrokerCertificatesResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+
+		assert.Equal(t, 0, len(r.CA))
+		assert.Equal(t, true, r.Client == nil)
+	})
+
+	t.Run("should return 200 when the CA bundle is uploaded", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/ca", body(dto.SetCACertificateRequest{Certificate: cert + soon}), &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := dto.GetBrokerCertificatesResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+
+		assert.Equal(t, 2, len(r.CA))
+		assert.Equal(t, 1, len(r.Warnings))
+		assert.Equal(t, "ca", r.Warnings[0].Kind)
+	})
+
+	t.Run("should return 400 when the certificate is expired", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/ca", body(dto.SetCACertificateRequest{Certificate: expired}), &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 400 when the certificate is not PEM", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/ca", body(dto.SetCACertificateRequest{Certificate: "invalid"}), &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 200 when the client certificate is uploaded", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/client", body(dto.SetClientCertificateRequest{Certificate: cert, Key: key}), &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := dto.GetBrokerCertificatesResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+
+		assert.Equal(t, true, r.HasClientKey)
+		assert.Equal(t, 2, len(r.CA))
+	})
+
+	t.Run("should return 400 when the key does not match", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/client", body(dto.SetClientCertificateRequest{Certificate: cert, Key: otherKey}), &u.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 200 when the CA bundle is removed", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", url+"/ca", nil, &u.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := dto.GetBrokerCertificatesResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+
+		assert.Equal(t, 0, len(r.CA))
+		assert.Equal(t, true, r.HasClientKey)
+	})
+
+	t.Run("should return 404 when broker belongs to another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &u2.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CloneHandler interface {
+	CloneDevice(ctx *gin.Context)
+	CloneBroker(ctx *gin.Context)
+}
+
+type cloneHandler struct {
+	cs application.CloneService
+	bm mapper.BrokerMapper
+	dm mapper.DeviceMapper
+}
+
+func NewCloneHandler(cs application.CloneService, bm mapper.BrokerMapper, dm mapper.DeviceMapper) CloneHandler {
+	return &cloneHandler{cs, bm, dm}
+}
+
+// CloneDevice godoc
+//
+//	@Summary		Clone a device
+//	@Description	The device is copied along with its controls and tags, the fields which are not sent keep
+//	@Description	the values of the device. The null broker leaves the copy without one.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path		string					true	"Device UUID"
+//	@Param			request		body		dto.CloneDeviceRequest	true	"Clone options"
+//	@Success		201			{object}	dto.CreateDeviceResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		409			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/clone [post]
+func (h *cloneHandler) CloneDevice(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	body := &dto.CloneDeviceRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	clone := h.dm.CloneDTOToCloneModel(body)
+	clone.UserID = userID
+	clone.DeviceID = uuid.MustParse(params.DeviceID)
+
+	deviceID, err := h.cs.CloneDevice(ctx, clone)
+	if err != nil {
+		if errors.Is(err, ae.ErrDeviceNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, ae.ErrBrokerNotFound) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		} else if errors.Is(err, ae.ErrTopicConflict) {
+			problem.Abort(ctx, http.StatusConflict, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateDeviceResponse{ID: deviceID})
+}
+
+// CloneBroker godoc
+//
+//	@Summary		Clone a broker
+//	@Description	The broker is copied to the new server along with its credentials, certificates and all its
+//	@Description	devices with their controls. The client ID is not copied.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string					true	"Broker UUID"
+//	@Param			request		body		dto.CloneBrokerRequest	true	"Clone options"
+//	@Success		201			{object}	dto.CreateBrokerResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		409			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/clone [post]
+func (h *cloneHandler) CloneBroker(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.BrokerParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	body := &dto.CloneBrokerRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	clone := h.bm.CloneDTOToCloneModel(body)
+	clone.UserID = userID
+	clone.BrokerID = uuid.MustParse(params.BrokerID)
+
+	brokerID, err := h.cs.CloneBroker(ctx, clone)
+	if err != nil {
+		if errors.Is(err, ae.ErrBrokerNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, ae.ErrBrokerServerExists) || errors.Is(err, ae.ErrTopicConflict) {
+			problem.Abort(ctx, http.StatusConflict, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateBrokerResponse{ID: brokerID})
+}
+
+func (h *cloneHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestClone(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	tt.CreateDeviceControl(a, usr.ID, dID)
+
+	t.Run("should clone a device with its controls", func(t *testing.T) {
+		p := strings.NewReader(`{"name": "cloned-device", "basePath": "/clone"}`)
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/clone", dID), p, &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		r := dto.CreateDeviceResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.NotEqual(t, dID, r.ID)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s", r.ID), nil, &usr.AccessToken)
+		d := dto.GetDeviceResponse{}
+		json.Unmarshal(w.Body.Bytes(), &d)
+		assert.Equal(t, "cloned-device", d.Name)
+		assert.Equal(t, "/clone", *d.BasePath)
+		assert.Equal(t, bID, d.BrokerID.UUID)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/controls", r.ID), nil, &usr.AccessToken)
+		assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should return 400 when the target broker does not exist", func(t *testing.T) {
+		p := strings.NewReader(fmt.Sprintf(`{"brokerId": "%s"}`, uuid.New()))
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/clone", dID), p, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should clone a broker with its devices", func(t *testing.T) {
+		p := strings.NewReader(`{"name": "cloned-broker", "server": "cloned.hivemq.com"}`)
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/clone", bID), p, &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		r := dto.CreateBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices?brokerId=%s", r.ID), nil, &usr.AccessToken)
+		assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should return 409 when the server is taken", func(t *testing.T) {
+		p := strings.NewReader(`{"name": "cloned-broker", "server": "cloned.hivemq.com"}`)
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/clone", bID), p, &usr.AccessToken)
+		assert.Equal(t, 409, w.Code)
+	})
+}
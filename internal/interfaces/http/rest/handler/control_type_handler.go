@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type ControlTypeHandler interface {
+	List(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	PreviewTransform(ctx *gin.Context)
+}
+
+type controlTypeHandler struct {
+	cts application.ControlTypeService
+	m   mapper.ControlTypeMapper
+}
+
+func NewControlTypeHandler(cts application.ControlTypeService, m mapper.ControlTypeMapper) ControlTypeHandler {
+	return &controlTypeHandler{cts, m}
+}
+
+// ControlTypeList godoc
+//
+//	@Summary		List control types
+//	@Description	Each control type comes with the JSON Schema of its attributes, the forms of the controls can be rendered from it.
+//	@Tags			Control Types
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		dto.GetControlTypeResponse
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/control-types [get]
+func (h *controlTypeHandler) List(ctx *gin.Context) {
+	r := []dto.GetControlTypeResponse{}
+	for _, t := range h.cts.List() {
+		r = append(r, *h.m.ModelToDTO(t))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// ControlTypeGet godoc
+//
+//	@Summary	Get a single control type
+//	@Tags		Control Types
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		type	path		string	true	"Control type"
+//	@Success	200		{object}	dto.GetControlTypeResponse
+//	@Failure	401		{object}	errors.HTTPError
+//	@Failure	404		{object}	errors.HTTPError
+//	@Failure	500		{object}	errors.HTTPError
+//	@Router		/control-types/{type} [get]
+func (h *controlTypeHandler) Get(ctx *gin.Context) {
+	t, err := h.cts.Get(enum.ControlType(ctx.Param("type")))
+	if err != nil {
+		if errors.Is(err, ae.ErrControlTypeUnknown) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(t))
+}
+
+// ControlTransformPreview godoc
+//
+//	@Summary		Preview a transform of the received payloads
+//	@Description	The transform of the displayed values reads the value with the JSONPath, captures it with the regex,
+//	@Description	scales, offsets and rounds it and replaces it with its label. The result is decoded as a control
+//	@Description	of the type when it is set, with the attributes which the type requires.
+//	@Tags			Control Types
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.TransformPreviewRequest	true	"Payload and transform"
+//	@Success		200		{object}	dto.TransformPreviewResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/controls/transform/preview [post]
+func (h *controlTypeHandler) PreviewTransform(ctx *gin.Context) {
+	body := &dto.TransformPreviewRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	r, err := h.cts.Preview(h.m.PreviewDTOToModel(body))
+	if err != nil {
+		if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrControlAttributesInvalid) ||
+			errors.Is(err, ae.ErrControlTypeUnknown) || errors.Is(err, ae.ErrControlPayloadInvalid) ||
+			errors.Is(err, ae.ErrControlNotReadable) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ResultToDTO(r))
+}
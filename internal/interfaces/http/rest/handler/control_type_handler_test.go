@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func TestListControlTypes(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+
+	t.Run("should return the control types with their schemas", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/control-types", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []dto.GetControlTypeResponse{}
+		assert.Equal(t, nil, json.Unmarshal(w.Body.Bytes(), &r))
+		assert.Equal(t, 13, len(r))
+		assert.Equal(t, "button", string(r[0].Type))
+		assert.Equal(t, []string{"payload"}, r[0].Schema.Required)
+		assert.Equal(t, true, r[0].CanPublish)
+		assert.Equal(t, false, r[0].CanDisplay)
+	})
+
+	t.Run("should return a single control type", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/control-types/gauge", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("should return 404 for unknown control types", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/control-types/lamp", nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 401 without a token", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/control-types", nil, nil)
+		assert.Equal(t, 401, w.Code)
+	})
+}
+
+func TestPreviewControlTransform(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+
+	t.Run("should return the transformed value", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"payload":   `{"temp": 214, "hum": 40}`,
+			"transform": map[string]interface{}{"path": "$.temp", "scale": 0.1, "unit": "°C"},
+		})
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/controls/transform/preview", bytes.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := dto.TransformPreviewResponse{}
+		assert.Equal(t, nil, json.Unmarshal(w.Body.Bytes(), &r))
+		assert.Equal(t, "21.4", r.Text)
+		assert.Equal(t, "°C", *r.Unit)
+	})
+
+	t.Run("should decode the value as the type", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"payload":    `{"relay": 1}`,
+			"transform":  map[string]interface{}{"path": "$.relay", "labels": map[string]string{"1": "ON", "0": "OFF"}},
+			"type":       "state",
+			"attributes": map[string]interface{}{"onPayload": "ON", "offPayload": "OFF"},
+		})
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/controls/transform/preview", bytes.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := dto.TransformPreviewResponse{}
+		assert.Equal(t, nil, json.Unmarshal(w.Body.Bytes(), &r))
+		assert.Equal(t, "ON", *r.Label)
+		assert.Equal(t, true, r.Decoded)
+	})
+
+	t.Run("should return 400 for payloads the transform can not read", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"payload":   "plain",
+			"transform": map[string]interface{}{"path": "$.temp"},
+		})
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/controls/transform/preview", bytes.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 400 for invalid transforms", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"payload":   "1",
+			"transform": map[string]interface{}{"regex": "("},
+		})
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/controls/transform/preview", bytes.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 401 without a token", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/controls/transform/preview", nil, nil)
+		assert.Equal(t, 401, w.Code)
+	})
+}
@@ -0,0 +1,744 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DashboardHandler interface {
+	Get(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Reorder(ctx *gin.Context)
+	CreateTab(ctx *gin.Context)
+	UpdateTab(ctx *gin.Context)
+	DeleteTab(ctx *gin.Context)
+	ReorderTabs(ctx *gin.Context)
+	CreateWidget(ctx *gin.Context)
+	UpdateWidget(ctx *gin.Context)
+	DeleteWidget(ctx *gin.Context)
+	SetLayout(ctx *gin.Context)
+}
+
+type dashboardHandler struct {
+	ds application.DashboardService
+	m  mapper.DashboardMapper
+}
+
+func NewDashboardHandler(ds application.DashboardService, m mapper.DashboardMapper) DashboardHandler {
+	return &dashboardHandler{ds, m}
+}
+
+// DashboardGet godoc
+//
+//	@Summary	Get a single dashboard with its tabs and widgets
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path		string	true	"Dashboard UUID"
+//	@Success	200			{object}	dto.GetDashboardDetailsResponse
+//	@Failure	400			{object}	errors.HTTPError
+//	@Failure	401			{object}	errors.HTTPError
+//	@Failure	404			{object}	errors.HTTPError
+//	@Failure	500			{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId} [get]
+func (h *dashboardHandler) Get(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, err = h.getDashboardID(ctx)
+	if err != nil {
+		return
+	}
+
+	var dashboard *domain.Dashboard
+	dashboard, err = h.ds.Get(ctx, dashboardID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDetailsDTO(dashboard))
+}
+
+// DashboardList godoc
+//
+//	@Summary	List dashboards in their order
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		dto.GetDashboardResponse
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/dashboards [get]
+func (h *dashboardHandler) List(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	var dashboards []*domain.Dashboard
+	dashboards, err = h.ds.List(ctx, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := []dto.GetDashboardResponse{}
+
+	for _, dashboard := range dashboards {
+		r = append(r, *h.m.ModelToDTO(dashboard))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// DashboardCreate godoc
+//
+//	@Summary	Create a dashboard
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		data	body		dto.CreateDashboardRequest	true	"Create data"
+//	@Success	201		{object}	dto.CreateDashboardResponse
+//	@Failure	400		{object}	errors.HTTPError
+//	@Failure	401		{object}	errors.HTTPError
+//	@Failure	500		{object}	errors.HTTPError
+//	@Router		/dashboards [post]
+func (h *dashboardHandler) Create(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.CreateDashboardRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var dashboardID uuid.UUID
+	dashboardID, err = h.ds.Create(ctx, h.m.CreateDTOToCreateModel(userID, body))
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateDashboardResponse{
+		ID: dashboardID,
+	})
+}
+
+// DashboardUpdate godoc
+//
+//	@Summary	Update a dashboard
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path	string						true	"Dashboard UUID"
+//	@Param		data		body	dto.UpdateDashboardRequest	true	"Update data"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId} [patch]
+func (h *dashboardHandler) Update(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, err = h.getDashboardID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.UpdateDashboardRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	dashboard := h.m.UpdateDTOToUpdateModel(body)
+	dashboard.ID = dashboardID
+	dashboard.UserID = userID
+
+	err = h.ds.Update(ctx, dashboard)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardDelete godoc
+//
+//	@Summary	Delete a dashboard with its tabs and widgets
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path	string	true	"Dashboard UUID"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId} [delete]
+func (h *dashboardHandler) Delete(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, err = h.getDashboardID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.ds.Delete(ctx, dashboardID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardReorder godoc
+//
+//	@Summary		Reorder dashboards
+//	@Description	The ids have to list every dashboard of the user exactly once.
+//	@Tags			Dashboards
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body	dto.OrderRequest	true	"Dashboard ids in the new order"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/dashboards/order [put]
+func (h *dashboardHandler) Reorder(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.OrderRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ds.Reorder(ctx, userID, body.IDs)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardCreateTab godoc
+//
+//	@Summary	Add a tab at the end of a dashboard
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path		string							true	"Dashboard UUID"
+//	@Param		data		body		dto.CreateDashboardTabRequest	true	"Create data"
+//	@Success	201			{object}	dto.CreateDashboardTabResponse
+//	@Failure	400			{object}	errors.HTTPError
+//	@Failure	401			{object}	errors.HTTPError
+//	@Failure	404			{object}	errors.HTTPError
+//	@Failure	500			{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId}/tabs [post]
+func (h *dashboardHandler) CreateTab(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, err = h.getDashboardID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.CreateDashboardTabRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var tabID uuid.UUID
+	tabID, err = h.ds.CreateTab(ctx, userID, h.m.CreateTabDTOToCreateModel(dashboardID, body))
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateDashboardTabResponse{
+		ID: tabID,
+	})
+}
+
+// DashboardUpdateTab godoc
+//
+//	@Summary	Update a dashboard tab
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path	string							true	"Dashboard UUID"
+//	@Param		tabId		path	string							true	"Tab UUID"
+//	@Param		data		body	dto.UpdateDashboardTabRequest	true	"Update data"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId}/tabs/{tabId} [patch]
+func (h *dashboardHandler) UpdateTab(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID, tabID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, tabID, err = h.getTabIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.UpdateDashboardTabRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	tab := h.m.UpdateTabDTOToUpdateModel(body)
+	tab.ID = tabID
+	tab.DashboardID = dashboardID
+
+	err = h.ds.UpdateTab(ctx, userID, tab)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardDeleteTab godoc
+//
+//	@Summary	Delete a dashboard tab with its widgets
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path	string	true	"Dashboard UUID"
+//	@Param		tabId		path	string	true	"Tab UUID"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId}/tabs/{tabId} [delete]
+func (h *dashboardHandler) DeleteTab(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID, tabID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, tabID, err = h.getTabIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.ds.DeleteTab(ctx, userID, dashboardID, tabID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardReorderTabs godoc
+//
+//	@Summary		Reorder dashboard tabs
+//	@Description	The ids have to list every tab of the dashboard exactly once.
+//	@Tags			Dashboards
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboardId	path	string				true	"Dashboard UUID"
+//	@Param			data		body	dto.OrderRequest	true	"Tab ids in the new order"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/dashboards/{dashboardId}/tabs/order [put]
+func (h *dashboardHandler) ReorderTabs(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, err = h.getDashboardID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.OrderRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ds.ReorderTabs(ctx, userID, dashboardID, body.IDs)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardCreateWidget godoc
+//
+//	@Summary		Place a widget on a dashboard tab
+//	@Description	The widget shows a device, or one of its controls when the control is given.
+//	@Tags			Dashboards
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboardId	path		string								true	"Dashboard UUID"
+//	@Param			tabId		path		string								true	"Tab UUID"
+//	@Param			data		body		dto.CreateDashboardWidgetRequest	true	"Create data"
+//	@Success		201			{object}	dto.CreateDashboardWidgetResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/dashboards/{dashboardId}/tabs/{tabId}/widgets [post]
+func (h *dashboardHandler) CreateWidget(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID, tabID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, tabID, err = h.getTabIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.CreateDashboardWidgetRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var widgetID uuid.UUID
+	widgetID, err = h.ds.CreateWidget(ctx, userID, dashboardID, h.m.CreateWidgetDTOToCreateModel(tabID, body))
+	if err != nil {
+		if errors.Is(err, ae.ErrDeviceNotFound) || errors.Is(err, ae.ErrDeviceControlNotFound) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateDashboardWidgetResponse{
+		ID: widgetID,
+	})
+}
+
+// DashboardUpdateWidget godoc
+//
+//	@Summary	Move, resize or change the overrides of a widget
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path	string								true	"Dashboard UUID"
+//	@Param		tabId		path	string								true	"Tab UUID"
+//	@Param		widgetId	path	string								true	"Widget UUID"
+//	@Param		data		body	dto.UpdateDashboardWidgetRequest	true	"Update data"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId}/tabs/{tabId}/widgets/{widgetId} [patch]
+func (h *dashboardHandler) UpdateWidget(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID, tabID, widgetID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, tabID, widgetID, err = h.getWidgetIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.UpdateDashboardWidgetRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	widget := h.m.UpdateWidgetDTOToUpdateModel(body)
+	widget.ID = widgetID
+	widget.TabID = tabID
+
+	err = h.ds.UpdateWidget(ctx, userID, dashboardID, widget)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardDeleteWidget godoc
+//
+//	@Summary	Remove a widget from a dashboard tab
+//	@Tags		Dashboards
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		dashboardId	path	string	true	"Dashboard UUID"
+//	@Param		tabId		path	string	true	"Tab UUID"
+//	@Param		widgetId	path	string	true	"Widget UUID"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/dashboards/{dashboardId}/tabs/{tabId}/widgets/{widgetId} [delete]
+func (h *dashboardHandler) DeleteWidget(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID, tabID, widgetID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, tabID, widgetID, err = h.getWidgetIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.ds.DeleteWidget(ctx, userID, dashboardID, tabID, widgetID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DashboardSetLayout godoc
+//
+//	@Summary		Move and resize widgets of a dashboard tab at once
+//	@Description	The widgets which are not listed keep their places.
+//	@Tags			Dashboards
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboardId	path	string					true	"Dashboard UUID"
+//	@Param			tabId		path	string					true	"Tab UUID"
+//	@Param			data		body	dto.WidgetLayoutRequest	true	"Widget places"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/dashboards/{dashboardId}/tabs/{tabId}/layout [put]
+func (h *dashboardHandler) SetLayout(ctx *gin.Context) {
+	var err error
+	var userID, dashboardID, tabID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	dashboardID, tabID, err = h.getTabIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.WidgetLayoutRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ds.SetLayout(ctx, userID, dashboardID, tabID, h.m.LayoutDTOToModel(body))
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *dashboardHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrDashboardNotFound) ||
+		errors.Is(err, ae.ErrDashboardTabNotFound) ||
+		errors.Is(err, ae.ErrDashboardWidgetNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrDashboardOrderInvalid) || errors.Is(err, ae.ErrMissingParams) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *dashboardHandler) getDashboardID(ctx *gin.Context) (uuid.UUID, error) {
+	params := &dto.DashboardParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	var dashboardID uuid.UUID
+	dashboardID, err = uuid.Parse(params.DashboardID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return dashboardID, nil
+}
+
+func (h *dashboardHandler) getTabIDs(ctx *gin.Context) (uuid.UUID, uuid.UUID, error) {
+	params := &dto.DashboardTabParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	var dashboardID, tabID uuid.UUID
+	dashboardID, err = uuid.Parse(params.DashboardID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	tabID, err = uuid.Parse(params.TabID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return dashboardID, tabID, nil
+}
+
+func (h *dashboardHandler) getWidgetIDs(ctx *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	params := &dto.DashboardWidgetParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	var dashboardID, tabID, widgetID uuid.UUID
+	dashboardID, err = uuid.Parse(params.DashboardID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	tabID, err = uuid.Parse(params.TabID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	widgetID, err = uuid.Parse(params.WidgetID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	return dashboardID, tabID, widgetID, nil
+}
+
+func (h *dashboardHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
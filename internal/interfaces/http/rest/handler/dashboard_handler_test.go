@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestDashboards(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	dID := tt.CreateDevice(a, usr.ID, tt.CreateBroker(a, usr.ID))
+	cID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+	other := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	otherDID := tt.CreateDevice(a, other.ID, tt.CreateBroker(a, other.ID))
+
+	create := func(g *gin.Engine, url string, body string) uuid.UUID {
+		w := tt.MakeRequest(g, "POST", url, strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		r := struct{ ID uuid.UUID }{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		return r.ID
+	}
+
+	icon := `"icon":{"name":"home","backgroundColor":"#ffffff"}`
+	first := create(g, "/api/v1/dashboards", `{"name":"First",`+icon+`}`)
+	second := create(g, "/api/v1/dashboards", `{"name":"Second",`+icon+`}`)
+	url := "/api/v1/dashboards/" + first.String()
+	tab := create(g, url+"/tabs", `{"name":"Main"}`)
+	widget := create(g, url+"/tabs/"+tab.String()+"/widgets",
+		fmt.Sprintf(`{"deviceId":"%s","controlId":"%s","x":0,"y":0,"width":2,"height":1,"overrides":{"name":"Lamp"}}`, dID, cID))
+
+	t.Run("should return the dashboard with its tabs and widgets", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.GetDashboardDetailsResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 1, len(r.Tabs))
+		assert.Equal(t, 1, len(r.Tabs[0].Widgets))
+		assert.Equal(t, widget, r.Tabs[0].Widgets[0].ID)
+		assert.Equal(t, "Lamp", *r.Tabs[0].Widgets[0].Overrides.Name)
+	})
+
+	t.Run("should reorder the dashboards", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", "/api/v1/dashboards/order",
+			strings.NewReader(fmt.Sprintf(`{"ids":["%s","%s"]}`, second, first)), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/dashboards", nil, &usr.AccessToken)
+		r := []dto.GetDashboardResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.Equal(t, 2, len(r))
+		assert.Equal(t, second, r[0].ID)
+		assert.Equal(t, first, r[1].ID)
+	})
+
+	t.Run("should return 400 when the order does not list every dashboard", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", "/api/v1/dashboards/order",
+			strings.NewReader(fmt.Sprintf(`{"ids":["%s"]}`, first)), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"DASHBOARD_ORDER_INVALID"`))
+	})
+
+	t.Run("should move the widgets with the layout", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/tabs/"+tab.String()+"/layout",
+			strings.NewReader(fmt.Sprintf(`{"widgets":[{"id":"%s","x":4,"y":2,"width":3,"height":3}]}`, widget)), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"x":4,"y":2,"width":3,"height":3`))
+	})
+
+	t.Run("should return 400 when the device belongs to another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", url+"/tabs/"+tab.String()+"/widgets",
+			strings.NewReader(fmt.Sprintf(`{"deviceId":"%s","x":0,"y":0,"width":1,"height":1}`, otherDID)), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 404 for the dashboard of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should delete the tab with its widgets", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", url+"/tabs/"+tab.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "DELETE", url+"/tabs/"+tab.String()+"/widgets/"+widget.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/Deve-Lite/DashboardX-API/internal/application"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,10 +21,13 @@ type DeviceHandler interface {
 	Create(ctx *gin.Context)
 	Update(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	Restore(ctx *gin.Context)
 	ListControls(ctx *gin.Context)
 	CreateControl(ctx *gin.Context)
 	UpdateControl(ctx *gin.Context)
 	DeleteControl(ctx *gin.Context)
+	RestoreControl(ctx *gin.Context)
+	RenderControl(ctx *gin.Context)
 }
 
 type deviceHandler struct {
@@ -38,18 +43,22 @@ func NewDeviceHandler(ds application.DeviceService, dcs application.DeviceContro
 
 // DeviceGet godoc
 //
-//	@Summary	Get a single device
-//	@Tags		Devices
-//	@Security	BearerAuth
-//	@Accept		json
-//	@Produce	json
-//	@Param		deviceId	path		string	true	"Device UUID"
-//	@Success	200			{object}	dto.GetDeviceResponse
-//	@Failure	400			{object}	errors.HTTPError
-//	@Failure	401			{object}	errors.HTTPError
-//	@Failure	404			{object}	errors.HTTPError
-//	@Failure	500			{object}	errors.HTTPError
-//	@Router		/devices/{deviceId} [get]
+//	@Summary		Get a single device
+//	@Description	The ETag follows the version of the device along with its status and the time it was last seen.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId		path		string	true	"Device UUID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached device"
+//	@Success		200				{object}	dto.GetDeviceResponse
+//	@Header			200				{string}	ETag	"Version and status of the device"
+//	@Success		304
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/devices/{deviceId} [get]
 func (h *deviceHandler) Get(ctx *gin.Context) {
 	var err error
 	var deviceID, userID uuid.UUID
@@ -68,11 +77,15 @@ func (h *deviceHandler) Get(ctx *gin.Context) {
 	device, err = h.ds.Get(ctx, deviceID, userID)
 	if err != nil {
 		if errors.Is(err, ae.ErrDeviceNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if notModified(ctx, device.Version, device.Status, device.LastSeenAt) {
 		return
 	}
 
@@ -87,7 +100,19 @@ func (h *deviceHandler) Get(ctx *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		brokerId	query		string	false	"Broker UUID"	Format(UUID)
+//	@Param		roomId		query		string	false	"Room UUID"		Format(UUID)
+//	@Param		tagId		query		string	false	"Tag UUID"		Format(UUID)
+//	@Param		placing		query		string	false	"Placing, deprecated in favour of the rooms"
+//	@Param		controlType	query		string	false	"Type of a control of the device"
+//	@Param		cursor		query		string	false	"Cursor of the next page, sent in the Link header"
+//	@Param		limit		query		int		false	"Page size"		minimum(1)	maximum(100)	default(50)
+//	@Param		order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param		search		query		string	false	"Search by name"
+//	@Param		sort		query		string	false	"Sort key"	Enums(name, placing, createdAt, updatedAt)	default(createdAt)
 //	@Success	200			{array}		dto.GetDeviceResponse
+//	@Header		200			{integer}	X-Total-Count	"Count of all the matching devices"
+//	@Header		200			{string}	Link			"Link to the next page"
+//	@Failure	400			{object}	errors.HTTPError
 //	@Failure	401			{object}	errors.HTTPError
 //	@Failure	500			{object}	errors.HTTPError
 //	@Router		/devices [get]
@@ -108,33 +133,58 @@ func (h *deviceHandler) List(ctx *gin.Context) {
 	query := &dto.DeviceQuery{}
 	err = ctx.ShouldBindQuery(query)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if query.BrokerID != nil {
 		brokerID, err = uuid.Parse(*query.BrokerID)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
 		filters.BrokerID = uuid.NullUUID{UUID: brokerID, Valid: true}
 	}
 
-	var devices []*domain.Device
+	if query.RoomID != nil {
+		filters.RoomID = uuid.NullUUID{UUID: uuid.MustParse(*query.RoomID), Valid: true}
+	}
+
+	if query.TagID != nil {
+		filters.TagID = uuid.NullUUID{UUID: uuid.MustParse(*query.TagID), Valid: true}
+	}
+
+	filters.Placing = query.Placing
+	if query.ControlType != nil {
+		controlType := enum.ControlType(*query.ControlType)
+		filters.ControlType = &controlType
+	}
+
+	filters.Page, err = bindPage(ctx)
+	if err != nil {
+		return
+	}
+
+	var devices *domain.List[*domain.Device]
 	devices, err = h.ds.List(ctx, filters)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrInvalidCursor) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	r := []dto.GetDeviceResponse{}
 
-	for _, device := range devices {
+	for _, device := range devices.Items {
 		r = append(r, *h.dm.ModelToDTO(device))
 	}
 
+	setPageHeaders(ctx, devices)
 	ctx.JSON(http.StatusOK, r)
 }
 
@@ -163,7 +213,7 @@ func (h *deviceHandler) Create(ctx *gin.Context) {
 
 	body := &dto.CreateDeviceRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -173,12 +223,12 @@ func (h *deviceHandler) Create(ctx *gin.Context) {
 	var deviceID uuid.UUID
 	deviceID, err = h.ds.Create(ctx, device)
 	if err != nil {
-		if errors.Is(err, ae.ErrBrokerNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrRoomNotFound) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -195,11 +245,13 @@ func (h *deviceHandler) Create(ctx *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		deviceId	path	string					true	"Device UUID"
+//	@Param		If-Match	header	string					false	"ETag of the device the update is based on"
 //	@Param		data		body	dto.UpdateDeviceRequest	true	"Update data"
 //	@Success	204
 //	@Failure	400	{object}	errors.HTTPError
 //	@Failure	401	{object}	errors.HTTPError
 //	@Failure	404	{object}	errors.HTTPError
+//	@Failure	412	{object}	errors.HTTPError
 //	@Failure	500	{object}	errors.HTTPError
 //	@Router		/devices/{deviceId} [patch]
 func (h *deviceHandler) Update(ctx *gin.Context) {
@@ -218,7 +270,7 @@ func (h *deviceHandler) Update(ctx *gin.Context) {
 
 	body := &dto.UpdateDeviceRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -226,17 +278,25 @@ func (h *deviceHandler) Update(ctx *gin.Context) {
 	device.UserID = userID
 	device.ID = deviceID
 
+	device.Version, err = ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
 	err = h.ds.Update(ctx, device)
 	if err != nil {
 		if errors.Is(err, ae.ErrDeviceNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
-		} else if errors.Is(err, ae.ErrBrokerNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		} else if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrRoomNotFound) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		} else if errors.Is(err, ae.ErrPreconditionFailed) {
+			problem.Abort(ctx, http.StatusPreconditionFailed, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -251,10 +311,12 @@ func (h *deviceHandler) Update(ctx *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		deviceId	path	string	true	"Device UUID"
+//	@Param		If-Match	header	string	false	"ETag of the device to delete"
 //	@Success	204
 //	@Failure	400	{object}	errors.HTTPError
 //	@Failure	401	{object}	errors.HTTPError
 //	@Failure	404	{object}	errors.HTTPError
+//	@Failure	412	{object}	errors.HTTPError
 //	@Failure	500	{object}	errors.HTTPError
 //	@Router		/devices/{deviceId} [delete]
 func (h *deviceHandler) Delete(ctx *gin.Context) {
@@ -271,14 +333,67 @@ func (h *deviceHandler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err = h.ds.Delete(ctx, deviceID, userID)
+	var version *int64
+	version, err = ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.ds.Delete(ctx, deviceID, userID, version)
 	if err != nil {
 		if errors.Is(err, ae.ErrDeviceNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, ae.ErrPreconditionFailed) {
+			problem.Abort(ctx, http.StatusPreconditionFailed, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeviceRestore godoc
+//
+//	@Summary		Restore a deleted device
+//	@Description	The controls deleted along with the device are restored too.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path	string	true	"Device UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/restore [post]
+func (h *deviceHandler) Restore(ctx *gin.Context) {
+	var err error
+	var userID, deviceID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	deviceID, err = h.getDeviceID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.ds.Restore(ctx, deviceID, userID)
+	if err != nil {
+		if errors.Is(err, ae.ErrDeviceNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -293,7 +408,17 @@ func (h *deviceHandler) Delete(ctx *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		deviceId	path		string	true	"Device UUID"
+//	@Param		type		query		string	false	"Control type"
+//	@Param		isAvailable	query		bool	false	"Availability"
+//	@Param		tagId		query		string	false	"Tag UUID"	Format(UUID)
+//	@Param		cursor		query		string	false	"Cursor of the next page, sent in the Link header"
+//	@Param		limit		query		int		false	"Page size"		minimum(1)	maximum(100)	default(50)
+//	@Param		order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param		search		query		string	false	"Search by name"
+//	@Param		sort		query		string	false	"Sort key"	Enums(name, type, topic)	default(name)
 //	@Success	200			{array}		dto.GetDeviceControlResponse
+//	@Header		200			{integer}	X-Total-Count	"Count of all the matching controls"
+//	@Header		200			{string}	Link			"Link to the next page"
 //	@Failure	400			{object}	errors.HTTPError
 //	@Failure	401			{object}	errors.HTTPError
 //	@Failure	404			{object}	errors.HTTPError
@@ -313,23 +438,55 @@ func (h *deviceHandler) ListControls(ctx *gin.Context) {
 		return
 	}
 
-	controls, err := h.dcs.List(ctx, userID, deviceID)
+	query := &dto.DeviceControlQuery{}
+	err = ctx.ShouldBindQuery(query)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filters := &domain.ListDeviceControlFilters{
+		DeviceID:    deviceID,
+		IsAvailable: query.IsAvailable,
+	}
+
+	if query.Type != nil {
+		controlType := enum.ControlType(*query.Type)
+		filters.Type = &controlType
+	}
+
+	if query.TagID != nil {
+		filters.TagID = uuid.NullUUID{UUID: uuid.MustParse(*query.TagID), Valid: true}
+	}
+
+	filters.Page, err = bindPage(ctx)
+	if err != nil {
+		return
+	}
+
+	controls, err := h.dcs.List(ctx, userID, filters)
 	if err != nil {
 		if errors.Is(err, ae.ErrDeviceNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrInvalidCursor) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	r := []dto.GetDeviceControlResponse{}
 
-	for _, control := range controls {
+	for _, control := range controls.Items {
 		r = append(r, *h.dcm.ModelToDTO(control))
 	}
 
+	setPageHeaders(ctx, controls)
 	ctx.JSON(http.StatusOK, r)
 }
 
@@ -365,7 +522,7 @@ func (h *deviceHandler) CreateControl(ctx *gin.Context) {
 
 	body := &dto.CreateDeviceControlRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -376,15 +533,19 @@ func (h *deviceHandler) CreateControl(ctx *gin.Context) {
 	controlID, err = h.dcs.Create(ctx, userID, control)
 	if err != nil {
 		if errors.Is(err, ae.ErrDeviceNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
-		if errors.Is(err, ae.ErrControlStateExists) {
-			ctx.AbortWithStatusJSON(http.StatusConflict, ae.NewHTTPError(err))
+		if errors.Is(err, ae.ErrControlStateExists) || errors.Is(err, ae.ErrTopicConflict) {
+			problem.Abort(ctx, http.StatusConflict, err)
+			return
+		}
+		if errors.Is(err, ae.ErrControlTypeUnknown) || errors.Is(err, ae.ErrControlAttributesInvalid) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -402,12 +563,14 @@ func (h *deviceHandler) CreateControl(ctx *gin.Context) {
 //	@Produce	json
 //	@Param		deviceId	path	string							true	"Device UUID"
 //	@Param		controlId	path	string							true	"Control UUID"
+//	@Param		If-Match	header	string							false	"Version of the control the update is based on, in quotes"
 //	@Param		data		body	dto.UpdateDeviceControlRequest	true	"Update data"
 //	@Success	204
 //	@Failure	400	{object}	errors.HTTPError
 //	@Failure	401	{object}	errors.HTTPError
 //	@Failure	404	{object}	errors.HTTPError
 //	@Failure	409	{object}	errors.HTTPError
+//	@Failure	412	{object}	errors.HTTPError
 //	@Failure	500	{object}	errors.HTTPError
 //	@Router		/devices/{deviceId}/controls/{controlId} [patch]
 func (h *deviceHandler) UpdateControl(ctx *gin.Context) {
@@ -426,7 +589,7 @@ func (h *deviceHandler) UpdateControl(ctx *gin.Context) {
 
 	body := &dto.UpdateDeviceControlRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -434,22 +597,35 @@ func (h *deviceHandler) UpdateControl(ctx *gin.Context) {
 	control.DeviceID = deviceID
 	control.ID = controlID
 
+	control.Version, err = ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
 	err = h.dcs.Update(ctx, userID, control)
 	if err != nil {
 		if errors.Is(err, ae.ErrDeviceNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 		if errors.Is(err, ae.ErrDeviceControlNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 		if errors.Is(err, ae.ErrControlStateExists) {
-			ctx.AbortWithStatusJSON(http.StatusConflict, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusConflict, err)
+			return
+		}
+		if errors.Is(err, ae.ErrPreconditionFailed) {
+			problem.Abort(ctx, http.StatusPreconditionFailed, err)
+			return
+		}
+		if errors.Is(err, ae.ErrControlTypeUnknown) || errors.Is(err, ae.ErrControlAttributesInvalid) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -465,10 +641,12 @@ func (h *deviceHandler) UpdateControl(ctx *gin.Context) {
 //	@Produce	json
 //	@Param		deviceId	path	string	true	"Device UUID"
 //	@Param		controlId	path	string	true	"Control UUID"
+//	@Param		If-Match	header	string	false	"Version of the control to delete, in quotes"
 //	@Success	204
 //	@Failure	400	{object}	errors.HTTPError
 //	@Failure	401	{object}	errors.HTTPError
 //	@Failure	404	{object}	errors.HTTPError
+//	@Failure	412	{object}	errors.HTTPError
 //	@Failure	500	{object}	errors.HTTPError
 //	@Router		/devices/{deviceId}/controls/{controlId} [delete]
 func (h *deviceHandler) DeleteControl(ctx *gin.Context) {
@@ -485,37 +663,153 @@ func (h *deviceHandler) DeleteControl(ctx *gin.Context) {
 		return
 	}
 
-	err = h.dcs.Delete(ctx, userID, deviceID, controlID)
+	var version *int64
+	version, err = ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.dcs.Delete(ctx, userID, deviceID, controlID, version)
 	if err != nil {
 		if errors.Is(err, ae.ErrDeviceNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 		if errors.Is(err, ae.ErrDeviceControlNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, ae.ErrPreconditionFailed) {
+			problem.Abort(ctx, http.StatusPreconditionFailed, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeviceRestoreControl godoc
+//
+//	@Summary		Restore a deleted device control
+//	@Description	The device has to be restored first when it has been deleted too.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path	string	true	"Device UUID"
+//	@Param			controlId	path	string	true	"Control UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		409	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/controls/{controlId}/restore [post]
+func (h *deviceHandler) RestoreControl(ctx *gin.Context) {
+	var err error
+	var userID, deviceID, controlID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	deviceID, controlID, err = h.getDeviceControlIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.dcs.Restore(ctx, userID, deviceID, controlID)
+	if err != nil {
+		if errors.Is(err, ae.ErrDeviceNotFound) || errors.Is(err, ae.ErrDeviceControlNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, ae.ErrControlStateExists) {
+			problem.Abort(ctx, http.StatusConflict, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	ctx.Status(http.StatusNoContent)
 }
 
+// DeviceRenderControl godoc
+//
+//	@Summary		Render the payload of a device control
+//	@Description	Returns the payload which the control would publish for the value, rendered with its payload template,
+//	@Description	without publishing it. The payloads which are not valid UTF-8 are encoded with base64.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path		string						true	"Device UUID"
+//	@Param			controlId	path		string						true	"Control UUID"
+//	@Param			data		body		dto.RenderControlRequest	true	"Rendered value"
+//	@Success		200			{object}	dto.RenderControlResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/controls/{controlId}/render [post]
+func (h *deviceHandler) RenderControl(ctx *gin.Context) {
+	var err error
+	var userID, deviceID, controlID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	deviceID, controlID, err = h.getDeviceControlIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.RenderControlRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	rendered, err := h.dcs.Render(ctx, userID, deviceID, controlID, body.Value)
+	if err != nil {
+		if errors.Is(err, ae.ErrDeviceNotFound) || errors.Is(err, ae.ErrDeviceControlNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, ae.ErrControlValueInvalid) || errors.Is(err, ae.ErrControlNotWritable) ||
+			errors.Is(err, ae.ErrControlAttributesInvalid) || errors.Is(err, ae.ErrControlTypeUnknown) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.dcm.RenderedToDTO(rendered))
+}
+
 func (h *deviceHandler) getDeviceID(ctx *gin.Context) (uuid.UUID, error) {
 	params := &dto.DeviceParams{}
 
 	err := ctx.BindUri(params)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, err
 	}
 
 	var deviceID uuid.UUID
 	deviceID, err = uuid.Parse(params.DeviceID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, err
 	}
 
@@ -527,21 +821,21 @@ func (h *deviceHandler) getDeviceControlIDs(ctx *gin.Context) (uuid.UUID, uuid.U
 
 	err := ctx.BindUri(params)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, uuid.Nil, err
 	}
 
 	var deviceID uuid.UUID
 	deviceID, err = uuid.Parse(params.DeviceID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, uuid.Nil, err
 	}
 
 	var controlID uuid.UUID
 	controlID, err = uuid.Parse(params.ControlID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, uuid.Nil, err
 	}
 
@@ -551,7 +845,7 @@ func (h *deviceHandler) getDeviceControlIDs(ctx *gin.Context) (uuid.UUID, uuid.U
 func (h *deviceHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
 	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return uuid.Nil, err
 	}
 
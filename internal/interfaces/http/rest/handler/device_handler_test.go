@@ -1,10 +1,15 @@
 package handler_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+
 	"github.com/Deve-Lite/DashboardX-API/test"
 	"github.com/go-playground/assert"
 	"github.com/google/uuid"
@@ -177,3 +182,277 @@ func TestUpdateDeviceControl(t *testing.T) {
 		assert.Equal(t, 409, w2.Code)
 	})
 }
+
+func TestCreateDeviceControlTypes(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+
+	body := func(controlType, attributes string) *strings.Reader {
+		return strings.NewReader(fmt.Sprintf(`
+			{
+				"type": "%s",
+				"attributes": %s,
+				"canDisplayName": true,
+				"canNotifyOnPublish": false,
+				"icon": {
+					"name": "Home",
+					"backgroundColor": "#ff00ff"
+				},
+				"isAvailable": true,
+				"isConfirmationRequired": false,
+				"name": "Control",
+				"qualityOfService": 0,
+				"topic": "test"
+			}
+		`, controlType, attributes))
+	}
+
+	cases := []struct {
+		name       string
+		typ        string
+		attributes string
+		code       int
+	}{
+		{"gauge", "gauge", `{"minValue": 0, "maxValue": 100, "unit": "%", "thresholds": [{"value": 80, "color": "#ff0000"}]}`, 201},
+		{"gauge without thresholds", "gauge", `{"minValue": -20, "maxValue": 40}`, 201},
+		{"gauge with threshold out of range", "gauge", `{"minValue": 0, "maxValue": 100, "thresholds": [{"value": 120, "color": "#ff0000"}]}`, 400},
+		{"gauge with invalid range", "gauge", `{"minValue": 100, "maxValue": 0}`, 400},
+		{"chart", "chart", `{"unit": "°C", "series": [{"name": "inside", "path": "$.inside"}, {"name": "outside", "path": "$.outside", "color": "#0000ff"}]}`, 201},
+		{"chart with invalid path", "chart", `{"series": [{"name": "inside", "path": "inside"}]}`, 400},
+		{"chart without series", "chart", `{"series": []}`, 400},
+		{"json state", "json-state", `{"fields": [{"label": "Battery", "path": "$.battery", "unit": "%"}, {"label": "Name", "path": "$['device']['name']"}]}`, 201},
+		{"json state without label", "json-state", `{"fields": [{"label": "", "path": "$.battery"}]}`, 400},
+		{"number input", "number-in", `{"payloadTemplate": "{{ value }}", "minValue": 0, "maxValue": 10, "step": 0.5, "unit": "h"}`, 201},
+		{"number input without step", "number-in", `{"payloadTemplate": "{{ value }}", "minValue": 0, "maxValue": 10}`, 400},
+		{"number input with invalid template", "number-in", `{"payloadTemplate": "{{ range .Value }}{{ end }}", "minValue": 0, "maxValue": 10, "step": 1}`, 400},
+		{"multi switch", "multi-switch", `{"switches": [{"name": "1", "onPayload": "1:ON", "offPayload": "1:OFF"}, {"name": "2", "onPayload": "2:ON", "offPayload": "2:OFF"}]}`, 201},
+		{"multi switch with duplicated names", "multi-switch", `{"switches": [{"name": "1", "onPayload": "ON", "offPayload": "OFF"}, {"name": "1", "onPayload": "ON", "offPayload": "OFF"}]}`, 400},
+		{"multi switch with other attributes", "multi-switch", `{"switches": [{"name": "1", "onPayload": "ON", "offPayload": "OFF"}], "payload": "test"}`, 400},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("should return %d for %s", c.code, c.name), func(t *testing.T) {
+			w := tt.MakeRequest(g, "POST", createControlURL(dID), body(c.typ, c.attributes), &usr.AccessToken)
+			assert.Equal(t, c.code, w.Code)
+		})
+	}
+
+	t.Run("should return the fields of invalid attributes", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", createControlURL(dID), body("gauge", `{"minValue": 0, "maxValue": 100, "thresholds": [{"value": 50, "color": "red"}]}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"field":"attributes.thresholds[0].color"`))
+	})
+
+	t.Run("should return 400 for unknown control types", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", createControlURL(dID), body("lamp", `{}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"field":"type"`))
+	})
+
+	t.Run("should return the attributes of the new controls", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf(`/api/v1/devices/%s/controls`, dID.String()), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"thresholds":[{"color":"#ff0000","value":80}]`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"step":0.5`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"path":"$['device']['name']"`))
+	})
+}
+
+func TestListDevices(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	tt.CreateDevice(a, usr.ID, bID)
+	tt.CreateDevice(a, usr.ID, bID)
+	tt.CreateDeviceControl(a, usr.ID, dID)
+
+	t.Run("should return the pages linked by the cursor", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/devices?limit=2&sort=createdAt", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, 2, strings.Count(w.Body.String(), `"id"`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), dID.String()))
+
+		link := w.Header().Get("Link")
+		assert.Equal(t, true, strings.HasSuffix(link, `>; rel="next"`))
+
+		w = tt.MakeRequest(g, "GET", link[1:strings.Index(link, ">")], nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, strings.Count(w.Body.String(), `"id"`))
+		assert.Equal(t, "", w.Header().Get("Link"))
+	})
+
+	t.Run("should filter the devices", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/devices?controlType=button", nil, &usr.AccessToken)
+		assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices?placing=Home&search=DEVICE", nil, &usr.AccessToken)
+		assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices?search=%25", nil, &usr.AccessToken)
+		assert.Equal(t, "0", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should return 400 when the sort is unknown", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/devices?sort=id", nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"field":"sort"`))
+	})
+
+	t.Run("should return 400 when the cursor is invalid", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/devices?cursor=invalid", nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"code":"INVALID_CURSOR"`))
+	})
+}
+
+func TestListDeviceControls(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	tt.CreateDeviceControl(a, usr.ID, dID)
+	tt.CreateDeviceControl(a, usr.ID, dID)
+
+	t.Run("should filter the controls", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", createControlURL(dID)+"?type=button&isAvailable=true", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+
+		w = tt.MakeRequest(g, "GET", createControlURL(dID)+"?isAvailable=false", nil, &usr.AccessToken)
+		assert.Equal(t, "0", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should return the controls in the requested order", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", createControlURL(dID)+"?limit=1&order=desc", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, strings.Count(w.Body.String(), `"id"`))
+		assert.Equal(t, true, strings.Contains(w.Header().Get("Link"), "order=desc"))
+	})
+}
+
+func TestRenderDeviceControl(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+
+	w := tt.MakeRequest(g, "POST", createControlURL(dID), strings.NewReader(`
+		{
+			"type": "slider",
+			"attributes": {"payloadTemplate": "{\"device\": {{ json .Device.Name }}, \"level\": {{ .Value | round 1 }}}", "minValue": 0, "maxValue": 10},
+			"canDisplayName": true,
+			"canNotifyOnPublish": false,
+			"icon": {"name": "Home", "backgroundColor": "#ff00ff"},
+			"isAvailable": true,
+			"isConfirmationRequired": false,
+			"name": "Slider",
+			"qualityOfService": 1,
+			"topic": "level"
+		}
+	`), &usr.AccessToken)
+	assert.Equal(t, 201, w.Code)
+
+	created := dto.CreateDeviceControlResponse{}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	renderURL := fmt.Sprintf("%s/render", patchControlURL(dID, created.ID))
+
+	t.Run("should return the rendered payload", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", renderURL, strings.NewReader(`{"value": 2.25}`), &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := dto.RenderControlResponse{}
+		assert.Equal(t, nil, json.Unmarshal(w.Body.Bytes(), &r))
+		assert.Equal(t, `{"device": "test-device", "level": 2.3}`, r.Payload)
+		assert.Equal(t, "utf8", string(r.Encoding))
+		assert.Equal(t, 1, int(r.QoS))
+	})
+
+	t.Run("should return 400 for invalid values", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", renderURL, strings.NewReader(`{"value": 20}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 404 for unknown controls", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("%s/render", patchControlURL(dID, uuid.New())), strings.NewReader(`{"value": 1}`), &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestDeviceConditionalRequests(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	dcID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+	deviceURL := fmt.Sprintf(`/api/v1/devices/%s`, dID.String())
+
+	request := func(method, url, body, header, etag string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", usr.AccessToken)
+		req.Header.Set(header, etag)
+		g.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should return 304 when the device has not been modified", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", deviceURL, nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+		w = request("GET", deviceURL, "", "If-None-Match", `"0", W/"1"`)
+		assert.Equal(t, 304, w.Code)
+	})
+
+	t.Run("should return 412 when the device has been modified since it was fetched", func(t *testing.T) {
+		w := request("PATCH", deviceURL, `{"name": "first"}`, "If-Match", `"1"`)
+		assert.Equal(t, 204, w.Code)
+
+		w = request("PATCH", deviceURL, `{"name": "second"}`, "If-Match", `"1"`)
+		assert.Equal(t, 412, w.Code)
+
+		w = request("PATCH", deviceURL, `{"name": "second"}`, "If-Match", `"2"`)
+		assert.Equal(t, 204, w.Code)
+	})
+
+	t.Run("should return 412 when the control has been modified since it was fetched", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", createControlURL(dID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		var controls []dto.GetDeviceControlResponse
+		json.Unmarshal(w.Body.Bytes(), &controls)
+		assert.Equal(t, 1, len(controls))
+
+		etag := fmt.Sprintf(`"%d"`, controls[0].Version)
+
+		w = request("PATCH", patchControlURL(dID, dcID), `{"topic": "first"}`, "If-Match", etag)
+		assert.Equal(t, 204, w.Code)
+
+		w = request("DELETE", patchControlURL(dID, dcID), "", "If-Match", etag)
+		assert.Equal(t, 412, w.Code)
+
+		w = request("DELETE", patchControlURL(dID, dcID), "", "If-Match", "*")
+		assert.Equal(t, 204, w.Code)
+	})
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DiscoveryHandler interface {
+	List(ctx *gin.Context)
+	Accept(ctx *gin.Context)
+	Dismiss(ctx *gin.Context)
+}
+
+type discoveryHandler struct {
+	ds application.DiscoveryService
+	m  mapper.DiscoveryMapper
+}
+
+func NewDiscoveryHandler(ds application.DiscoveryService, m mapper.DiscoveryMapper) DiscoveryHandler {
+	return &discoveryHandler{ds, m}
+}
+
+// DiscoveryList godoc
+//
+//	@Summary		List discovery proposals of a broker
+//	@Description	Proposals are created from the Home Assistant discovery messages when the discovery mode of the broker is set to propose.
+//					With the auto mode, devices and controls are created right away instead.
+//	@Tags			Discovery
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string	true	"Broker UUID"
+//	@Success		200			{array}		dto.GetDiscoveryProposalResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/discovery [get]
+func (h *discoveryHandler) List(ctx *gin.Context) {
+	var err error
+	var userID, brokerID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.BrokerParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	brokerID, err = uuid.Parse(params.BrokerID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var proposals []*domain.DiscoveryProposal
+	proposals, err = h.ds.List(ctx, userID, brokerID)
+	if err != nil {
+		if errors.Is(err, ae.ErrBrokerNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	r := []dto.GetDiscoveryProposalResponse{}
+
+	for _, proposal := range proposals {
+		r = append(r, *h.m.ModelToDTO(proposal))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// DiscoveryAccept godoc
+//
+//	@Summary		Accept a discovery proposal
+//	@Description	Creates the control of the proposal, the device is reused when the broker already has a device with the same name.
+//	@Tags			Discovery
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string	true	"Broker UUID"
+//	@Param			proposalId	path		string	true	"Proposal UUID"
+//	@Success		201			{object}	dto.AcceptDiscoveryProposalResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		409			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/discovery/{proposalId}/accept [post]
+func (h *discoveryHandler) Accept(ctx *gin.Context) {
+	var err error
+	var userID, brokerID, proposalID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	brokerID, proposalID, err = h.getDiscoveryIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	var result *domain.DiscoveryResult
+	result, err = h.ds.Accept(ctx, userID, brokerID, proposalID)
+	if err != nil {
+		if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDiscoveryProposalNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, ae.ErrControlStateExists) || errors.Is(err, ae.ErrTopicConflict) {
+			problem.Abort(ctx, http.StatusConflict, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, h.m.ResultModelToDTO(result))
+}
+
+// DiscoveryDismiss godoc
+//
+//	@Summary		Dismiss a discovery proposal
+//	@Description	A dismissed proposal is not proposed again, even if the entity is announced once more.
+//	@Tags			Discovery
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path	string	true	"Broker UUID"
+//	@Param			proposalId	path	string	true	"Proposal UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/discovery/{proposalId} [delete]
+func (h *discoveryHandler) Dismiss(ctx *gin.Context) {
+	var err error
+	var userID, brokerID, proposalID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	brokerID, proposalID, err = h.getDiscoveryIDs(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.ds.Dismiss(ctx, userID, brokerID, proposalID)
+	if err != nil {
+		if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDiscoveryProposalNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *discoveryHandler) getDiscoveryIDs(ctx *gin.Context) (uuid.UUID, uuid.UUID, error) {
+	params := &dto.DiscoveryParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	var brokerID uuid.UUID
+	brokerID, err = uuid.Parse(params.BrokerID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	var proposalID uuid.UUID
+	proposalID, err = uuid.Parse(params.ProposalID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return brokerID, proposalID, nil
+}
+
+func (h *discoveryHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
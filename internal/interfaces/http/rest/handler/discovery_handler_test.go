@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestListDiscovery(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	usr2 := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+
+	t.Run("should return 200 and an empty list", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/discovery", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []dto.GetDiscoveryProposalResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+
+		assert.Equal(t, 0, len(r))
+	})
+
+	t.Run("should return 404 when broker belongs to another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/discovery", bID), nil, &usr2.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 404 when proposal does not exist", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/discovery/%s/accept", bID, uuid.New()), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 400 when discovery prefix is not a topic", func(t *testing.T) {
+		for _, prefix := range []string{"homeassistant/#", "home+assistant", ""} {
+			body, _ := json.Marshal(map[string]string{"discoveryPrefix": prefix})
+			w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bID), strings.NewReader(string(body)), &usr.AccessToken)
+			assert.Equal(t, 400, w.Code)
+		}
+	})
+
+	t.Run("should save the quoted discovery prefix", func(t *testing.T) {
+		p := strings.NewReader(`{"discoveryPrefix": "o'hara/ha"}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bID), p, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s", bID), nil, &usr.AccessToken)
+		r := dto.GetBrokerResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.Equal(t, "o'hara/ha", r.DiscoveryPrefix)
+	})
+
+	t.Run("should return 400 when broker id is invalid", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/brokers/invalid/discovery", nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+}
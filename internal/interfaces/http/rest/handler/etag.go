@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// etag formats the version of the entity as a strong entity tag. The state which is sent along with the entity
+// but not versioned with it, such as its status, is hashed into the tag after the version.
+func etag(version int64, state ...interface{}) string {
+	tag := strconv.FormatInt(version, 10)
+
+	if len(state) > 0 {
+		h := fnv.New32a()
+		for _, s := range state {
+			if t, ok := s.(*time.Time); ok {
+				s = ""
+				if t != nil {
+					s = t.UTC().Format(time.RFC3339Nano)
+				}
+			}
+
+			fmt.Fprintf(h, "%v\x00", s)
+		}
+
+		tag = fmt.Sprintf("%s-%08x", tag, h.Sum32())
+	}
+
+	return `"` + tag + `"`
+}
+
+// notModified sends the ETag of the entity and tells whether the client has its version and state already,
+// the request is then ended with 304 Not Modified.
+func notModified(ctx *gin.Context, version int64, state ...interface{}) bool {
+	tag := etag(version, state...)
+	ctx.Header("ETag", tag)
+
+	for _, t := range strings.Split(ctx.GetHeader("If-None-Match"), ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == tag {
+			ctx.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// ifMatch reads the version of the entity required by the If-Match header, it is nil when the header is missing
+// or matches any version. The request is aborted with 412 Precondition Failed when the header is not a single
+// strong ETag, as the weak ones never match the strong comparison. The hash of the state is not compared,
+// the updates are based on the version only.
+func ifMatch(ctx *gin.Context) (*int64, error) {
+	h := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if h == "" || h == "*" {
+		return nil, nil
+	}
+
+	if len(h) < 2 || h[0] != '"' || h[len(h)-1] != '"' {
+		err := fmt.Errorf("%w: %s is not a strong ETag", ae.ErrPreconditionFailed, h)
+		problem.Abort(ctx, http.StatusPreconditionFailed, err)
+		return nil, err
+	}
+
+	v, _, _ := strings.Cut(h[1:len(h)-1], "-")
+
+	version, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		err = fmt.Errorf("%w: %s is not an ETag of the resource", ae.ErrPreconditionFailed, h)
+		problem.Abort(ctx, http.StatusPreconditionFailed, err)
+		return nil, err
+	}
+
+	return &version, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Deve-Lite/DashboardX-API/config"
 	"github.com/Deve-Lite/DashboardX-API/internal/application"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -44,6 +45,10 @@ func (h *eventHandler) Broadcast(ctx *gin.Context) {
 
 	eventChannels, channelID := h.es.Subscribe(ctx, userID)
 
+	eventChannels.Mutex.Lock()
+	events := eventChannels.Channels[channelID.String()]
+	eventChannels.Mutex.Unlock()
+
 	var cause string
 
 	defer func() {
@@ -66,7 +71,7 @@ func (h *eventHandler) Broadcast(ctx *gin.Context) {
 		case <-ctx.Request.Context().Done():
 			cause = "user disconnected"
 			return
-		case evt := <-eventChannels.Channels[channelID.String()]:
+		case evt := <-events:
 			ctx.SSEvent(string(evt.Data.Action), evt.String())
 			ctx.Writer.Flush()
 
@@ -81,7 +86,7 @@ func (h *eventHandler) Broadcast(ctx *gin.Context) {
 func (h *eventHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
 	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.HTTPError{Message: "could not retrieve user info"})
+		problem.Abort(ctx, http.StatusInternalServerError, ae.ErrUnexpected)
 		return uuid.Nil, err
 	}
 
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ExploreHandler interface {
+	Explore(ctx *gin.Context)
+	CreateControl(ctx *gin.Context)
+}
+
+type exploreHandler struct {
+	es application.ExploreService
+	m  mapper.ExploreMapper
+}
+
+func NewExploreHandler(es application.ExploreService, m mapper.ExploreMapper) ExploreHandler {
+	return &exploreHandler{es, m}
+}
+
+// ExploreHandler godoc
+//
+//	@Summary		Explore the topics of a broker
+//	@Description	The server subscribes to the filter for the window and streams the topic tree as the tree events
+//	@Description	whenever new messages arrive, with their counts, the previews of the last payloads and the retained flags.
+//	@Description	The end event is sent after the last tree, when the window is over.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		text/event-stream
+//	@Param			brokerId	path		string	true	"Broker UUID"
+//	@Param			filter		query		string	false	"Topic filter, # by default"
+//	@Param			window		query		int		false	"Window in seconds"
+//	@Success		200			{object}	dto.TopicTreeResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/explore [get]
+func (h *exploreHandler) Explore(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.BrokerParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	query := &dto.ExploreQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	explore := h.m.QueryDTOToModel(userID, uuid.MustParse(params.BrokerID), query)
+
+	trees, err := h.es.Explore(ctx.Request.Context(), explore)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Writer.Header().Set("Content-Type", "text/event-stream")
+	ctx.Writer.Header().Set("Cache-Control", "no-cache")
+	ctx.Writer.Header().Set("Connection", "keep-alive")
+	ctx.Writer.Header().Set("Transfer-Encoding", "chunked")
+	ctx.Writer.Flush()
+
+	for tree := range trees {
+		ctx.SSEvent("tree", h.m.TreeToDTO(tree))
+		ctx.Writer.Flush()
+	}
+
+	if ctx.Request.Context().Err() == nil {
+		ctx.SSEvent("end", "")
+		ctx.Writer.Flush()
+	}
+}
+
+// ExploreCreateControl godoc
+//
+//	@Summary		Create a control from an explored topic
+//	@Description	The control is added to the device of the broker with the topic relative to the base path of the device,
+//	@Description	which has to contain the topic. The text-out type is used when the type is not sent.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string						true	"Broker UUID"
+//	@Param			request		body		dto.ExploreControlRequest	true	"Control"
+//	@Success		201			{object}	dto.CreateDeviceControlResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		409			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/explore/controls [post]
+func (h *exploreHandler) CreateControl(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.BrokerParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	body := &dto.ExploreControlRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	control := h.m.ControlDTOToModel(body)
+	control.UserID = userID
+	control.BrokerID = uuid.MustParse(params.BrokerID)
+
+	controlID, err := h.es.CreateControl(ctx, control)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateDeviceControlResponse{ID: controlID})
+}
+
+func (h *exploreHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDeviceNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrDeviceBrokerMismatch) ||
+		errors.Is(err, ae.ErrControlTypeUnknown) || errors.Is(err, ae.ErrControlAttributesInvalid) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, ae.ErrControlStateExists) || errors.Is(err, ae.ErrTopicConflict) {
+		code = http.StatusConflict
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *exploreHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
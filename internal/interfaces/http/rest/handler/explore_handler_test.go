@@ -0,0 +1,63 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestExplore(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+
+	t.Run("should return 404 when the broker does not exist", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/explore", uuid.New()), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 400 for an invalid filter", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/explore?filter=a/%%23/b", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 400 when the window is too long", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/explore?window=3600", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should create a control relative to the base path of the device", func(t *testing.T) {
+		p := strings.NewReader(fmt.Sprintf(`{"deviceId": "%s", "name": "temperature", "topic": "/sensors/temperature"}`, dID))
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/explore/controls", bID), p, &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		r := dto.CreateDeviceControlResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/controls", dID), nil, &usr.AccessToken)
+		c := []dto.GetDeviceControlResponse{}
+		json.Unmarshal(w.Body.Bytes(), &c)
+		assert.Equal(t, 1, len(c))
+		assert.Equal(t, r.ID, c[0].ID)
+		assert.Equal(t, "sensors/temperature", c[0].Topic)
+		assert.Equal(t, "text-out", string(c[0].Type))
+	})
+
+	t.Run("should return 400 when the device is not connected to the broker", func(t *testing.T) {
+		other := tt.CreateBroker(a, usr.ID)
+
+		p := strings.NewReader(fmt.Sprintf(`{"deviceId": "%s", "name": "temperature", "topic": "/sensors/temperature"}`, dID))
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/explore/controls", other), p, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+}
@@ -0,0 +1,426 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GroupHandler interface {
+	Get(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	SetControls(ctx *gin.Context)
+	Publish(ctx *gin.Context)
+	GetState(ctx *gin.Context)
+}
+
+type groupHandler struct {
+	gs application.GroupService
+	m  mapper.GroupMapper
+}
+
+func NewGroupHandler(gs application.GroupService, m mapper.GroupMapper) GroupHandler {
+	return &groupHandler{gs, m}
+}
+
+// GroupGet godoc
+//
+//	@Summary	Get a single device group
+//	@Tags		Groups
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		groupId	path		string	true	"Group UUID"
+//	@Success	200		{object}	dto.GetGroupResponse
+//	@Failure	400		{object}	errors.HTTPError
+//	@Failure	401		{object}	errors.HTTPError
+//	@Failure	404		{object}	errors.HTTPError
+//	@Failure	500		{object}	errors.HTTPError
+//	@Router		/groups/{groupId} [get]
+func (h *groupHandler) Get(ctx *gin.Context) {
+	var err error
+	var userID, groupID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	groupID, err = h.getGroupID(ctx)
+	if err != nil {
+		return
+	}
+
+	var group *domain.Group
+	group, err = h.gs.Get(ctx, groupID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(group))
+}
+
+// GroupList godoc
+//
+//	@Summary	List device groups
+//	@Tags		Groups
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		dto.GetGroupResponse
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/groups [get]
+func (h *groupHandler) List(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	var groups []*domain.Group
+	groups, err = h.gs.List(ctx, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := []dto.GetGroupResponse{}
+
+	for _, group := range groups {
+		r = append(r, *h.m.ModelToDTO(group))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// GroupCreate godoc
+//
+//	@Summary		Create a device group
+//	@Description	The type of the group is the type of all its controls.
+//	@Tags			Groups
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body		dto.CreateGroupRequest	true	"Create data"
+//	@Success		201		{object}	dto.CreateGroupResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/groups [post]
+func (h *groupHandler) Create(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.CreateGroupRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var groupID uuid.UUID
+	groupID, err = h.gs.Create(ctx, h.m.CreateDTOToCreateModel(userID, body))
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateGroupResponse{
+		ID: groupID,
+	})
+}
+
+// GroupUpdate godoc
+//
+//	@Summary	Update a device group
+//	@Tags		Groups
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		groupId	path	string					true	"Group UUID"
+//	@Param		data	body	dto.UpdateGroupRequest	true	"Update data"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/groups/{groupId} [patch]
+func (h *groupHandler) Update(ctx *gin.Context) {
+	var err error
+	var userID, groupID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	groupID, err = h.getGroupID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.UpdateGroupRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	group := h.m.UpdateDTOToUpdateModel(body)
+	group.ID = groupID
+	group.UserID = userID
+
+	err = h.gs.Update(ctx, group)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GroupDelete godoc
+//
+//	@Summary	Delete a device group
+//	@Tags		Groups
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		groupId	path	string	true	"Group UUID"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/groups/{groupId} [delete]
+func (h *groupHandler) Delete(ctx *gin.Context) {
+	var err error
+	var userID, groupID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	groupID, err = h.getGroupID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.gs.Delete(ctx, groupID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GroupSetControls godoc
+//
+//	@Summary		Replace the controls of a device group
+//	@Description	The controls have to be of the type of the group.
+//	@Tags			Groups
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			groupId	path	string						true	"Group UUID"
+//	@Param			data	body	dto.SetGroupControlsRequest	true	"Control ids"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/groups/{groupId}/controls [put]
+func (h *groupHandler) SetControls(ctx *gin.Context) {
+	var err error
+	var userID, groupID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	groupID, err = h.getGroupID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.SetGroupControlsRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.gs.SetControls(ctx, userID, groupID, body.IDs)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GroupPublish godoc
+//
+//	@Summary		Publish a value to every control of a device group
+//	@Description	The value is published to the brokers of the members concurrently, the result of each member is returned.
+//	@Description	Once a broker fails, its remaining members get the same error without waiting for it again.
+//	@Description	The members which could not be published do not fail the request.
+//	@Tags			Groups
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			groupId	path		string					true	"Group UUID"
+//	@Param			data	body		dto.PublishGroupRequest	true	"Published value"
+//	@Success		200		{object}	dto.PublishGroupResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		404		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/groups/{groupId}/publish [post]
+func (h *groupHandler) Publish(ctx *gin.Context) {
+	var err error
+	var userID, groupID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	groupID, err = h.getGroupID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.PublishGroupRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var results []*domain.GroupPublishResult
+	results, err = h.gs.Publish(ctx, userID, groupID, body.Value)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := dto.PublishGroupResponse{Results: []dto.GroupPublishResult{}}
+
+	for _, result := range results {
+		item := dto.GroupPublishResult{
+			ControlID: result.ControlID,
+			DeviceID:  result.DeviceID,
+			Published: result.Err == nil,
+		}
+
+		if result.Err != nil {
+			p := ae.NewProblem(result.Err, http.StatusInternalServerError, problem.Language(ctx))
+			item.Error = &dto.GroupPublishError{Code: p.Code, Message: p.Message}
+		}
+
+		r.Results = append(r.Results, item)
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// GroupGetState godoc
+//
+//	@Summary		Get the state of a device group
+//	@Description	The state is derived from the last values published to the controls. The group is all-on
+//	@Description	when every control with a known value is on, e.g. a switch set to true or a slider above its minimum.
+//	@Tags			Groups
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			groupId	path		string	true	"Group UUID"
+//	@Success		200		{object}	dto.GetGroupStateResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		404		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/groups/{groupId}/state [get]
+func (h *groupHandler) GetState(ctx *gin.Context) {
+	var err error
+	var userID, groupID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	groupID, err = h.getGroupID(ctx)
+	if err != nil {
+		return
+	}
+
+	var state *domain.GroupState
+	state, err = h.gs.GetState(ctx, groupID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.StateModelToDTO(state))
+}
+
+func (h *groupHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrGroupNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrDeviceControlNotFound) || errors.Is(err, ae.ErrGroupControlIncompatible) ||
+		errors.Is(err, ae.ErrMissingParams) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *groupHandler) getGroupID(ctx *gin.Context) (uuid.UUID, error) {
+	params := &dto.GroupParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	var groupID uuid.UUID
+	groupID, err = uuid.Parse(params.GroupID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return groupID, nil
+}
+
+func (h *groupHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
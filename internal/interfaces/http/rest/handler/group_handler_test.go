@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestGroups(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	dID := tt.CreateDevice(a, usr.ID, tt.CreateBroker(a, usr.ID))
+	cID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+	other := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	otherCID := tt.CreateDeviceControl(a, other.ID, tt.CreateDevice(a, other.ID, tt.CreateBroker(a, other.ID)))
+
+	create := func(groupType string) uuid.UUID {
+		w := tt.MakeRequest(g, "POST", "/api/v1/groups",
+			strings.NewReader(`{"name":"Lights","type":"`+groupType+`","icon":{"name":"bulb","backgroundColor":"#ffffff"}}`), &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		r := &dto.CreateGroupResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		return r.ID
+	}
+
+	buttons := create("button")
+	switches := create("switch")
+	url := "/api/v1/groups/" + buttons.String()
+
+	t.Run("should return 400 for a type without group actions", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/groups",
+			strings.NewReader(`{"name":"Charts","type":"chart","icon":{"name":"bulb","backgroundColor":"#ffffff"}}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should set the controls of the group", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/controls", strings.NewReader(fmt.Sprintf(`{"ids":["%s"]}`, cID)), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		r := &dto.GetGroupResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, []uuid.UUID{cID}, r.ControlIDs)
+	})
+
+	t.Run("should return 400 for a control of another type", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", "/api/v1/groups/"+switches.String()+"/controls",
+			strings.NewReader(fmt.Sprintf(`{"ids":["%s"]}`, cID)), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"GROUP_CONTROL_INCOMPATIBLE"`))
+	})
+
+	t.Run("should return 400 for a control of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url+"/controls", strings.NewReader(fmt.Sprintf(`{"ids":["%s"]}`, otherCID)), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should report the members which could not be published", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", url+"/publish", strings.NewReader(`{"value":null}`), &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.PublishGroupResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 1, len(r.Results))
+		assert.Equal(t, cID, r.Results[0].ControlID)
+		assert.Equal(t, false, r.Results[0].Published)
+		assert.NotEqual(t, nil, r.Results[0].Error)
+	})
+
+	t.Run("should return the unknown state without published values", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url+"/state", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.GetGroupStateResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, enum.GroupUnknown, r.State)
+		assert.Equal(t, 1, r.UnknownCount)
+	})
+
+	t.Run("should return 404 for the group of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", url+"/publish", strings.NewReader(`{"value":true}`), &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MessageHandler interface {
+	List(ctx *gin.Context)
+	Export(ctx *gin.Context)
+}
+
+type messageHandler struct {
+	ms application.MessageLogService
+	m  mapper.MessageMapper
+}
+
+func NewMessageHandler(ms application.MessageLogService, m mapper.MessageMapper) MessageHandler {
+	return &messageHandler{ms, m}
+}
+
+// MessageList godoc
+//
+//	@Summary		List the messages of a device
+//	@Description	The log holds the messages received on the topics of the controls of the device and the ones published
+//	@Description	by the server, the outbound ones are linked to their source. The topic is a filter which can contain
+//	@Description	wildcards and the contains is searched for in the payloads. The payloads which are not valid UTF-8
+//	@Description	are encoded with base64.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path		string	true	"Device UUID"
+//	@Param			topic		query		string	false	"Topic filter"
+//	@Param			contains	query		string	false	"Text contained in the payload"
+//	@Param			from		query		string	false	"Received at or after, RFC 3339"
+//	@Param			to			query		string	false	"Received before, RFC 3339"
+//	@Param			cursor		query		string	false	"Cursor of the next page, sent in the Link header"
+//	@Param			limit		query		int		false	"Page size"		minimum(1)	maximum(100)	default(50)
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort		query		string	false	"Sort key"		Enums(createdAt, topic)	default(createdAt)
+//	@Success		200			{array}		dto.DeviceMessageResponse
+//	@Header			200			{integer}	X-Total-Count	"Count of all the matching messages"
+//	@Header			200			{string}	Link			"Link to the next page"
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/messages [get]
+func (h *messageHandler) List(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	query := &dto.DeviceMessageQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filters := h.m.QueryDTOToModel(uuid.MustParse(params.DeviceID), query)
+
+	filters.Page, err = bindPage(ctx)
+	if err != nil {
+		return
+	}
+
+	messages, err := h.ms.List(ctx, userID, filters)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := []*dto.DeviceMessageResponse{}
+	for _, m := range messages.Items {
+		r = append(r, h.m.ModelToDTO(m))
+	}
+
+	setPageHeaders(ctx, messages)
+	ctx.JSON(http.StatusOK, r)
+}
+
+// MessageExport godoc
+//
+//	@Summary		Export the messages of a device
+//	@Description	Exports the messages matching the filters from the oldest one, as newline delimited JSON
+//	@Description	with a message per line or as CSV with a header row.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Produce		application/x-ndjson
+//	@Produce		text/csv
+//	@Param			deviceId	path		string	true	"Device UUID"
+//	@Param			format		query		string	false	"Export format"	Enums(ndjson, csv)
+//	@Param			topic		query		string	false	"Topic filter"
+//	@Param			contains	query		string	false	"Text contained in the payload"
+//	@Param			from		query		string	false	"Received at or after, RFC 3339"
+//	@Param			to			query		string	false	"Received before, RFC 3339"
+//	@Success		200			{array}		dto.DeviceMessageResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/messages/export [get]
+func (h *messageHandler) Export(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	query := &dto.DeviceMessageExportQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filters := h.m.QueryDTOToModel(uuid.MustParse(params.DeviceID), &query.DeviceMessageQuery)
+
+	messages, err := h.ms.List(ctx, userID, filters)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	if query.Format == "" {
+		query.Format = enum.MessageExportNDJSON
+	}
+
+	filename := fmt.Sprintf("messages-%s-%s.%s", params.DeviceID, time.Now().Format(time.DateOnly), query.Format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if query.Format == enum.MessageExportCSV {
+		ctx.Header("Content-Type", "text/csv")
+		ctx.Status(http.StatusOK)
+
+		w := csv.NewWriter(ctx.Writer)
+		w.Write([]string{"id", "createdAt", "direction", "topic", "qualityOfService", "retained",
+			"payload", "encoding", "truncated", "controlId", "sourceType", "sourceId"})
+
+		for _, m := range messages.Items {
+			r := h.m.ModelToDTO(m)
+
+			controlID, sourceType, sourceID := "", "", ""
+			if r.ControlID.Valid {
+				controlID = r.ControlID.UUID.String()
+			}
+			if r.Source != nil {
+				sourceType, sourceID = string(r.Source.Type), r.Source.ID.String()
+			}
+
+			w.Write([]string{r.ID.String(), r.CreatedAt.Format(time.RFC3339Nano), string(r.Direction), r.Topic,
+				strconv.Itoa(int(r.QoS)), strconv.FormatBool(r.Retained), r.Payload, string(r.Encoding),
+				strconv.FormatBool(r.Truncated), controlID, sourceType, sourceID})
+		}
+
+		w.Flush()
+		return
+	}
+
+	ctx.Header("Content-Type", "application/x-ndjson")
+	ctx.Status(http.StatusOK)
+
+	e := json.NewEncoder(ctx.Writer)
+	for _, m := range messages.Items {
+		e.Encode(h.m.ModelToDTO(m))
+	}
+}
+
+func (h *messageHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrDeviceNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrInvalidCursor) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *messageHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,75 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestDeviceMessages(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+
+	tt.CreateDeviceMessage(dID, enum.MessageInbound, "home/kitchen/temperature", `{"temp":21.4}`)
+	tt.CreateDeviceMessage(dID, enum.MessageInbound, "home/kitchen/humidity", `{"hum":40}`)
+	tt.CreateDeviceMessage(dID, enum.MessageOutbound, "home/hall/light", "ON")
+
+	t.Run("should list the messages of the device", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/messages", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should filter the messages by the topic filter", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/messages?topic=home/kitchen/%%23", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should filter the messages by the payload", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/messages?contains=hum", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []dto.DeviceMessageResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.Equal(t, 1, len(r))
+		assert.Equal(t, "home/kitchen/humidity", r[0].Topic)
+		assert.Equal(t, enum.PayloadUTF8, r[0].Encoding)
+	})
+
+	t.Run("should return 400 for an invalid topic filter", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/messages?topic=home/%%23/light", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 404 when the device does not exist", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/messages", uuid.New()), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should export the messages as NDJSON", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/messages/export", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Equal(t, 3, len(strings.Split(strings.TrimSpace(w.Body.String()), "\n")))
+	})
+
+	t.Run("should export the messages as CSV", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/messages/export?format=csv", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Equal(t, 4, len(strings.Split(strings.TrimSpace(w.Body.String()), "\n")))
+	})
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	"github.com/Deve-Lite/DashboardX-API/pkg/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultPageLimit = 50
+
+// bindPage reads the requested page of a list, the request is aborted when the query is not valid.
+func bindPage(ctx *gin.Context) (domain.Page, error) {
+	query := &dto.PageQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return domain.Page{}, err
+	}
+
+	page := domain.Page{
+		Search: query.Search,
+		Sort:   query.Sort,
+		Desc:   query.Order == "desc",
+		Limit:  query.Limit,
+	}
+
+	if page.Limit == 0 {
+		page.Limit = defaultPageLimit
+	}
+
+	if query.Cursor != "" {
+		c, err := pagination.Parse(query.Cursor)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return domain.Page{}, err
+		}
+
+		page.After = c
+	}
+
+	return page, nil
+}
+
+// setPageHeaders sends the total count of the items and the link to the next page, if there is one.
+func setPageHeaders[T any](ctx *gin.Context, list *domain.List[T]) {
+	ctx.Header("X-Total-Count", strconv.Itoa(list.Total))
+
+	if list.Next != nil {
+		ctx.Header("Link", pagination.Link(ctx.Request.URL, list.Next))
+	}
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PresenceHandler interface {
+	Get(ctx *gin.Context)
+	Set(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	ListHistory(ctx *gin.Context)
+}
+
+type presenceHandler struct {
+	ps application.PresenceService
+	m  mapper.AvailabilityMapper
+}
+
+func NewPresenceHandler(ps application.PresenceService, m mapper.AvailabilityMapper) PresenceHandler {
+	return &presenceHandler{ps, m}
+}
+
+// PresenceGet godoc
+//
+//	@Summary	Get the availability of a device
+//	@Tags		Devices
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		deviceId	path		string	true	"Device UUID"
+//	@Success	200			{object}	dto.GetAvailabilityResponse
+//	@Failure	400			{object}	errors.HTTPError
+//	@Failure	401			{object}	errors.HTTPError
+//	@Failure	404			{object}	errors.HTTPError
+//	@Failure	500			{object}	errors.HTTPError
+//	@Router		/devices/{deviceId}/availability [get]
+func (h *presenceHandler) Get(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	deviceID, err := h.getDeviceID(ctx)
+	if err != nil {
+		return
+	}
+
+	availability, err := h.ps.Get(ctx, deviceID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(availability))
+}
+
+// PresenceSet godoc
+//
+//	@Summary		Set the availability of a device
+//	@Description	The status of the device is tracked by the server. The lwt devices go online and offline with
+//	@Description	the payloads received on the topic, usually their last will. The heartbeat devices are online while
+//	@Description	they publish on the topic, or on the topics of their controls without it, within the timeout and the
+//	@Description	retained messages are not counted. The topic is relative to the base path of the device. Every change
+//	@Description	of the status is recorded in the history and published as a DEVICE_ONLINE or DEVICE_OFFLINE event.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path	string						true	"Device UUID"
+//	@Param			data		body	dto.SetAvailabilityRequest	true	"Availability data"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/availability [put]
+func (h *presenceHandler) Set(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	deviceID, err := h.getDeviceID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.SetAvailabilityRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.ps.Set(ctx, h.m.SetDTOToModel(deviceID, userID, body)); err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// PresenceDelete godoc
+//
+//	@Summary		Delete the availability of a device
+//	@Description	Stops the tracking of the device, its status is unknown again while the history is kept.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path	string	true	"Device UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/availability [delete]
+func (h *presenceHandler) Delete(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	deviceID, err := h.getDeviceID(ctx)
+	if err != nil {
+		return
+	}
+
+	if err := h.ps.Delete(ctx, deviceID, userID); err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// PresenceListHistory godoc
+//
+//	@Summary		List the availability history of a device
+//	@Description	Lists the changes of the status of the device, along with what has caused them.
+//	@Tags			Devices
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path		string	true	"Device UUID"
+//	@Param			from		query		string	false	"Changed at or after, RFC 3339"
+//	@Param			to			query		string	false	"Changed before, RFC 3339"
+//	@Param			cursor		query		string	false	"Cursor of the next page, sent in the Link header"
+//	@Param			limit		query		int		false	"Page size"		minimum(1)	maximum(100)	default(50)
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort		query		string	false	"Sort key"		Enums(createdAt)	default(createdAt)
+//	@Success		200			{array}		dto.AvailabilityTransitionResponse
+//	@Header			200			{integer}	X-Total-Count	"Count of all the matching changes"
+//	@Header			200			{string}	Link			"Link to the next page"
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/availability/history [get]
+func (h *presenceHandler) ListHistory(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	deviceID, err := h.getDeviceID(ctx)
+	if err != nil {
+		return
+	}
+
+	query := &dto.AvailabilityTransitionQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filters := h.m.QueryDTOToModel(deviceID, query)
+
+	filters.Page, err = bindPage(ctx)
+	if err != nil {
+		return
+	}
+
+	transitions, err := h.ps.ListTransitions(ctx, userID, filters)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := []*dto.AvailabilityTransitionResponse{}
+	for _, t := range transitions.Items {
+		r = append(r, h.m.TransitionToDTO(t))
+	}
+
+	setPageHeaders(ctx, transitions)
+	ctx.JSON(http.StatusOK, r)
+}
+
+func (h *presenceHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrDeviceNotFound) || errors.Is(err, ae.ErrAvailabilityNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrValidation) || errors.Is(err, ae.ErrInvalidCursor) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *presenceHandler) getDeviceID(ctx *gin.Context) (uuid.UUID, error) {
+	params := &dto.DeviceParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	var deviceID uuid.UUID
+	deviceID, err = uuid.Parse(params.DeviceID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return deviceID, nil
+}
+
+func (h *presenceHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
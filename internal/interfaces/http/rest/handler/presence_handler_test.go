@@ -0,0 +1,129 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func TestDeviceAvailability(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	dID := tt.CreateDevice(a, usr.ID, tt.CreateBroker(a, usr.ID))
+	url := "/api/v1/devices/" + dID.String() + "/availability"
+
+	other := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+
+	t.Run("should return 404 before the availability is set", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 400 without the fields of the mode", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url, strings.NewReader(`{"mode":"lwt","topic":"status"}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"onlinePayload"`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"offlinePayload"`))
+
+		w = tt.MakeRequest(g, "PUT", url, strings.NewReader(`{"mode":"heartbeat"}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"timeoutSeconds"`))
+	})
+
+	t.Run("should return 400 for the same online and offline payloads", func(t *testing.T) {
+		body := `{"mode":"lwt","topic":"status","onlinePayload":"1","offlinePayload":"1"}`
+		w := tt.MakeRequest(g, "PUT", url, strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should set the availability and clear the fields of the other mode", func(t *testing.T) {
+		body := `{"mode":"lwt","topic":"status","onlinePayload":"online","offlinePayload":"offline","timeoutSeconds":60}`
+		w := tt.MakeRequest(g, "PUT", url, strings.NewReader(body), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.GetAvailabilityResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, enum.AvailabilityLWT, r.Mode)
+		assert.Equal(t, "online", *r.OnlinePayload)
+		assert.Equal(t, (*int)(nil), r.TimeoutSeconds)
+	})
+
+	t.Run("should replace the availability", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", url, strings.NewReader(`{"mode":"heartbeat","timeoutSeconds":120}`), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		r := &dto.GetAvailabilityResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, enum.AvailabilityHeartbeat, r.Mode)
+		assert.Equal(t, 120, *r.TimeoutSeconds)
+		assert.Equal(t, (*string)(nil), r.OnlinePayload)
+	})
+
+	t.Run("should return the status of the device", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/devices/"+dID.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.GetDeviceResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, enum.DeviceUnknown, r.Status)
+		assert.Equal(t, true, r.LastSeenAt == nil)
+	})
+
+	t.Run("should return the empty history", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url+"/history", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "0", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should return 404 for a device of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", url, nil, &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+
+		w = tt.MakeRequest(g, "GET", url+"/history", nil, &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should change the ETag of the device with its status", func(t *testing.T) {
+		deviceURL := "/api/v1/devices/" + dID.String()
+
+		w := tt.MakeRequest(g, "GET", deviceURL, nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		etag := w.Header().Get("ETag")
+
+		tt.SetDeviceStatus(dID, enum.DeviceOnline)
+
+		req := httptest.NewRequest("GET", deviceURL, nil)
+		req.Header.Set("Authorization", usr.AccessToken)
+		req.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		g.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+		r := &dto.GetDeviceResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, enum.DeviceOnline, r.Status)
+	})
+
+	t.Run("should delete the availability", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", url, nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "DELETE", url, nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+}
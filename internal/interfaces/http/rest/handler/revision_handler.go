@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RevisionHandler interface {
+	ListBroker(ctx *gin.Context)
+	RestoreBroker(ctx *gin.Context)
+	ListDevice(ctx *gin.Context)
+	RestoreDevice(ctx *gin.Context)
+	ListControl(ctx *gin.Context)
+	RestoreControl(ctx *gin.Context)
+}
+
+type revisionHandler struct {
+	rs application.RevisionService
+	m  mapper.RevisionMapper
+}
+
+func NewRevisionHandler(rs application.RevisionService, m mapper.RevisionMapper) RevisionHandler {
+	return &revisionHandler{rs, m}
+}
+
+// RevisionListBroker godoc
+//
+//	@Summary		List the revisions of a broker
+//	@Description	The revisions are ordered from the latest one, the credentials are not kept in them.
+//	@Tags			Revisions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string	true	"Broker UUID"
+//	@Success		200			{array}		dto.RevisionResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/revisions [get]
+func (h *revisionHandler) ListBroker(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.BrokerParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	revisions, err := h.rs.ListBroker(ctx, userID, uuid.MustParse(params.BrokerID))
+	h.list(ctx, revisions, err)
+}
+
+// RevisionRestoreBroker godoc
+//
+//	@Summary		Restore a broker to a revision
+//	@Description	The settings of the broker are set back to the revision, which is stored as a new revision.
+//	@Tags			Revisions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path	string	true	"Broker UUID"
+//	@Param			revision	path	int		true	"Revision number"
+//	@Param			If-Match	header	string	false	"ETag of the broker the restore is based on"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		409	{object}	errors.HTTPError
+//	@Failure		412	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/revisions/{revision}/restore [post]
+func (h *revisionHandler) RestoreBroker(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.BrokerRevisionParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	version, err := ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.rs.RestoreBroker(ctx, userID, uuid.MustParse(params.BrokerID), params.Revision, version)
+	h.restore(ctx, err)
+}
+
+// RevisionListDevice godoc
+//
+//	@Summary		List the revisions of a device
+//	@Description	The revisions are ordered from the latest one, a device revision holds the definitions of its controls,
+//	@Description	so changing a control makes a new revision of the device too.
+//	@Tags			Revisions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path		string	true	"Device UUID"
+//	@Success		200			{array}		dto.RevisionResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/revisions [get]
+func (h *revisionHandler) ListDevice(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	revisions, err := h.rs.ListDevice(ctx, userID, uuid.MustParse(params.DeviceID))
+	h.list(ctx, revisions, err)
+}
+
+// RevisionRestoreDevice godoc
+//
+//	@Summary		Restore a device to a revision
+//	@Description	The device and its control set are set back to the revision. The controls added since are deleted,
+//	@Description	the deleted ones are restored and the ones purged from the trash are created with a new ID.
+//	@Description	The broker and the room which no longer exist are left unset.
+//	@Tags			Revisions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path	string	true	"Device UUID"
+//	@Param			revision	path	int		true	"Revision number"
+//	@Param			If-Match	header	string	false	"ETag of the device the restore is based on"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		409	{object}	errors.HTTPError
+//	@Failure		412	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/revisions/{revision}/restore [post]
+func (h *revisionHandler) RestoreDevice(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceRevisionParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	version, err := ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.rs.RestoreDevice(ctx, userID, uuid.MustParse(params.DeviceID), params.Revision, version)
+	h.restore(ctx, err)
+}
+
+// RevisionListControl godoc
+//
+//	@Summary		List the revisions of a device control
+//	@Description	The revisions are ordered from the latest one.
+//	@Tags			Revisions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path		string	true	"Device UUID"
+//	@Param			controlId	path		string	true	"Control UUID"
+//	@Success		200			{array}		dto.RevisionResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/controls/{controlId}/revisions [get]
+func (h *revisionHandler) ListControl(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceControlParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	revisions, err := h.rs.ListControl(ctx, userID, uuid.MustParse(params.DeviceID), uuid.MustParse(params.ControlID))
+	h.list(ctx, revisions, err)
+}
+
+// RevisionRestoreControl godoc
+//
+//	@Summary		Restore a device control to a revision
+//	@Description	The definition of the control is set back to the revision, which is stored as a new revision.
+//	@Tags			Revisions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			deviceId	path	string	true	"Device UUID"
+//	@Param			controlId	path	string	true	"Control UUID"
+//	@Param			revision	path	int		true	"Revision number"
+//	@Param			If-Match	header	string	false	"ETag of the control the restore is based on"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		409	{object}	errors.HTTPError
+//	@Failure		412	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/devices/{deviceId}/controls/{controlId}/revisions/{revision}/restore [post]
+func (h *revisionHandler) RestoreControl(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceControlRevisionParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	version, err := ifMatch(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.rs.RestoreControl(ctx, userID, uuid.MustParse(params.DeviceID), uuid.MustParse(params.ControlID), params.Revision, version)
+	h.restore(ctx, err)
+}
+
+func (h *revisionHandler) list(ctx *gin.Context, revisions []*domain.Revision, err error) {
+	if err != nil {
+		if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDeviceNotFound) ||
+			errors.Is(err, ae.ErrDeviceControlNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	r := []*dto.RevisionResponse{}
+	for _, rev := range revisions {
+		r = append(r, h.m.ModelToDTO(rev))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// restore ends the restore request, the revision which can not be applied anymore, like the one whose
+// broker server is taken already, is a conflict.
+func (h *revisionHandler) restore(ctx *gin.Context, err error) {
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDeviceNotFound) ||
+			errors.Is(err, ae.ErrDeviceControlNotFound) || errors.Is(err, ae.ErrRevisionNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, ae.ErrBrokerServerExists) || errors.Is(err, ae.ErrControlStateExists) ||
+			errors.Is(err, ae.ErrBrokerPathInvalid) || errors.Is(err, ae.ErrBrokerPrefixInvalid) || errors.Is(err, ae.ErrBrokerMQTT5Required) ||
+			errors.Is(err, ae.ErrControlTypeUnknown) || errors.Is(err, ae.ErrControlAttributesInvalid) ||
+			errors.Is(err, ae.ErrTopicConflict) {
+			code = http.StatusConflict
+		} else if errors.Is(err, ae.ErrPreconditionFailed) {
+			code = http.StatusPreconditionFailed
+		}
+
+		problem.Abort(ctx, code, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *revisionHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
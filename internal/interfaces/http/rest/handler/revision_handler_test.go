@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func TestRevision(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+
+	revisions := func(url string) []dto.RevisionResponse {
+		w := tt.MakeRequest(g, "GET", url, nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []dto.RevisionResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		return r
+	}
+
+	t.Run("should store a revision with the changed fields", func(t *testing.T) {
+		p := strings.NewReader(`{"name": "renamed-device"}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/devices/%s", dID), p, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		r := revisions(fmt.Sprintf("/api/v1/devices/%s/revisions", dID))
+		assert.Equal(t, 2, len(r))
+		assert.Equal(t, 2, r[0].Revision)
+		assert.Equal(t, 1, len(r[0].Changes))
+		assert.Equal(t, "name", r[0].Changes[0].Field)
+		assert.Equal(t, "test-device", r[0].Changes[0].From)
+		assert.Equal(t, "renamed-device", r[0].Changes[0].To)
+	})
+
+	t.Run("should store a device revision when a control is added", func(t *testing.T) {
+		cID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+		r := revisions(fmt.Sprintf("/api/v1/devices/%s/revisions", dID))
+		assert.Equal(t, 3, len(r))
+		assert.Equal(t, fmt.Sprintf("controls.%s", cID), r[0].Changes[0].Field)
+
+		r = revisions(fmt.Sprintf("/api/v1/devices/%s/controls/%s/revisions", dID, cID))
+		assert.Equal(t, 1, len(r))
+	})
+
+	t.Run("should restore the device with its control set", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/revisions/1/restore", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/controls", dID), nil, &usr.AccessToken)
+		assert.Equal(t, "0", w.Header().Get("X-Total-Count"))
+
+		r := revisions(fmt.Sprintf("/api/v1/devices/%s/revisions", dID))
+		assert.Equal(t, 4, len(r))
+
+		w = tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/revisions/3/restore", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/controls", dID), nil, &usr.AccessToken)
+		assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should restore a broker revision", func(t *testing.T) {
+		p := strings.NewReader(`{"port": 8884}`)
+		w := tt.MakeRequest(g, "PATCH", fmt.Sprintf("/api/v1/brokers/%s", bID), p, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/revisions/1/restore", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		r := revisions(fmt.Sprintf("/api/v1/brokers/%s/revisions", bID))
+		assert.Equal(t, 3, len(r))
+		assert.Equal(t, "port", r[0].Changes[0].Field)
+	})
+
+	t.Run("should return 404 for a missing revision", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/revisions/99/restore", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should return 412 when the device has changed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/devices/%s/revisions/1/restore", dID), nil)
+		req.Header.Set("Authorization", usr.AccessToken)
+		req.Header.Set("If-Match", `"0"`)
+		g.ServeHTTP(w, req)
+		assert.Equal(t, 412, w.Code)
+	})
+}
@@ -0,0 +1,357 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RoomHandler interface {
+	Get(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Reorder(ctx *gin.Context)
+	GetState(ctx *gin.Context)
+}
+
+type roomHandler struct {
+	rs application.RoomService
+	m  mapper.RoomMapper
+}
+
+func NewRoomHandler(rs application.RoomService, m mapper.RoomMapper) RoomHandler {
+	return &roomHandler{rs, m}
+}
+
+// RoomGet godoc
+//
+//	@Summary	Get a single room
+//	@Tags		Rooms
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		roomId	path		string	true	"Room UUID"
+//	@Success	200		{object}	dto.GetRoomResponse
+//	@Failure	400		{object}	errors.HTTPError
+//	@Failure	401		{object}	errors.HTTPError
+//	@Failure	404		{object}	errors.HTTPError
+//	@Failure	500		{object}	errors.HTTPError
+//	@Router		/rooms/{roomId} [get]
+func (h *roomHandler) Get(ctx *gin.Context) {
+	var err error
+	var userID, roomID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	roomID, err = h.getRoomID(ctx)
+	if err != nil {
+		return
+	}
+
+	var room *domain.Room
+	room, err = h.rs.Get(ctx, roomID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(room))
+}
+
+// RoomList godoc
+//
+//	@Summary	List rooms in their order
+//	@Tags		Rooms
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		dto.GetRoomResponse
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/rooms [get]
+func (h *roomHandler) List(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	var rooms []*domain.Room
+	rooms, err = h.rs.List(ctx, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	r := []dto.GetRoomResponse{}
+
+	for _, room := range rooms {
+		r = append(r, *h.m.ModelToDTO(room))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// RoomCreate godoc
+//
+//	@Summary		Create a room
+//	@Description	Room names are unique per user regardless of the case.
+//	@Tags			Rooms
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body		dto.CreateRoomRequest	true	"Create data"
+//	@Success		201		{object}	dto.CreateRoomResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		409		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/rooms [post]
+func (h *roomHandler) Create(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.CreateRoomRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var roomID uuid.UUID
+	roomID, err = h.rs.Create(ctx, h.m.CreateDTOToCreateModel(userID, body))
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateRoomResponse{
+		ID: roomID,
+	})
+}
+
+// RoomUpdate godoc
+//
+//	@Summary	Update a room
+//	@Tags		Rooms
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		roomId	path	string					true	"Room UUID"
+//	@Param		data	body	dto.UpdateRoomRequest	true	"Update data"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	409	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/rooms/{roomId} [patch]
+func (h *roomHandler) Update(ctx *gin.Context) {
+	var err error
+	var userID, roomID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	roomID, err = h.getRoomID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.UpdateRoomRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	room := h.m.UpdateDTOToUpdateModel(body)
+	room.ID = roomID
+	room.UserID = userID
+
+	err = h.rs.Update(ctx, room)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RoomDelete godoc
+//
+//	@Summary		Delete a room
+//	@Description	The devices of the room are left without a room.
+//	@Tags			Rooms
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			roomId	path	string	true	"Room UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/rooms/{roomId} [delete]
+func (h *roomHandler) Delete(ctx *gin.Context) {
+	var err error
+	var userID, roomID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	roomID, err = h.getRoomID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.rs.Delete(ctx, roomID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RoomReorder godoc
+//
+//	@Summary		Reorder rooms
+//	@Description	The ids have to list every room of the user exactly once.
+//	@Tags			Rooms
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body	dto.OrderRequest	true	"Room ids in the new order"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/rooms/order [put]
+func (h *roomHandler) Reorder(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.OrderRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.rs.Reorder(ctx, userID, body.IDs)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RoomGetState godoc
+//
+//	@Summary		Get the aggregate state of a room
+//	@Description	Counts the devices and controls of the room. The room is online when the brokers of all its devices
+//	@Description	are online, partial when only some of them are and offline when none of them is.
+//	@Tags			Rooms
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			roomId	path		string	true	"Room UUID"
+//	@Success		200		{object}	dto.GetRoomStateResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		404		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/rooms/{roomId}/state [get]
+func (h *roomHandler) GetState(ctx *gin.Context) {
+	var err error
+	var userID, roomID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	roomID, err = h.getRoomID(ctx)
+	if err != nil {
+		return
+	}
+
+	var state *domain.RoomState
+	state, err = h.rs.GetState(ctx, roomID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.StateModelToDTO(state))
+}
+
+func (h *roomHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrRoomNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrRoomExists) {
+		code = http.StatusConflict
+	} else if errors.Is(err, ae.ErrRoomOrderInvalid) || errors.Is(err, ae.ErrMissingParams) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *roomHandler) getRoomID(ctx *gin.Context) (uuid.UUID, error) {
+	params := &dto.RoomParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	var roomID uuid.UUID
+	roomID, err = uuid.Parse(params.RoomID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return roomID, nil
+}
+
+func (h *roomHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
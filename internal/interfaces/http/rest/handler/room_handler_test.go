@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestRooms(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	dID := tt.CreateDevice(a, usr.ID, tt.CreateBroker(a, usr.ID))
+	tt.CreateDeviceControl(a, usr.ID, dID)
+
+	other := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+
+	create := func(name string) uuid.UUID {
+		w := tt.MakeRequest(g, "POST", "/api/v1/rooms",
+			strings.NewReader(`{"name":"`+name+`","icon":{"name":"home","backgroundColor":"#ffffff"}}`), &usr.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		r := &dto.CreateRoomResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		return r.ID
+	}
+
+	kitchen := create("Kitchen")
+	garage := create("Garage")
+
+	t.Run("should return 409 for a duplicated name", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/rooms",
+			strings.NewReader(`{"name":"kitchen","icon":{"name":"home","backgroundColor":"#ffffff"}}`), &usr.AccessToken)
+		assert.Equal(t, 409, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"ROOM_EXISTS"`))
+	})
+
+	t.Run("should reorder the rooms", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", "/api/v1/rooms/order",
+			strings.NewReader(fmt.Sprintf(`{"ids":["%s","%s"]}`, garage, kitchen)), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/rooms", nil, &usr.AccessToken)
+		r := []dto.GetRoomResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.Equal(t, 2, len(r))
+		assert.Equal(t, garage, r[0].ID)
+		assert.Equal(t, kitchen, r[1].ID)
+	})
+
+	t.Run("should filter the devices by the room", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PATCH", "/api/v1/devices/"+dID.String(),
+			strings.NewReader(`{"roomId":"`+kitchen.String()+`"}`), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices?roomId="+kitchen.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), dID.String()))
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices?roomId="+garage.String(), nil, &usr.AccessToken)
+		assert.Equal(t, false, strings.Contains(w.Body.String(), dID.String()))
+	})
+
+	t.Run("should return the aggregate state of the room", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/rooms/"+kitchen.String()+"/state", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.GetRoomStateResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 1, r.DeviceCount)
+		assert.Equal(t, 1, r.ControlCount)
+	})
+
+	t.Run("should return 400 when the room belongs to another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/rooms",
+			strings.NewReader(`{"name":"Attic","icon":{"name":"home","backgroundColor":"#ffffff"}}`), &other.AccessToken)
+		assert.Equal(t, 201, w.Code)
+
+		r := &dto.CreateRoomResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+
+		w = tt.MakeRequest(g, "PATCH", "/api/v1/devices/"+dID.String(),
+			strings.NewReader(`{"roomId":"`+r.ID.String()+`"}`), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 404 for the room of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/rooms/"+kitchen.String(), nil, &other.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("should leave the devices without a room on delete", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", "/api/v1/rooms/"+kitchen.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices/"+dID.String(), nil, &usr.AccessToken)
+		r := &dto.GetDeviceResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, false, r.RoomID.Valid)
+	})
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SearchHandler interface {
+	Search(ctx *gin.Context)
+}
+
+type searchHandler struct {
+	ss application.SearchService
+	m  mapper.SearchMapper
+}
+
+func NewSearchHandler(ss application.SearchService, m mapper.SearchMapper) SearchHandler {
+	return &searchHandler{ss, m}
+}
+
+// Search godoc
+//
+//	@Summary		Search brokers, devices and controls
+//	@Description	The text mode matches the words of the names, servers, placings, paths and topics, the last word as a prefix.
+//					The results are ranked by relevance, the matches in the highlight are wrapped in <mark> tags.
+//					The topic mode matches the topics of the controls, also prefixed with the base paths of their devices,
+//					with an MQTT topic filter, e.g. home/+/humidity or home/#.
+//	@Tags			Search
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string		true	"Searched text or topic filter"
+//	@Param			mode	query		string		false	"Search mode"			Enums(text, topic)				default(text)
+//	@Param			types	query		[]string	false	"Types of the results"	Enums(broker, device, control)	collectionFormat(multi)
+//	@Param			limit	query		int			false	"Count of the results"	minimum(1)						maximum(50)	default(20)
+//	@Success		200		{array}		dto.SearchResultResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/search [get]
+func (h *searchHandler) Search(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	query := &dto.SearchQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	results, err := h.ss.Search(ctx, h.m.QueryDTOToModel(userID, query))
+	if err != nil {
+		if errors.Is(err, ae.ErrValidation) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	r := []dto.SearchResultResponse{}
+	for _, result := range results {
+		r = append(r, *h.m.ModelToDTO(result))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+func (h *searchHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func TestSearch(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	cID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+	other := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	tt.CreateDevice(a, other.ID, tt.CreateBroker(a, other.ID))
+
+	t.Run("should return the ranked results of all types", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/search?q=test", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"type":"broker"`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"type":"device"`))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"type":"control"`))
+		assert.Equal(t, 3, strings.Count(w.Body.String(), `"id"`))
+	})
+
+	t.Run("should highlight the matches", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/search?q=hom&types=device", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), dID.String()))
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `<mark>Home</mark>`))
+	})
+
+	t.Run("should match the topics with a filter", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/search?mode=topic&q=%23", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), cID.String()))
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/search?mode=topic&q=%2B%2Bnothing", nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"rule":"topic_filter"`))
+	})
+
+	t.Run("should return 400 when the query is missing", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/search", nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+}
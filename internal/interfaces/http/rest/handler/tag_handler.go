@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagHandler interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	SetDeviceTags(ctx *gin.Context)
+	SetControlTags(ctx *gin.Context)
+}
+
+type tagHandler struct {
+	ts application.TagService
+	m  mapper.TagMapper
+}
+
+func NewTagHandler(ts application.TagService, m mapper.TagMapper) TagHandler {
+	return &tagHandler{ts, m}
+}
+
+// TagList godoc
+//
+//	@Summary	List tags
+//	@Tags		Tags
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		dto.GetTagResponse
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/tags [get]
+func (h *tagHandler) List(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	var tags []*domain.Tag
+	tags, err = h.ts.List(ctx, userID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	r := []dto.GetTagResponse{}
+
+	for _, tag := range tags {
+		r = append(r, *h.m.ModelToDTO(tag))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// TagCreate godoc
+//
+//	@Summary		Create a tag
+//	@Description	Tag names are unique per user regardless of the case.
+//	@Tags			Tags
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body		dto.CreateTagRequest	true	"Create data"
+//	@Success		201		{object}	dto.CreateTagResponse
+//	@Failure		400		{object}	errors.HTTPError
+//	@Failure		401		{object}	errors.HTTPError
+//	@Failure		409		{object}	errors.HTTPError
+//	@Failure		500		{object}	errors.HTTPError
+//	@Router			/tags [post]
+func (h *tagHandler) Create(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.CreateTagRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var tagID uuid.UUID
+	tagID, err = h.ts.Create(ctx, h.m.CreateDTOToCreateModel(userID, body))
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.CreateTagResponse{
+		ID: tagID,
+	})
+}
+
+// TagUpdate godoc
+//
+//	@Summary	Update a tag
+//	@Tags		Tags
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		tagId	path	string					true	"Tag UUID"
+//	@Param		data	body	dto.UpdateTagRequest	true	"Update data"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	409	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/tags/{tagId} [patch]
+func (h *tagHandler) Update(ctx *gin.Context) {
+	var err error
+	var userID, tagID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	tagID, err = h.getTagID(ctx)
+	if err != nil {
+		return
+	}
+
+	body := &dto.UpdateTagRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	tag := h.m.UpdateDTOToUpdateModel(body)
+	tag.ID = tagID
+	tag.UserID = userID
+
+	err = h.ts.Update(ctx, tag)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TagDelete godoc
+//
+//	@Summary		Delete a tag
+//	@Description	The tag is removed from all devices and controls.
+//	@Tags			Tags
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tagId	path	string	true	"Tag UUID"
+//	@Success		204
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		404	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/tags/{tagId} [delete]
+func (h *tagHandler) Delete(ctx *gin.Context) {
+	var err error
+	var userID, tagID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	tagID, err = h.getTagID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = h.ts.Delete(ctx, tagID, userID)
+	if err != nil {
+		h.abort(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TagSetDeviceTags godoc
+//
+//	@Summary	Replace the tags of a device
+//	@Tags		Tags
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		deviceId	path	string				true	"Device UUID"
+//	@Param		data		body	dto.SetTagsRequest	true	"Tag ids"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/devices/{deviceId}/tags [put]
+func (h *tagHandler) SetDeviceTags(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	body := &dto.SetTagsRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ts.SetDeviceTags(ctx, userID, uuid.MustParse(params.DeviceID), body.IDs)
+	if err != nil {
+		h.abortSet(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TagSetControlTags godoc
+//
+//	@Summary	Replace the tags of a device control
+//	@Tags		Tags
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		deviceId	path	string				true	"Device UUID"
+//	@Param		controlId	path	string				true	"Control UUID"
+//	@Param		data		body	dto.SetTagsRequest	true	"Tag ids"
+//	@Success	204
+//	@Failure	400	{object}	errors.HTTPError
+//	@Failure	401	{object}	errors.HTTPError
+//	@Failure	404	{object}	errors.HTTPError
+//	@Failure	500	{object}	errors.HTTPError
+//	@Router		/devices/{deviceId}/controls/{controlId}/tags [put]
+func (h *tagHandler) SetControlTags(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.DeviceControlParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	body := &dto.SetTagsRequest{}
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ts.SetControlTags(ctx, userID, uuid.MustParse(params.DeviceID), uuid.MustParse(params.ControlID), body.IDs)
+	if err != nil {
+		h.abortSet(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *tagHandler) abort(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrTagNotFound) {
+		code = http.StatusNotFound
+	} else if errors.Is(err, ae.ErrTagExists) {
+		code = http.StatusConflict
+	} else if errors.Is(err, ae.ErrMissingParams) {
+		code = http.StatusBadRequest
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+// abortSet reports unknown tags in the body as a bad request, the device or control in the path as not found.
+func (h *tagHandler) abortSet(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ae.ErrTagNotFound) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, ae.ErrDeviceNotFound) || errors.Is(err, ae.ErrDeviceControlNotFound) {
+		code = http.StatusNotFound
+	}
+
+	problem.Abort(ctx, code, err)
+}
+
+func (h *tagHandler) getTagID(ctx *gin.Context) (uuid.UUID, error) {
+	params := &dto.TagParams{}
+
+	err := ctx.BindUri(params)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	var tagID uuid.UUID
+	tagID, err = uuid.Parse(params.TagID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return tagID, nil
+}
+
+func (h *tagHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
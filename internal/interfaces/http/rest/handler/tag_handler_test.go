@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestTags(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	dID := tt.CreateDevice(a, usr.ID, tt.CreateBroker(a, usr.ID))
+	cID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+	other := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+
+	create := func(token *string, name string) uuid.UUID {
+		w := tt.MakeRequest(g, "POST", "/api/v1/tags", strings.NewReader(`{"name":"`+name+`","color":"#ff0000"}`), token)
+		assert.Equal(t, 201, w.Code)
+
+		r := &dto.CreateTagResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		return r.ID
+	}
+
+	light := create(&usr.AccessToken, "Light")
+	foreign := create(&other.AccessToken, "Foreign")
+
+	t.Run("should tag the device and filter by the tag", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", "/api/v1/devices/"+dID.String()+"/tags",
+			strings.NewReader(fmt.Sprintf(`{"ids":["%s"]}`, light)), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices?tagId="+light.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), dID.String()))
+	})
+
+	t.Run("should tag the control and filter by the tag", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", "/api/v1/devices/"+dID.String()+"/controls/"+cID.String()+"/tags",
+			strings.NewReader(fmt.Sprintf(`{"ids":["%s"]}`, light)), &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices/"+dID.String()+"/controls?tagId="+light.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), cID.String()))
+	})
+
+	t.Run("should return 400 for the tag of another user", func(t *testing.T) {
+		w := tt.MakeRequest(g, "PUT", "/api/v1/devices/"+dID.String()+"/tags",
+			strings.NewReader(fmt.Sprintf(`{"ids":["%s"]}`, foreign)), &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), `"TAG_NOT_FOUND"`))
+	})
+
+	t.Run("should return 409 for a duplicated name", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/tags", strings.NewReader(`{"name":"LIGHT","color":"#00ff00"}`), &usr.AccessToken)
+		assert.Equal(t, 409, w.Code)
+	})
+
+	t.Run("should untag the device when the tag is deleted", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", "/api/v1/tags/"+light.String(), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", "/api/v1/devices/"+dID.String(), nil, &usr.AccessToken)
+		r := &dto.GetDeviceResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 0, len(r.TagIDs))
+	})
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TopicHandler interface {
+	Lint(ctx *gin.Context)
+}
+
+type topicHandler struct {
+	ts application.TopicService
+	m  mapper.TopicMapper
+}
+
+func NewTopicHandler(ts application.TopicService, m mapper.TopicMapper) TopicHandler {
+	return &topicHandler{ts, m}
+}
+
+// TopicLint godoc
+//
+//	@Summary		Lint the topics of a broker
+//	@Description	Reports the invalid topics, the topics published to by more than one control and the wildcard
+//	@Description	subscriptions receiving the messages of other controls. The topics are the effective ones,
+//	@Description	the base path of the device joined with the topic of the control.
+//	@Tags			Brokers
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			brokerId	path		string	true	"Broker UUID"
+//	@Success		200			{array}		dto.TopicIssueResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/brokers/{brokerId}/topics/lint [get]
+func (h *topicHandler) Lint(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	params := &dto.BrokerParams{}
+	if err := ctx.BindUri(params); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	issues, err := h.ts.Lint(ctx, userID, uuid.MustParse(params.BrokerID))
+	if err != nil {
+		if errors.Is(err, ae.ErrBrokerNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	r := []*dto.TopicIssueResponse{}
+	for _, issue := range issues {
+		r = append(r, h.m.IssueToDTO(issue))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+func (h *topicHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestTopicLint(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+
+	t.Run("should return no issues for distinct topics", func(t *testing.T) {
+		dID := tt.CreateDevice(a, usr.ID, bID)
+		tt.CreateDeviceControl(a, usr.ID, dID)
+
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/topics/lint", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []*dto.TopicIssueResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.Equal(t, 0, len(r))
+	})
+
+	t.Run("should report controls publishing to the same topic", func(t *testing.T) {
+		dID := tt.CreateDevice(a, usr.ID, bID)
+		tt.CreateDeviceControl(a, usr.ID, dID)
+
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/topics/lint", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []*dto.TopicIssueResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		assert.Equal(t, 1, len(r))
+		assert.Equal(t, enum.TopicDuplicate, r[0].Kind)
+		assert.Equal(t, "/nothing", r[0].Topic)
+		assert.Equal(t, 2, len(r[0].Controls))
+	})
+
+	t.Run("should return 404 when the broker does not exist", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/brokers/%s/topics/lint", uuid.New()), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+}
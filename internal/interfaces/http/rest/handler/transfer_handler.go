@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TransferHandler interface {
+	Export(ctx *gin.Context)
+	Import(ctx *gin.Context)
+}
+
+type transferHandler struct {
+	ts application.TransferService
+	m  mapper.TransferMapper
+}
+
+func NewTransferHandler(ts application.TransferService, m mapper.TransferMapper) TransferHandler {
+	return &transferHandler{ts, m}
+}
+
+// TransferExport godoc
+//
+//	@Summary		Export brokers, devices and controls
+//	@Description	Exports the whole configuration of a user as a single document, which can be imported later on.
+//					Broker credentials are included in plain text only when explicitly requested.
+//	@Tags			Transfer
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Produce		application/x-yaml
+//	@Param			format		query		string	false	"Document format"	Enums(json, yaml)
+//	@Param			credentials	query		bool	false	"Include broker credentials"
+//	@Success		200			{object}	dto.TransferDocument
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/export [get]
+func (h *transferHandler) Export(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	query := &dto.ExportQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var transfer *domain.Transfer
+	transfer, err = h.ts.Export(ctx, userID, query.Credentials)
+	if err != nil {
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if query.Format == "" {
+		query.Format = enum.TransferJSON
+	}
+
+	filename := fmt.Sprintf("dashboardx-%s.%s", transfer.ExportedAt.Format(time.DateOnly), query.Format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if query.Format == enum.TransferYAML {
+		ctx.YAML(http.StatusOK, h.m.ModelToDTO(transfer))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ModelToDTO(transfer))
+}
+
+// TransferImport godoc
+//
+//	@Summary		Import brokers, devices and controls
+//	@Description	Imports a document created by the export. Entities already present are matched - brokers by their server,
+//					devices by their name within the broker and controls by their name within the device. The strategy decides
+//					what happens with them: skip leaves them untouched, overwrite updates them and rename creates a copy
+//					with a numbered name. Brokers can not share a server, so rename reuses the existing broker.
+//					The import is made in a single transaction, the failing entity rolls back all of them.
+//					With dryRun the import is made and rolled back, so nothing is saved while the report
+//					and the errors are the ones of the real import.
+//	@Tags			Transfer
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Accept			application/x-yaml
+//	@Produce		json
+//	@Param			dryRun		query		bool					false	"Only report the changes"
+//	@Param			strategy	query		string					false	"Conflict strategy"	Enums(skip, overwrite, rename)
+//	@Param			data		body		dto.TransferDocument	true	"Configuration document"
+//	@Success		200			{object}	dto.TransferReportResponse
+//	@Failure		400			{object}	errors.HTTPError
+//	@Failure		401			{object}	errors.HTTPError
+//	@Failure		404			{object}	errors.HTTPError
+//	@Failure		409			{object}	errors.HTTPError
+//	@Failure		500			{object}	errors.HTTPError
+//	@Router			/import [post]
+func (h *transferHandler) Import(ctx *gin.Context) {
+	var err error
+	var userID uuid.UUID
+
+	userID, err = h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	query := &dto.ImportQuery{}
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	body := &dto.TransferDocument{}
+	if strings.Contains(ctx.ContentType(), "yaml") {
+		err = ctx.ShouldBindYAML(body)
+	} else {
+		err = ctx.ShouldBindJSON(body)
+	}
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	options := &domain.TransferOptions{
+		DryRun:   query.DryRun,
+		Strategy: query.Strategy,
+	}
+	if options.Strategy == "" {
+		options.Strategy = enum.TransferSkip
+	}
+
+	var report *domain.TransferReport
+	report, err = h.ts.Import(ctx, userID, h.m.DTOToModel(body), options)
+	if err != nil {
+		if errors.Is(err, ae.ErrValidation) ||
+			errors.Is(err, ae.ErrTransferVersion) ||
+			errors.Is(err, ae.ErrTransferRefNotFound) ||
+			errors.Is(err, ae.ErrTransferRefDuplicated) ||
+			errors.Is(err, ae.ErrControlTypeUnknown) ||
+			errors.Is(err, ae.ErrControlAttributesInvalid) {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if errors.Is(err, ae.ErrBrokerNotFound) || errors.Is(err, ae.ErrDeviceNotFound) {
+			problem.Abort(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, ae.ErrBrokerServerExists) || errors.Is(err, ae.ErrControlStateExists) ||
+			errors.Is(err, ae.ErrTopicConflict) {
+			problem.Abort(ctx, http.StatusConflict, err)
+			return
+		}
+
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.m.ReportModelToDTO(report))
+}
+
+func (h *transferHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
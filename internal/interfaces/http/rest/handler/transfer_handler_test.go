@@ -0,0 +1,176 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func TestExport(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	tt.CreateDeviceControl(a, usr.ID, dID)
+
+	t.Run("should return 200 and the document without credentials", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/export", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.TransferDocument{}
+		json.Unmarshal(w.Body.Bytes(), r)
+
+		assert.Equal(t, 1, r.Version)
+		assert.Equal(t, 1, len(r.Brokers))
+		assert.Equal(t, bID.String(), r.Brokers[0].Ref)
+		assert.Equal(t, true, r.Brokers[0].Credentials == nil)
+		assert.Equal(t, 1, len(r.Devices))
+		assert.Equal(t, bID.String(), *r.Devices[0].BrokerRef)
+		assert.Equal(t, 1, len(r.Devices[0].Controls))
+	})
+
+	t.Run("should return 200 and the document with credentials", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/export?credentials=true", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.TransferDocument{}
+		json.Unmarshal(w.Body.Bytes(), r)
+
+		assert.Equal(t, "user-test", *r.Brokers[0].Credentials.Username)
+		assert.Equal(t, "secret-password", *r.Brokers[0].Credentials.Password)
+	})
+
+	t.Run("should return 200 and the document as yaml", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/export?format=yaml", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, true, strings.HasPrefix(w.Body.String(), "version: 1"))
+	})
+
+	t.Run("should return 400 when format is not supported", func(t *testing.T) {
+		w := tt.MakeRequest(g, "GET", "/api/v1/export?format=xml", nil, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+}
+
+func TestImport(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	usr2 := tt.CreateUser(a, "user2", "test123", "user2@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	tt.CreateDeviceControl(a, usr.ID, dID)
+
+	w := tt.MakeRequest(g, "GET", "/api/v1/export?credentials=true", nil, &usr.AccessToken)
+	document := w.Body.String()
+
+	countActions := func(r *dto.TransferReportResponse, action enum.TransferAction) int {
+		c := 0
+		for _, i := range r.Items {
+			if i.Action == action {
+				c++
+			}
+		}
+		return c
+	}
+
+	t.Run("should return 200 and not save anything when dry run is set", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/import?dryRun=true", strings.NewReader(document), &usr2.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.TransferReportResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 3, countActions(r, enum.TransferCreateAction))
+
+		w2 := tt.MakeRequest(g, "GET", "/api/v1/brokers", nil, &usr2.AccessToken)
+		assert.Equal(t, "[]", w2.Body.String())
+	})
+
+	t.Run("should return 409 and not save anything when two brokers share a server", func(t *testing.T) {
+		d := &dto.TransferDocument{}
+		json.Unmarshal([]byte(document), d)
+
+		b := *d.Brokers[0]
+		b.Ref = "copy"
+		d.Brokers = append(d.Brokers, &b)
+		body, _ := json.Marshal(d)
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/import?dryRun=true", strings.NewReader(string(body)), &usr2.AccessToken)
+		assert.Equal(t, 409, w.Code)
+
+		w = tt.MakeRequest(g, "POST", "/api/v1/import", strings.NewReader(string(body)), &usr2.AccessToken)
+		assert.Equal(t, 409, w.Code)
+
+		w2 := tt.MakeRequest(g, "GET", "/api/v1/brokers", nil, &usr2.AccessToken)
+		assert.Equal(t, "[]", w2.Body.String())
+	})
+
+	t.Run("should return 200 when the document has been imported", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/import", strings.NewReader(document), &usr2.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.TransferReportResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 3, countActions(r, enum.TransferCreateAction))
+	})
+
+	t.Run("should return 200 and skip entities which already exist", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/import?strategy=skip", strings.NewReader(document), &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.TransferReportResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 3, countActions(r, enum.TransferSkipAction))
+	})
+
+	t.Run("should return 200 and rename devices which already exist", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", "/api/v1/import?strategy=rename", strings.NewReader(document), &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := &dto.TransferReportResponse{}
+		json.Unmarshal(w.Body.Bytes(), r)
+		assert.Equal(t, 1, countActions(r, enum.TransferRenameAction))
+		assert.Equal(t, "test-device (2)", r.Items[1].Name)
+	})
+
+	t.Run("should return 400 when version is not supported", func(t *testing.T) {
+		p := strings.NewReader(`{"version": 99, "brokers": [], "devices": []}`)
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/import", p, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("should return 400 when device references an unknown broker", func(t *testing.T) {
+		p := strings.NewReader(`
+			{
+				"version": 1,
+				"brokers": [],
+				"devices": [
+					{
+						"ref": "device",
+						"brokerRef": "unknown",
+						"name": "Device",
+						"icon": {
+							"name": "Home",
+							"backgroundColor": "#ff00ff"
+						},
+						"controls": []
+					}
+				]
+			}
+		`)
+
+		w := tt.MakeRequest(g, "POST", "/api/v1/import", p, &usr.AccessToken)
+		assert.Equal(t, 400, w.Code)
+	})
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TrashHandler interface {
+	List(ctx *gin.Context)
+}
+
+type trashHandler struct {
+	ts application.TrashService
+	m  mapper.TrashMapper
+}
+
+func NewTrashHandler(ts application.TrashService, m mapper.TrashMapper) TrashHandler {
+	return &trashHandler{ts, m}
+}
+
+// TrashList godoc
+//
+//	@Summary		List the deleted brokers, devices and controls
+//	@Description	The entries are ordered from the most recently deleted, they are purged for good at purgeAt.
+//	@Description	The dependents of a broker are the devices detached from it and the dependents of a device are
+//	@Description	the controls deleted along with it, restoring the entry restores its dependents too.
+//	@Tags			Trash
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		dto.TrashEntryResponse
+//	@Failure		400	{object}	errors.HTTPError
+//	@Failure		401	{object}	errors.HTTPError
+//	@Failure		500	{object}	errors.HTTPError
+//	@Router			/trash [get]
+func (h *trashHandler) List(ctx *gin.Context) {
+	userID, err := h.getUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	entries, err := h.ts.List(ctx, userID)
+	if err != nil {
+		problem.Abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	r := []*dto.TrashEntryResponse{}
+	for _, entry := range entries {
+		r = append(r, h.m.ModelToDTO(entry))
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+func (h *trashHandler) getUserID(ctx *gin.Context) (uuid.UUID, error) {
+	userID, err := uuid.Parse(ctx.MustGet("UserID").(string))
+	if err != nil {
+		problem.Abort(ctx, http.StatusBadRequest, err)
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
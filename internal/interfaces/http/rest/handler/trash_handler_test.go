@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
+	"github.com/Deve-Lite/DashboardX-API/test"
+	"github.com/go-playground/assert"
+)
+
+func TestTrash(t *testing.T) {
+	tt := test.NewTest()
+	defer tt.Teardown()
+	g, a := tt.SetupApp()
+
+	usr := tt.CreateUser(a, "user1", "test123", "user1@user.com")
+	bID := tt.CreateBroker(a, usr.ID)
+	dID := tt.CreateDevice(a, usr.ID, bID)
+	cID := tt.CreateDeviceControl(a, usr.ID, dID)
+
+	trash := func() []dto.TrashEntryResponse {
+		w := tt.MakeRequest(g, "GET", "/api/v1/trash", nil, &usr.AccessToken)
+		assert.Equal(t, 200, w.Code)
+
+		r := []dto.TrashEntryResponse{}
+		json.Unmarshal(w.Body.Bytes(), &r)
+		return r
+	}
+
+	t.Run("should restore a device with its controls", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", fmt.Sprintf("/api/v1/devices/%s", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+
+		r := trash()
+		assert.Equal(t, 1, len(r))
+		assert.Equal(t, dID, r[0].ID)
+		assert.Equal(t, cID, r[0].Dependents[0].ID)
+
+		w = tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/restore", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/controls", dID), nil, &usr.AccessToken)
+		assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, 0, len(trash()))
+	})
+
+	t.Run("should attach the devices back to the restored broker", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", fmt.Sprintf("/api/v1/brokers/%s", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices?brokerId=%s", bID), nil, &usr.AccessToken)
+		assert.Equal(t, "0", w.Header().Get("X-Total-Count"))
+
+		r := trash()
+		assert.Equal(t, 1, len(r))
+		assert.Equal(t, dID, r[0].Dependents[0].ID)
+
+		w = tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/restore", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices?brokerId=%s", bID), nil, &usr.AccessToken)
+		assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should not restore a control of a deleted device", func(t *testing.T) {
+		w := tt.MakeRequest(g, "DELETE", fmt.Sprintf("/api/v1/devices/%s/controls/%s", dID, cID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "DELETE", fmt.Sprintf("/api/v1/devices/%s", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/controls/%s/restore", dID, cID), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+
+		w = tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/restore", dID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+
+		w = tt.MakeRequest(g, "GET", fmt.Sprintf("/api/v1/devices/%s/controls", dID), nil, &usr.AccessToken)
+		assert.Equal(t, "0", w.Header().Get("X-Total-Count"))
+
+		w = tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/devices/%s/controls/%s/restore", dID, cID), nil, &usr.AccessToken)
+		assert.Equal(t, 204, w.Code)
+	})
+
+	t.Run("should return 404 for an entity not in the trash", func(t *testing.T) {
+		w := tt.MakeRequest(g, "POST", fmt.Sprintf("/api/v1/brokers/%s/restore", bID), nil, &usr.AccessToken)
+		assert.Equal(t, 404, w.Code)
+	})
+}
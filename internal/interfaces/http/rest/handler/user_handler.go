@@ -11,6 +11,7 @@ import (
 	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/mapper"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
 	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/gin-gonic/gin"
@@ -59,7 +60,7 @@ func NewUserHandler(c *config.Config, us application.UserService, m mapper.UserM
 func (h *userHandler) Register(ctx *gin.Context) {
 	body := &dto.CreateUserRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -72,7 +73,7 @@ func (h *userHandler) Register(ctx *gin.Context) {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
 		return
 	}
 
@@ -110,7 +111,7 @@ func (h *userHandler) ConfirmAccount(ctx *gin.Context) {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
 		return
 	}
 
@@ -133,7 +134,7 @@ func (h *userHandler) ConfirmAccount(ctx *gin.Context) {
 func (h *userHandler) ResendConfirmAccount(ctx *gin.Context) {
 	body := &dto.UserEmailRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -145,7 +146,7 @@ func (h *userHandler) ResendConfirmAccount(ctx *gin.Context) {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
 		return
 	}
 
@@ -168,7 +169,7 @@ func (h *userHandler) ResendConfirmAccount(ctx *gin.Context) {
 func (h *userHandler) Login(ctx *gin.Context) {
 	body := &dto.LoginUserRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -183,7 +184,7 @@ func (h *userHandler) Login(ctx *gin.Context) {
 			code = http.StatusConflict
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
 		return
 	}
 
@@ -212,7 +213,7 @@ func (h *userHandler) Logout(ctx *gin.Context) {
 	if ecID != "" {
 		channelID, err = uuid.Parse(ecID)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(ae.ErrUnexpected))
+			problem.Abort(ctx, http.StatusInternalServerError, ae.ErrUnexpected)
 			return
 		}
 	}
@@ -246,7 +247,7 @@ func (h *userHandler) Tokens(ctx *gin.Context) {
 
 	tokens, err := h.us.GetTokens(ctx, userID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -291,11 +292,11 @@ func (h *userHandler) Get(ctx *gin.Context) {
 	user, err = h.us.Get(ctx, userID)
 	if err != nil {
 		if errors.Is(err, ae.ErrUserNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -325,7 +326,7 @@ func (h *userHandler) Update(ctx *gin.Context) {
 
 	body := &dto.UpdateUserRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -341,7 +342,7 @@ func (h *userHandler) Update(ctx *gin.Context) {
 			code = http.StatusInternalServerError
 		}
 
-		ctx.AbortWithStatusJSON(code, ae.NewHTTPError(err))
+		problem.Abort(ctx, code, err)
 		return
 	}
 
@@ -370,24 +371,24 @@ func (h *userHandler) Delete(ctx *gin.Context) {
 
 	body := &dto.DeleteUserRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	err = h.us.Verify(ctx, userID, body.Password)
 	if err != nil {
 		if errors.Is(err, ae.ErrInvalidPassword) {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	err = h.us.Delete(ctx, userID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -416,18 +417,18 @@ func (h *userHandler) ChangePassword(ctx *gin.Context) {
 
 	body := &dto.ChangeUserPasswordRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	err = h.us.Verify(ctx, userID, body.Password)
 	if err != nil {
 		if errors.Is(err, ae.ErrInvalidPassword) {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -438,7 +439,7 @@ func (h *userHandler) ChangePassword(ctx *gin.Context) {
 
 	err = h.us.Update(ctx, user)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -460,7 +461,7 @@ func (h *userHandler) ChangePassword(ctx *gin.Context) {
 func (h *userHandler) ResetPasswordToken(ctx *gin.Context) {
 	body := &dto.UserEmailRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -470,7 +471,7 @@ func (h *userHandler) ResetPasswordToken(ctx *gin.Context) {
 	if err != nil {
 		// return successful response when user does not exist to do not let scan the API
 		if !errors.Is(err, ae.ErrUserNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return
 		}
 	}
@@ -507,12 +508,12 @@ func (h *userHandler) ResetPasswordChange(ctx *gin.Context) {
 
 	body := &dto.ResetUserPasswordRequest{}
 	if err := ctx.ShouldBindJSON(body); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := h.us.ResetPassword(ctx, subID, body.Password); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(ae.ErrUnexpected))
+		problem.Abort(ctx, http.StatusInternalServerError, ae.ErrUnexpected)
 		return
 	}
 
@@ -530,13 +531,13 @@ func (h *userHandler) resolveSubject(ctx *gin.Context) (uuid.UUID, error) {
 		var err error
 		ID, err = uuid.Parse(v.(string))
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return uuid.Nil, err
 		}
 	}
 
 	if ID == uuid.Nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(ae.ErrUnexpected))
+		problem.Abort(ctx, http.StatusInternalServerError, ae.ErrUnexpected)
 		return uuid.Nil, ae.ErrUnexpected
 	}
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Deve-Lite/DashboardX-API/config"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
 	"github.com/gin-gonic/gin"
 )
@@ -38,5 +39,5 @@ func (i *info) Disabled(ctx *gin.Context) {
 		return
 	}
 
-	ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, ae.NewHTTPError(ae.ErrEndpointDisabled))
+	problem.Abort(ctx, http.StatusServiceUnavailable, ae.ErrEndpointDisabled)
 }
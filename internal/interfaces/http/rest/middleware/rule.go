@@ -9,6 +9,7 @@ import (
 	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/problem"
 	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -40,7 +41,7 @@ func (r *rule) LoggedIn(ctx *gin.Context) {
 
 	claims, err := r.a.VerifyToken(ctx, token, "access")
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -59,7 +60,7 @@ func (r *rule) ValidRefresh(ctx *gin.Context) {
 
 	claims, err := r.a.VerifyToken(ctx, token, "refresh")
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -78,7 +79,7 @@ func (r *rule) ValidConfirm(ctx *gin.Context) {
 
 	claims, err := r.a.VerifyConfirmToken(ctx, token)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -97,7 +98,7 @@ func (r *rule) ValidReset(ctx *gin.Context) {
 
 	claims, err := r.a.VerifyResetToken(ctx, token)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -111,18 +112,18 @@ func (r *rule) ValidReset(ctx *gin.Context) {
 func (r *rule) ValidResetSubject(ctx *gin.Context) {
 	subID, err := uuid.Parse(ctx.GetString("SubID"))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	hashSubID, err := ctx.Cookie(string(enum.ResetPasswordCookie))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusUnauthorized, err)
 		return
 	}
 
 	if err := r.a.VerifyResetPasswordSubject(ctx, subID, hashSubID); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -132,18 +133,18 @@ func (r *rule) ValidResetSubject(ctx *gin.Context) {
 func (r *rule) getToken(ctx *gin.Context) string {
 	bearer := ctx.GetHeader("authorization")
 	if bearer == "" {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(ae.ErrMissingAuthToken))
+		problem.Abort(ctx, http.StatusUnauthorized, ae.ErrMissingAuthToken)
 		return ""
 	}
 
 	parts := strings.Split(bearer, " ")
 	if len(parts) != 2 {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(ae.ErrMissingAuthToken))
+		problem.Abort(ctx, http.StatusUnauthorized, ae.ErrMissingAuthToken)
 		return ""
 	}
 
 	if parts[1] == "" {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, ae.NewHTTPError(ae.ErrMissingAuthToken))
+		problem.Abort(ctx, http.StatusUnauthorized, ae.ErrMissingAuthToken)
 		return ""
 	}
 
@@ -154,7 +155,7 @@ func (r *rule) setUserContext(claims *dto.RESTClaims, ctx *gin.Context) error {
 	var userID uuid.UUID
 	userID, err := uuid.Parse(claims.Subject)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return err
 	}
 
@@ -162,22 +163,23 @@ func (r *rule) setUserContext(claims *dto.RESTClaims, ctx *gin.Context) error {
 	user, err = r.us.Get(ctx, userID)
 	if err != nil {
 		if errors.Is(err, ae.ErrUserNotFound) {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, ae.NewHTTPError(err))
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return err
 		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusInternalServerError, err)
 		return err
 	}
 
 	var JWTID uuid.UUID
 	JWTID, err = uuid.Parse(claims.ID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return err
 	}
 
 	ctx.Set("UserID", user.ID.String())
 	ctx.Set("IsAdmin", user.IsAdmin)
+	ctx.Set("Language", user.Language)
 	ctx.Set("JWTID", JWTID.String())
 
 	return nil
@@ -187,14 +189,14 @@ func (r *rule) setSubjectContext(claims *jwt.RegisteredClaims, ctx *gin.Context)
 	var subID uuid.UUID
 	subID, err := uuid.Parse(claims.Subject)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return err
 	}
 
 	var JWTID uuid.UUID
 	JWTID, err = uuid.Parse(claims.ID)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, ae.NewHTTPError(err))
+		problem.Abort(ctx, http.StatusBadRequest, err)
 		return err
 	}
 
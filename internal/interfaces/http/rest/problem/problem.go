@@ -0,0 +1,20 @@
+package problem
+
+import (
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// Abort ends the request with the error described as RFC 7807 problem details,
+// in the language of the logged in user or the one accepted by the client.
+func Abort(ctx *gin.Context, status int, err error) {
+	p := ae.NewProblem(err, status, Language(ctx))
+	p.Instance = ctx.Request.URL.Path
+
+	ctx.Header("Content-Type", ae.ProblemContentType)
+	ctx.AbortWithStatusJSON(status, p)
+}
+
+func Language(ctx *gin.Context) string {
+	return ae.MatchLanguage(ctx.GetString("Language"), ctx.GetHeader("Accept-Language"))
+}
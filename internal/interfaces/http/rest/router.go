@@ -15,7 +15,25 @@ func NewRouter(
 	uh handler.UserHandler,
 	bh handler.BrokerHandler,
 	dh handler.DeviceHandler,
-	eh handler.EventHandler) {
+	eh handler.EventHandler,
+	th handler.TransferHandler,
+	dsh handler.DiscoveryHandler,
+	ch handler.CertificateHandler,
+	cth handler.ControlTypeHandler,
+	sh handler.SearchHandler,
+	dbh handler.DashboardHandler,
+	rh handler.RoomHandler,
+	tgh handler.TagHandler,
+	gh handler.GroupHandler,
+	bth handler.BatchHandler,
+	trh handler.TrashHandler,
+	rvh handler.RevisionHandler,
+	clh handler.CloneHandler,
+	tph handler.TopicHandler,
+	exh handler.ExploreHandler,
+	msh handler.MessageHandler,
+	alh handler.AlertHandler,
+	psh handler.PresenceHandler) {
 	r := g.Group("/api/v1")
 
 	// User API
@@ -41,11 +59,28 @@ func NewRouter(
 	bg := r.Group("brokers")
 	bg.GET("", mr.LoggedIn, bh.List)
 	bg.POST("", mr.LoggedIn, bh.Create)
+	bg.POST("/test", mr.LoggedIn, bh.TestSettings)
 	bg.GET("/:brokerId", mr.LoggedIn, bh.Get)
 	bg.PATCH("/:brokerId", mr.LoggedIn, bh.Update)
 	bg.DELETE("/:brokerId", mr.LoggedIn, bh.Delete)
+	bg.POST("/:brokerId/restore", mr.LoggedIn, bh.Restore)
+	bg.POST("/:brokerId/clone", mr.LoggedIn, clh.CloneBroker)
+	bg.GET("/:brokerId/revisions", mr.LoggedIn, rvh.ListBroker)
+	bg.POST("/:brokerId/revisions/:revision/restore", mr.LoggedIn, rvh.RestoreBroker)
 	bg.GET("/:brokerId/credentials", mr.LoggedIn, bh.GetCredentials)
 	bg.PUT("/:brokerId/credentials", mr.LoggedIn, bh.SetCredentials)
+	bg.POST("/:brokerId/test", mr.LoggedIn, bh.Test)
+	bg.GET("/:brokerId/topics/lint", mr.LoggedIn, tph.Lint)
+	bg.GET("/:brokerId/explore", mr.LoggedIn, exh.Explore)
+	bg.POST("/:brokerId/explore/controls", mr.LoggedIn, exh.CreateControl)
+	bg.GET("/:brokerId/certificates", mr.LoggedIn, ch.Get)
+	bg.PUT("/:brokerId/certificates/ca", mr.LoggedIn, ch.SetCA)
+	bg.DELETE("/:brokerId/certificates/ca", mr.LoggedIn, ch.DeleteCA)
+	bg.PUT("/:brokerId/certificates/client", mr.LoggedIn, ch.SetClient)
+	bg.DELETE("/:brokerId/certificates/client", mr.LoggedIn, ch.DeleteClient)
+	bg.GET("/:brokerId/discovery", mr.LoggedIn, dsh.List)
+	bg.POST("/:brokerId/discovery/:proposalId/accept", mr.LoggedIn, dsh.Accept)
+	bg.DELETE("/:brokerId/discovery/:proposalId", mr.LoggedIn, dsh.Dismiss)
 
 	// Device API
 	dg := r.Group("devices")
@@ -54,11 +89,101 @@ func NewRouter(
 	dg.GET("/:deviceId", mr.LoggedIn, dh.Get)
 	dg.PATCH("/:deviceId", mr.LoggedIn, dh.Update)
 	dg.DELETE("/:deviceId", mr.LoggedIn, dh.Delete)
+	dg.POST("/:deviceId/restore", mr.LoggedIn, dh.Restore)
+	dg.POST("/:deviceId/clone", mr.LoggedIn, clh.CloneDevice)
+	dg.GET("/:deviceId/revisions", mr.LoggedIn, rvh.ListDevice)
+	dg.POST("/:deviceId/revisions/:revision/restore", mr.LoggedIn, rvh.RestoreDevice)
+	dg.GET("/:deviceId/messages", mr.LoggedIn, msh.List)
+	dg.GET("/:deviceId/messages/export", mr.LoggedIn, msh.Export)
+	dg.GET("/:deviceId/availability", mr.LoggedIn, psh.Get)
+	dg.PUT("/:deviceId/availability", mr.LoggedIn, psh.Set)
+	dg.DELETE("/:deviceId/availability", mr.LoggedIn, psh.Delete)
+	dg.GET("/:deviceId/availability/history", mr.LoggedIn, psh.ListHistory)
 	dg.GET("/:deviceId/controls", mr.LoggedIn, dh.ListControls)
 	dg.POST("/:deviceId/controls", mr.LoggedIn, dh.CreateControl)
 	dg.PATCH("/:deviceId/controls/:controlId", mr.LoggedIn, dh.UpdateControl)
 	dg.DELETE("/:deviceId/controls/:controlId", mr.LoggedIn, dh.DeleteControl)
+	dg.POST("/:deviceId/controls/:controlId/restore", mr.LoggedIn, dh.RestoreControl)
+	dg.POST("/:deviceId/controls/:controlId/render", mr.LoggedIn, dh.RenderControl)
+	dg.GET("/:deviceId/controls/:controlId/revisions", mr.LoggedIn, rvh.ListControl)
+	dg.POST("/:deviceId/controls/:controlId/revisions/:revision/restore", mr.LoggedIn, rvh.RestoreControl)
+	dg.PUT("/:deviceId/tags", mr.LoggedIn, tgh.SetDeviceTags)
+	dg.PUT("/:deviceId/controls/:controlId/tags", mr.LoggedIn, tgh.SetControlTags)
+
+	// Control Type API
+	ctg := r.Group("control-types")
+	ctg.GET("", mr.LoggedIn, cth.List)
+	ctg.GET("/:type", mr.LoggedIn, cth.Get)
+	r.POST("controls/transform/preview", mr.LoggedIn, cth.PreviewTransform)
+
+	// Dashboard API
+	dbg := r.Group("dashboards")
+	dbg.GET("", mr.LoggedIn, dbh.List)
+	dbg.POST("", mr.LoggedIn, dbh.Create)
+	dbg.PUT("/order", mr.LoggedIn, dbh.Reorder)
+	dbg.GET("/:dashboardId", mr.LoggedIn, dbh.Get)
+	dbg.PATCH("/:dashboardId", mr.LoggedIn, dbh.Update)
+	dbg.DELETE("/:dashboardId", mr.LoggedIn, dbh.Delete)
+	dbg.POST("/:dashboardId/tabs", mr.LoggedIn, dbh.CreateTab)
+	dbg.PUT("/:dashboardId/tabs/order", mr.LoggedIn, dbh.ReorderTabs)
+	dbg.PATCH("/:dashboardId/tabs/:tabId", mr.LoggedIn, dbh.UpdateTab)
+	dbg.DELETE("/:dashboardId/tabs/:tabId", mr.LoggedIn, dbh.DeleteTab)
+	dbg.POST("/:dashboardId/tabs/:tabId/widgets", mr.LoggedIn, dbh.CreateWidget)
+	dbg.PUT("/:dashboardId/tabs/:tabId/layout", mr.LoggedIn, dbh.SetLayout)
+	dbg.PATCH("/:dashboardId/tabs/:tabId/widgets/:widgetId", mr.LoggedIn, dbh.UpdateWidget)
+	dbg.DELETE("/:dashboardId/tabs/:tabId/widgets/:widgetId", mr.LoggedIn, dbh.DeleteWidget)
+
+	// Room API
+	rg := r.Group("rooms")
+	rg.GET("", mr.LoggedIn, rh.List)
+	rg.POST("", mr.LoggedIn, rh.Create)
+	rg.PUT("/order", mr.LoggedIn, rh.Reorder)
+	rg.GET("/:roomId", mr.LoggedIn, rh.Get)
+	rg.PATCH("/:roomId", mr.LoggedIn, rh.Update)
+	rg.DELETE("/:roomId", mr.LoggedIn, rh.Delete)
+	rg.GET("/:roomId/state", mr.LoggedIn, rh.GetState)
+
+	// Tag API
+	tg := r.Group("tags")
+	tg.GET("", mr.LoggedIn, tgh.List)
+	tg.POST("", mr.LoggedIn, tgh.Create)
+	tg.PATCH("/:tagId", mr.LoggedIn, tgh.Update)
+	tg.DELETE("/:tagId", mr.LoggedIn, tgh.Delete)
+
+	// Group API
+	gg := r.Group("groups")
+	gg.GET("", mr.LoggedIn, gh.List)
+	gg.POST("", mr.LoggedIn, gh.Create)
+	gg.GET("/:groupId", mr.LoggedIn, gh.Get)
+	gg.PATCH("/:groupId", mr.LoggedIn, gh.Update)
+	gg.DELETE("/:groupId", mr.LoggedIn, gh.Delete)
+	gg.PUT("/:groupId/controls", mr.LoggedIn, gh.SetControls)
+	gg.POST("/:groupId/publish", mr.LoggedIn, gh.Publish)
+	gg.GET("/:groupId/state", mr.LoggedIn, gh.GetState)
+
+	// Alert API
+	alg := r.Group("alerts")
+	alg.GET("", mr.LoggedIn, alh.List)
+	alg.POST("", mr.LoggedIn, alh.Create)
+	alg.GET("/incidents", mr.LoggedIn, alh.ListIncidents)
+	alg.POST("/incidents/:incidentId/acknowledge", mr.LoggedIn, alh.Acknowledge)
+	alg.GET("/:alertId", mr.LoggedIn, alh.Get)
+	alg.PUT("/:alertId", mr.LoggedIn, alh.Update)
+	alg.DELETE("/:alertId", mr.LoggedIn, alh.Delete)
+
+	// Batch API
+	r.POST("batch", mr.LoggedIn, bth.Execute)
+
+	// Trash API
+	r.GET("trash", mr.LoggedIn, trh.List)
+
+	// Search API
+	r.GET("search", mr.LoggedIn, sh.Search)
 
 	// Event API
 	r.GET("events", mr.LoggedIn, eh.Broadcast)
+
+	// Transfer API
+	r.GET("export", mr.LoggedIn, th.Export)
+	r.POST("import", mr.LoggedIn, th.Import)
 }
@@ -0,0 +1,5 @@
+ALTER TABLE "brokers"
+    DROP COLUMN "discovery_mode",
+    DROP COLUMN "discovery_prefix";
+
+DROP TYPE "public"."discovery_mode";
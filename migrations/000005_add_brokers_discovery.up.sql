@@ -0,0 +1,9 @@
+CREATE TYPE "public"."discovery_mode" AS ENUM(
+    'disabled',
+    'propose',
+    'auto'
+);
+
+ALTER TABLE "brokers"
+    ADD COLUMN "discovery_mode" "public"."discovery_mode" NOT NULL DEFAULT 'disabled',
+    ADD COLUMN "discovery_prefix" text NOT NULL DEFAULT 'homeassistant';
@@ -0,0 +1 @@
+DROP TABLE "broker_certificates";
\ No newline at end of file
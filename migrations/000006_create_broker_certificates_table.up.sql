@@ -0,0 +1,13 @@
+CREATE TABLE "broker_certificates" (
+    "broker_id" uuid NOT NULL,
+    "ca_certificate" text,
+    "client_certificate" text,
+    "client_key" text,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "broker_certificates_broker_id_pkey" PRIMARY KEY ("broker_id"),
+    CONSTRAINT "broker_certificates_broker_id_fkey" FOREIGN KEY ("broker_id")
+        REFERENCES "brokers"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
@@ -0,0 +1,10 @@
+ALTER TABLE "brokers"
+    DROP COLUMN "protocol_version",
+    DROP COLUMN "transport",
+    DROP COLUMN "path",
+    DROP COLUMN "clean_start",
+    DROP COLUMN "session_expiry",
+    DROP COLUMN "user_properties";
+
+DROP TYPE "public"."mqtt_transport";
+DROP TYPE "public"."mqtt_version";
@@ -0,0 +1,18 @@
+CREATE TYPE "public"."mqtt_version" AS ENUM(
+    '3.1.1',
+    '5'
+);
+
+CREATE TYPE "public"."mqtt_transport" AS ENUM(
+    'tcp',
+    'ws',
+    'wss'
+);
+
+ALTER TABLE "brokers"
+    ADD COLUMN "protocol_version" "public"."mqtt_version" NOT NULL DEFAULT '3.1.1',
+    ADD COLUMN "transport" "public"."mqtt_transport" NOT NULL DEFAULT 'tcp',
+    ADD COLUMN "path" text,
+    ADD COLUMN "clean_start" boolean NOT NULL DEFAULT true,
+    ADD COLUMN "session_expiry" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "user_properties" jsonb NOT NULL DEFAULT '{}';
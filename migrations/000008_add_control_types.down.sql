@@ -0,0 +1,20 @@
+DELETE FROM "device_controls"
+    WHERE "type"::text IN ('gauge', 'chart', 'json-state', 'number-in', 'multi-switch');
+
+ALTER TYPE "public"."control_type" RENAME TO "control_type_old";
+
+CREATE TYPE "public"."control_type" AS ENUM(
+    'button',
+    'color',
+    'date-time',
+    'radio',
+    'slider',
+    'state',
+    'switch',
+    'text-out'
+);
+
+ALTER TABLE "device_controls"
+    ALTER COLUMN "type" TYPE "public"."control_type" USING "type"::text::"public"."control_type";
+
+DROP TYPE "public"."control_type_old";
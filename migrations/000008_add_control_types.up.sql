@@ -0,0 +1,5 @@
+ALTER TYPE "public"."control_type" ADD VALUE 'gauge';
+ALTER TYPE "public"."control_type" ADD VALUE 'chart';
+ALTER TYPE "public"."control_type" ADD VALUE 'json-state';
+ALTER TYPE "public"."control_type" ADD VALUE 'number-in';
+ALTER TYPE "public"."control_type" ADD VALUE 'multi-switch';
@@ -0,0 +1,24 @@
+DELETE FROM "device_controls"
+    WHERE "type" NOT IN (
+        'button', 'color', 'date-time', 'radio', 'slider', 'state', 'switch', 'text-out',
+        'gauge', 'chart', 'json-state', 'number-in', 'multi-switch'
+    );
+
+CREATE TYPE "public"."control_type" AS ENUM(
+    'button',
+    'color',
+    'date-time',
+    'radio',
+    'slider',
+    'state',
+    'switch',
+    'text-out',
+    'gauge',
+    'chart',
+    'json-state',
+    'number-in',
+    'multi-switch'
+);
+
+ALTER TABLE "device_controls"
+    ALTER COLUMN "type" TYPE "public"."control_type" USING "type"::"public"."control_type";
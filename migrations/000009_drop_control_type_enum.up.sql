@@ -0,0 +1,4 @@
+ALTER TABLE "device_controls"
+    ALTER COLUMN "type" TYPE text USING "type"::text;
+
+DROP TYPE "public"."control_type";
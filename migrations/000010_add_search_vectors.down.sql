@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "device_controls_search_idx";
+
+ALTER TABLE "device_controls" DROP COLUMN IF EXISTS "search";
+
+DROP INDEX IF EXISTS "devices_search_idx";
+
+ALTER TABLE "devices" DROP COLUMN IF EXISTS "search";
+
+DROP INDEX IF EXISTS "brokers_search_idx";
+
+ALTER TABLE "brokers" DROP COLUMN IF EXISTS "search";
@@ -0,0 +1,22 @@
+-- Separators of servers, paths and topics are replaced with spaces, so their parts are searchable as words.
+ALTER TABLE "brokers" ADD COLUMN "search" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', "name"), 'A') ||
+    setweight(to_tsvector('simple', translate("server", './:-_', '     ')), 'B')
+) STORED;
+
+CREATE INDEX "brokers_search_idx" ON "brokers" USING GIN ("search");
+
+ALTER TABLE "devices" ADD COLUMN "search" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', "name"), 'A') ||
+    setweight(to_tsvector('simple', coalesce("placing", '')), 'B') ||
+    setweight(to_tsvector('simple', translate(coalesce("base_path", ''), '/-_', '   ')), 'C')
+) STORED;
+
+CREATE INDEX "devices_search_idx" ON "devices" USING GIN ("search");
+
+ALTER TABLE "device_controls" ADD COLUMN "search" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', "name"), 'A') ||
+    setweight(to_tsvector('simple', translate("topic", '/-_', '   ')), 'B')
+) STORED;
+
+CREATE INDEX "device_controls_search_idx" ON "device_controls" USING GIN ("search");
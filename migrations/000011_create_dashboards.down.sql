@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "dashboard_widgets";
+
+DROP TABLE IF EXISTS "dashboard_tabs";
+
+DROP TABLE IF EXISTS "dashboards";
@@ -0,0 +1,64 @@
+CREATE TABLE "dashboards" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "icon_name" text NOT NULL,
+    "icon_background_color" text NOT NULL,
+    "position" integer NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "dashboards_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "dashboards_user_id_fkey" FOREIGN KEY ("user_id")
+        REFERENCES "users"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "dashboards_user_id_idx" ON "dashboards"("user_id");
+
+CREATE TABLE "dashboard_tabs" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "dashboard_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "position" integer NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "dashboard_tabs_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "dashboard_tabs_dashboard_id_fkey" FOREIGN KEY ("dashboard_id")
+        REFERENCES "dashboards"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "dashboard_tabs_dashboard_id_idx" ON "dashboard_tabs"("dashboard_id");
+
+CREATE TABLE "dashboard_widgets" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "tab_id" uuid NOT NULL,
+    "device_id" uuid NOT NULL,
+    "control_id" uuid,
+    "x" integer NOT NULL,
+    "y" integer NOT NULL,
+    "width" integer NOT NULL,
+    "height" integer NOT NULL,
+    "overrides" jsonb NOT NULL DEFAULT '{}',
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "dashboard_widgets_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "dashboard_widgets_tab_id_fkey" FOREIGN KEY ("tab_id")
+        REFERENCES "dashboard_tabs"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "dashboard_widgets_device_id_fkey" FOREIGN KEY ("device_id")
+        REFERENCES "devices"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "dashboard_widgets_control_id_fkey" FOREIGN KEY ("control_id")
+        REFERENCES "device_controls"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "dashboard_widgets_position_check" CHECK ("x" >= 0 AND "y" >= 0),
+    CONSTRAINT "dashboard_widgets_size_check" CHECK ("width" > 0 AND "height" > 0)
+);
+
+CREATE INDEX "dashboard_widgets_tab_id_idx" ON "dashboard_widgets"("tab_id");
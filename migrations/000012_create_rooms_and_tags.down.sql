@@ -0,0 +1,9 @@
+ALTER TABLE "devices" DROP COLUMN IF EXISTS "room_id";
+
+DROP TABLE IF EXISTS "device_control_tags";
+
+DROP TABLE IF EXISTS "device_tags";
+
+DROP TABLE IF EXISTS "tags";
+
+DROP TABLE IF EXISTS "rooms";
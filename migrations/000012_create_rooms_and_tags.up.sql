@@ -0,0 +1,83 @@
+CREATE TABLE "rooms" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "icon_name" text NOT NULL,
+    "icon_background_color" text NOT NULL,
+    "position" integer NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "rooms_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "rooms_user_id_fkey" FOREIGN KEY ("user_id")
+        REFERENCES "users"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+-- Names differing only in case are the same room.
+CREATE UNIQUE INDEX "rooms_user_id_name_key" ON "rooms"("user_id", lower("name"));
+
+CREATE TABLE "tags" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "color" text NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "tags_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "tags_user_id_fkey" FOREIGN KEY ("user_id")
+        REFERENCES "users"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE UNIQUE INDEX "tags_user_id_name_key" ON "tags"("user_id", lower("name"));
+
+CREATE TABLE "device_tags" (
+    "device_id" uuid NOT NULL,
+    "tag_id" uuid NOT NULL,
+    CONSTRAINT "device_tags_pkey" PRIMARY KEY ("device_id", "tag_id"),
+    CONSTRAINT "device_tags_device_id_fkey" FOREIGN KEY ("device_id")
+        REFERENCES "devices"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "device_tags_tag_id_fkey" FOREIGN KEY ("tag_id")
+        REFERENCES "tags"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "device_tags_tag_id_idx" ON "device_tags"("tag_id");
+
+CREATE TABLE "device_control_tags" (
+    "control_id" uuid NOT NULL,
+    "tag_id" uuid NOT NULL,
+    CONSTRAINT "device_control_tags_pkey" PRIMARY KEY ("control_id", "tag_id"),
+    CONSTRAINT "device_control_tags_control_id_fkey" FOREIGN KEY ("control_id")
+        REFERENCES "device_controls"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "device_control_tags_tag_id_fkey" FOREIGN KEY ("tag_id")
+        REFERENCES "tags"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "device_control_tags_tag_id_idx" ON "device_control_tags"("tag_id");
+
+ALTER TABLE "devices" ADD COLUMN "room_id" uuid
+    CONSTRAINT "devices_room_id_fkey" REFERENCES "rooms"("id") ON DELETE SET NULL ON UPDATE NO ACTION;
+
+CREATE INDEX "devices_room_id_idx" ON "devices"("room_id");
+
+-- Every distinct placing of the user becomes a room, the placings differing only in case or surrounding spaces are merged.
+INSERT INTO "rooms" ("user_id", "name", "icon_name", "icon_background_color", "position")
+SELECT "user_id", MIN(trim("placing")), 'home', '#41bdf5',
+    ROW_NUMBER() OVER (PARTITION BY "user_id" ORDER BY lower(trim("placing"))) - 1
+FROM "devices"
+WHERE trim(coalesce("placing", '')) <> ''
+GROUP BY "user_id", lower(trim("placing"));
+
+UPDATE "devices" SET "room_id" = "rooms"."id"
+FROM "rooms"
+WHERE "rooms"."user_id" = "devices"."user_id" AND lower("rooms"."name") = lower(trim("devices"."placing"));
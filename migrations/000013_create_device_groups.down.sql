@@ -0,0 +1,7 @@
+ALTER TABLE "device_controls" DROP COLUMN IF EXISTS "last_value_at";
+
+ALTER TABLE "device_controls" DROP COLUMN IF EXISTS "last_value";
+
+DROP TABLE IF EXISTS "device_group_controls";
+
+DROP TABLE IF EXISTS "device_groups";
@@ -0,0 +1,37 @@
+CREATE TABLE "device_groups" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "type" text NOT NULL,
+    "icon_name" text NOT NULL,
+    "icon_background_color" text NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "device_groups_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "device_groups_user_id_fkey" FOREIGN KEY ("user_id")
+        REFERENCES "users"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "device_groups_user_id_idx" ON "device_groups"("user_id");
+
+CREATE TABLE "device_group_controls" (
+    "group_id" uuid NOT NULL,
+    "control_id" uuid NOT NULL,
+    CONSTRAINT "device_group_controls_pkey" PRIMARY KEY ("group_id", "control_id"),
+    CONSTRAINT "device_group_controls_group_id_fkey" FOREIGN KEY ("group_id")
+        REFERENCES "device_groups"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "device_group_controls_control_id_fkey" FOREIGN KEY ("control_id")
+        REFERENCES "device_controls"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "device_group_controls_control_id_idx" ON "device_group_controls"("control_id");
+
+-- The last value published to the control, it is the base of the derived group states.
+ALTER TABLE "device_controls" ADD COLUMN "last_value" jsonb;
+ALTER TABLE "device_controls" ADD COLUMN "last_value_at" TIMESTAMP WITH TIME ZONE;
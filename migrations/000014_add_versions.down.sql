@@ -0,0 +1,5 @@
+ALTER TABLE "device_controls" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE "devices" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE "brokers" DROP COLUMN IF EXISTS "version";
@@ -0,0 +1,6 @@
+-- The versions are increased with every update, they are the ETags of the entities.
+ALTER TABLE "brokers" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "devices" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "device_controls" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,23 @@
+DELETE FROM "device_controls" WHERE "deleted_at" IS NOT NULL;
+
+DELETE FROM "devices" WHERE "deleted_at" IS NOT NULL;
+
+DELETE FROM "brokers" WHERE "deleted_at" IS NOT NULL;
+
+DROP INDEX IF EXISTS "device_controls_deleted_at_idx";
+
+DROP INDEX IF EXISTS "devices_deleted_at_idx";
+
+DROP INDEX IF EXISTS "brokers_deleted_at_idx";
+
+ALTER TABLE "device_controls" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE "devices" DROP COLUMN IF EXISTS "deleted_broker_id";
+
+ALTER TABLE "devices" DROP COLUMN IF EXISTS "deleted_at";
+
+DROP INDEX IF EXISTS "brokers_user_id_server_key";
+
+ALTER TABLE "brokers" ADD CONSTRAINT "brokers_user_id_server_key" UNIQUE ("user_id", "server");
+
+ALTER TABLE "brokers" DROP COLUMN IF EXISTS "deleted_at";
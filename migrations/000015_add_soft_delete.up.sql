@@ -0,0 +1,22 @@
+-- The deleted brokers, devices and controls stay in the trash until they are restored or purged.
+ALTER TABLE "brokers" ADD COLUMN "deleted_at" TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE "brokers" DROP CONSTRAINT "brokers_user_id_server_key";
+
+CREATE UNIQUE INDEX "brokers_user_id_server_key" ON "brokers"("user_id", "server") WHERE "deleted_at" IS NULL;
+
+ALTER TABLE "devices" ADD COLUMN "deleted_at" TIMESTAMP WITH TIME ZONE;
+
+-- The broker the device has been detached from by its deletion, the device is attached back when it is restored.
+ALTER TABLE "devices" ADD COLUMN "deleted_broker_id" uuid
+    CONSTRAINT "devices_deleted_broker_id_fkey" REFERENCES "brokers"("id")
+        ON DELETE SET NULL
+        ON UPDATE NO ACTION;
+
+ALTER TABLE "device_controls" ADD COLUMN "deleted_at" TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX "brokers_deleted_at_idx" ON "brokers"("deleted_at") WHERE "deleted_at" IS NOT NULL;
+
+CREATE INDEX "devices_deleted_at_idx" ON "devices"("deleted_at") WHERE "deleted_at" IS NOT NULL;
+
+CREATE INDEX "device_controls_deleted_at_idx" ON "device_controls"("deleted_at") WHERE "deleted_at" IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "device_control_revisions";
+
+DROP TABLE IF EXISTS "device_revisions";
+
+DROP TABLE IF EXISTS "broker_revisions";
@@ -0,0 +1,42 @@
+-- The revisions are the snapshots of the entities taken after every change, numbered from 1 for each entity.
+CREATE TABLE "broker_revisions" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "broker_id" uuid NOT NULL,
+    "revision" integer NOT NULL,
+    "snapshot" jsonb NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "broker_revisions_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "broker_revisions_broker_id_revision_key" UNIQUE ("broker_id", "revision"),
+    CONSTRAINT "broker_revisions_broker_id_fkey" FOREIGN KEY ("broker_id")
+        REFERENCES "brokers"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE TABLE "device_revisions" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "device_id" uuid NOT NULL,
+    "revision" integer NOT NULL,
+    "snapshot" jsonb NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "device_revisions_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "device_revisions_device_id_revision_key" UNIQUE ("device_id", "revision"),
+    CONSTRAINT "device_revisions_device_id_fkey" FOREIGN KEY ("device_id")
+        REFERENCES "devices"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE TABLE "device_control_revisions" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "control_id" uuid NOT NULL,
+    "revision" integer NOT NULL,
+    "snapshot" jsonb NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "device_control_revisions_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "device_control_revisions_control_id_revision_key" UNIQUE ("control_id", "revision"),
+    CONSTRAINT "device_control_revisions_control_id_fkey" FOREIGN KEY ("control_id")
+        REFERENCES "device_controls"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
@@ -0,0 +1,27 @@
+-- The log of the messages received on the topics of the devices and published to them,
+-- it is cut to the retention and to the count of the messages kept for a device.
+CREATE TABLE "device_messages" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "device_id" uuid NOT NULL,
+    "control_id" uuid,
+    "direction" text NOT NULL,
+    "topic" text NOT NULL,
+    "payload" bytea NOT NULL,
+    "truncated" boolean NOT NULL DEFAULT false,
+    "quality_of_service" "public"."qos_level" NOT NULL,
+    "retained" boolean NOT NULL DEFAULT false,
+    "source_type" text,
+    "source_id" uuid,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "device_messages_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "device_messages_device_id_fkey" FOREIGN KEY ("device_id")
+        REFERENCES "devices"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "device_messages_control_id_fkey" FOREIGN KEY ("control_id")
+        REFERENCES "device_controls"("id")
+        ON DELETE SET NULL
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "device_messages_device_id_created_at_idx" ON "device_messages" ("device_id", "created_at");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "alert_incidents";
+DROP TABLE IF EXISTS "alerts";
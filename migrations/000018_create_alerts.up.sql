@@ -0,0 +1,61 @@
+-- The alerts watch the values of the controls, the devices and the brokers of a user,
+-- the columns of the conditions are set according to the kind of the alert.
+CREATE TABLE "alerts" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "kind" text NOT NULL,
+    "broker_id" uuid,
+    "device_id" uuid,
+    "control_id" uuid,
+    "operator" text,
+    "threshold" double precision,
+    "hysteresis" double precision NOT NULL DEFAULT 0,
+    "state" jsonb,
+    "offline_minutes" integer,
+    "cooldown_minutes" integer NOT NULL DEFAULT 0,
+    "notify_email" boolean NOT NULL DEFAULT false,
+    "webhook_url" text,
+    "is_enabled" boolean NOT NULL DEFAULT true,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "alerts_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "alerts_user_id_fkey" FOREIGN KEY ("user_id")
+        REFERENCES "users"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "alerts_broker_id_fkey" FOREIGN KEY ("broker_id")
+        REFERENCES "brokers"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "alerts_device_id_fkey" FOREIGN KEY ("device_id")
+        REFERENCES "devices"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION,
+    CONSTRAINT "alerts_control_id_fkey" FOREIGN KEY ("control_id")
+        REFERENCES "device_controls"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "alerts_control_id_idx" ON "alerts" ("control_id");
+
+CREATE TABLE "alert_incidents" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "alert_id" uuid NOT NULL,
+    "status" text NOT NULL,
+    "value" jsonb,
+    "message" text NOT NULL,
+    "opened_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "acknowledged_at" TIMESTAMP WITH TIME ZONE,
+    "resolved_at" TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT "alert_incidents_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "alert_incidents_alert_id_fkey" FOREIGN KEY ("alert_id")
+        REFERENCES "alerts"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+-- An alert has at most one incident which has not been resolved.
+CREATE UNIQUE INDEX "alert_incidents_alert_id_unresolved_key" ON "alert_incidents" ("alert_id") WHERE "status" <> 'resolved';
+CREATE INDEX "alert_incidents_alert_id_opened_at_idx" ON "alert_incidents" ("alert_id", "opened_at");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "device_availability_transitions";
+DROP TABLE IF EXISTS "device_availability";
+
+ALTER TABLE "devices" DROP COLUMN IF EXISTS "last_seen_at";
+ALTER TABLE "devices" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,35 @@
+-- The presence of the devices, the status is tracked by the server from the last will messages
+-- or the heartbeats of the devices whose availability is configured.
+ALTER TABLE "devices" ADD COLUMN "status" text NOT NULL DEFAULT 'unknown';
+ALTER TABLE "devices" ADD COLUMN "last_seen_at" TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE "device_availability" (
+    "device_id" uuid NOT NULL,
+    "mode" text NOT NULL,
+    "topic" text,
+    "online_payload" text,
+    "offline_payload" text,
+    "timeout_seconds" integer,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "device_availability_device_id_pkey" PRIMARY KEY ("device_id"),
+    CONSTRAINT "device_availability_device_id_fkey" FOREIGN KEY ("device_id")
+        REFERENCES "devices"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE TABLE "device_availability_transitions" (
+    "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+    "device_id" uuid NOT NULL,
+    "status" text NOT NULL,
+    "reason" text NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT "device_availability_transitions_id_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "device_availability_transitions_device_id_fkey" FOREIGN KEY ("device_id")
+        REFERENCES "devices"("id")
+        ON DELETE CASCADE
+        ON UPDATE NO ACTION
+);
+
+CREATE INDEX "device_availability_transitions_device_id_created_at_idx" ON "device_availability_transitions" ("device_id", "created_at");
@@ -0,0 +1,80 @@
+package certs
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNoCertificate = errors.New("no PEM encoded certificate found")
+	ErrExpired       = errors.New("certificate has expired or is not valid yet")
+)
+
+// Parse decodes every PEM block of the data, only CERTIFICATE blocks are accepted.
+func Parse(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.Errorf("certs.Parse: unexpected PEM block %s", block.Type)
+		}
+
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "certs.Parse.ParseCertificate")
+		}
+
+		certificates = append(certificates, c)
+	}
+
+	if len(certificates) == 0 || len(strings.TrimSpace(string(data))) > 0 {
+		return nil, ErrNoCertificate
+	}
+
+	return certificates, nil
+}
+
+// Validate checks that every certificate is valid at the given time.
+func Validate(certificates []*x509.Certificate, at time.Time) error {
+	for _, c := range certificates {
+		if at.Before(c.NotBefore) || at.After(c.NotAfter) {
+			return errors.Wrapf(ErrExpired, "certs.Validate: %s", c.Subject)
+		}
+	}
+
+	return nil
+}
+
+// ValidateKeyPair checks that the private key is a valid PEM key matching the certificate.
+func ValidateKeyPair(certificate, key []byte) error {
+	if _, err := tls.X509KeyPair(certificate, key); err != nil {
+		return errors.Wrap(err, "certs.ValidateKeyPair.X509KeyPair")
+	}
+
+	return nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the certificate as colon separated hex.
+func Fingerprint(c *x509.Certificate) string {
+	sum := sha256.Sum256(c.Raw)
+
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return strings.Join(parts, ":")
+}
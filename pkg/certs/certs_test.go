@@ -0,0 +1,85 @@
+package certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Deve-Lite/DashboardX-API/pkg/certs"
+	"github.com/go-playground/assert"
+)
+
+func generate(t *testing.T, notAfter time.Time) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestParse(t *testing.T) {
+	c1, _ := generate(t, time.Now().Add(time.Hour))
+	c2, _ := generate(t, time.Now().Add(time.Hour))
+
+	t.Run("should parse a bundle", func(t *testing.T) {
+		r, err := certs.Parse(append(c1, c2...))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 2, len(r))
+		assert.Equal(t, "test", r[0].Subject.CommonName)
+	})
+
+	t.Run("should fail on empty input", func(t *testing.T) {
+		_, err := certs.Parse([]byte("not a certificate"))
+		assert.NotEqual(t, nil, err)
+	})
+
+	t.Run("should fail on a private key", func(t *testing.T) {
+		_, k := generate(t, time.Now().Add(time.Hour))
+		_, err := certs.Parse(k)
+		assert.NotEqual(t, nil, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid, _ := generate(t, time.Now().Add(time.Hour))
+	expired, _ := generate(t, time.Now().Add(-time.Minute))
+
+	r, _ := certs.Parse(valid)
+	assert.Equal(t, nil, certs.Validate(r, time.Now()))
+
+	r, _ = certs.Parse(expired)
+	assert.NotEqual(t, nil, certs.Validate(r, time.Now()))
+}
+
+func TestValidateKeyPair(t *testing.T) {
+	c1, k1 := generate(t, time.Now().Add(time.Hour))
+	_, k2 := generate(t, time.Now().Add(time.Hour))
+
+	assert.Equal(t, nil, certs.ValidateKeyPair(c1, k1))
+	assert.NotEqual(t, nil, certs.ValidateKeyPair(c1, k2))
+}
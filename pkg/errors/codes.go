@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+)
+
+type errorCode struct {
+	err  error
+	code string
+}
+
+// codes are the machine readable codes of the errors, they are part of the API and must not change.
+var codes = []errorCode{
+	{ErrBrokerNotFound, "BROKER_NOT_FOUND"},
+	{ErrUserNotFound, "USER_NOT_FOUND"},
+	{ErrDeviceNotFound, "DEVICE_NOT_FOUND"},
+	{ErrDeviceControlNotFound, "DEVICE_CONTROL_NOT_FOUND"},
+	{ErrInvalidPassword, "INVALID_PASSWORD"},
+	{ErrEmailExists, "EMAIL_EXISTS"},
+	{ErrBrokerServerExists, "BROKER_SERVER_EXISTS"},
+	{ErrMissingAuthToken, "MISSING_AUTH_TOKEN"},
+	{ErrControlStateExists, "CONTROL_STATE_EXISTS"},
+	{ErrMissingParams, "MISSING_PARAMS"},
+	{ErrInvalidRefreshToken, "INVALID_REFRESH_TOKEN"},
+	{ErrNoBrokerCredentials, "NO_BROKER_CREDENTIALS"},
+	{ErrUserCreation, "USER_CREATION_FAILED"},
+	{ErrNoAwaitingConfirm, "NO_AWAITING_CONFIRMATION"},
+	{ErrConfirmationRequired, "CONFIRMATION_REQUIRED"},
+	{ErrUnexpected, "UNEXPECTED"},
+	{ErrUnauthorized, "UNAUTHORIZED"},
+	{ErrTokenNotFound, "TOKEN_NOT_FOUND"},
+	{ErrEndpointDisabled, "ENDPOINT_DISABLED"},
+	{ErrTransferVersion, "TRANSFER_VERSION_UNSUPPORTED"},
+	{ErrTransferRefNotFound, "TRANSFER_REF_NOT_FOUND"},
+	{ErrTransferRefDuplicated, "TRANSFER_REF_DUPLICATED"},
+	{ErrDiscoveryProposalNotFound, "DISCOVERY_PROPOSAL_NOT_FOUND"},
+	{ErrDiscoveryUnsupported, "DISCOVERY_UNSUPPORTED"},
+	{ErrBridgeConnection, "BRIDGE_CONNECTION_FAILED"},
+	{ErrBrokerCertificatesNotFound, "BROKER_CERTIFICATES_NOT_FOUND"},
+	{ErrCertificateInvalid, "CERTIFICATE_INVALID"},
+	{ErrCertificateExpired, "CERTIFICATE_EXPIRED"},
+	{ErrCertificateKeyInvalid, "CERTIFICATE_KEY_INVALID"},
+	{ErrBrokerPathInvalid, "BROKER_PATH_INVALID"},
+	{ErrBrokerPrefixInvalid, "BROKER_PREFIX_INVALID"},
+	{ErrBrokerMQTT5Required, "BROKER_MQTT5_REQUIRED"},
+	{ErrControlTypeUnknown, "CONTROL_TYPE_UNKNOWN"},
+	{ErrControlAttributesInvalid, "CONTROL_ATTRIBUTES_INVALID"},
+	{ErrControlValueInvalid, "CONTROL_VALUE_INVALID"},
+	{ErrControlPayloadInvalid, "CONTROL_PAYLOAD_INVALID"},
+	{ErrControlNotWritable, "CONTROL_NOT_WRITABLE"},
+	{ErrControlNotReadable, "CONTROL_NOT_READABLE"},
+	{ErrValidation, "VALIDATION_FAILED"},
+	{ErrMalformedBody, "MALFORMED_BODY"},
+	{ErrInvalidCursor, "INVALID_CURSOR"},
+	{ErrDashboardNotFound, "DASHBOARD_NOT_FOUND"},
+	{ErrDashboardTabNotFound, "DASHBOARD_TAB_NOT_FOUND"},
+	{ErrDashboardWidgetNotFound, "DASHBOARD_WIDGET_NOT_FOUND"},
+	{ErrDashboardOrderInvalid, "DASHBOARD_ORDER_INVALID"},
+	{ErrRoomNotFound, "ROOM_NOT_FOUND"},
+	{ErrRoomExists, "ROOM_EXISTS"},
+	{ErrRoomOrderInvalid, "ROOM_ORDER_INVALID"},
+	{ErrTagNotFound, "TAG_NOT_FOUND"},
+	{ErrTagExists, "TAG_EXISTS"},
+	{ErrGroupNotFound, "GROUP_NOT_FOUND"},
+	{ErrGroupControlIncompatible, "GROUP_CONTROL_INCOMPATIBLE"},
+	{ErrPreconditionFailed, "PRECONDITION_FAILED"},
+	{ErrBatchRefNotFound, "BATCH_REF_NOT_FOUND"},
+	{ErrBatchRefDuplicated, "BATCH_REF_DUPLICATED"},
+	{ErrRevisionNotFound, "REVISION_NOT_FOUND"},
+	{ErrTopicConflict, "TOPIC_CONFLICT"},
+	{ErrDeviceBrokerMismatch, "DEVICE_BROKER_MISMATCH"},
+	{ErrAlertNotFound, "ALERT_NOT_FOUND"},
+	{ErrAlertIncidentNotFound, "ALERT_INCIDENT_NOT_FOUND"},
+	{ErrAlertIncidentResolved, "ALERT_INCIDENT_RESOLVED"},
+	{ErrAvailabilityNotFound, "AVAILABILITY_NOT_FOUND"},
+}
+
+// statusCodes are used for the errors which are not known, based on the response status.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          "BAD_REQUEST",
+	http.StatusUnauthorized:        "UNAUTHORIZED",
+	http.StatusForbidden:           "FORBIDDEN",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusConflict:            "CONFLICT",
+	http.StatusServiceUnavailable:  "SERVICE_UNAVAILABLE",
+	http.StatusInternalServerError: "UNEXPECTED",
+}
+
+// Code returns the code of the known error wrapped by err, it is empty for the unknown ones.
+func Code(err error) string {
+	if e := known(err); e != nil {
+		return e.code
+	}
+
+	return ""
+}
+
+func known(err error) *errorCode {
+	for i := range codes {
+		if errors.Is(err, codes[i].err) {
+			return &codes[i]
+		}
+	}
+
+	return nil
+}
@@ -2,36 +2,92 @@ package errors
 
 import (
 	"errors"
-	"log"
 )
 
 var (
-	ErrBrokerNotFound        = errors.New("broker not found")
-	ErrUserNotFound          = errors.New("user not found")
-	ErrDeviceNotFound        = errors.New("device not found")
-	ErrDeviceControlNotFound = errors.New("device control not found")
-	ErrInvalidPassword       = errors.New("invalid user password")
-	ErrEmailExists           = errors.New("email is already taken")
-	ErrBrokerServerExists    = errors.New("provided server already exists")
-	ErrMissingAuthToken      = errors.New("missing authorization token")
-	ErrControlStateExists    = errors.New("one state control per device is allowed")
-	ErrMissingParams         = errors.New("no valid properties were provided")
-	ErrInvalidRefreshToken   = errors.New("refresh token is invalid")
-	ErrNoBrokerCredentials   = errors.New("broker credentials are not set")
-	ErrUserCreation          = errors.New("could not create a user")
-	ErrNoAwaitingConfirm     = errors.New("account does not await to be confirmed")
-	ErrConfirmationRequired  = errors.New("email has to be verified")
-	ErrUnexpected            = errors.New("something went wrong")
-	ErrUnauthorized          = errors.New("could not authorize a user")
-	ErrTokenNotFound         = errors.New("token no longer applies")
-	ErrEndpointDisabled      = errors.New("the endpoint has been temporarily disabled")
+	ErrBrokerNotFound             = errors.New("broker not found")
+	ErrUserNotFound               = errors.New("user not found")
+	ErrDeviceNotFound             = errors.New("device not found")
+	ErrDeviceControlNotFound      = errors.New("device control not found")
+	ErrInvalidPassword            = errors.New("invalid user password")
+	ErrEmailExists                = errors.New("email is already taken")
+	ErrBrokerServerExists         = errors.New("provided server already exists")
+	ErrMissingAuthToken           = errors.New("missing authorization token")
+	ErrControlStateExists         = errors.New("one state control per device is allowed")
+	ErrMissingParams              = errors.New("no valid properties were provided")
+	ErrInvalidRefreshToken        = errors.New("refresh token is invalid")
+	ErrNoBrokerCredentials        = errors.New("broker credentials are not set")
+	ErrUserCreation               = errors.New("could not create a user")
+	ErrNoAwaitingConfirm          = errors.New("account does not await to be confirmed")
+	ErrConfirmationRequired       = errors.New("email has to be verified")
+	ErrUnexpected                 = errors.New("something went wrong")
+	ErrUnauthorized               = errors.New("could not authorize a user")
+	ErrTokenNotFound              = errors.New("token no longer applies")
+	ErrEndpointDisabled           = errors.New("the endpoint has been temporarily disabled")
+	ErrTransferVersion            = errors.New("unsupported configuration document version")
+	ErrTransferRefNotFound        = errors.New("configuration document references an unknown entity")
+	ErrTransferRefDuplicated      = errors.New("configuration document contains duplicated references")
+	ErrDiscoveryProposalNotFound  = errors.New("discovery proposal not found")
+	ErrDiscoveryUnsupported       = errors.New("discovery config is not supported")
+	ErrBridgeConnection           = errors.New("could not connect to the broker")
+	ErrBrokerCertificatesNotFound = errors.New("broker certificates not found")
+	ErrCertificateInvalid         = errors.New("certificate is not a valid PEM encoded x509 certificate")
+	ErrCertificateExpired         = errors.New("certificate has expired or is not valid yet")
+	ErrCertificateKeyInvalid      = errors.New("private key is not valid or does not match the certificate")
+	ErrBrokerPathInvalid          = errors.New("path has to start with a slash and is allowed for websocket transports only")
+	ErrBrokerPrefixInvalid        = errors.New("discovery prefix has to be a valid topic without wildcards")
+	ErrBrokerMQTT5Required        = errors.New("session expiry and user properties require MQTT 5")
+	ErrControlTypeUnknown         = errors.New("unknown control type")
+	ErrControlAttributesInvalid   = errors.New("control attributes do not match the control type")
+	ErrControlValueInvalid        = errors.New("value is not valid for the control")
+	ErrControlPayloadInvalid      = errors.New("payload does not match the control")
+	ErrControlNotWritable         = errors.New("control does not publish values")
+	ErrControlNotReadable         = errors.New("control does not display values")
+	ErrValidation                 = errors.New("request validation failed")
+	ErrMalformedBody              = errors.New("request body is not valid JSON")
+	ErrInvalidCursor              = errors.New("cursor is not valid for the requested list")
+	ErrDashboardNotFound          = errors.New("dashboard not found")
+	ErrDashboardTabNotFound       = errors.New("dashboard tab not found")
+	ErrDashboardWidgetNotFound    = errors.New("dashboard widget not found")
+	ErrDashboardOrderInvalid      = errors.New("order has to list every item exactly once")
+	ErrRoomNotFound               = errors.New("room not found")
+	ErrRoomOrderInvalid           = errors.New("order has to list every room exactly once")
+	ErrRoomExists                 = errors.New("room with provided name already exists")
+	ErrTagNotFound                = errors.New("tag not found")
+	ErrTagExists                  = errors.New("tag with provided name already exists")
+	ErrGroupNotFound              = errors.New("group not found")
+	ErrGroupControlIncompatible   = errors.New("control type does not match the type of the group")
+	ErrPreconditionFailed         = errors.New("resource has been modified since it was fetched")
+	ErrBatchRefNotFound           = errors.New("operation references an entity not created earlier in the batch")
+	ErrBatchRefDuplicated         = errors.New("batch contains duplicated references")
+	ErrRevisionNotFound           = errors.New("revision not found")
+	ErrTopicConflict              = errors.New("topic conflicts with another control")
+	ErrDeviceBrokerMismatch       = errors.New("device is not connected to the broker")
+	ErrAlertNotFound              = errors.New("alert not found")
+	ErrAlertIncidentNotFound      = errors.New("alert incident not found")
+	ErrAlertIncidentResolved      = errors.New("alert incident has already been resolved")
+	ErrAvailabilityNotFound       = errors.New("device availability not found")
 )
 
-type HTTPError struct {
+// FieldError points at the invalid value of the request, the field is the path of JSON names,
+// e.g. icon.backgroundColor or attributes.series[0].path, and the rule is the failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
 	Message string `json:"message"`
 }
 
-func NewHTTPError(err error) *HTTPError {
-	log.Printf("Error: %s", err.Error())
-	return &HTTPError{Message: err.Error()}
+// ValidationError wraps the error with the invalid fields which caused it.
+type ValidationError struct {
+	Err    error
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
 }
@@ -0,0 +1,185 @@
+package errors
+
+import (
+	"strings"
+)
+
+const (
+	LanguageEnglish = "en"
+	LanguagePolish  = "pl"
+
+	DefaultLanguage = LanguageEnglish
+)
+
+// messages translate the errors by their codes, the English ones are the texts of the errors.
+var messages = map[string]map[string]string{
+	LanguagePolish: {
+		"BROKER_NOT_FOUND":              "nie znaleziono brokera",
+		"USER_NOT_FOUND":                "nie znaleziono użytkownika",
+		"DEVICE_NOT_FOUND":              "nie znaleziono urządzenia",
+		"DEVICE_CONTROL_NOT_FOUND":      "nie znaleziono kontrolki urządzenia",
+		"INVALID_PASSWORD":              "nieprawidłowe hasło użytkownika",
+		"EMAIL_EXISTS":                  "adres email jest już zajęty",
+		"BROKER_SERVER_EXISTS":          "podany serwer już istnieje",
+		"MISSING_AUTH_TOKEN":            "brak tokenu autoryzacji",
+		"CONTROL_STATE_EXISTS":          "urządzenie może mieć tylko jedną kontrolkę stanu",
+		"MISSING_PARAMS":                "nie podano żadnych poprawnych właściwości",
+		"INVALID_REFRESH_TOKEN":         "token odświeżania jest nieprawidłowy",
+		"NO_BROKER_CREDENTIALS":         "dane logowania brokera nie są ustawione",
+		"USER_CREATION_FAILED":          "nie udało się utworzyć użytkownika",
+		"NO_AWAITING_CONFIRMATION":      "konto nie oczekuje na potwierdzenie",
+		"CONFIRMATION_REQUIRED":         "adres email musi zostać zweryfikowany",
+		"UNEXPECTED":                    "coś poszło nie tak",
+		"UNAUTHORIZED":                  "nie udało się autoryzować użytkownika",
+		"TOKEN_NOT_FOUND":               "token jest już nieważny",
+		"ENDPOINT_DISABLED":             "endpoint został tymczasowo wyłączony",
+		"TRANSFER_VERSION_UNSUPPORTED":  "nieobsługiwana wersja dokumentu konfiguracji",
+		"TRANSFER_REF_NOT_FOUND":        "dokument konfiguracji odwołuje się do nieznanego obiektu",
+		"TRANSFER_REF_DUPLICATED":       "dokument konfiguracji zawiera zduplikowane odwołania",
+		"DISCOVERY_PROPOSAL_NOT_FOUND":  "nie znaleziono propozycji wykrywania",
+		"DISCOVERY_UNSUPPORTED":         "konfiguracja wykrywania nie jest obsługiwana",
+		"BRIDGE_CONNECTION_FAILED":      "nie udało się połączyć z brokerem",
+		"BROKER_CERTIFICATES_NOT_FOUND": "nie znaleziono certyfikatów brokera",
+		"CERTIFICATE_INVALID":           "certyfikat nie jest poprawnym certyfikatem x509 w formacie PEM",
+		"CERTIFICATE_EXPIRED":           "certyfikat wygasł lub nie jest jeszcze ważny",
+		"CERTIFICATE_KEY_INVALID":       "klucz prywatny jest nieprawidłowy lub nie pasuje do certyfikatu",
+		"BROKER_PATH_INVALID":           "ścieżka musi zaczynać się od ukośnika i jest dozwolona tylko dla transportu websocket",
+		"BROKER_PREFIX_INVALID":         "prefiks wykrywania musi być poprawnym tematem bez symboli wieloznacznych",
+		"BROKER_MQTT5_REQUIRED":         "wygaśnięcie sesji i właściwości użytkownika wymagają MQTT 5",
+		"CONTROL_TYPE_UNKNOWN":          "nieznany typ kontrolki",
+		"CONTROL_ATTRIBUTES_INVALID":    "atrybuty kontrolki nie pasują do jej typu",
+		"CONTROL_VALUE_INVALID":         "wartość jest nieprawidłowa dla kontrolki",
+		"CONTROL_PAYLOAD_INVALID":       "wiadomość nie pasuje do kontrolki",
+		"CONTROL_NOT_WRITABLE":          "kontrolka nie publikuje wartości",
+		"CONTROL_NOT_READABLE":          "kontrolka nie wyświetla wartości",
+		"VALIDATION_FAILED":             "walidacja żądania nie powiodła się",
+		"MALFORMED_BODY":                "treść żądania nie jest poprawnym JSON",
+		"INVALID_CURSOR":                "kursor nie pasuje do żądanej listy",
+		"DASHBOARD_NOT_FOUND":           "nie znaleziono pulpitu",
+		"DASHBOARD_TAB_NOT_FOUND":       "nie znaleziono zakładki pulpitu",
+		"DASHBOARD_WIDGET_NOT_FOUND":    "nie znaleziono widżetu pulpitu",
+		"DASHBOARD_ORDER_INVALID":       "kolejność musi zawierać każdy element dokładnie raz",
+		"ROOM_NOT_FOUND":                "nie znaleziono pomieszczenia",
+		"ROOM_EXISTS":                   "pomieszczenie o podanej nazwie już istnieje",
+		"ROOM_ORDER_INVALID":            "kolejność musi zawierać każde pomieszczenie dokładnie raz",
+		"TAG_NOT_FOUND":                 "nie znaleziono tagu",
+		"TAG_EXISTS":                    "tag o podanej nazwie już istnieje",
+		"GROUP_NOT_FOUND":               "nie znaleziono grupy",
+		"GROUP_CONTROL_INCOMPATIBLE":    "typ kontrolki nie pasuje do typu grupy",
+		"PRECONDITION_FAILED":           "zasób został zmieniony od czasu jego pobrania",
+		"BATCH_REF_NOT_FOUND":           "operacja odwołuje się do obiektu, który nie został utworzony wcześniej w paczce",
+		"BATCH_REF_DUPLICATED":          "paczka zawiera zduplikowane odwołania",
+		"REVISION_NOT_FOUND":            "nie znaleziono wersji",
+		"TOPIC_CONFLICT":                "temat koliduje z inną kontrolką",
+		"DEVICE_BROKER_MISMATCH":        "urządzenie nie jest połączone z brokerem",
+		"ALERT_NOT_FOUND":               "nie znaleziono alertu",
+		"ALERT_INCIDENT_NOT_FOUND":      "nie znaleziono incydentu alertu",
+		"ALERT_INCIDENT_RESOLVED":       "incydent alertu został już rozwiązany",
+		"AVAILABILITY_NOT_FOUND":        "nie skonfigurowano dostępności urządzenia",
+	},
+}
+
+// ruleMessages describe the failed validation rules, {param} is replaced with the parameter of the rule.
+var ruleMessages = map[string]map[string]string{
+	LanguageEnglish: {
+		"required":             "is required",
+		"required_if":          "is required",
+		"required_unless":      "is required",
+		"requirednullstring":   "is required and can not be empty",
+		"email":                "should be a valid email",
+		"emptyemail":           "should be a valid email or empty",
+		"uuid":                 "should be a valid UUID",
+		"emptyuuid":            "should be a valid UUID or empty",
+		"hexcolor":             "should be a hex color",
+		"emptyhexcolor":        "should be a hex color or empty",
+		"min":                  "should be at least {param}",
+		"min_string":           "should have at least {param} characters",
+		"emptymin":             "should have at least {param} characters or be empty",
+		"max":                  "should be at most {param}",
+		"max_string":           "should have at most {param} characters",
+		"oneof":                "should be one of {param}",
+		"qos_level":            "should be 0, 1 or 2",
+		"type":                 "should be {param}",
+		"enum":                 "should be one of {param}",
+		"format":               "should be a valid {param}",
+		"minLength":            "should have at least {param} characters",
+		"maxLength":            "should have at most {param} characters",
+		"minimum":              "should be greater than or equal to {param}",
+		"maximum":              "should be less than or equal to {param}",
+		"exclusiveMinimum":     "should be greater than {param}",
+		"minItems":             "should have at least {param} items",
+		"maxItems":             "should have at most {param} items",
+		"additionalProperties": "is not allowed",
+		"unique":               "should be unique",
+		"range":                "should be greater than the minimum value",
+		"within":               "should be between the minimum and the maximum value",
+		"topic_filter":         "should be a valid MQTT topic filter",
+		"batchref":             "should be a valid UUID or a reference prefixed with $",
+	},
+	LanguagePolish: {
+		"required":             "jest wymagane",
+		"required_if":          "jest wymagane",
+		"required_unless":      "jest wymagane",
+		"requirednullstring":   "jest wymagane i nie może być puste",
+		"email":                "powinno być poprawnym adresem email",
+		"emptyemail":           "powinno być poprawnym adresem email lub puste",
+		"uuid":                 "powinno być poprawnym UUID",
+		"emptyuuid":            "powinno być poprawnym UUID lub puste",
+		"hexcolor":             "powinno być kolorem w zapisie szesnastkowym",
+		"emptyhexcolor":        "powinno być kolorem w zapisie szesnastkowym lub puste",
+		"min":                  "powinno wynosić co najmniej {param}",
+		"min_string":           "powinno mieć co najmniej {param} znaków",
+		"emptymin":             "powinno mieć co najmniej {param} znaków lub być puste",
+		"max":                  "powinno wynosić co najwyżej {param}",
+		"max_string":           "powinno mieć co najwyżej {param} znaków",
+		"oneof":                "powinno być jednym z {param}",
+		"qos_level":            "powinno wynosić 0, 1 lub 2",
+		"type":                 "powinno być typu {param}",
+		"enum":                 "powinno być jednym z {param}",
+		"format":               "powinno być w formacie {param}",
+		"minLength":            "powinno mieć co najmniej {param} znaków",
+		"maxLength":            "powinno mieć co najwyżej {param} znaków",
+		"minimum":              "powinno być większe lub równe {param}",
+		"maximum":              "powinno być mniejsze lub równe {param}",
+		"exclusiveMinimum":     "powinno być większe niż {param}",
+		"minItems":             "powinno mieć co najmniej {param} elementów",
+		"maxItems":             "powinno mieć co najwyżej {param} elementów",
+		"additionalProperties": "jest niedozwolone",
+		"unique":               "powinno być unikalne",
+		"range":                "powinno być większe niż wartość minimalna",
+		"within":               "powinno mieścić się między wartością minimalną i maksymalną",
+		"topic_filter":         "powinno być poprawnym filtrem tematów MQTT",
+		"batchref":             "powinno być poprawnym UUID lub odwołaniem poprzedzonym znakiem $",
+	},
+}
+
+// MatchLanguage returns the first supported language of the candidates, which may be
+// languages of the users or Accept-Language headers, or the default one.
+func MatchLanguage(candidates ...string) string {
+	for _, c := range candidates {
+		for _, tag := range strings.Split(c, ",") {
+			tag = strings.TrimSpace(strings.SplitN(tag, ";", 2)[0])
+			tag = strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+
+			if _, ok := ruleMessages[tag]; ok {
+				return tag
+			}
+		}
+	}
+
+	return DefaultLanguage
+}
+
+func message(language string, code string) (string, bool) {
+	m, ok := messages[language][code]
+	return m, ok
+}
+
+func ruleMessage(language string, rule string, param string) (string, bool) {
+	m, ok := ruleMessages[language][rule]
+	if !ok {
+		return "", false
+	}
+
+	return strings.ReplaceAll(m, "{param}", param), true
+}
@@ -0,0 +1,159 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// HTTPError is the RFC 7807 problem details of a failed request. It is extended with the stable code of the error,
+// the invalid fields of the request and the message, which repeats the detail for the clients reading it.
+type HTTPError struct {
+	Type     string        `json:"type" example:"about:blank"`
+	Title    string        `json:"title" example:"Bad Request"`
+	Status   int           `json:"status" example:"400"`
+	Detail   string        `json:"detail" example:"request validation failed"`
+	Instance string        `json:"instance,omitempty" example:"/api/v1/brokers"`
+	Code     string        `json:"code" example:"VALIDATION_FAILED"`
+	Message  string        `json:"message" example:"request validation failed"`
+	Errors   []*FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem describes the error in the language, the binding and decoding errors are turned into the invalid fields.
+func NewProblem(err error, status int, language string) *HTTPError {
+	log.Printf("Error: %s", err.Error())
+
+	language = MatchLanguage(language)
+
+	// the messages of the validator errors are described while binding, they depend on the kind of the field
+	var ves validator.ValidationErrors
+	bound := errors.As(err, &ves)
+	err = bindingError(err, language)
+
+	p := &HTTPError{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   Code(err),
+		Detail: localize(err, language),
+	}
+	p.Message = p.Detail
+
+	if p.Code == "" {
+		if p.Code = statusCodes[status]; p.Code == "" {
+			p.Code = "ERROR"
+		}
+	}
+
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		p.Errors = make([]*FieldError, len(ve.Fields))
+		for i, f := range ve.Fields {
+			p.Errors[i] = &FieldError{Field: f.Field, Rule: f.Rule, Param: f.Param, Message: f.Message}
+			if m, ok := ruleMessage(language, f.Rule, f.Param); ok && !bound {
+				p.Errors[i].Message = m
+			}
+		}
+	}
+
+	return p
+}
+
+// bindingError converts the errors returned by gin when the request can not be bound.
+func bindingError(err error, language string) error {
+	var ves validator.ValidationErrors
+	var te *json.UnmarshalTypeError
+	var se *json.SyntaxError
+
+	switch {
+	case errors.As(err, &ves):
+		fields := make([]*FieldError, len(ves))
+		for i, fe := range ves {
+			fields[i] = &FieldError{Field: fieldName(fe.Namespace()), Rule: fe.Tag(), Param: fe.Param()}
+
+			key := fe.Tag()
+			if (key == "min" || key == "max") && fe.Kind() == reflect.String {
+				key += "_string"
+			}
+
+			fields[i].Message, _ = ruleMessage(language, key, fe.Param())
+			if fields[i].Message == "" {
+				fields[i].Message = fe.Error()
+			}
+		}
+
+		return &ValidationError{Err: ErrValidation, Fields: fields}
+	case errors.As(err, &te):
+		return &ValidationError{
+			Err:    ErrValidation,
+			Fields: []*FieldError{{Field: te.Field, Rule: "type", Param: jsonType(te.Type), Message: te.Error()}},
+		}
+	case errors.As(err, &se):
+		return fmt.Errorf("%w: %s", ErrMalformedBody, se.Error())
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrMalformedBody
+	}
+
+	return err
+}
+
+// localize translates the known error, the details appended to it are kept.
+func localize(err error, language string) string {
+	msg := err.Error()
+
+	e := known(err)
+	if e == nil {
+		return msg
+	}
+
+	t, ok := message(language, e.code)
+	if !ok {
+		return msg
+	}
+
+	if s := e.err.Error(); strings.HasPrefix(msg, s) {
+		return t + msg[len(s):]
+	}
+
+	return t
+}
+
+// fieldName drops the name of the request struct from the namespace.
+func fieldName(namespace string) string {
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		return namespace[i+1:]
+	}
+
+	return namespace
+}
+
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+
+	return "object"
+}
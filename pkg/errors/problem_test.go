@@ -0,0 +1,74 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/validate"
+	"github.com/go-playground/assert"
+	"github.com/go-playground/validator/v10"
+)
+
+type request struct {
+	Name  string `json:"name" validate:"required,max=5"`
+	Email string `json:"email" validate:"email"`
+}
+
+func TestNewProblem(t *testing.T) {
+	t.Run("should describe the known errors", func(t *testing.T) {
+		p := ae.NewProblem(ae.ErrBrokerNotFound, http.StatusNotFound, "")
+		assert.Equal(t, "BROKER_NOT_FOUND", p.Code)
+		assert.Equal(t, "Not Found", p.Title)
+		assert.Equal(t, http.StatusNotFound, p.Status)
+		assert.Equal(t, "broker not found", p.Detail)
+		assert.Equal(t, p.Detail, p.Message)
+	})
+
+	t.Run("should use the code of the status for unknown errors", func(t *testing.T) {
+		p := ae.NewProblem(fmt.Errorf("boom"), http.StatusConflict, "")
+		assert.Equal(t, "CONFLICT", p.Code)
+		assert.Equal(t, "boom", p.Detail)
+	})
+
+	t.Run("should translate the errors and keep their details", func(t *testing.T) {
+		p := ae.NewProblem(fmt.Errorf("%w: ref 1", ae.ErrTransferRefNotFound), http.StatusBadRequest, "pl-PL,en;q=0.8")
+		assert.Equal(t, "TRANSFER_REF_NOT_FOUND", p.Code)
+		assert.Equal(t, "dokument konfiguracji odwołuje się do nieznanego obiektu: ref 1", p.Detail)
+	})
+
+	t.Run("should return the invalid fields", func(t *testing.T) {
+		v := validator.New()
+		v.RegisterTagNameFunc(validate.FieldName)
+
+		err := v.Struct(&request{Name: "too long", Email: "test"})
+		p := ae.NewProblem(err, http.StatusBadRequest, "en")
+		assert.Equal(t, "VALIDATION_FAILED", p.Code)
+		assert.Equal(t, 2, len(p.Errors))
+		assert.Equal(t, &ae.FieldError{Field: "name", Rule: "max", Param: "5", Message: "should have at most 5 characters"}, p.Errors[0])
+		assert.Equal(t, &ae.FieldError{Field: "email", Rule: "email", Message: "should be a valid email"}, p.Errors[1])
+
+		p = ae.NewProblem(err, http.StatusBadRequest, "pl")
+		assert.Equal(t, "powinno mieć co najwyżej 5 znaków", p.Errors[0].Message)
+	})
+
+	t.Run("should describe the bodies which can not be decoded", func(t *testing.T) {
+		var r request
+
+		err := json.Unmarshal([]byte(`{"name": 1}`), &r)
+		p := ae.NewProblem(err, http.StatusBadRequest, "en")
+		assert.Equal(t, "VALIDATION_FAILED", p.Code)
+		assert.Equal(t, &ae.FieldError{Field: "name", Rule: "type", Param: "string", Message: "should be string"}, p.Errors[0])
+
+		err = json.Unmarshal([]byte(`{"name"`), &r)
+		assert.Equal(t, "MALFORMED_BODY", ae.NewProblem(err, http.StatusBadRequest, "en").Code)
+	})
+}
+
+func TestMatchLanguage(t *testing.T) {
+	assert.Equal(t, "pl", ae.MatchLanguage("", "de-DE, pl;q=0.9"))
+	assert.Equal(t, "en", ae.MatchLanguage("en-US"))
+	assert.Equal(t, ae.DefaultLanguage, ae.MatchLanguage("de"))
+}
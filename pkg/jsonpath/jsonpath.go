@@ -0,0 +1,138 @@
+// Package jsonpath implements the subset of JSONPath used to pick values out of MQTT payloads:
+// the root "$", member access ".name" or "['name']" and array indexes "[0]" or "[-1]".
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrSyntax   = errors.New("invalid path")
+	ErrNotFound = errors.New("value not found")
+)
+
+type segment struct {
+	key   string
+	index int
+	array bool
+}
+
+// Path is a parsed JSONPath expression.
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// Parse parses the expression, it has to start with the root "$".
+func Parse(s string) (*Path, error) {
+	p := &Path{raw: s, segments: []segment{}}
+
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("%w: %q has to start with $", ErrSyntax, s)
+	}
+
+	for i := 1; i < len(s); {
+		switch s[i] {
+		case '.':
+			j := i + 1
+			for j < len(s) && s[j] != '.' && s[j] != '[' {
+				j++
+			}
+
+			if j == i+1 {
+				return nil, fmt.Errorf("%w: %q has an empty member at %d", ErrSyntax, s, i)
+			}
+
+			p.segments = append(p.segments, segment{key: s[i+1 : j]})
+			i = j
+		case '[':
+			j := strings.IndexByte(s[i:], ']')
+			if j < 0 {
+				return nil, fmt.Errorf("%w: %q has an unclosed bracket at %d", ErrSyntax, s, i)
+			}
+
+			v := s[i+1 : i+j]
+			if len(v) >= 2 && (v[0] == '\'' || v[0] == '"') && v[len(v)-1] == v[0] {
+				p.segments = append(p.segments, segment{key: v[1 : len(v)-1]})
+			} else {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %q has an invalid index %q", ErrSyntax, s, v)
+				}
+
+				p.segments = append(p.segments, segment{index: n, array: true})
+			}
+
+			i += j + 1
+		default:
+			return nil, fmt.Errorf("%w: %q has an unexpected %q at %d", ErrSyntax, s, s[i], i)
+		}
+	}
+
+	return p, nil
+}
+
+// Validate reports whether the expression can be parsed.
+func Validate(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Get walks the decoded JSON value, as produced by encoding/json, along the path.
+func (p *Path) Get(v interface{}) (interface{}, error) {
+	for _, s := range p.segments {
+		if s.array {
+			a, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: %s is not an array", ErrNotFound, p.raw)
+			}
+
+			i := s.index
+			if i < 0 {
+				i += len(a)
+			}
+
+			if i < 0 || i >= len(a) {
+				return nil, fmt.Errorf("%w: %s index %d out of range", ErrNotFound, p.raw, s.index)
+			}
+
+			v = a[i]
+			continue
+		}
+
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not an object", ErrNotFound, p.raw)
+		}
+
+		if v, ok = o[s.key]; !ok {
+			return nil, fmt.Errorf("%w: %s has no member %q", ErrNotFound, p.raw, s.key)
+		}
+	}
+
+	return v, nil
+}
+
+// Lookup decodes the JSON document and returns the value at the path.
+func Lookup(path string, data []byte) (interface{}, error) {
+	p, err := Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, errors.Wrap(err, "jsonpath.Lookup.Unmarshal")
+	}
+
+	return p.Get(v)
+}
@@ -0,0 +1,59 @@
+package jsonpath_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonpath"
+	"github.com/go-playground/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse valid paths", func(t *testing.T) {
+		for _, p := range []string{"$", "$.a", "$.a.b", "$['a b']", "$.a[0]", "$.a[-1].b", `$["a"][2]`} {
+			_, err := jsonpath.Parse(p)
+			assert.Equal(t, nil, err)
+		}
+	})
+
+	t.Run("should reject invalid paths", func(t *testing.T) {
+		for _, p := range []string{"", "a.b", "$.", "$..a", "$[", "$[a]", "$a"} {
+			_, err := jsonpath.Parse(p)
+			assert.Equal(t, true, errors.Is(err, jsonpath.ErrSyntax))
+		}
+	})
+}
+
+func TestLookup(t *testing.T) {
+	doc := []byte(`{"temperature": 21.5, "sensor": {"name": "living room", "values": [1, 2, 3]}, "a b": true}`)
+
+	t.Run("should return the values", func(t *testing.T) {
+		v, err := jsonpath.Lookup("$.temperature", doc)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 21.5, v)
+
+		v, err = jsonpath.Lookup("$.sensor.name", doc)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "living room", v)
+
+		v, err = jsonpath.Lookup("$.sensor.values[-1]", doc)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, float64(3), v)
+
+		v, err = jsonpath.Lookup("$['a b']", doc)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, v)
+	})
+
+	t.Run("should fail on missing values", func(t *testing.T) {
+		for _, p := range []string{"$.humidity", "$.sensor.values[3]", "$.temperature.value", "$.sensor[0]"} {
+			_, err := jsonpath.Lookup(p, doc)
+			assert.Equal(t, true, errors.Is(err, jsonpath.ErrNotFound))
+		}
+	})
+
+	t.Run("should fail on invalid documents", func(t *testing.T) {
+		_, err := jsonpath.Lookup("$.a", []byte("not json"))
+		assert.NotEqual(t, nil, err)
+	})
+}
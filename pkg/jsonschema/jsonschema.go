@@ -0,0 +1,298 @@
+// Package jsonschema implements the subset of JSON Schema used to describe and validate the control attributes:
+// type, enum, the numeric and length bounds, format, properties, required, additionalProperties and items.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+
+	// boolean is set for the true and false schemas.
+	boolean *bool
+}
+
+// Error describes a value which does not match the schema. The path is a JSON Pointer to the value,
+// the field is the same path written as in code, e.g. series[0].path, and the keyword is the failed one.
+type Error struct {
+	Path    string
+	Field   string
+	Keyword string
+	Param   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]func(string) bool{
+		"hexcolor": regexp.MustCompile(`^#(?:[0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`).MatchString,
+	}
+)
+
+// RegisterFormat adds a format checked for strings, unknown formats are not validated.
+func RegisterFormat(name string, f func(string) bool) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	formats[name] = f
+}
+
+// Bool returns the schema which accepts every value or none.
+func Bool(v bool) *Schema {
+	return &Schema{boolean: &v}
+}
+
+func Int(v int) *int {
+	return &v
+}
+
+func Number(v float64) *float64 {
+	return &v
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+
+	type schema Schema
+	return json.Marshal((*schema)(s))
+}
+
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	var v bool
+	if err := json.Unmarshal(b, &v); err == nil {
+		*s = Schema{boolean: &v}
+		return nil
+	}
+
+	type schema Schema
+	return json.Unmarshal(b, (*schema)(s))
+}
+
+// Validate checks the value decoded by encoding/json, values of other Go types are round tripped through JSON first.
+func (s *Schema) Validate(v interface{}) []*Error {
+	v, err := normalize(v)
+	if err != nil {
+		return []*Error{{Keyword: "type", Message: err.Error()}}
+	}
+
+	errs := []*Error{}
+	s.validate("", "", v, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, field string, v interface{}, errs *[]*Error) {
+	fail := func(keyword string, param interface{}, format string, a ...interface{}) {
+		*errs = append(*errs, &Error{
+			Path:    path,
+			Field:   field,
+			Keyword: keyword,
+			Param:   fmt.Sprint(param),
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	if s.boolean != nil {
+		if !*s.boolean {
+			fail("false", "", "is not allowed")
+		}
+		return
+	}
+
+	if s.Type != "" && !hasType(v, s.Type) {
+		fail("type", s.Type, "should be %s", s.Type)
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if reflect.DeepEqual(mustNormalize(e), v) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fail("enum", enumString(s.Enum), "should be one of %s", enumString(s.Enum))
+		}
+	}
+
+	switch e := v.(type) {
+	case string:
+		n := len([]rune(e))
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("minLength", *s.MinLength, "should have at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("maxLength", *s.MaxLength, "should have at most %d characters", *s.MaxLength)
+		}
+
+		if s.Format != "" {
+			formatsMu.RLock()
+			f, ok := formats[s.Format]
+			formatsMu.RUnlock()
+
+			if ok && !f(e) {
+				fail("format", s.Format, "should be a valid %s", s.Format)
+			}
+		}
+	case float64:
+		if s.Minimum != nil && e < *s.Minimum {
+			fail("minimum", *s.Minimum, "should be greater than or equal to %v", *s.Minimum)
+		}
+		if s.Maximum != nil && e > *s.Maximum {
+			fail("maximum", *s.Maximum, "should be less than or equal to %v", *s.Maximum)
+		}
+		if s.ExclusiveMinimum != nil && e <= *s.ExclusiveMinimum {
+			fail("exclusiveMinimum", *s.ExclusiveMinimum, "should be greater than %v", *s.ExclusiveMinimum)
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(e) < *s.MinItems {
+			fail("minItems", *s.MinItems, "should have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(e) > *s.MaxItems {
+			fail("maxItems", *s.MaxItems, "should have at most %d items", *s.MaxItems)
+		}
+
+		if s.Items != nil {
+			for i, item := range e {
+				s.Items.validate(path+"/"+strconv.Itoa(i), fmt.Sprintf("%s[%d]", field, i), item, errs)
+			}
+		}
+	case map[string]interface{}:
+		for _, r := range s.Required {
+			if _, ok := e[r]; !ok {
+				*errs = append(*errs, &Error{
+					Path:    path + "/" + escape(r),
+					Field:   join(field, r),
+					Keyword: "required",
+					Message: "is required",
+				})
+			}
+		}
+
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if p, ok := s.Properties[k]; ok {
+				p.validate(path+"/"+escape(k), join(field, k), e[k], errs)
+			} else if a := s.AdditionalProperties; a != nil && a.boolean != nil && !*a.boolean {
+				*errs = append(*errs, &Error{
+					Path:    path + "/" + escape(k),
+					Field:   join(field, k),
+					Keyword: "additionalProperties",
+					Message: "is not allowed",
+				})
+			} else if a != nil {
+				a.validate(path+"/"+escape(k), join(field, k), e[k], errs)
+			}
+		}
+	}
+}
+
+func hasType(v interface{}, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		n, ok := v.(float64)
+		return ok && n == math.Trunc(n)
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	}
+
+	return false
+}
+
+func normalize(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var r interface{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func mustNormalize(v interface{}) interface{} {
+	r, _ := normalize(v)
+	return r
+}
+
+func enumString(values []interface{}) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		b, _ := json.Marshal(v)
+		s[i] = string(b)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+func join(field string, k string) string {
+	if field == "" {
+		return k
+	}
+
+	return field + "." + k
+}
+
+// escape encodes the key as a JSON Pointer reference token.
+func escape(k string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(k, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,93 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/pkg/jsonschema"
+	"github.com/go-playground/assert"
+)
+
+func paths(errs []*jsonschema.Error) []string {
+	r := []string{}
+	for _, e := range errs {
+		r = append(r, e.Path)
+	}
+
+	return r
+}
+
+func TestValidate(t *testing.T) {
+	s := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"name":  {Type: "string", MinLength: jsonschema.Int(1)},
+			"color": {Type: "string", Format: "hexcolor"},
+			"level": {Type: "integer", Minimum: jsonschema.Number(0), Maximum: jsonschema.Number(10)},
+			"mode":  {Type: "string", Enum: []interface{}{"a", "b"}},
+			"items": {
+				Type:     "array",
+				MinItems: jsonschema.Int(1),
+				Items: &jsonschema.Schema{
+					Type:                 "object",
+					Properties:           map[string]*jsonschema.Schema{"value": {Type: "number"}},
+					Required:             []string{"value"},
+					AdditionalProperties: jsonschema.Bool(false),
+				},
+			},
+		},
+		Required:             []string{"name"},
+		AdditionalProperties: jsonschema.Bool(false),
+	}
+
+	t.Run("should accept a valid value", func(t *testing.T) {
+		errs := s.Validate(map[string]interface{}{
+			"name":  "test",
+			"color": "#ff00ff",
+			"level": 3,
+			"mode":  "b",
+			"items": []map[string]interface{}{{"value": 1.5}},
+		})
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("should return the paths of invalid values", func(t *testing.T) {
+		errs := s.Validate(map[string]interface{}{
+			"color": "red",
+			"level": 3.5,
+			"mode":  "c",
+			"items": []interface{}{map[string]interface{}{"value": "1", "other": true}},
+			"other": 1,
+		})
+		assert.Equal(t, []string{"/name", "/color", "/items/0/other", "/items/0/value", "/level", "/mode", "/other"}, paths(errs))
+		assert.Equal(t, "items[0].other", errs[2].Field)
+		assert.Equal(t, "additionalProperties", errs[2].Keyword)
+		assert.Equal(t, "format", errs[1].Keyword)
+		assert.Equal(t, "hexcolor", errs[1].Param)
+	})
+
+	t.Run("should check the bounds", func(t *testing.T) {
+		errs := s.Validate(map[string]interface{}{"name": "", "level": 11, "items": []interface{}{}})
+		assert.Equal(t, []string{"/items", "/level", "/name"}, paths(errs))
+	})
+
+	t.Run("should check the registered formats", func(t *testing.T) {
+		jsonschema.RegisterFormat("even", func(v string) bool { return len(v)%2 == 0 })
+
+		f := &jsonschema.Schema{Type: "string", Format: "even"}
+		assert.Equal(t, 0, len(f.Validate("ab")))
+		assert.Equal(t, 1, len(f.Validate("abc")))
+	})
+}
+
+func TestMarshal(t *testing.T) {
+	t.Run("should marshal boolean schemas", func(t *testing.T) {
+		b, err := json.Marshal(&jsonschema.Schema{Type: "object", AdditionalProperties: jsonschema.Bool(false)})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"type":"object","additionalProperties":false}`, string(b))
+
+		s := &jsonschema.Schema{}
+		assert.Equal(t, nil, json.Unmarshal(b, s))
+		assert.Equal(t, 1, len(s.Validate(map[string]interface{}{"a": 1})))
+	})
+}
@@ -0,0 +1,242 @@
+package mqtt
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"sync"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/pkg/errors"
+)
+
+// client5 speaks MQTT 5 through autopaho, the clean start, the session expiry
+// and the user properties of the options are sent with every CONNECT.
+type client5 struct {
+	o         *Options
+	h         Handler
+	cm        *autopaho.ConnectionManager
+	connected bool
+	topics    map[string]byte
+	mutex     sync.Mutex
+}
+
+func newClient5(o *Options, h Handler) *client5 {
+	return &client5{o: o, h: h, topics: make(map[string]byte)}
+}
+
+func (c *client5) Connect() error {
+	u, err := url.Parse(c.o.URL())
+	if err != nil {
+		return errors.Wrap(err, "mqtt.client5.Connect.Parse")
+	}
+
+	tc, err := c.o.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	// The first error is kept to be returned instead of the timeout, autopaho retries in the background.
+	errs := make(chan error, 1)
+
+	cfg := autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{u},
+		TlsCfg:                        tc,
+		KeepAlive:                     c.o.KeepAlive,
+		CleanStartOnInitialConnection: c.o.CleanStart,
+		SessionExpiryInterval:         c.o.SessionExpiry,
+		ConnectTimeout:                timeout,
+		ConnectUsername:               c.o.Username,
+		ConnectPassword:               []byte(c.o.Password),
+		ConnectPacketBuilder: func(p *paho.Connect, _ *url.URL) (*paho.Connect, error) {
+			if len(c.o.UserProperties) == 0 {
+				return p, nil
+			}
+
+			if p.Properties == nil {
+				p.Properties = &paho.ConnectProperties{}
+			}
+
+			for k, v := range c.o.UserProperties {
+				p.Properties.User.Add(k, v)
+			}
+
+			return p, nil
+		},
+		OnConnectionUp: func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+			c.setConnected(true)
+			c.resubscribe(cm)
+		},
+		OnConnectError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: c.o.ClientID,
+			OnClientError: func(error) {
+				c.setConnected(false)
+			},
+			OnServerDisconnect: func(*paho.Disconnect) {
+				c.setConnected(false)
+			},
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				func(r paho.PublishReceived) (bool, error) {
+					c.h(r.Packet.Topic, r.Packet.Payload, r.Packet.Retain)
+					return true, nil
+				},
+			},
+		},
+	}
+
+	cm, err := autopaho.NewConnection(context.Background(), cfg)
+	if err != nil {
+		return errors.Wrap(err, "mqtt.client5.Connect.NewConnection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	up := make(chan error, 1)
+	go func() { up <- cm.AwaitConnection(ctx) }()
+
+	select {
+	case err = <-up:
+		if err != nil {
+			err = errors.New("mqtt.client5.Connect: timeout")
+		}
+	case err = <-errs:
+		err = errors.Wrap(err, "mqtt.client5.Connect")
+	}
+
+	if err != nil {
+		cm.Disconnect(context.Background())
+		return err
+	}
+
+	c.mutex.Lock()
+	c.cm = cm
+	c.mutex.Unlock()
+
+	return nil
+}
+
+func (c *client5) Disconnect() {
+	cm := c.manager()
+	if cm == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cm.Disconnect(ctx)
+	c.setConnected(false)
+}
+
+func (c *client5) IsConnected() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.cm != nil && c.connected
+}
+
+func (c *client5) Subscribe(topic string, qos byte) error {
+	c.mutex.Lock()
+	c.topics[topic] = qos
+	c.mutex.Unlock()
+
+	if !c.IsConnected() {
+		return nil
+	}
+
+	return c.subscribe(c.manager(), map[string]byte{topic: qos}, "mqtt.client5.Subscribe")
+}
+
+func (c *client5) Unsubscribe(topics ...string) error {
+	c.mutex.Lock()
+	for _, topic := range topics {
+		delete(c.topics, topic)
+	}
+	c.mutex.Unlock()
+
+	if !c.IsConnected() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err := c.manager().Unsubscribe(ctx, &paho.Unsubscribe{Topics: topics})
+
+	return errors.Wrap(err, "mqtt.client5.Unsubscribe")
+}
+
+func (c *client5) Publish(topic string, qos byte, retained bool, payload []byte) error {
+	cm := c.manager()
+	if cm == nil {
+		return errors.New("mqtt.client5.Publish: not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err := cm.Publish(ctx, &paho.Publish{Topic: topic, QoS: qos, Retain: retained, Payload: payload})
+
+	return errors.Wrap(err, "mqtt.client5.Publish")
+}
+
+func (c *client5) manager() *autopaho.ConnectionManager {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.cm
+}
+
+func (c *client5) setConnected(connected bool) {
+	c.mutex.Lock()
+	c.connected = connected
+	c.mutex.Unlock()
+}
+
+func (c *client5) resubscribe(cm *autopaho.ConnectionManager) {
+	c.mutex.Lock()
+	filters := make(map[string]byte, len(c.topics))
+	for topic, qos := range c.topics {
+		filters[topic] = qos
+	}
+	c.mutex.Unlock()
+
+	if len(filters) == 0 {
+		return
+	}
+
+	if err := c.subscribe(cm, filters, "mqtt.client5.resubscribe"); err != nil {
+		log.Print(err)
+	}
+}
+
+func (c *client5) subscribe(cm *autopaho.ConnectionManager, filters map[string]byte, name string) error {
+	s := &paho.Subscribe{}
+	for topic, qos := range filters {
+		s.Subscriptions = append(s.Subscriptions, paho.SubscribeOptions{Topic: topic, QoS: qos})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ack, err := cm.Subscribe(ctx, s)
+	if err != nil {
+		return errors.Wrap(err, name)
+	}
+
+	for i, code := range ack.Reasons {
+		if code >= 0x80 && i < len(s.Subscriptions) {
+			return errors.Errorf("%s: %s refused with reason code 0x%02X", name, s.Subscriptions[i].Topic, code)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package mqtt_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/go-playground/assert"
+)
+
+// serveConnect5 accepts a single connection, passes its MQTT 5 CONNECT to the channel and answers it with the reason code.
+func serveConnect5(t *testing.T, code byte) (uint16, <-chan *packets.Connect) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	connects := make(chan *packets.Connect, 1)
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		cp, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		connects <- cp.Content.(*packets.Connect)
+
+		ca := packets.NewControlPacket(packets.CONNACK)
+		ca.Content.(*packets.Connack).ReasonCode = code
+		ca.WriteTo(conn)
+
+		for {
+			if _, err := packets.ReadPacket(conn); err != nil {
+				return
+			}
+		}
+	}()
+
+	return uint16(l.Addr().(*net.TCPAddr).Port), connects
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("should start a clean MQTT 5 session with the user properties", func(t *testing.T) {
+		port, connects := serveConnect5(t, packets.ConnackSuccess)
+
+		c, err := mqtt.NewClient(&mqtt.Options{
+			Server:          "127.0.0.1",
+			Port:            port,
+			ClientID:        "client",
+			KeepAlive:       60,
+			ProtocolVersion: 5,
+			CleanStart:      true,
+			UserProperties:  map[string]string{"region": "eu"},
+		}, func(string, []byte, bool) {})
+		assert.Equal(t, nil, err)
+
+		assert.Equal(t, nil, c.Connect())
+		defer c.Disconnect()
+
+		connect := <-connects
+		assert.Equal(t, byte(5), connect.ProtocolVersion)
+		assert.Equal(t, true, connect.CleanStart)
+		assert.Equal(t, true, connect.Properties.SessionExpiryInterval == nil)
+		assert.Equal(t, 1, len(connect.Properties.User))
+		assert.Equal(t, "region", connect.Properties.User[0].Key)
+		assert.Equal(t, "eu", connect.Properties.User[0].Value)
+		assert.Equal(t, true, c.IsConnected())
+	})
+
+	t.Run("should fail when the MQTT 5 connection is refused", func(t *testing.T) {
+		port, _ := serveConnect5(t, packets.ConnackBadUsernameOrPassword)
+
+		c, err := mqtt.NewClient(&mqtt.Options{Server: "127.0.0.1", Port: port, ProtocolVersion: 5}, func(string, []byte, bool) {})
+		assert.Equal(t, nil, err)
+
+		assert.NotEqual(t, nil, c.Connect())
+		assert.Equal(t, false, c.IsConnected())
+	})
+}
@@ -0,0 +1,137 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	propSessionExpiry = 0x11
+	propUserProperty  = 0x26
+)
+
+var reasonCodes5 = map[byte]string{
+	0x80: "Unspecified error",
+	0x81: "Malformed Packet",
+	0x82: "Protocol Error",
+	0x83: "Implementation specific error",
+	0x84: "Unsupported Protocol Version",
+	0x85: "Client Identifier not valid",
+	0x86: "Bad User Name or Password",
+	0x87: "Not authorized",
+	0x88: "Server unavailable",
+	0x89: "Server busy",
+	0x8A: "Banned",
+	0x8C: "Bad authentication method",
+	0x95: "Packet too large",
+	0x97: "Quota exceeded",
+	0x9F: "Connection rate exceeded",
+}
+
+// connect5 sends an MQTT 5 CONNECT packet and waits for the CONNACK,
+// paho.mqtt.golang speaks MQTT 3.1.1 only so the packets are encoded here.
+func connect5(rw io.ReadWriter, o *Options) error {
+	if _, err := rw.Write(encodeConnect5(o)); err != nil {
+		return errors.Wrap(err, "mqtt.connect5.Write")
+	}
+
+	r := bufio.NewReader(rw)
+
+	header, err := r.ReadByte()
+	if err != nil {
+		return errors.Wrap(err, "mqtt.connect5.ReadByte")
+	}
+
+	if header>>4 != 2 {
+		return errors.Errorf("mqtt.connect5: unexpected packet type %d", header>>4)
+	}
+
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errors.Wrap(err, "mqtt.connect5.ReadUvarint")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return errors.Wrap(err, "mqtt.connect5.ReadFull")
+	}
+
+	if len(body) < 2 {
+		return errors.New("mqtt.connect5: malformed CONNACK")
+	}
+
+	// A broker without MQTT 5 support answers with the MQTT 3.1.1 CONNACK and its return code.
+	if code := body[1]; code != 0 {
+		if reason, ok := reasonCodes5[code]; ok {
+			return fmt.Errorf("mqtt.connect5: %s", reason)
+		}
+
+		return fmt.Errorf("mqtt.connect5: connection refused with reason code 0x%02X", code)
+	}
+
+	rw.Write([]byte{0xE0, 0x00})
+
+	return nil
+}
+
+func encodeConnect5(o *Options) []byte {
+	var props bytes.Buffer
+	if o.SessionExpiry > 0 {
+		props.WriteByte(propSessionExpiry)
+		binary.Write(&props, binary.BigEndian, o.SessionExpiry)
+	}
+	for k, v := range o.UserProperties {
+		props.WriteByte(propUserProperty)
+		writeString(&props, []byte(k))
+		writeString(&props, []byte(v))
+	}
+
+	var flags byte
+	if o.CleanStart {
+		flags |= 0x02
+	}
+	if o.Username != "" {
+		flags |= 0x80
+	}
+	if o.Password != "" {
+		flags |= 0x40
+	}
+
+	var body bytes.Buffer
+	writeString(&body, []byte("MQTT"))
+	body.WriteByte(5)
+	body.WriteByte(flags)
+	binary.Write(&body, binary.BigEndian, o.KeepAlive)
+	writeVarint(&body, props.Len())
+	body.Write(props.Bytes())
+
+	writeString(&body, []byte(o.ClientID))
+	if o.Username != "" {
+		writeString(&body, []byte(o.Username))
+	}
+	if o.Password != "" {
+		writeString(&body, []byte(o.Password))
+	}
+
+	var packet bytes.Buffer
+	packet.WriteByte(0x10)
+	writeVarint(&packet, body.Len())
+	packet.Write(body.Bytes())
+
+	return packet.Bytes()
+}
+
+func writeString(b *bytes.Buffer, v []byte) {
+	binary.Write(b, binary.BigEndian, uint16(len(v)))
+	b.Write(v)
+}
+
+// writeVarint writes the variable byte integer of the MQTT specification, it matches the unsigned LEB128 encoding.
+func writeVarint(b *bytes.Buffer, v int) {
+	b.Write(binary.AppendUvarint(nil, uint64(v)))
+}
@@ -0,0 +1,238 @@
+package mqtt
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/pkg/errors"
+)
+
+const timeout = 10 * time.Second
+
+const (
+	TransportTCP = "tcp"
+	TransportWS  = "ws"
+	TransportWSS = "wss"
+)
+
+type Options struct {
+	Server    string
+	Port      uint16
+	IsSSL     bool
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive uint16
+
+	// Transport is tcp when empty, the Path is used by the websocket transports only.
+	Transport string
+	Path      string
+
+	// ProtocolVersion is 4 for MQTT 3.1.1 and 5 for MQTT 5, the clean start is the clean session
+	// of MQTT 3.1.1 while the session expiry and the user properties are sent with MQTT 5 only.
+	ProtocolVersion uint
+	CleanStart      bool
+	SessionExpiry   uint32
+	UserProperties  map[string]string
+
+	// CACertificate is a PEM bundle trusted instead of the system roots,
+	// ClientCertificate and ClientKey are PEM encoded and used for mutual TLS.
+	CACertificate     []byte
+	ClientCertificate []byte
+	ClientKey         []byte
+}
+
+// URL returns the address of the broker in the format expected by paho.
+func (o *Options) URL() string {
+	switch o.Transport {
+	case TransportWS, TransportWSS:
+		path := o.Path
+		if path == "" {
+			path = "/mqtt"
+		}
+
+		return fmt.Sprintf("%s://%s%s", o.Transport, net.JoinHostPort(o.Server, strconv.Itoa(int(o.Port))), path)
+	}
+
+	scheme := "tcp"
+	if o.IsSSL {
+		scheme = "ssl"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(o.Server, strconv.Itoa(int(o.Port))))
+}
+
+// IsTLS reports whether the connection is encrypted.
+func (o *Options) IsTLS() bool {
+	if o.Transport == TransportWS {
+		return false
+	}
+
+	return o.IsSSL || o.Transport == TransportWSS
+}
+
+// TLSConfig builds the TLS configuration of the options.
+func (o *Options) TLSConfig() (*tls.Config, error) {
+	c := &tls.Config{ServerName: o.Server}
+
+	if len(o.CACertificate) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(o.CACertificate) {
+			return nil, errors.New("mqtt.Options.TLSConfig: invalid CA certificate")
+		}
+		c.RootCAs = pool
+	}
+
+	if len(o.ClientCertificate) > 0 {
+		pair, err := tls.X509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "mqtt.Options.TLSConfig.X509KeyPair")
+		}
+		c.Certificates = []tls.Certificate{pair}
+	}
+
+	return c, nil
+}
+
+type Handler func(topic string, payload []byte, retained bool)
+
+type Client interface {
+	Connect() error
+	Disconnect()
+	IsConnected() bool
+	Subscribe(topic string, qos byte) error
+	Unsubscribe(topics ...string) error
+	Publish(topic string, qos byte, retained bool, payload []byte) error
+}
+
+type client struct {
+	c      paho.Client
+	topics map[string]byte
+	mutex  sync.Mutex
+}
+
+// NewClient creates a client which keeps its subscriptions across reconnects,
+// every message received is passed to the handler. The client speaks MQTT 5
+// when the protocol version of the options is 5 and MQTT 3.1.1 otherwise.
+func NewClient(o *Options, h Handler) (Client, error) {
+	if o.ProtocolVersion == 5 {
+		if _, err := o.TLSConfig(); err != nil {
+			return nil, err
+		}
+
+		return newClient5(o, h), nil
+	}
+
+	c := &client{topics: make(map[string]byte)}
+
+	tc, err := o.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	po := paho.NewClientOptions().
+		AddBroker(o.URL()).
+		SetProtocolVersion(4).
+		SetClientID(o.ClientID).
+		SetUsername(o.Username).
+		SetPassword(o.Password).
+		SetKeepAlive(time.Duration(o.KeepAlive) * time.Second).
+		SetConnectTimeout(timeout).
+		SetAutoReconnect(true).
+		SetCleanSession(o.CleanStart).
+		SetOrderMatters(false).
+		SetTLSConfig(tc).
+		SetDefaultPublishHandler(func(_ paho.Client, m paho.Message) {
+			h(m.Topic(), m.Payload(), m.Retained())
+		}).
+		SetOnConnectHandler(func(_ paho.Client) {
+			c.resubscribe()
+		})
+
+	c.c = paho.NewClient(po)
+
+	return c, nil
+}
+
+func (c *client) Connect() error {
+	t := c.c.Connect()
+	if !t.WaitTimeout(timeout) {
+		return errors.New("mqtt.client.Connect: timeout")
+	}
+
+	return errors.Wrap(t.Error(), "mqtt.client.Connect")
+}
+
+func (c *client) Disconnect() {
+	c.c.Disconnect(250)
+}
+
+func (c *client) IsConnected() bool {
+	return c.c.IsConnectionOpen()
+}
+
+func (c *client) Subscribe(topic string, qos byte) error {
+	c.mutex.Lock()
+	c.topics[topic] = qos
+	c.mutex.Unlock()
+
+	if !c.c.IsConnectionOpen() {
+		return nil
+	}
+
+	return c.wait(c.c.Subscribe(topic, qos, nil), "mqtt.client.Subscribe")
+}
+
+func (c *client) Unsubscribe(topics ...string) error {
+	c.mutex.Lock()
+	for _, topic := range topics {
+		delete(c.topics, topic)
+	}
+	c.mutex.Unlock()
+
+	if !c.c.IsConnectionOpen() {
+		return nil
+	}
+
+	return c.wait(c.c.Unsubscribe(topics...), "mqtt.client.Unsubscribe")
+}
+
+func (c *client) Publish(topic string, qos byte, retained bool, payload []byte) error {
+	return c.wait(c.c.Publish(topic, qos, retained, payload), "mqtt.client.Publish")
+}
+
+func (c *client) resubscribe() {
+	c.mutex.Lock()
+	filters := make(map[string]byte, len(c.topics))
+	for topic, qos := range c.topics {
+		filters[topic] = qos
+	}
+	c.mutex.Unlock()
+
+	if len(filters) == 0 {
+		return
+	}
+
+	if err := c.wait(c.c.SubscribeMultiple(filters, nil), "mqtt.client.resubscribe"); err != nil {
+		log.Print(err)
+	}
+}
+
+func (c *client) wait(t paho.Token, name string) error {
+	if !t.WaitTimeout(timeout) {
+		return errors.Errorf("%s: timeout", name)
+	}
+
+	if err := t.Error(); err != nil {
+		return errors.Wrap(err, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/pkg/errors"
+)
+
+const (
+	ProbeDNS       = "dns"
+	ProbeTCP       = "tcp"
+	ProbeTLS       = "tls"
+	ProbeWebsocket = "websocket"
+	ProbeConnack   = "connack"
+)
+
+type ProbeStep struct {
+	Name    string
+	Latency time.Duration
+	Err     error
+}
+
+type ProbeReport struct {
+	Steps []*ProbeStep
+}
+
+// Ok reports whether the broker has accepted the connection.
+func (r *ProbeReport) Ok() bool {
+	if len(r.Steps) == 0 {
+		return false
+	}
+
+	last := r.Steps[len(r.Steps)-1]
+	return last.Name == ProbeConnack && last.Err == nil
+}
+
+// Probe connects to the broker step by step and stops at the first failing step,
+// the connection is closed right after the CONNACK has been received.
+// The websocket step covers the TCP connection, the TLS handshake and the HTTP upgrade.
+func Probe(ctx context.Context, o *Options) *ProbeReport {
+	r := &ProbeReport{Steps: []*ProbeStep{}}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	step := func(name string, f func() error) bool {
+		start := time.Now()
+		err := f()
+		r.Steps = append(r.Steps, &ProbeStep{name, time.Since(start), err})
+		return err == nil
+	}
+
+	var addrs []string
+	if !step(ProbeDNS, func() (err error) {
+		addrs, err = net.DefaultResolver.LookupHost(ctx, o.Server)
+		return errors.Wrap(err, "mqtt.Probe.LookupHost")
+	}) {
+		return r
+	}
+
+	var conn net.Conn
+	if o.Transport == TransportWS || o.Transport == TransportWSS {
+		if !step(ProbeWebsocket, func() (err error) {
+			var c *tls.Config
+			if o.IsTLS() {
+				if c, err = o.TLSConfig(); err != nil {
+					return err
+				}
+			}
+
+			conn, err = paho.NewWebsocket(o.URL(), c, timeout, nil, nil)
+			return errors.Wrap(err, "mqtt.Probe.NewWebsocket")
+		}) {
+			return r
+		}
+		defer conn.Close()
+	} else {
+		if !step(ProbeTCP, func() (err error) {
+			d := &net.Dialer{}
+			conn, err = d.DialContext(ctx, "tcp", net.JoinHostPort(addrs[0], strconv.Itoa(int(o.Port))))
+			return errors.Wrap(err, "mqtt.Probe.DialContext")
+		}) {
+			return r
+		}
+		defer conn.Close()
+
+		if o.IsTLS() {
+			if !step(ProbeTLS, func() error {
+				c, err := o.TLSConfig()
+				if err != nil {
+					return err
+				}
+
+				tc := tls.Client(conn, c)
+				conn = tc
+				return errors.Wrap(tc.HandshakeContext(ctx), "mqtt.Probe.HandshakeContext")
+			}) {
+				return r
+			}
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	step(ProbeConnack, func() error {
+		if o.ProtocolVersion == 5 {
+			return connect5(conn, o)
+		}
+
+		return connect(conn, o)
+	})
+
+	return r
+}
+
+func connect(conn net.Conn, o *Options) error {
+	p := packets.NewControlPacket(packets.Connect).(*packets.ConnectPacket)
+	p.ProtocolName = "MQTT"
+	p.ProtocolVersion = 4
+	p.CleanSession = true
+	p.Keepalive = o.KeepAlive
+	p.ClientIdentifier = o.ClientID
+
+	if o.Username != "" {
+		p.UsernameFlag = true
+		p.Username = o.Username
+	}
+
+	if o.Password != "" {
+		p.PasswordFlag = true
+		p.Password = []byte(o.Password)
+	}
+
+	if err := p.Write(conn); err != nil {
+		return errors.Wrap(err, "mqtt.connect.Write")
+	}
+
+	cp, err := packets.ReadPacket(conn)
+	if err != nil {
+		return errors.Wrap(err, "mqtt.connect.ReadPacket")
+	}
+
+	ca, ok := cp.(*packets.ConnackPacket)
+	if !ok {
+		return errors.Errorf("mqtt.connect: unexpected packet %s", cp)
+	}
+
+	if ca.ReturnCode != packets.Accepted {
+		return fmt.Errorf("mqtt.connect: %s", packets.ConnackReturnCodes[ca.ReturnCode])
+	}
+
+	packets.NewControlPacket(packets.Disconnect).Write(conn)
+
+	return nil
+}
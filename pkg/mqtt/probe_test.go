@@ -0,0 +1,155 @@
+package mqtt_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/go-playground/assert"
+)
+
+// serve accepts a single connection and answers its CONNECT with the return code.
+func serve(t *testing.T, code byte) uint16 {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if _, err := packets.ReadPacket(conn); err != nil {
+			return
+		}
+
+		ca := packets.NewControlPacket(packets.Connack).(*packets.ConnackPacket)
+		ca.ReturnCode = code
+		ca.Write(conn)
+
+		packets.ReadPacket(conn)
+	}()
+
+	return uint16(l.Addr().(*net.TCPAddr).Port)
+}
+
+// serve5 accepts a single connection and answers an MQTT 5 CONNECT with the reason code.
+func serve5(t *testing.T, code byte) uint16 {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		if _, err := r.ReadByte(); err != nil {
+			return
+		}
+
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return
+		}
+
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil || body[6] != 5 {
+			return
+		}
+
+		conn.Write([]byte{0x20, 0x03, 0x00, code, 0x00})
+		r.ReadByte()
+	}()
+
+	return uint16(l.Addr().(*net.TCPAddr).Port)
+}
+
+func TestProbe(t *testing.T) {
+	t.Run("should pass every step when the connection is accepted", func(t *testing.T) {
+		port := serve(t, packets.Accepted)
+
+		r := mqtt.Probe(context.Background(), &mqtt.Options{Server: "localhost", Port: port, KeepAlive: 60})
+
+		assert.Equal(t, true, r.Ok())
+		assert.Equal(t, 3, len(r.Steps))
+		assert.Equal(t, mqtt.ProbeDNS, r.Steps[0].Name)
+		assert.Equal(t, mqtt.ProbeTCP, r.Steps[1].Name)
+		assert.Equal(t, mqtt.ProbeConnack, r.Steps[2].Name)
+	})
+
+	t.Run("should fail on connack when the credentials are refused", func(t *testing.T) {
+		port := serve(t, packets.ErrRefusedNotAuthorised)
+
+		r := mqtt.Probe(context.Background(), &mqtt.Options{Server: "localhost", Port: port, Username: "user", Password: "wrong"})
+
+		assert.Equal(t, false, r.Ok())
+		assert.Equal(t, mqtt.ProbeConnack, r.Steps[len(r.Steps)-1].Name)
+		assert.NotEqual(t, nil, r.Steps[len(r.Steps)-1].Err)
+	})
+
+	t.Run("should fail on tcp when nothing listens", func(t *testing.T) {
+		l, _ := net.Listen("tcp", "127.0.0.1:0")
+		port := uint16(l.Addr().(*net.TCPAddr).Port)
+		l.Close()
+
+		r := mqtt.Probe(context.Background(), &mqtt.Options{Server: "127.0.0.1", Port: port})
+
+		assert.Equal(t, false, r.Ok())
+		assert.Equal(t, 2, len(r.Steps))
+		assert.Equal(t, mqtt.ProbeTCP, r.Steps[1].Name)
+	})
+
+	t.Run("should pass with MQTT 5", func(t *testing.T) {
+		port := serve5(t, 0x00)
+
+		r := mqtt.Probe(context.Background(), &mqtt.Options{
+			Server:          "127.0.0.1",
+			Port:            port,
+			ProtocolVersion: 5,
+			CleanStart:      true,
+			UserProperties:  map[string]string{"region": "eu"},
+		})
+
+		assert.Equal(t, true, r.Ok())
+	})
+
+	t.Run("should fail with the MQTT 5 reason code", func(t *testing.T) {
+		port := serve5(t, 0x86)
+
+		r := mqtt.Probe(context.Background(), &mqtt.Options{Server: "127.0.0.1", Port: port, ProtocolVersion: 5})
+
+		assert.Equal(t, false, r.Ok())
+		assert.Equal(t, "mqtt.connect5: Bad User Name or Password", r.Steps[len(r.Steps)-1].Err.Error())
+	})
+}
+
+func TestURL(t *testing.T) {
+	cases := []struct {
+		options *mqtt.Options
+		url     string
+	}{
+		{&mqtt.Options{Server: "broker.io", Port: 1883}, "tcp://broker.io:1883"},
+		{&mqtt.Options{Server: "broker.io", Port: 8883, IsSSL: true}, "ssl://broker.io:8883"},
+		{&mqtt.Options{Server: "broker.io", Port: 80, Transport: mqtt.TransportWS}, "ws://broker.io:80/mqtt"},
+		{&mqtt.Options{Server: "broker.io", Port: 443, Transport: mqtt.TransportWSS, Path: "/ws"}, "wss://broker.io:443/ws"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.url, c.options.URL())
+	}
+}
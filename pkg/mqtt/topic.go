@@ -0,0 +1,120 @@
+package mqtt
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Match reports whether the topic is matched by the filter, the filter can contain
+// single level (+) and multi level (#) wildcards.
+func Match(filter string, topic string) bool {
+	f := strings.Split(filter, "/")
+	t := strings.Split(topic, "/")
+
+	// Topics starting with $ are not matched by filters starting with a wildcard
+	if strings.HasPrefix(topic, "$") && (f[0] == "+" || f[0] == "#") {
+		return false
+	}
+
+	for i, level := range f {
+		if level == "#" {
+			return i == len(f)-1
+		}
+
+		if i >= len(t) {
+			return false
+		}
+
+		if level != "+" && level != t[i] {
+			return false
+		}
+	}
+
+	return len(f) == len(t)
+}
+
+// ValidFilter reports whether the filter can be subscribed to, the wildcards have to take whole levels
+// and the multi level one can only be the last.
+func ValidFilter(filter string) bool {
+	if filter == "" {
+		return false
+	}
+
+	f := strings.Split(filter, "/")
+	for i, level := range f {
+		if level == "#" && i != len(f)-1 {
+			return false
+		}
+
+		if len(level) > 1 && strings.ContainsAny(level, "+#") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidTopic reports whether the topic can be published to, it can not be empty nor contain the wildcards.
+func ValidTopic(topic string) bool {
+	return topic != "" && len(topic) <= 65535 && !strings.ContainsAny(topic, "+#\x00")
+}
+
+// Overlap reports whether any topic is matched by both filters.
+func Overlap(a string, b string) bool {
+	fa := strings.Split(a, "/")
+	fb := strings.Split(b, "/")
+
+	// Topics starting with $ are not matched by filters starting with a wildcard
+	if (strings.HasPrefix(fa[0], "$") && (fb[0] == "+" || fb[0] == "#")) ||
+		(strings.HasPrefix(fb[0], "$") && (fa[0] == "+" || fa[0] == "#")) {
+		return false
+	}
+
+	for i := 0; ; i++ {
+		if i == len(fa) && i == len(fb) {
+			return true
+		}
+
+		// The multi level wildcard matches its parent level too
+		if (i < len(fa) && fa[i] == "#") || (i < len(fb) && fb[i] == "#") {
+			return true
+		}
+
+		if i == len(fa) || i == len(fb) {
+			return false
+		}
+
+		if fa[i] != "+" && fb[i] != "+" && fa[i] != fb[i] {
+			return false
+		}
+	}
+}
+
+// FilterRegexp translates the filter to an anchored regular expression matching the same topics as Match,
+// the expression is understood by both the regexp package and PostgreSQL.
+func FilterRegexp(filter string) string {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i, level := range strings.Split(filter, "/") {
+		switch {
+		case level == "#" && i == 0:
+			b.WriteString("([^$].*)?")
+		case level == "#":
+			// The multi level wildcard matches its parent level too
+			b.WriteString("(/.*)?")
+		case level == "+" && i == 0:
+			b.WriteString("([^/$][^/]*)?")
+		case level == "+":
+			b.WriteString("/[^/]*")
+		case i == 0:
+			b.WriteString(regexp.QuoteMeta(level))
+		default:
+			b.WriteString("/" + regexp.QuoteMeta(level))
+		}
+	}
+
+	b.WriteString("$")
+
+	return b.String()
+}
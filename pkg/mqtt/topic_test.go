@@ -0,0 +1,137 @@
+package mqtt_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Deve-Lite/DashboardX-API/pkg/mqtt"
+	"github.com/go-playground/assert"
+)
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		filter string
+		topic  string
+		match  bool
+	}{
+		{"home/kitchen/light", "home/kitchen/light", true},
+		{"home/kitchen/light", "home/kitchen/lamp", false},
+		{"home/+/light", "home/kitchen/light", true},
+		{"home/+/light", "home/kitchen/hall/light", false},
+		{"home/#", "home", true},
+		{"home/#", "home/kitchen/light", true},
+		{"#", "home/kitchen", true},
+		{"+/+", "home/kitchen", true},
+		{"+/+", "home", false},
+		{"home/+", "home/", true},
+		{"#", "$SYS/broker/uptime", false},
+		{"+/broker/uptime", "$SYS/broker/uptime", false},
+		{"$SYS/#", "$SYS/broker/uptime", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.filter+" "+c.topic, func(t *testing.T) {
+			assert.Equal(t, c.match, mqtt.Match(c.filter, c.topic))
+		})
+	}
+}
+
+func TestValidFilter(t *testing.T) {
+	cases := []struct {
+		filter string
+		valid  bool
+	}{
+		{"home/kitchen/light", true},
+		{"home/+/light", true},
+		{"home/#", true},
+		{"#", true},
+		{"+", true},
+		{"", false},
+		{"home/#/light", false},
+		{"home/kitchen+", false},
+		{"home#", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.filter, func(t *testing.T) {
+			assert.Equal(t, c.valid, mqtt.ValidFilter(c.filter))
+		})
+	}
+}
+
+func TestValidTopic(t *testing.T) {
+	cases := []struct {
+		topic string
+		valid bool
+	}{
+		{"home/kitchen/light", true},
+		{"home/kitchen/", true},
+		{"", false},
+		{"home/+/light", false},
+		{"home/#", false},
+		{"home/kitchen#", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.topic, func(t *testing.T) {
+			assert.Equal(t, c.valid, mqtt.ValidTopic(c.topic))
+		})
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	cases := []struct {
+		a       string
+		b       string
+		overlap bool
+	}{
+		{"home/kitchen/light", "home/kitchen/light", true},
+		{"home/kitchen/light", "home/kitchen/lamp", false},
+		{"home/+/light", "home/kitchen/+", true},
+		{"home/+/light", "home/kitchen/lamp", false},
+		{"home/#", "home", true},
+		{"home/#", "office/+", false},
+		{"+/+", "home/kitchen/light", false},
+		{"#", "$SYS/broker/uptime", false},
+		{"$SYS/#", "$SYS/+/uptime", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.a+" "+c.b, func(t *testing.T) {
+			assert.Equal(t, c.overlap, mqtt.Overlap(c.a, c.b))
+			assert.Equal(t, c.overlap, mqtt.Overlap(c.b, c.a))
+		})
+	}
+}
+
+func TestFilterRegexp(t *testing.T) {
+	cases := []struct {
+		filter string
+		topic  string
+		match  bool
+	}{
+		{"home/kitchen/light", "home/kitchen/light", true},
+		{"home/kitchen/light", "home/kitchen/lamp", false},
+		{"home/+/light", "home/kitchen/light", true},
+		{"home/+/light", "home/kitchen/hall/light", false},
+		{"home/#", "home", true},
+		{"home/#", "home/kitchen/light", true},
+		{"home/#", "homes/kitchen", false},
+		{"#", "home/kitchen", true},
+		{"+/+", "home/kitchen", true},
+		{"+/+", "/kitchen", true},
+		{"+/+", "home", false},
+		{"home/+", "home/", true},
+		{"#", "$SYS/broker/uptime", false},
+		{"+/broker/uptime", "$SYS/broker/uptime", false},
+		{"$SYS/#", "$SYS/broker/uptime", true},
+		{"sensors/temp.c", "sensors/tempxc", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.filter+" "+c.topic, func(t *testing.T) {
+			assert.Equal(t, c.match, regexp.MustCompile(mqtt.FilterRegexp(c.filter)).MatchString(c.topic))
+			assert.Equal(t, mqtt.Match(c.filter, c.topic), regexp.MustCompile(mqtt.FilterRegexp(c.filter)).MatchString(c.topic))
+		})
+	}
+}
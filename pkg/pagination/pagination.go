@@ -0,0 +1,50 @@
+// Package pagination implements the opaque cursors of the list endpoints. The cursor points after the last item
+// of a page by the value it was sorted by and its id, so the next page is stable when the items are added or removed.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type Cursor struct {
+	Sort  string    `json:"s"`
+	Desc  bool      `json:"d,omitempty"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"i"`
+}
+
+// String encodes the cursor to be sent to the client.
+func (c *Cursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Parse decodes the cursor sent by the client.
+func Parse(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ae.ErrInvalidCursor
+	}
+
+	c := &Cursor{}
+	if err := json.Unmarshal(b, c); err != nil || c.Sort == "" || c.ID == uuid.Nil {
+		return nil, ae.ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// Link returns the Link header value pointing to the page after the cursor, the other query parameters are kept.
+func Link(u *url.URL, next *Cursor) string {
+	q := u.Query()
+	q.Set("cursor", next.String())
+
+	n := url.URL{Path: u.Path, RawQuery: q.Encode()}
+	return fmt.Sprintf(`<%s>; rel="next"`, n.String())
+}
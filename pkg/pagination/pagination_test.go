@@ -0,0 +1,35 @@
+package pagination_test
+
+import (
+	"net/url"
+	"testing"
+
+	ae "github.com/Deve-Lite/DashboardX-API/pkg/errors"
+	"github.com/Deve-Lite/DashboardX-API/pkg/pagination"
+	"github.com/go-playground/assert"
+	"github.com/google/uuid"
+)
+
+func TestCursor(t *testing.T) {
+	c := &pagination.Cursor{Sort: "name", Desc: true, Value: "Lamp", ID: uuid.New()}
+
+	t.Run("should decode the encoded cursor", func(t *testing.T) {
+		p, err := pagination.Parse(c.String())
+		assert.Equal(t, nil, err)
+		assert.Equal(t, c, p)
+	})
+
+	t.Run("should reject invalid cursors", func(t *testing.T) {
+		for _, s := range []string{"", "not base64!", "e30", "bm90IGpzb24"} {
+			_, err := pagination.Parse(s)
+			assert.Equal(t, ae.ErrInvalidCursor, err)
+		}
+	})
+}
+
+func TestLink(t *testing.T) {
+	c := &pagination.Cursor{Sort: "name", Value: "Lamp", ID: uuid.New()}
+
+	u, _ := url.Parse("/api/v1/devices?cursor=old&limit=10&sort=name")
+	assert.Equal(t, `</api/v1/devices?cursor=`+c.String()+`&limit=10&sort=name>; rel="next"`, pagination.Link(u, c))
+}
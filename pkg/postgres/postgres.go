@@ -23,6 +23,8 @@ const (
 const (
 	UserEmailConstraint          = "users_email_key"
 	BrokerUserIDServerConstraint = "brokers_user_id_server_key"
+	RoomUserIDNameConstraint     = "rooms_user_id_name_key"
+	TagUserIDNameConstraint      = "tags_user_id_name_key"
 )
 
 func NewDB(c *config.PostgresConfig) *sqlx.DB {
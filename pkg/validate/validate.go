@@ -3,18 +3,32 @@ package validate
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
-	"github.com/Deve-Lite/DashboardX-API/internal/application/dto"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	t "github.com/Deve-Lite/DashboardX-API/pkg/nullable"
 	"github.com/go-playground/validator/v10"
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 )
 
 var validate = validator.New(validator.WithRequiredStructEnabled())
 
+// FieldName names the fields of the validation errors as they are sent by the clients.
+func FieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "uri", "form"} {
+		name := strings.SplitN(f.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+
+		if name != "" {
+			return name
+		}
+	}
+
+	return f.Name
+}
+
 var EmptyMin validator.Func = func(fl validator.FieldLevel) bool {
 	v1, ok1 := fl.Field().Interface().(t.String)
 	if ok1 {
@@ -97,100 +111,18 @@ var RequiredNullString validator.Func = func(fl validator.FieldLevel) bool {
 	return true
 }
 
-var ControlType validator.Func = func(fl validator.FieldLevel) bool {
-	_, ok := fl.Parent().FieldByName("Type").Interface().(*enum.ControlType)
-	if !ok {
-		return false
-	}
-
-	v, ok := fl.Field().Interface().(enum.ControlType)
-	if !ok {
-		return false
-	}
-
-	if fl.Parent().FieldByName("Attributes").IsNil() && v != enum.ControlTextOut {
-		return false
-	}
-
-	switch v {
-	case enum.ControlButton:
-		fallthrough
-	case enum.ControlColor:
-		fallthrough
-	case enum.ControlDateTime:
-		fallthrough
-	case enum.ControlRadio:
-		fallthrough
-	case enum.ControlSlider:
-		fallthrough
-	case enum.ControlState:
-		fallthrough
-	case enum.ControlSwitch:
-		fallthrough
-	case enum.ControlTextOut:
-		return true
-	}
-
-	return false
-}
-
-func onlyRequiredAttributes(d dto.ControlAttributes, v ...string) bool {
-	e := []string{}
-
-	s := reflect.ValueOf(d)
-	g := s.Type()
-
-	for i := 0; i < s.NumField(); i++ {
-		if s.Field(i).IsNil() {
-			continue
-		}
-
-		e = append(e, g.Field(i).Name)
-	}
-
-	if len(v) == 0 {
-		return len(v) == len(e)
-	}
-
-	less := func(a, b string) bool { return a < b }
-	return cmp.Diff(v, e, cmpopts.SortSlices(less)) == ""
-}
-
-var ControlAttributes validator.Func = func(fl validator.FieldLevel) bool {
-	if fl.Parent().FieldByName("Type").IsNil() {
-		return false
-	}
-
-	t, ok := fl.Parent().FieldByName("Type").Interface().(*enum.ControlType)
+// BatchRef accepts the id of an entity or the reference of an entity created earlier in the batch, e.g. $lamp.
+var BatchRef validator.Func = func(fl validator.FieldLevel) bool {
+	v, ok := fl.Field().Interface().(string)
 	if !ok {
 		return false
 	}
 
-	v, ok := fl.Field().Interface().(dto.ControlAttributes)
-	if !ok {
-		return false
+	if strings.HasPrefix(v, "$") {
+		return len(v) > 1
 	}
 
-	switch *t {
-	case enum.ControlButton:
-		return onlyRequiredAttributes(v, "Payload")
-	case enum.ControlColor:
-		return onlyRequiredAttributes(v, "PayloadTemplate", "ColorFormat")
-	case enum.ControlDateTime:
-		return onlyRequiredAttributes(v, "PayloadTemplate", "SendAsTicks")
-	case enum.ControlRadio:
-		return onlyRequiredAttributes(v, "Payloads")
-	case enum.ControlSlider:
-		return onlyRequiredAttributes(v, "PayloadTemplate", "MinValue", "MaxValue")
-	case enum.ControlState:
-		return onlyRequiredAttributes(v, "OnPayload", "OffPayload")
-	case enum.ControlSwitch:
-		return onlyRequiredAttributes(v, "OnPayload", "OffPayload")
-	case enum.ControlTextOut:
-		return onlyRequiredAttributes(v)
-	}
-
-	return false
+	return validate.Var(v, "uuid") == nil
 }
 
 var QoSLevel validator.Func = func(fl validator.FieldLevel) bool {
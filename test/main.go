@@ -7,11 +7,13 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/Deve-Lite/DashboardX-API/config"
 	"github.com/Deve-Lite/DashboardX-API/internal/application"
 	"github.com/Deve-Lite/DashboardX-API/internal/application/enum"
 	"github.com/Deve-Lite/DashboardX-API/internal/domain"
+	"github.com/Deve-Lite/DashboardX-API/internal/infrastructure/persistance"
 	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest"
 	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/handler"
 	"github.com/Deve-Lite/DashboardX-API/internal/interfaces/http/rest/middleware"
@@ -48,6 +50,8 @@ type Test interface {
 	CreateDevice(app *application.Application, userID, brokerID uuid.UUID) uuid.UUID
 	CreateBroker(app *application.Application, userID uuid.UUID) uuid.UUID
 	CreateDeviceControl(app *application.Application, userID, deviceID uuid.UUID) uuid.UUID
+	CreateDeviceMessage(deviceID uuid.UUID, direction enum.MessageDirection, topic string, payload string)
+	SetDeviceStatus(deviceID uuid.UUID, status enum.DeviceStatus)
 	MakeRequest(g *gin.Engine, method string, url string, payload io.Reader, token *string) *httptest.ResponseRecorder
 }
 
@@ -99,11 +103,29 @@ func (t *test) SetupApp() (*gin.Engine, *application.Application) {
 	mInfo := middleware.NewInfo(t.c)
 
 	userHnd := handler.NewUserHandler(t.c, app.UserSrv, app.UserMap)
-	brokerHnd := handler.NewBrokerHandler(app.BrokerSrv, app.BrokerMap)
+	brokerHnd := handler.NewBrokerHandler(app.BrokerSrv, app.MonitorSrv, app.BrokerMap)
 	deviceHnd := handler.NewDeviceHandler(app.DeviceSrv, app.ControlSrv, app.DeviceMap, app.ControlMap)
 	eventHnd := handler.NewEventHandler(t.c, app.EventSrv)
-
-	rest.NewRouter(gin, mRule, mInfo, userHnd, brokerHnd, deviceHnd, eventHnd)
+	transferHnd := handler.NewTransferHandler(app.TransferSrv, app.TransferMap)
+	discoveryHnd := handler.NewDiscoveryHandler(app.DiscoverySrv, app.DiscoveryMap)
+	certificateHnd := handler.NewCertificateHandler(app.CertSrv, app.CertMap)
+	controlTypeHnd := handler.NewControlTypeHandler(app.ControlTypes, app.TypeMap)
+	searchHnd := handler.NewSearchHandler(app.SearchSrv, app.SearchMap)
+	dashboardHnd := handler.NewDashboardHandler(app.DashboardSrv, app.DashboardMap)
+	roomHnd := handler.NewRoomHandler(app.RoomSrv, app.RoomMap)
+	tagHnd := handler.NewTagHandler(app.TagSrv, app.TagMap)
+	groupHnd := handler.NewGroupHandler(app.GroupSrv, app.GroupMap)
+	batchHnd := handler.NewBatchHandler(app.BatchSrv, app.BatchMap)
+	trashHnd := handler.NewTrashHandler(app.TrashSrv, app.TrashMap)
+	revisionHnd := handler.NewRevisionHandler(app.RevisionSrv, app.RevisionMap)
+	cloneHnd := handler.NewCloneHandler(app.CloneSrv, app.BrokerMap, app.DeviceMap)
+	topicHnd := handler.NewTopicHandler(app.TopicSrv, app.TopicMap)
+	exploreHnd := handler.NewExploreHandler(app.ExploreSrv, app.ExploreMap)
+	messageHnd := handler.NewMessageHandler(app.MessageSrv, app.MessageMap)
+	alertHnd := handler.NewAlertHandler(app.AlertSrv, app.AlertMap)
+	presenceHnd := handler.NewPresenceHandler(app.PresenceSrv, app.PresenceMap)
+
+	rest.NewRouter(gin, mRule, mInfo, userHnd, brokerHnd, deviceHnd, eventHnd, transferHnd, discoveryHnd, certificateHnd, controlTypeHnd, searchHnd, dashboardHnd, roomHnd, tagHnd, groupHnd, batchHnd, trashHnd, revisionHnd, cloneHnd, topicHnd, exploreHnd, messageHnd, alertHnd, presenceHnd)
 
 	return gin, app
 }
@@ -236,3 +258,30 @@ func (t *test) CreateDeviceControl(app *application.Application, userID, deviceI
 
 	return controlID
 }
+
+func (t *test) CreateDeviceMessage(deviceID uuid.UUID, direction enum.MessageDirection, topic string, payload string) {
+	ctx := context.Background()
+	defer ctx.Done()
+
+	err := persistance.NewDeviceMessageRepository(t.d).Create(ctx, &domain.DeviceMessage{
+		DeviceID:  deviceID,
+		Direction: direction,
+		Topic:     topic,
+		Payload:   []byte(payload),
+		QoS:       enum.QoSZero,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func (t *test) SetDeviceStatus(deviceID uuid.UUID, status enum.DeviceStatus) {
+	ctx := context.Background()
+	defer ctx.Done()
+
+	now := time.Now()
+	if err := persistance.NewDeviceAvailabilityRepository(t.d).SetStatus(ctx, deviceID, status, &now); err != nil {
+		log.Panic(err)
+	}
+}